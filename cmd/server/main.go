@@ -27,18 +27,28 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	_ "github.com/ralvarezdev/uru-mobiles-recipes-api/docs"
+	internalbulk "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/bulk"
+	internalcookbook "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/cookbook"
 	internalcookie "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/cookie"
 	internalredis "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/redis"
 	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalfeed "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/feed"
 	internalgrpcauth "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/grpc/auth"
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
 	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
 	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
 	internalloader "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/loader"
 	internallogger "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/logger"
+	internalmessages "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/messages"
 	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+	internalprofiles "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/profiles"
 	internalprotojson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/protojson"
 	internalrabbitmq "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/rabbitmq"
+	internalrecommender "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/recommender"
 	internalrouter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router"
+	internalshare "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/share"
+	internalsubstitutions "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/substitutions"
+	internaltrending "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/trending"
 )
 
 var (
@@ -77,8 +87,9 @@ func init() {
 	internallogger.Load(ModeFlag)
 	internalloader.Load(ModeFlag, internallogger.Logger)
 	internalcookie.Load(ModeFlag)
-	internaljson.Load(ModeFlag, internallogger.Logger)
-	internalprotojson.Load(ModeFlag, internallogger.Logger)
+	internalmessages.Load(internallogger.Logger)
+	internaljson.Load(ModeFlag, internalmessages.Messages, internallogger.Logger)
+	internalprotojson.Load(ModeFlag, internalmessages.Messages, internallogger.Logger)
 	internalredis.Load()
 	internalsqlite.Load(internallogger.Logger)
 	internaljwt.Load(
@@ -94,15 +105,45 @@ func init() {
 		internaljson.Handler,
 		internalprotojson.Handler,
 		internalredis.RateLimiter,
+		internalredis.PublicRateLimiter,
 		internaljwt.Validator,
 		internallogger.Logger,
 	)
 	internalgrpcauth.Load()
+	internalimporter.Load(internallogger.Logger)
+	internalcookbook.Load(
+		internalsqlite.RecipesService,
+		internalimporter.Fetcher,
+		internallogger.Logger,
+	)
+	internalbulk.Load(internalsqlite.RecipesService, internallogger.Logger)
+	internalshare.Load()
+	internalfeed.Load(
+		internalsqlite.RecipesService,
+		internalredis.Client,
+		internallogger.Logger,
+	)
+	internaltrending.Load(
+		internalsqlite.RecipesService,
+		internalredis.Client,
+		internallogger.Logger,
+	)
+	internalrecommender.Load(internalsqlite.RecipesService, internallogger.Logger)
+	internalsubstitutions.Load(internallogger.Logger)
 }
 
 //	@Title			Cooking REST API
 //	@Version		1.0
 //	@Description	This is the REST API for the Cooking application.
+//	@Description	Fail and error bodies carry a stable code, listed in the errorcodes.Code definition, that clients
+//	@Description	should rely on instead of the messages, which are localized to the Accept-Language header.
+//	@Description	Errors are rendered as RFC 7807 problem details instead of JSend when the Accept header prefers
+//	@Description	application/problem+json.
+//	@Description	Success responses are encoded in MessagePack (application/msgpack) or, for the auth responses that hold
+//	@Description	a protobuf message, in binary protobuf (application/x-protobuf) when the Accept header prefers them.
+//	@Description	Recipes and groups are versioned: reads send the version in the ETag header and answer 304 Not Modified
+//	@Description	to a matching If-None-Match header, while writes require the ETag they were made over in the If-Match
+//	@Description	header and fail with 412 Precondition Failed if the resource changed since.
 
 //	@License.name	GPL-3.0
 //	@License.url	http://www.gnu.org/licenses/gpl-3.0.html
@@ -137,6 +178,34 @@ func main() {
 		internallogger.Logger.Info("Connected to Token Validator SQLite database")
 	}
 
+	// Connect to the Recipes SQLite database
+	if connErr := internalsqlite.RecipesService.Connect(ctx); connErr != nil {
+		panic(connErr)
+	}
+	if internallogger.Logger != nil {
+		internallogger.Logger.Info("Connected to Recipes SQLite database")
+	}
+
+	// Start the cookbook generator workers
+	if startErr := internalcookbook.Jobs.Start(ctx); startErr != nil {
+		panic(startErr)
+	}
+
+	// Start the bulk import workers
+	if startErr := internalbulk.Jobs.Start(ctx); startErr != nil {
+		panic(startErr)
+	}
+
+	// Start copying the trending counters to the database
+	if startErr := internaltrending.Recipes.Start(ctx); startErr != nil {
+		panic(startErr)
+	}
+
+	// Start computing the recommendations
+	if startErr := internalrecommender.Recommendations.Start(ctx); startErr != nil {
+		panic(startErr)
+	}
+
 	// Create the auth client JWT authentication interceptor
 	authJWTInterceptor, err := gogrpcclientinterceptorauthjwt.NewInterceptor(
 		pbauth.JWTInterceptions,
@@ -182,6 +251,11 @@ func main() {
 
 	// Create the auth client
 	internalgrpcauth.Client = pbauthcompiled.NewAuthClient(conn)
+	internalprofiles.Load(
+		internalsqlite.RecipesService,
+		internalgrpcauth.Client,
+		internallogger.Logger,
+	)
 
 	// Start the RabbitMQ service on a separate goroutine
 	go func() {
@@ -5,6 +5,7 @@ go 1.25.1
 require (
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/joho/godotenv v1.5.1
+	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/mattn/go-sqlite3 v1.14.32
 	github.com/rabbitmq/amqp091-go v1.10.0
 	github.com/ralvarezdev/go-databases v0.8.2
@@ -15,9 +16,13 @@ require (
 	github.com/ralvarezdev/go-net v0.14.6
 	github.com/ralvarezdev/go-rate-limiter v0.1.11
 	github.com/ralvarezdev/go-security-headers v0.1.4
+	github.com/ralvarezdev/go-strings v0.2.2
 	github.com/ralvarezdev/grpc-auth-proto-go v0.1.13
 	github.com/swaggo/http-swagger/v2 v2.0.2
 	github.com/swaggo/swag v1.16.6
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/net v0.46.0
+	golang.org/x/text v0.30.0
 	google.golang.org/grpc v1.76.0
 	google.golang.org/protobuf v1.36.10
 )
@@ -40,17 +45,15 @@ require (
 	github.com/ralvarezdev/go-api-key v0.1.4 // indirect
 	github.com/ralvarezdev/go-json v0.2.3 // indirect
 	github.com/ralvarezdev/go-reflect v0.3.1 // indirect
-	github.com/ralvarezdev/go-strings v0.2.2 // indirect
 	github.com/ralvarezdev/go-validator v0.7.5 // indirect
 	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/swaggo/files/v2 v2.0.2 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/crypto v0.43.0 // indirect
 	golang.org/x/mod v0.29.0 // indirect
-	golang.org/x/net v0.46.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
 	golang.org/x/sys v0.37.0 // indirect
-	golang.org/x/text v0.30.0 // indirect
 	golang.org/x/tools v0.38.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251029180050-ab9386a59fda // indirect
 )
@@ -0,0 +1,51 @@
+package bulk
+
+import (
+	"log/slog"
+	"time"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+)
+
+const (
+	// Workers is the number of archives imported at the same time
+	Workers = 1
+
+	// PollPeriod is the period the idle workers check for pending jobs the queue notification missed
+	PollPeriod = 30 * time.Second
+
+	// MaxArchiveSize is the maximum size of an uploaded archive, in bytes
+	MaxArchiveSize = 32 << 20
+
+	// MaxEntrySize is the maximum size of a decompressed Paprika recipe, which bounds the archives that expand
+	// into huge documents
+	MaxEntrySize = 2 << 20
+
+	// MaxEntries is the maximum number of recipes of an archive
+	MaxEntries = 5000
+
+	// ExportPageSize is the number of recipes loaded at a time when exporting a library
+	ExportPageSize = 100
+)
+
+var (
+	// Jobs is the bulk importer that runs the queued jobs
+	Jobs *Importer
+)
+
+// Load initializes the bulk constants
+//
+// Parameters:
+//
+//   - service: The recipes SQLite service
+//   - logger: The logger (optional, can be nil)
+func Load(
+	service *internalsqliterecipes.Service,
+	logger *slog.Logger,
+) {
+	importer, err := NewImporter(service, Workers, logger)
+	if err != nil {
+		panic(err)
+	}
+	Jobs = importer
+}
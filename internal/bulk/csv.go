@@ -0,0 +1,198 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	internalexporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/exporter"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	csvName            = "name"
+	csvDescription     = "description"
+	csvIngredients     = "ingredients"
+	csvSteps           = "steps"
+	csvPreparationTime = "preparation_time"
+	csvCookingTime     = "cooking_time"
+	csvServings        = "servings"
+	csvDifficulty      = "difficulty"
+	csvTags            = "tags"
+	csvSourceURL       = "source_url"
+	csvImageURL        = "image_url"
+)
+
+var (
+	// utf8BOM is the byte order mark spreadsheet apps write at the start of a UTF-8 CSV file
+	utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+	// csvColumns are the columns of an exported CSV file, in order
+	csvColumns = []string{
+		csvName,
+		csvDescription,
+		csvIngredients,
+		csvSteps,
+		csvPreparationTime,
+		csvCookingTime,
+		csvServings,
+		csvDifficulty,
+		csvTags,
+		csvSourceURL,
+		csvImageURL,
+	}
+
+	// csvAliases maps the column names other apps use to the columns of the CSV format
+	csvAliases = map[string]string{
+		"title":        csvName,
+		"recipe":       csvName,
+		"summary":      csvDescription,
+		"ingredient":   csvIngredients,
+		"directions":   csvSteps,
+		"instructions": csvSteps,
+		"method":       csvSteps,
+		"prep_time":    csvPreparationTime,
+		"prep":         csvPreparationTime,
+		"cook_time":    csvCookingTime,
+		"cook":         csvCookingTime,
+		"yield":        csvServings,
+		"serves":       csvServings,
+		"categories":   csvTags,
+		"category":     csvTags,
+		"keywords":     csvTags,
+		"url":          csvSourceURL,
+		"source":       csvSourceURL,
+		"image":        csvImageURL,
+		"photo_url":    csvImageURL,
+	}
+)
+
+// decodeCSV reads a CSV file with a header row and one recipe per row. The ingredients and the steps are written
+// one per line inside their cells
+//
+// Parameters:
+//
+//   - archive: the file
+//
+// Returns:
+//
+//   - []Entry: the entries
+//   - error: an error if the file or its header could not be read
+func decodeCSV(archive []byte) ([]Entry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(archive, utf8BOM)))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	// Map the header to the columns
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if alias, ok := csvAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns[csvName]; !ok {
+		return nil, fmt.Errorf("%w: the header has no %s column", ErrInvalidArchive, csvName)
+	}
+
+	var entries []Entry
+	for {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if len(entries) == MaxEntries {
+			return nil, ErrTooManyEntries
+		}
+		if readErr != nil {
+			entries = append(entries, Entry{Err: readErr})
+			continue
+		}
+
+		// Read a cell of the row, empty if the column is missing
+		cell := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entries = append(
+			entries, newEntry(
+				&internalrouterapiv1recipe.Recipe{
+					Name:            cell(csvName),
+					Description:     cell(csvDescription),
+					PreparationTime: parseTextDuration(cell(csvPreparationTime)),
+					CookingTime:     parseTextDuration(cell(csvCookingTime)),
+					Ingredients:     parseIngredients(cell(csvIngredients)),
+					Steps:           parseSteps(cell(csvSteps)),
+					Servings:        parseServings(cell(csvServings)),
+					Difficulty:      strings.ToLower(cell(csvDifficulty)),
+					SourceURL:       validURL(cell(csvSourceURL)),
+					ImageURL:        validURL(cell(csvImageURL)),
+				},
+				parseTags(cell(csvTags)),
+			),
+		)
+	}
+	return entries, nil
+}
+
+// encodeCSV writes recipes as a CSV file with a header row and one recipe per row
+//
+// Parameters:
+//
+//   - recipes: the recipes
+//
+// Returns:
+//
+//   - []byte: the file
+//   - error: an error if the file could not be written
+func encodeCSV(recipes []*internalrouterapiv1recipe.Recipe) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(csvColumns); err != nil {
+		return nil, err
+	}
+
+	for _, recipe := range recipes {
+		ingredients := make([]string, 0, len(recipe.Ingredients))
+		for _, ingredient := range recipe.Ingredients {
+			ingredients = append(ingredients, internalexporter.FormatIngredient(ingredient))
+		}
+		if err := writer.Write(
+			[]string{
+				recipe.Name,
+				recipe.Description,
+				strings.Join(ingredients, "\n"),
+				strings.Join(recipe.Steps, "\n"),
+				strconv.Itoa(recipe.PreparationTime),
+				strconv.Itoa(recipe.CookingTime),
+				strconv.Itoa(recipe.Servings),
+				recipe.Difficulty,
+				strings.Join(tagNames(recipe), ", "),
+				recipe.SourceURL,
+				recipe.ImageURL,
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
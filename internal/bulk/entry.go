@@ -0,0 +1,136 @@
+package bulk
+
+import (
+	"strings"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Entry is a recipe read from an archive, or the error that kept it from being read
+	Entry struct {
+		Name   string // name of the recipe, or of the archive file when it could not be read
+		Recipe *internalrouterapiv1recipe.Recipe
+		Tags   []string // labels taken from the categories
+		Err    error
+	}
+)
+
+// Decode reads the recipes of an archive
+//
+// Parameters:
+//
+//   - format: the format of the archive
+//   - archive: the archive
+//
+// Returns:
+//
+//   - []Entry: the entries, in archive order
+//   - error: an error if the archive as a whole could not be read
+func Decode(format Format, archive []byte) ([]Entry, error) {
+	if len(archive) == 0 {
+		return nil, ErrEmptyArchive
+	}
+
+	var entries []Entry
+	var err error
+	switch format {
+	case FormatPaprika:
+		entries, err = decodePaprika(archive)
+	case FormatMealMaster:
+		entries, err = decodeMealMaster(archive)
+	case FormatCSV:
+		entries, err = decodeCSV(archive)
+	default:
+		return nil, ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNoRecipes
+	}
+	return entries, nil
+}
+
+// Encode writes recipes as an archive
+//
+// Parameters:
+//
+//   - format: the format of the archive
+//   - recipes: the recipes
+//
+// Returns:
+//
+//   - []byte: the archive
+//   - error: an error if the archive could not be written
+func Encode(format Format, recipes []*internalrouterapiv1recipe.Recipe) ([]byte, error) {
+	switch format {
+	case FormatPaprika:
+		return encodePaprika(recipes)
+	case FormatMealMaster:
+		return encodeMealMaster(recipes), nil
+	case FormatCSV:
+		return encodeCSV(recipes)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// newEntry checks a decoded recipe and wraps it in an entry, dropping the empty and repeated tags and the ones over
+// the limit of a recipe
+//
+// Parameters:
+//
+//   - recipe: the decoded recipe
+//   - tags: the decoded tag labels
+//
+// Returns:
+//
+//   - Entry: the entry
+func newEntry(recipe *internalrouterapiv1recipe.Recipe, tags []string) Entry {
+	recipe.Name = strings.TrimSpace(recipe.Name)
+	if recipe.Name == "" {
+		return Entry{Err: ErrMissingName}
+	}
+	if recipe.Ingredients == nil {
+		recipe.Ingredients = []internalrouterapiv1recipe.Ingredient{}
+	}
+	if recipe.Steps == nil {
+		recipe.Steps = []string{}
+	}
+
+	labels := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := internalsqliterecipes.Slugify(tag)
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		if len(labels) == internalsqliterecipes.MaxRecipeTags {
+			break
+		}
+		seen[key] = struct{}{}
+		labels = append(labels, tag)
+	}
+	return Entry{Name: recipe.Name, Recipe: recipe, Tags: labels}
+}
+
+// tagNames returns the names of the tags of a recipe
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//
+// Returns:
+//
+//   - []string: the tag names
+func tagNames(recipe *internalrouterapiv1recipe.Recipe) []string {
+	names := make([]string, 0, len(recipe.Tags))
+	for _, tag := range recipe.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
@@ -0,0 +1,60 @@
+package bulk
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrNilImporter       = errors.New("bulk importer cannot be nil")
+	ErrNilRecipesService = errors.New("recipes service cannot be nil")
+	ErrInvalidFormat     = errors.New("invalid archive format, must be paprika, mealmaster or csv")
+	ErrEmptyArchive      = errors.New("archive cannot be empty")
+	ErrArchiveTooLarge   = errors.New("archive is too large")
+	ErrInvalidArchive    = errors.New("archive could not be read")
+	ErrNoRecipes         = errors.New("archive holds no recipes")
+	ErrTooManyEntries    = errors.New("archive holds too many recipes")
+	ErrEntryTooLarge     = errors.New("recipe entry is too large")
+	ErrMissingName       = errors.New("recipe has no name")
+)
+
+// ParseError maps a bulk import or export error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the bulk error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidFormat):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"format",
+			err,
+			internalerrorcodes.InvalidArchiveFormat.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrEmptyArchive):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"archive",
+			err,
+			internalerrorcodes.EmptyArchive.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrArchiveTooLarge):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"archive",
+			err,
+			internalerrorcodes.ArchiveTooLarge.String(),
+			http.StatusRequestEntityTooLarge,
+		)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,49 @@
+package bulk
+
+import (
+	"context"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// Export writes the whole recipe library of a user as an archive
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - service: the recipes SQLite service
+//   - ownerID: the ID of the user
+//   - format: the format of the archive
+//   - language: the language used to localize the tag names
+//
+// Returns:
+//
+//   - []byte: the archive
+//   - error: an error if the recipes could not be listed or written
+func Export(
+	ctx context.Context,
+	service *internalsqliterecipes.Service,
+	ownerID string,
+	format Format,
+	language string,
+) ([]byte, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+
+	// List the recipes a page at a time
+	var recipes []*internalrouterapiv1recipe.Recipe
+	for offset := 0; ; offset += ExportPageSize {
+		page, err := service.ListRecipesByOwnerID(ctx, ownerID, language, ExportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, page...)
+		if len(page) < ExportPageSize {
+			break
+		}
+	}
+	return Encode(format, recipes)
+}
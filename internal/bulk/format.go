@@ -0,0 +1,69 @@
+package bulk
+
+type (
+	// Format is the format of a recipe library archive shared with other recipe managers
+	Format string
+)
+
+const (
+	// FormatPaprika is the Paprika format, a zip archive of gzip compressed JSON recipes
+	FormatPaprika Format = "paprika"
+
+	// FormatMealMaster is the MealMaster plain text format
+	FormatMealMaster Format = "mealmaster"
+
+	// FormatCSV is the CSV format, one recipe per row with the ingredients and the steps one per line
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat parses an archive format
+//
+// Parameters:
+//
+//   - value: the requested format
+//
+// Returns:
+//
+//   - Format: the format
+//   - error: an error if the format is empty or unknown
+func ParseFormat(value string) (Format, error) {
+	format := Format(value)
+	switch format {
+	case FormatPaprika, FormatMealMaster, FormatCSV:
+		return format, nil
+	default:
+		return "", ErrInvalidFormat
+	}
+}
+
+// ContentType returns the media type of the format
+//
+// Returns:
+//
+//   - string: the media type
+func (f Format) ContentType() string {
+	switch f {
+	case FormatPaprika:
+		return "application/zip"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension of the format
+//
+// Returns:
+//
+//   - string: the file extension, with the leading dot
+func (f Format) Extension() string {
+	switch f {
+	case FormatPaprika:
+		return ".paprikarecipes"
+	case FormatCSV:
+		return ".csv"
+	default:
+		return ".mmf"
+	}
+}
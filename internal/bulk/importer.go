@@ -0,0 +1,246 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Importer imports the uploaded archives in the background. The jobs are queued in the database and report each
+	// entry as it is imported, so an interrupted job resumes after the last reported entry
+	Importer struct {
+		service *internalsqliterecipes.Service
+		workers int
+		wake    chan struct{}
+		logger  *slog.Logger
+	}
+)
+
+// NewImporter creates a new Importer
+//
+// Parameters:
+//
+//   - service: the recipes SQLite service
+//   - workers: the number of archives imported at the same time
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Importer: the Importer instance
+//   - error: an error if the service is nil
+func NewImporter(
+	service *internalsqliterecipes.Service,
+	workers int,
+	logger *slog.Logger,
+) (*Importer, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "bulk_importer"),
+		)
+	}
+
+	return &Importer{
+		service: service,
+		workers: workers,
+		wake:    make(chan struct{}, workers),
+		logger:  logger,
+	}, nil
+}
+
+// Start returns the jobs interrupted by the last shutdown to the queue and starts the workers, which stop when the
+// context is done
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the interrupted jobs could not be reset
+func (i *Importer) Start(ctx context.Context) error {
+	// Check if the importer is nil
+	if i == nil {
+		return ErrNilImporter
+	}
+
+	if err := i.service.ResetRunningImportJobs(ctx); err != nil {
+		return err
+	}
+	for range i.workers {
+		go i.work(ctx)
+	}
+	return nil
+}
+
+// Queue queues the import of an archive
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user the recipes are imported for
+//   - format: the format of the archive
+//   - archive: the archive
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ImportJob: the pending job
+//   - error: an error if the archive is empty or too large, or the job could not be queued
+func (i *Importer) Queue(
+	ctx context.Context,
+	ownerID string,
+	format Format,
+	archive []byte,
+) (*internalrouterapiv1recipe.ImportJob, error) {
+	// Check if the importer is nil
+	if i == nil {
+		return nil, ErrNilImporter
+	}
+
+	// Check the archive
+	if len(archive) == 0 {
+		return nil, ErrEmptyArchive
+	}
+	if len(archive) > MaxArchiveSize {
+		return nil, ErrArchiveTooLarge
+	}
+
+	job, err := i.service.CreateImportJob(ctx, ownerID, string(format), archive)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// work claims and imports the pending jobs until the context is done
+//
+// Parameters:
+//
+//   - ctx: the context
+func (i *Importer) work(ctx context.Context) {
+	ticker := time.NewTicker(PollPeriod)
+	defer ticker.Stop()
+
+	for {
+		jobID, err := i.service.ClaimImportJob(ctx)
+		if err == nil && jobID != 0 {
+			i.process(ctx, jobID)
+			continue
+		}
+
+		// Wait for a new job
+		select {
+		case <-ctx.Done():
+			return
+		case <-i.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// process imports the entries of a claimed job that were not reported yet and stores the result
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+func (i *Importer) process(ctx context.Context, jobID int) {
+	err := i.importEntries(ctx, jobID)
+	if ctx.Err() != nil {
+		// The job is interrupted by the shutdown and resumed on the next start
+		return
+	}
+	if err != nil && i.logger != nil {
+		i.logger.Error(
+			"Failed to import archive",
+			slog.Int("job_id", jobID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if finishErr := i.service.FinishImportJob(ctx, jobID, err); finishErr != nil && i.logger != nil {
+		i.logger.Error(
+			"Failed to store import result",
+			slog.Int("job_id", jobID),
+			slog.String("error", finishErr.Error()),
+		)
+	}
+}
+
+// importEntries reads the archive of a job and imports its entries after the last reported one
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - error: an error if the archive could not be read or an entry could not be reported
+func (i *Importer) importEntries(ctx context.Context, jobID int) error {
+	job, err := i.service.GetImportJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	archive, processed, err := i.service.GetImportArchive(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	// Read the archive
+	entries, err := Decode(Format(job.Format), archive)
+	if err != nil {
+		return err
+	}
+	if err = i.service.SetImportJobTotal(ctx, jobID, len(entries)); err != nil {
+		return err
+	}
+
+	// Import each entry, a failed entry is reported and does not stop the job
+	for index := processed; index < len(entries); index++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		entry := entries[index]
+		position := index + 1
+		if entry.Name == "" {
+			entry.Name = fmt.Sprintf("#%d", position)
+		}
+
+		if entry.Err == nil {
+			_, entry.Err = i.service.ImportRecipe(
+				ctx,
+				job.OwnerID,
+				jobID,
+				position,
+				entry.Recipe,
+				entry.Tags,
+			)
+			if entry.Err == nil || ctx.Err() != nil {
+				continue
+			}
+		}
+		if err = i.service.ReportImportFailure(ctx, jobID, position, entry.Name, entry.Err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,426 @@
+package bulk
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// mealMasterHeader is the first line of each recipe written by MealMaster
+	mealMasterHeader = "MMMMM----- Recipe via Meal-Master (tm) v8.05"
+
+	// mealMasterFooter is the last line of each recipe written by MealMaster
+	mealMasterFooter = "MMMMM"
+
+	// mealMasterWidth is the width the directions are wrapped at
+	mealMasterWidth = 72
+
+	// mealMasterColumnWidth is the width of each column of the two column ingredient layout
+	mealMasterColumnWidth = 41
+)
+
+var (
+	// mealMasterHeaderRegex matches the first line of a recipe, written either with M or dashes
+	mealMasterHeaderRegex = regexp.MustCompile(`(?i)^(?:MMMMM|-----).*meal-?master`)
+
+	// mealMasterFieldRegex matches the header fields of a recipe, such as "Title: Arepas"
+	mealMasterFieldRegex = regexp.MustCompile(`(?i)^\s*(title|categories|yield|servings)\s*:\s*(.*)$`)
+
+	// mealMasterQuantityRegex matches the quantity column of an ingredient line
+	mealMasterQuantityRegex = regexp.MustCompile(`^[\d ./]*$`)
+
+	// mealMasterSizes maps the MealMaster size codes to the words kept in the ingredient name, as the free text
+	// ingredients do in "2 large eggs"
+	mealMasterSizes = map[string]string{
+		"sm": "small",
+		"md": "medium",
+		"lg": "large",
+	}
+
+	// mealMasterUnits maps the MealMaster unit codes to the unit abbreviations of the recipes
+	mealMasterUnits = map[string]string{
+		"x":  "",
+		"ea": "",
+		"cn": "can",
+		"pk": "package",
+		"pn": "pinch",
+		"dr": "drop",
+		"ds": "dash",
+		"ct": "carton",
+		"bn": "bunch",
+		"sl": "slice",
+		"t":  "tsp",
+		"ts": "tsp",
+		"T":  "tbsp",
+		"tb": "tbsp",
+		"fl": "fl oz",
+		"c":  "cup",
+		"pt": "pt",
+		"qt": "qt",
+		"ga": "gal",
+		"oz": "oz",
+		"lb": "lb",
+		"ml": "ml",
+		"cb": "cm3",
+		"cl": "cl",
+		"dl": "dl",
+		"l":  "l",
+		"mg": "mg",
+		"cg": "cg",
+		"dg": "dg",
+		"g":  "g",
+		"kg": "kg",
+	}
+
+	// mealMasterCodes maps the unit abbreviations of the recipes to the MealMaster unit codes
+	mealMasterCodes = map[string]string{
+		"can":     "cn",
+		"package": "pk",
+		"pinch":   "pn",
+		"drop":    "dr",
+		"dash":    "ds",
+		"carton":  "ct",
+		"bunch":   "bn",
+		"slice":   "sl",
+		"tsp":     "ts",
+		"tbsp":    "tb",
+		"fl oz":   "fl",
+		"cup":     "c",
+		"pt":      "pt",
+		"qt":      "qt",
+		"gal":     "ga",
+		"oz":      "oz",
+		"lb":      "lb",
+		"ml":      "ml",
+		"cm3":     "cb",
+		"cl":      "cl",
+		"dl":      "dl",
+		"l":       "l",
+		"mg":      "mg",
+		"cg":      "cg",
+		"dg":      "dg",
+		"g":       "g",
+		"kg":      "kg",
+	}
+)
+
+type (
+	// mealMasterParser reads the recipes of a MealMaster file line by line
+	mealMasterParser struct {
+		entries    []Entry
+		recipe     *internalrouterapiv1recipe.Recipe
+		tags       []string
+		directions bool // true once the first direction line is read, so no more ingredients are expected
+		paragraph  []string
+	}
+)
+
+// decodeMealMaster reads a MealMaster file, which holds any number of recipes. Files that are not UTF-8 are read as
+// Windows-1252, the encoding MealMaster wrote
+//
+// Parameters:
+//
+//   - archive: the file
+//
+// Returns:
+//
+//   - []Entry: the entries
+//   - error: an error if the file could not be read
+func decodeMealMaster(archive []byte) ([]Entry, error) {
+	if !utf8.Valid(archive) {
+		decoded, err := charmap.Windows1252.NewDecoder().Bytes(archive)
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+		archive = decoded
+	}
+
+	var parser mealMasterParser
+	for _, line := range strings.Split(string(bytes.ReplaceAll(archive, []byte("\r\n"), []byte("\n"))), "\n") {
+		parser.readLine(line)
+		if len(parser.entries) > MaxEntries {
+			return nil, ErrTooManyEntries
+		}
+	}
+
+	// Keep the last recipe of a truncated file
+	parser.finishRecipe()
+	if len(parser.entries) > MaxEntries {
+		return nil, ErrTooManyEntries
+	}
+	return parser.entries, nil
+}
+
+// readLine reads a line of a MealMaster file
+//
+// Parameters:
+//
+//   - line: the line
+func (p *mealMasterParser) readLine(line string) {
+	line = strings.TrimRight(line, " \t")
+	trimmed := strings.TrimSpace(line)
+
+	// Start a recipe
+	if mealMasterHeaderRegex.MatchString(trimmed) {
+		p.finishRecipe()
+		p.recipe = &internalrouterapiv1recipe.Recipe{
+			Ingredients: []internalrouterapiv1recipe.Ingredient{},
+			Steps:       []string{},
+		}
+		return
+	}
+	if p.recipe == nil {
+		return
+	}
+
+	// Finish the recipe, or skip the section dividers such as "-----SAUCE-----"
+	if trimmed == "MMMMM" || trimmed == "-----" {
+		p.finishRecipe()
+		return
+	}
+	if strings.HasPrefix(trimmed, "MMMMM") || strings.HasPrefix(trimmed, "-----") {
+		return
+	}
+
+	// Read the header fields, which come before the ingredients
+	if len(p.recipe.Ingredients) == 0 && !p.directions {
+		if match := mealMasterFieldRegex.FindStringSubmatch(line); match != nil {
+			switch strings.ToLower(match[1]) {
+			case "title":
+				p.recipe.Name = strings.TrimSpace(match[2])
+			case "categories":
+				p.tags = append(p.tags, parseTags(match[2])...)
+			default:
+				p.recipe.Servings = parseServings(match[2])
+			}
+			return
+		}
+	}
+
+	// Blank lines separate the paragraphs of the directions
+	if trimmed == "" {
+		p.finishParagraph()
+		return
+	}
+
+	// Read the ingredients, written in one or two columns, until the directions start
+	if !p.directions {
+		if p.readIngredients(line) {
+			return
+		}
+		p.directions = true
+	}
+	p.paragraph = append(p.paragraph, trimmed)
+}
+
+// readIngredients reads an ingredient line, which may hold two ingredients side by side
+//
+// Parameters:
+//
+//   - line: the line
+//
+// Returns:
+//
+//   - bool: false if the line is not an ingredient line
+func (p *mealMasterParser) readIngredients(line string) bool {
+	columns := []string{line}
+	if len(line) > mealMasterColumnWidth && strings.TrimSpace(line[mealMasterColumnWidth-2:mealMasterColumnWidth]) == "" {
+		if _, ok := parseMealMasterIngredient(line[mealMasterColumnWidth:]); ok {
+			columns = []string{line[:mealMasterColumnWidth], line[mealMasterColumnWidth:]}
+		}
+	}
+
+	ingredients := make([]internalrouterapiv1recipe.Ingredient, 0, len(columns))
+	for _, column := range columns {
+		ingredient, ok := parseMealMasterIngredient(column)
+		if !ok {
+			return false
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+
+	for _, ingredient := range ingredients {
+		// Lines starting with a dash continue the previous ingredient
+		count := len(p.recipe.Ingredients)
+		if strings.HasPrefix(ingredient.Name, "-") && ingredient.Quantity == 0 && ingredient.Unit == "" && count > 0 {
+			previous := &p.recipe.Ingredients[count-1]
+			previous.Name += " " + strings.TrimSpace(strings.TrimPrefix(ingredient.Name, "-"))
+			continue
+		}
+		p.recipe.Ingredients = append(p.recipe.Ingredients, ingredient)
+	}
+	return true
+}
+
+// parseMealMasterIngredient parses an ingredient column, made of a 7 characters wide quantity, a 2 characters wide
+// unit code and the name, each separated by a space
+//
+// Parameters:
+//
+//   - column: the ingredient column
+//
+// Returns:
+//
+//   - internalrouterapiv1recipe.Ingredient: the ingredient
+//   - bool: false if the column is not an ingredient
+func parseMealMasterIngredient(column string) (internalrouterapiv1recipe.Ingredient, bool) {
+	if len(column) < 12 || column[7] != ' ' || column[10] != ' ' {
+		return internalrouterapiv1recipe.Ingredient{}, false
+	}
+	quantity, code, name := column[:7], strings.TrimSpace(column[8:10]), strings.TrimSpace(column[11:])
+	if !mealMasterQuantityRegex.MatchString(quantity) || name == "" {
+		return internalrouterapiv1recipe.Ingredient{}, false
+	}
+	if size, ok := mealMasterSizes[code]; ok {
+		code, name = "", size+" "+name
+	}
+	unit, ok := mealMasterUnits[code]
+	if !ok && code != "" {
+		return internalrouterapiv1recipe.Ingredient{}, false
+	}
+
+	ingredient := internalrouterapiv1recipe.Ingredient{Unit: unit, Name: name}
+	if quantity = strings.TrimSpace(quantity); quantity != "" {
+		ingredient.Quantity = internalimporter.ParseIngredient(quantity).Quantity
+	}
+	return ingredient, true
+}
+
+// finishParagraph adds the paragraph being read as a step
+func (p *mealMasterParser) finishParagraph() {
+	if len(p.paragraph) == 0 {
+		return
+	}
+	if step := strings.TrimSpace(stepNumberRegex.ReplaceAllString(strings.Join(p.paragraph, " "), "")); step != "" {
+		p.recipe.Steps = append(p.recipe.Steps, step)
+	}
+	p.paragraph = nil
+}
+
+// finishRecipe adds the recipe being read as an entry
+func (p *mealMasterParser) finishRecipe() {
+	if p.recipe == nil {
+		return
+	}
+	p.finishParagraph()
+	p.entries = append(p.entries, newEntry(p.recipe, p.tags))
+	p.recipe, p.tags, p.directions = nil, nil, false
+}
+
+// encodeMealMaster writes recipes as a MealMaster file. The format only holds the title, the categories, the yield,
+// the ingredients and the directions of each recipe
+//
+// Parameters:
+//
+//   - recipes: the recipes
+//
+// Returns:
+//
+//   - []byte: the file
+func encodeMealMaster(recipes []*internalrouterapiv1recipe.Recipe) []byte {
+	var b strings.Builder
+	for _, recipe := range recipes {
+		b.WriteString(mealMasterHeader + "\n\n")
+		b.WriteString("      Title: " + strings.Join(strings.Fields(recipe.Name), " ") + "\n")
+		b.WriteString(" Categories: " + strings.Join(tagNames(recipe), ", ") + "\n")
+		if recipe.Servings > 0 {
+			b.WriteString("      Yield: " + strconv.Itoa(recipe.Servings) + " servings\n")
+		}
+		b.WriteString("\n")
+
+		for _, ingredient := range recipe.Ingredients {
+			code, ok := mealMasterCodes[ingredient.Unit]
+			name := strings.Join(strings.Fields(ingredient.Name), " ")
+			if !ok && ingredient.Unit != "" {
+				// Keep the units MealMaster has no code for in the name
+				name = ingredient.Unit + " " + name
+			}
+			quantity := formatASCIIQuantity(ingredient.Quantity)
+			if len(quantity) > 7 {
+				// Keep the quantities that do not fit the column in the name
+				name, quantity = quantity+" "+name, ""
+			}
+			b.WriteString(padLeft(quantity, 7) + " " + padRight(code, 2) + " " + name + "\n")
+		}
+
+		for _, step := range recipe.Steps {
+			b.WriteString("\n")
+			for _, line := range wrapText(step, mealMasterWidth) {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+		b.WriteString("\n" + mealMasterFooter + "\n\n")
+	}
+	return []byte(b.String())
+}
+
+// padLeft pads a text with spaces on the left up to the given width
+//
+// Parameters:
+//
+//   - text: the text
+//   - width: the width
+//
+// Returns:
+//
+//   - string: the padded text
+func padLeft(text string, width int) string {
+	if count := width - utf8.RuneCountInString(text); count > 0 {
+		return strings.Repeat(" ", count) + text
+	}
+	return text
+}
+
+// padRight pads a text with spaces on the right up to the given width
+//
+// Parameters:
+//
+//   - text: the text
+//   - width: the width
+//
+// Returns:
+//
+//   - string: the padded text
+func padRight(text string, width int) string {
+	if count := width - utf8.RuneCountInString(text); count > 0 {
+		return text + strings.Repeat(" ", count)
+	}
+	return text
+}
+
+// wrapText wraps a text at the given width, breaking between words
+//
+// Parameters:
+//
+//   - text: the text
+//   - width: the maximum width of a line
+//
+// Returns:
+//
+//   - []string: the lines
+func wrapText(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
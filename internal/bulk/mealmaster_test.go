@@ -0,0 +1,244 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// checkEntries compares the decoded entries with the expected ones
+//
+// Parameters:
+//
+//   - t: the test
+//   - entries: the decoded entries
+//   - want: the expected entries
+func checkEntries(t *testing.T, entries, want []Entry) {
+	t.Helper()
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i := range want {
+		if entries[i].Name != want[i].Name {
+			t.Errorf("entry %d name = %q, want %q", i, entries[i].Name, want[i].Name)
+		}
+		// The decoding errors are not comparable, only their messages are
+		err, wantErr := entries[i].Err, want[i].Err
+		if (err == nil) != (wantErr == nil) || (err != nil && !errors.Is(err, wantErr) && err.Error() != wantErr.Error()) {
+			t.Errorf("entry %d error = %v, want %v", i, entries[i].Err, want[i].Err)
+		}
+		if !reflect.DeepEqual(entries[i].Recipe, want[i].Recipe) {
+			t.Errorf("entry %d recipe = %+v, want %+v", i, entries[i].Recipe, want[i].Recipe)
+		}
+		if !reflect.DeepEqual(entries[i].Tags, want[i].Tags) {
+			t.Errorf("entry %d tags = %q, want %q", i, entries[i].Tags, want[i].Tags)
+		}
+	}
+}
+
+// mealMasterRecipe writes a MealMaster recipe with the given title and no ingredients
+//
+// Parameters:
+//
+//   - title: the title
+//
+// Returns:
+//
+//   - string: the recipe
+func mealMasterRecipe(title string) string {
+	return mealMasterHeader + "\n      Title: " + title + "\n\n  Cook it.\nMMMMM\n"
+}
+
+func TestDecodeMealMaster(t *testing.T) {
+	arepas := mealMasterHeader + `
+
+      Title: Arepas
+ Categories: Venezuelan, Breads; Venezuelan
+      Yield: 4 servings
+
+` + fmt.Sprintf("%-41s%s", "      2 c  Corn flour", "      1 ts Salt") + `
+  2 1/2 c  Water
+           -warm
+      1 lg egg
+
+  1. Mix the flour and
+  the salt.
+
+  Add the water and knead.
+
+MMMMM
+`
+	arepasEntry := Entry{
+		Name: "Arepas",
+		Recipe: &internalrouterapiv1recipe.Recipe{
+			Name: "Arepas",
+			Ingredients: []internalrouterapiv1recipe.Ingredient{
+				{Quantity: 2, Unit: "cup", Name: "Corn flour"},
+				{Quantity: 1, Unit: "tsp", Name: "Salt"},
+				{Quantity: 2.5, Unit: "cup", Name: "Water warm"},
+				{Quantity: 1, Name: "large egg"},
+			},
+			Steps:    []string{"Mix the flour and the salt.", "Add the water and knead."},
+			Servings: 4,
+		},
+		Tags: []string{"Venezuelan", "Breads"},
+	}
+	simpleEntry := func(name string) Entry {
+		return Entry{
+			Name: name,
+			Recipe: &internalrouterapiv1recipe.Recipe{
+				Name:        name,
+				Ingredients: []internalrouterapiv1recipe.Ingredient{},
+				Steps:       []string{"Cook it."},
+			},
+			Tags: []string{},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		archive string
+		entries []Entry
+		err     error
+	}{
+		// Valid forms
+		{name: "recipe", archive: arepas, entries: []Entry{arepasEntry}},
+		{name: "windows line endings", archive: strings.ReplaceAll(arepas, "\n", "\r\n"), entries: []Entry{arepasEntry}},
+		{
+			name:    "several recipes with text around them",
+			archive: "Exported recipes\n\n" + mealMasterRecipe("Cachapas") + "\nsome text\n" + mealMasterRecipe("Hallacas"),
+			entries: []Entry{simpleEntry("Cachapas"), simpleEntry("Hallacas")},
+		},
+		{
+			name:    "header and footer written with dashes",
+			archive: "---------- Recipe via Meal-Master (tm) v8.05\n      Title: Cachapas\n\n  Cook it.\n-----\n",
+			entries: []Entry{simpleEntry("Cachapas")},
+		},
+		{
+			name:    "section dividers skipped",
+			archive: mealMasterHeader + "\n      Title: Cachapas\nMMMMM-----SAUCE-----\n  Cook it.\nMMMMM\n",
+			entries: []Entry{simpleEntry("Cachapas")},
+		},
+		{
+			name:    "windows-1252 encoding",
+			archive: mealMasterRecipe("Jalape\xf1os"),
+			entries: []Entry{simpleEntry("Jalapeños")},
+		},
+		{
+			name:    "truncated recipe kept",
+			archive: strings.TrimSuffix(mealMasterRecipe("Cachapas"), "MMMMM\n"),
+			entries: []Entry{simpleEntry("Cachapas")},
+		},
+
+		// Malformed forms
+		{
+			name:    "missing title",
+			archive: mealMasterHeader + "\n\n  Cook it.\nMMMMM\n",
+			entries: []Entry{{Err: ErrMissingName}},
+		},
+		{
+			name:    "unknown unit code starts the directions",
+			archive: mealMasterHeader + "\n      Title: Cachapas\n      1 zz corn\n  Cook it.\nMMMMM\n",
+			entries: []Entry{
+				{
+					Name: "Cachapas",
+					Recipe: &internalrouterapiv1recipe.Recipe{
+						Name:        "Cachapas",
+						Ingredients: []internalrouterapiv1recipe.Ingredient{},
+						Steps:       []string{"1 zz corn Cook it."},
+					},
+					Tags: []string{},
+				},
+			},
+		},
+		{name: "no header", archive: "Title: Cachapas\n\nCook it.\n", err: ErrNoRecipes},
+		{name: "empty", archive: "", err: ErrEmptyArchive},
+
+		// Limits
+		{
+			name:    "too many recipes",
+			archive: strings.Repeat(mealMasterRecipe("Cachapas"), MaxEntries+1),
+			err:     ErrTooManyEntries,
+		},
+		{
+			name:    "too many recipes with the last one truncated",
+			archive: strings.Repeat(mealMasterRecipe("Cachapas"), MaxEntries) + mealMasterHeader + "\n      Title: Hallacas\n",
+			err:     ErrTooManyEntries,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				entries, err := Decode(FormatMealMaster, []byte(test.archive))
+				if !errors.Is(err, test.err) {
+					t.Fatalf("Decode() error = %v, want %v", err, test.err)
+				}
+				checkEntries(t, entries, test.entries)
+			},
+		)
+	}
+
+	t.Run(
+		"most recipes", func(t *testing.T) {
+			entries, err := Decode(FormatMealMaster, []byte(strings.Repeat(mealMasterRecipe("Cachapas"), MaxEntries)))
+			if err != nil || len(entries) != MaxEntries {
+				t.Errorf("Decode() = %d entries, %v, want %d entries", len(entries), err, MaxEntries)
+			}
+		},
+	)
+}
+
+func TestEncodeMealMaster(t *testing.T) {
+	recipe := &internalrouterapiv1recipe.Recipe{
+		Name: "  Pabellón   criollo ",
+		Ingredients: []internalrouterapiv1recipe.Ingredient{
+			{Quantity: 1.5, Unit: "cup", Name: "black beans"},
+			{Quantity: 0.25, Unit: "tsp", Name: "cumin"},
+			{Quantity: 2, Unit: "clove", Name: "garlic"},
+			{Quantity: 12345678, Unit: "g", Name: "rice"},
+			{Name: "salt"},
+		},
+		Steps: []string{
+			"Cook the beans.",
+			strings.Repeat("Stir the shredded beef slowly ", 5) + "until tender.",
+		},
+		Servings: 6,
+		Tags:     []internalrouterapiv1recipe.Tag{{Name: "Venezuelan"}},
+	}
+
+	archive := encodeMealMaster([]*internalrouterapiv1recipe.Recipe{recipe})
+	for _, line := range strings.Split(string(archive), "\n") {
+		if len([]rune(line)) > mealMasterWidth+2 {
+			t.Errorf("line %q is wider than %d characters", line, mealMasterWidth+2)
+		}
+	}
+
+	entries, err := Decode(FormatMealMaster, archive)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	checkEntries(
+		t, entries, []Entry{
+			{
+				Name: "Pabellón criollo",
+				Recipe: &internalrouterapiv1recipe.Recipe{
+					Name: "Pabellón criollo",
+					Ingredients: []internalrouterapiv1recipe.Ingredient{
+						{Quantity: 1.5, Unit: "cup", Name: "black beans"},
+						{Quantity: 0.25, Unit: "tsp", Name: "cumin"},
+						{Quantity: 2, Name: "clove garlic"},
+						{Unit: "g", Name: "12345678 rice"},
+						{Name: "salt"},
+					},
+					Steps:    recipe.Steps,
+					Servings: 6,
+				},
+				Tags: []string{"Venezuelan"},
+			},
+		},
+	)
+}
@@ -0,0 +1,334 @@
+package bulk
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	internalexporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/exporter"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// paprikaExtension is the extension of each recipe of a Paprika archive
+	paprikaExtension = ".paprikarecipe"
+)
+
+var (
+	// zipMagic and gzipMagic are the leading bytes of the zip and gzip files
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+type (
+	// paprikaText is a Paprika text field, which some exporters write as a number
+	paprikaText string
+
+	// paprikaRecipe is a recipe of a Paprika archive
+	paprikaRecipe struct {
+		UID         string      `json:"uid"`
+		Name        string      `json:"name"`
+		Description string      `json:"description"`
+		Ingredients string      `json:"ingredients"`
+		Directions  string      `json:"directions"`
+		Notes       string      `json:"notes"`
+		Servings    paprikaText `json:"servings"`
+		PrepTime    paprikaText `json:"prep_time"`
+		CookTime    paprikaText `json:"cook_time"`
+		TotalTime   paprikaText `json:"total_time"`
+		Difficulty  string      `json:"difficulty"`
+		Source      string      `json:"source"`
+		SourceURL   string      `json:"source_url"`
+		ImageURL    string      `json:"image_url"`
+		Categories  []string    `json:"categories"`
+		Rating      int         `json:"rating"`
+		Hash        string      `json:"hash"`
+	}
+)
+
+// UnmarshalJSON reads a Paprika text field written as a text, a number or null
+//
+// Parameters:
+//
+//   - data: the JSON value
+//
+// Returns:
+//
+//   - error: an error if the value is neither a text nor a number
+func (p *paprikaText) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch typed := value.(type) {
+	case nil:
+		*p = ""
+	case string:
+		*p = paprikaText(typed)
+	case float64:
+		*p = paprikaText(strconv.FormatFloat(typed, 'f', -1, 64))
+	default:
+		return fmt.Errorf("unexpected JSON value %s", data)
+	}
+	return nil
+}
+
+// decodePaprika reads a Paprika archive, a zip of gzip compressed JSON recipes. A single recipe, either gzip
+// compressed or plain JSON, is read too
+//
+// Parameters:
+//
+//   - archive: the archive
+//
+// Returns:
+//
+//   - []Entry: the entries
+//   - error: an error if the archive could not be read
+func decodePaprika(archive []byte) ([]Entry, error) {
+	if !bytes.HasPrefix(archive, zipMagic) {
+		entry := decodePaprikaRecipe(bytes.NewReader(archive))
+		if entry.Err != nil && !errors.Is(entry.Err, ErrMissingName) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, entry.Err)
+		}
+		return []Entry{entry}, nil
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	var files []*zip.File
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() && !strings.HasPrefix(path.Base(file.Name), ".") {
+			files = append(files, file)
+		}
+	}
+	if len(files) > MaxEntries {
+		return nil, ErrTooManyEntries
+	}
+
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file.Name), paprikaExtension)
+		content, openErr := file.Open()
+		if openErr != nil {
+			entries = append(entries, Entry{Name: name, Err: openErr})
+			continue
+		}
+		entry := decodePaprikaRecipe(content)
+		_ = content.Close()
+		if entry.Name == "" {
+			entry.Name = name
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// decodePaprikaRecipe reads a recipe of a Paprika archive, either gzip compressed or plain JSON
+//
+// Parameters:
+//
+//   - content: the recipe file
+//
+// Returns:
+//
+//   - Entry: the entry
+func decodePaprikaRecipe(content io.Reader) Entry {
+	// Read the file, bounding its decompressed size
+	data, err := io.ReadAll(io.LimitReader(content, MaxEntrySize+1))
+	if err != nil {
+		return Entry{Err: err}
+	}
+	if bytes.HasPrefix(data, gzipMagic) {
+		decompressor, gzipErr := gzip.NewReader(bytes.NewReader(data))
+		if gzipErr != nil {
+			return Entry{Err: gzipErr}
+		}
+		data, err = io.ReadAll(io.LimitReader(decompressor, MaxEntrySize+1))
+		_ = decompressor.Close()
+		if err != nil {
+			return Entry{Err: err}
+		}
+	}
+	if len(data) > MaxEntrySize {
+		return Entry{Err: ErrEntryTooLarge}
+	}
+
+	var source paprikaRecipe
+	if err = json.Unmarshal(data, &source); err != nil {
+		return Entry{Err: err}
+	}
+
+	// Keep the notes, which have no field of their own, after the description
+	description := strings.TrimSpace(source.Description)
+	if notes := strings.TrimSpace(source.Notes); notes != "" {
+		description = strings.TrimSpace(description + "\n\n" + notes)
+	}
+
+	// Paprika only keeps the total time of some recipes, take it as the cooking time
+	cookingTime := parseTextDuration(string(source.CookTime))
+	preparationTime := parseTextDuration(string(source.PrepTime))
+	if cookingTime == 0 && preparationTime == 0 {
+		cookingTime = parseTextDuration(string(source.TotalTime))
+	}
+
+	entry := newEntry(
+		&internalrouterapiv1recipe.Recipe{
+			Name:            source.Name,
+			Description:     description,
+			PreparationTime: preparationTime,
+			CookingTime:     cookingTime,
+			Ingredients:     parseIngredients(source.Ingredients),
+			Steps:           parseSteps(source.Directions),
+			Servings:        parseServings(string(source.Servings)),
+			Difficulty:      strings.ToLower(strings.TrimSpace(source.Difficulty)),
+			SourceURL:       validURL(source.SourceURL),
+			ImageURL:        validURL(source.ImageURL),
+		},
+		source.Categories,
+	)
+	if entry.Err != nil {
+		// Fall back to the UID to name the failed entry
+		entry.Name = source.UID
+	}
+	return entry
+}
+
+// encodePaprika writes recipes as a Paprika archive
+//
+// Parameters:
+//
+//   - recipes: the recipes
+//
+// Returns:
+//
+//   - []byte: the archive
+//   - error: an error if the archive could not be written
+func encodePaprika(recipes []*internalrouterapiv1recipe.Recipe) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	names := make(map[string]int, len(recipes))
+	for _, recipe := range recipes {
+		target := &paprikaRecipe{
+			Name:        recipe.Name,
+			Description: recipe.Description,
+			Directions:  strings.Join(recipe.Steps, "\n"),
+			Difficulty:  recipe.Difficulty,
+			SourceURL:   recipe.SourceURL,
+			ImageURL:    recipe.ImageURL,
+			Categories:  tagNames(recipe),
+		}
+		ingredients := make([]string, 0, len(recipe.Ingredients))
+		for _, ingredient := range recipe.Ingredients {
+			ingredients = append(ingredients, internalexporter.FormatIngredient(ingredient))
+		}
+		target.Ingredients = strings.Join(ingredients, "\n")
+		if recipe.Servings > 0 {
+			target.Servings = paprikaText(strconv.Itoa(recipe.Servings))
+		}
+		if recipe.PreparationTime > 0 {
+			target.PrepTime = paprikaText(internalexporter.HumanDuration(recipe.PreparationTime))
+		}
+		if recipe.CookingTime > 0 {
+			target.CookTime = paprikaText(internalexporter.HumanDuration(recipe.CookingTime))
+		}
+		if total := recipe.PreparationTime + recipe.CookingTime; total > 0 {
+			target.TotalTime = paprikaText(internalexporter.HumanDuration(total))
+		}
+
+		// Identify the recipe, Paprika uses the hash to detect the recipes that changed
+		uid, err := newUUID()
+		if err != nil {
+			return nil, err
+		}
+		target.UID = uid
+		content, err := json.Marshal(target)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		target.Hash = strings.ToUpper(hex.EncodeToString(sum[:]))
+		if content, err = json.Marshal(target); err != nil {
+			return nil, err
+		}
+
+		// Write the gzip compressed recipe under a unique file name
+		file, err := archive.Create(paprikaFileName(recipe.Name, names))
+		if err != nil {
+			return nil, err
+		}
+		compressor := gzip.NewWriter(file)
+		if _, err = compressor.Write(content); err != nil {
+			return nil, err
+		}
+		if err = compressor.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// paprikaFileName returns the file name of a recipe of a Paprika archive, numbering the repeated names
+//
+// Parameters:
+//
+//   - name: the name of the recipe
+//   - names: the number of times each file name was used
+//
+// Returns:
+//
+//   - string: the file name
+func paprikaFileName(name string, names map[string]int) string {
+	name = strings.Map(
+		func(r rune) rune {
+			if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+				return '_'
+			}
+			return r
+		}, strings.TrimSpace(name),
+	)
+	if name == "" {
+		name = "Recipe"
+	}
+
+	names[strings.ToLower(name)]++
+	if count := names[strings.ToLower(name)]; count > 1 {
+		name = fmt.Sprintf("%s (%d)", name, count)
+	}
+	return name + paprikaExtension
+}
+
+// newUUID returns a random version 4 UUID in the uppercase form Paprika uses
+//
+// Returns:
+//
+//   - string: the UUID
+//   - error: an error if the random bytes could not be read
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return strings.ToUpper(
+		fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]),
+	), nil
+}
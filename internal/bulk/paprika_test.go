@@ -0,0 +1,284 @@
+package bulk
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// gzipBytes compresses a content with gzip
+//
+// Parameters:
+//
+//   - t: the test
+//   - content: the content
+//
+// Returns:
+//
+//   - []byte: the compressed content
+func gzipBytes(t *testing.T, content string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	compressor := gzip.NewWriter(&buffer)
+	if _, err := compressor.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := compressor.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// zipBytes writes the files of a zip archive, in order
+//
+// Parameters:
+//
+//   - t: the test
+//   - files: the names and contents of the files, a name ending in a slash is a directory
+//
+// Returns:
+//
+//   - []byte: the archive
+func zipBytes(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, file := range files {
+		writer, err := archive.Create(file[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = writer.Write([]byte(file[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestDecodePaprika(t *testing.T) {
+	tamales := `{
+		"uid": "A1",
+		"name": " Tamales ",
+		"description": "Corn dough",
+		"notes": "Freeze well",
+		"ingredients": "2 cups masa\n\n1 tsp salt\r\n",
+		"directions": "1. Mix\nStep 2: Steam",
+		"servings": 12,
+		"prep_time": "1 hr 30 mins",
+		"cook_time": 45,
+		"difficulty": " Hard ",
+		"source_url": "https://example.com/tamales",
+		"image_url": "ftp://example.com/tamales.jpg",
+		"categories": ["Mexican", " mexican ", "", "Mains"]
+	}`
+	tamalesEntry := Entry{
+		Name: "Tamales",
+		Recipe: &internalrouterapiv1recipe.Recipe{
+			Name:            "Tamales",
+			Description:     "Corn dough\n\nFreeze well",
+			PreparationTime: 90,
+			CookingTime:     45,
+			Ingredients: []internalrouterapiv1recipe.Ingredient{
+				{Quantity: 2, Unit: "cup", Name: "masa"},
+				{Quantity: 1, Unit: "tsp", Name: "salt"},
+			},
+			Steps:      []string{"Mix", "Steam"},
+			Servings:   12,
+			Difficulty: "hard",
+			SourceURL:  "https://example.com/tamales",
+		},
+		Tags: []string{"Mexican", "Mains"},
+	}
+	durationEntry := func(preparationTime, cookingTime int) Entry {
+		return Entry{
+			Name: "Soup",
+			Recipe: &internalrouterapiv1recipe.Recipe{
+				Name:            "Soup",
+				PreparationTime: preparationTime,
+				CookingTime:     cookingTime,
+				Ingredients:     []internalrouterapiv1recipe.Ingredient{},
+				Steps:           []string{},
+			},
+			Tags: []string{},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		entries []Entry
+		err     error
+	}{
+		// Valid forms
+		{name: "plain recipe", archive: []byte(tamales), entries: []Entry{tamalesEntry}},
+		{name: "gzip recipe", archive: gzipBytes(t, tamales), entries: []Entry{tamalesEntry}},
+		{
+			name: "archive",
+			archive: zipBytes(
+				t,
+				[2]string{"Recipes/", ""},
+				[2]string{"Recipes/Tamales.paprikarecipe", string(gzipBytes(t, tamales))},
+				[2]string{"Recipes/.DS_Store", "junk"},
+				[2]string{"Recipes/Soup.paprikarecipe", `{"name":"Soup","total_time":"PT1H"}`},
+			),
+			entries: []Entry{tamalesEntry, durationEntry(0, 60)},
+		},
+		{
+			name:    "total time taken as the cooking time",
+			archive: []byte(`{"name":"Soup","total_time":"2 horas"}`),
+			entries: []Entry{durationEntry(0, 120)},
+		},
+		{
+			name:    "total time ignored with a preparation time",
+			archive: []byte(`{"name":"Soup","prep_time":"PT10M","total_time":"PT1H"}`),
+			entries: []Entry{durationEntry(10, 0)},
+		},
+		{
+			name:    "null fields",
+			archive: []byte(`{"name":"Soup","servings":null,"prep_time":null,"categories":null}`),
+			entries: []Entry{durationEntry(0, 0)},
+		},
+
+		// Malformed forms
+		{
+			name:    "recipe without a name",
+			archive: []byte(`{"uid":"B2","name":"  "}`),
+			entries: []Entry{{Name: "B2", Err: ErrMissingName}},
+		},
+		{
+			name: "archive entries without a name or not valid",
+			archive: zipBytes(
+				t,
+				[2]string{"Nameless.paprikarecipe", `{"name":""}`},
+				[2]string{"Broken.paprikarecipe", `{"name":`},
+				[2]string{"Soup.paprikarecipe", `{"name":"Soup"}`},
+			),
+			entries: []Entry{
+				{Name: "Nameless", Err: ErrMissingName},
+				{Name: "Broken", Err: errors.New("unexpected end of JSON input")},
+				durationEntry(0, 0),
+			},
+		},
+		{name: "not json", archive: []byte("Tamales"), err: ErrInvalidArchive},
+		{name: "field of the wrong type", archive: []byte(`{"name":"Soup","servings":true}`), err: ErrInvalidArchive},
+		{name: "corrupted gzip", archive: append([]byte{0x1f, 0x8b}, "junk"...), err: ErrInvalidArchive},
+		{name: "corrupted archive", archive: []byte("PK\x03\x04junk"), err: ErrInvalidArchive},
+		{name: "archive without recipes", archive: zipBytes(t, [2]string{"Recipes/", ""}), err: ErrNoRecipes},
+		{name: "empty", archive: nil, err: ErrEmptyArchive},
+
+		// Limits
+		{
+			name:    "duration clamped",
+			archive: []byte(`{"name":"Soup","prep_time":"99999999999999999999 days"}`),
+			entries: []Entry{durationEntry(internalimporter.MaxDurationMinutes, 0)},
+		},
+		{
+			name:    "recipe too large",
+			archive: []byte(`{"name":"Soup","notes":"` + strings.Repeat("a", MaxEntrySize) + `"}`),
+			err:     ErrInvalidArchive,
+		},
+		{
+			name: "archive entry too large",
+			archive: zipBytes(
+				t,
+				[2]string{"Soup.paprikarecipe", string(gzipBytes(t, `{"notes":"`+strings.Repeat("a", MaxEntrySize)+`"}`))},
+			),
+			entries: []Entry{{Name: "Soup", Err: ErrEntryTooLarge}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				entries, err := Decode(FormatPaprika, test.archive)
+				if !errors.Is(err, test.err) {
+					t.Fatalf("Decode() error = %v, want %v", err, test.err)
+				}
+				checkEntries(t, entries, test.entries)
+			},
+		)
+	}
+
+	t.Run(
+		"too many recipes", func(t *testing.T) {
+			files := make([][2]string, 0, MaxEntries+1)
+			for i := 0; i <= MaxEntries; i++ {
+				files = append(files, [2]string{strconv.Itoa(i) + paprikaExtension, `{"name":"Soup"}`})
+			}
+			if _, err := Decode(FormatPaprika, zipBytes(t, files...)); !errors.Is(err, ErrTooManyEntries) {
+				t.Errorf("Decode() error = %v, want %v", err, ErrTooManyEntries)
+			}
+		},
+	)
+}
+
+func TestEncodePaprika(t *testing.T) {
+	recipe := &internalrouterapiv1recipe.Recipe{
+		Name:            "Tamales",
+		Description:     "Corn dough",
+		PreparationTime: 90,
+		CookingTime:     45,
+		Ingredients: []internalrouterapiv1recipe.Ingredient{
+			{Quantity: 2, Unit: "cup", Name: "masa"},
+			{Name: "salt"},
+		},
+		Steps:      []string{"Mix", "Steam"},
+		Servings:   12,
+		Difficulty: "hard",
+		SourceURL:  "https://example.com/tamales",
+		Tags:       []internalrouterapiv1recipe.Tag{{Name: "Mexican"}},
+	}
+	untitled := &internalrouterapiv1recipe.Recipe{Name: " a/b:c "}
+
+	archive, err := encodePaprika([]*internalrouterapiv1recipe.Recipe{recipe, recipe, untitled})
+	if err != nil {
+		t.Fatalf("encodePaprika() error = %v", err)
+	}
+
+	// The file names are unique and safe
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	wantNames := []string{"Tamales.paprikarecipe", "Tamales (2).paprikarecipe", "a_b_c.paprikarecipe"}
+	if strings.Join(names, "|") != strings.Join(wantNames, "|") {
+		t.Errorf("file names = %q, want %q", names, wantNames)
+	}
+
+	// The recipes read back as written
+	entries, err := Decode(FormatPaprika, archive)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	decoded := *recipe
+	decoded.Tags = nil
+	tamalesEntry := Entry{Name: "Tamales", Recipe: &decoded, Tags: []string{"Mexican"}}
+	checkEntries(
+		t, entries, []Entry{
+			tamalesEntry,
+			tamalesEntry,
+			{
+				Name: "a/b:c",
+				Recipe: &internalrouterapiv1recipe.Recipe{
+					Name:        "a/b:c",
+					Ingredients: []internalrouterapiv1recipe.Ingredient{},
+					Steps:       []string{},
+				},
+				Tags: []string{},
+			},
+		},
+	)
+}
@@ -0,0 +1,211 @@
+package bulk
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+var (
+	// textDurationRegex matches the amounts of a free text duration, such as "1 hr 30 mins" or "1 hora"
+	textDurationRegex = regexp.MustCompile(
+		`(?i)(\d+(?:[.,]\d+)?)\s*(d|days?|días?|dias?|h|hrs?|hours?|horas?|m|mins?|minutes?|minutos?)\b`,
+	)
+
+	// integerRegex matches the first integer of a text, used to read the servings out of a yield like "4 servings"
+	integerRegex = regexp.MustCompile(`\d+`)
+
+	// stepNumberRegex matches the numbering some apps write before each step, such as "1." or "Step 2:"
+	stepNumberRegex = regexp.MustCompile(`(?i)^(?:(?:step|paso)\s*\d+\s*[.):-]?|\d+\s*[.)])\s+`)
+
+	// asciiFractions are the fractions written by the plain text formats, by eighths
+	asciiFractions = []string{"", "1/8", "1/4", "1/3", "1/2", "2/3", "3/4", "7/8"}
+
+	// asciiFractionValues are the values of asciiFractions
+	asciiFractionValues = []float64{0, 0.125, 0.25, 1.0 / 3, 0.5, 2.0 / 3, 0.75, 0.875}
+)
+
+// parseTextDuration parses a duration written by a recipe manager, an ISO-8601 duration, a plain number of minutes
+// or a text such as "1 hr 30 mins", into whole minutes, clamped as the imported durations are
+//
+// Parameters:
+//
+//   - value: the duration
+//
+// Returns:
+//
+//   - int: the duration in minutes, or 0 if it could not be read
+func parseTextDuration(value string) int {
+	if minutes, ok := internalimporter.ParseDuration(value); ok {
+		return minutes
+	}
+
+	var minutes float64
+	for _, match := range textDurationRegex.FindAllStringSubmatch(value, -1) {
+		amount, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			continue
+		}
+		switch unit := strings.ToLower(match[2]); {
+		case strings.HasPrefix(unit, "d"):
+			minutes += amount * 24 * 60
+		case strings.HasPrefix(unit, "h"):
+			minutes += amount * 60
+		default:
+			minutes += amount
+		}
+	}
+	return int(math.Ceil(math.Min(minutes, internalimporter.MaxDurationMinutes)))
+}
+
+// parseServings reads the number of servings of a yield such as "4", "4 servings" or "4-6"
+//
+// Parameters:
+//
+//   - value: the yield
+//
+// Returns:
+//
+//   - int: the number of servings, or 0 if it is missing
+func parseServings(value string) int {
+	servings, err := strconv.Atoi(integerRegex.FindString(value))
+	if err != nil {
+		return 0
+	}
+	return servings
+}
+
+// splitLines splits a text into its non-empty trimmed lines
+//
+// Parameters:
+//
+//   - text: the text
+//
+// Returns:
+//
+//   - []string: the lines
+func splitLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseIngredients parses the ingredient lines of a text, one per line
+//
+// Parameters:
+//
+//   - text: the text
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Ingredient: the ingredients
+func parseIngredients(text string) []internalrouterapiv1recipe.Ingredient {
+	lines := splitLines(text)
+	ingredients := make([]internalrouterapiv1recipe.Ingredient, 0, len(lines))
+	for _, line := range lines {
+		ingredients = append(ingredients, internalimporter.ParseIngredient(line))
+	}
+	return ingredients
+}
+
+// parseSteps parses the steps of a text, one per line, removing their numbering
+//
+// Parameters:
+//
+//   - text: the text
+//
+// Returns:
+//
+//   - []string: the steps
+func parseSteps(text string) []string {
+	lines := splitLines(text)
+	steps := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if step := strings.TrimSpace(stepNumberRegex.ReplaceAllString(line, "")); step != "" {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// parseTags splits a list of labels separated by commas or semicolons
+//
+// Parameters:
+//
+//   - text: the labels
+//
+// Returns:
+//
+//   - []string: the labels
+func parseTags(text string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(
+		text, func(r rune) bool {
+			return r == ',' || r == ';'
+		},
+	) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// validURL returns a URL if it is an absolute http or https URL
+//
+// Parameters:
+//
+//   - value: the URL
+//
+// Returns:
+//
+//   - string: the URL, or an empty string if it is not valid
+func validURL(value string) string {
+	value = strings.TrimSpace(value)
+	if _, err := internalimporter.ParseURL(value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// formatASCIIQuantity formats a quantity with ASCII fractions, such as "1 1/2", for the plain text formats
+//
+// Parameters:
+//
+//   - quantity: the quantity
+//
+// Returns:
+//
+//   - string: the formatted quantity, empty when it is 0
+func formatASCIIQuantity(quantity float64) string {
+	if quantity <= 0 {
+		return ""
+	}
+	whole, fraction := math.Modf(quantity)
+	for i, value := range asciiFractionValues {
+		if math.Abs(fraction-value) > 0.01 {
+			continue
+		}
+		switch {
+		case i == 0:
+			return strconv.Itoa(int(whole))
+		case whole == 0:
+			return asciiFractions[i]
+		default:
+			return strconv.Itoa(int(whole)) + " " + asciiFractions[i]
+		}
+	}
+	if math.Abs(fraction-1) <= 0.01 {
+		return strconv.Itoa(int(whole) + 1)
+	}
+	return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,54 @@
+package cookbook
+
+import (
+	"log/slog"
+	"time"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+)
+
+const (
+	// Workers is the number of cookbooks generated at the same time
+	Workers = 2
+
+	// PollPeriod is the period the idle workers check for pending jobs the queue notification missed
+	PollPeriod = 30 * time.Second
+
+	// JobTimeout is the maximum time spent generating a cookbook
+	JobTimeout = 5 * time.Minute
+
+	// MaxImagePixels is the maximum number of pixels of a recipe image embedded in a cookbook
+	MaxImagePixels = 25_000_000
+
+	// ImageQuality is the JPEG quality of the images embedded in a cookbook
+	ImageQuality = 80
+
+	// LayoutVersion is the version of the PDF layout. It is part of the fingerprint, bump it when the layout
+	// changes so the cached cookbooks are generated again
+	LayoutVersion = 1
+)
+
+var (
+	// Jobs is the cookbook generator that runs the queued jobs
+	Jobs *Generator
+)
+
+// Load initializes the cookbook constants
+//
+// Parameters:
+//
+//   - service: The recipes SQLite service
+//   - fetcher: The fetcher used to download the recipe images
+//   - logger: The logger (optional, can be nil)
+func Load(
+	service *internalsqliterecipes.Service,
+	fetcher internalimporter.RecipeFetcher,
+	logger *slog.Logger,
+) {
+	generator, err := NewGenerator(service, fetcher, Workers, logger)
+	if err != nil {
+		panic(err)
+	}
+	Jobs = generator
+}
@@ -0,0 +1,12 @@
+package cookbook
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilGenerator          = errors.New("cookbook generator cannot be nil")
+	ErrNilRecipesService     = errors.New("recipes service cannot be nil")
+	ErrUnsupportedImage      = errors.New("unsupported recipe image")
+	ErrInvalidImageDimension = errors.New("recipe image is too large")
+)
@@ -0,0 +1,59 @@
+package cookbook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// Fingerprint hashes the content a cookbook is generated from, so a generated PDF is reused until the group, any of
+// its recipes or the translations they are printed in change
+//
+// Parameters:
+//
+//   - group: the group
+//   - recipes: the recipes of the group, in order, translated to the language of the cookbook
+//   - language: the language of the cookbook
+//
+// Returns:
+//
+//   - string: the hex encoded fingerprint
+//   - error: an error if the content could not be encoded
+func Fingerprint(
+	group *internalrouterapiv1recipe.Group,
+	recipes []*internalrouterapiv1recipe.Recipe,
+	language string,
+) (string, error) {
+	// The fork count and the languages of the other translations are not printed, so a new fork or translation to
+	// another language does not change the cookbook
+	printed := make([]internalrouterapiv1recipe.Recipe, len(recipes))
+	for i, recipe := range recipes {
+		printed[i] = *recipe
+		printed[i].ForkCount = 0
+		printed[i].Translations = nil
+	}
+
+	content, err := json.Marshal(
+		struct {
+			Version  int                                `json:"version"`
+			Language string                             `json:"language"`
+			Title    string                             `json:"title"`
+			Desc     string                             `json:"description"`
+			Recipes  []internalrouterapiv1recipe.Recipe `json:"recipes"`
+		}{
+			Version:  LayoutVersion,
+			Language: language,
+			Title:    group.Title,
+			Desc:     group.Description,
+			Recipes:  printed,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
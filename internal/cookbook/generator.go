@@ -0,0 +1,293 @@
+package cookbook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Generator generates the PDF cookbooks in the background. The jobs are queued in the database, so they survive
+	// restarts, and the workers are woken up when a job is queued
+	Generator struct {
+		service *internalsqliterecipes.Service
+		fetcher internalimporter.RecipeFetcher
+		workers int
+		wake    chan struct{}
+		logger  *slog.Logger
+	}
+)
+
+// NewGenerator creates a new Generator
+//
+// Parameters:
+//
+//   - service: the recipes SQLite service
+//   - fetcher: the fetcher used to download the recipe images (optional, can be nil to skip the images)
+//   - workers: the number of cookbooks generated at the same time
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Generator: the Generator instance
+//   - error: an error if the service is nil
+func NewGenerator(
+	service *internalsqliterecipes.Service,
+	fetcher internalimporter.RecipeFetcher,
+	workers int,
+	logger *slog.Logger,
+) (*Generator, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "cookbook_generator"),
+		)
+	}
+
+	return &Generator{
+		service: service,
+		fetcher: fetcher,
+		workers: workers,
+		wake:    make(chan struct{}, workers),
+		logger:  logger,
+	}, nil
+}
+
+// Start returns the jobs interrupted by the last shutdown to the queue and starts the workers, which stop when the
+// context is done
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the interrupted jobs could not be reset
+func (g *Generator) Start(ctx context.Context) error {
+	// Check if the generator is nil
+	if g == nil {
+		return ErrNilGenerator
+	}
+
+	if err := g.service.ResetRunningCookbookJobs(ctx); err != nil {
+		return err
+	}
+	for range g.workers {
+		go g.work(ctx)
+	}
+	return nil
+}
+
+// Request returns the cookbook job of a group for its current content, queuing a new one unless a job generated from
+// the same content is already done or in progress
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - language: the language of the cookbook
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the job
+//   - bool: true if a new job was queued
+//   - error: an error if the group does not exist, is not owned by the user or the job could not be queued
+func (g *Generator) Request(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+	language string,
+) (*internalrouterapiv1recipe.CookbookJob, bool, error) {
+	// Check if the generator is nil
+	if g == nil {
+		return nil, false, ErrNilGenerator
+	}
+
+	// Get the current content of the group
+	_, _, fingerprint, err := g.load(ctx, ownerID, groupID, language)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Reuse the job generated from the same content
+	job, err := g.service.FindCookbookJob(ctx, groupID, language, fingerprint)
+	if err != nil {
+		return nil, false, err
+	}
+	if job != nil {
+		return job, false, nil
+	}
+
+	// Queue a new job
+	job, err = g.service.CreateCookbookJob(ctx, ownerID, groupID, language, fingerprint)
+	if err != nil {
+		return nil, false, err
+	}
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+	return job, true, nil
+}
+
+// work claims and generates the pending jobs until the context is done
+//
+// Parameters:
+//
+//   - ctx: the context
+func (g *Generator) work(ctx context.Context) {
+	ticker := time.NewTicker(PollPeriod)
+	defer ticker.Stop()
+
+	for {
+		jobID, err := g.service.ClaimCookbookJob(ctx)
+		if err == nil && jobID != 0 {
+			g.generate(ctx, jobID)
+			continue
+		}
+
+		// Wait for a new job
+		select {
+		case <-ctx.Done():
+			return
+		case <-g.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// load loads the current content of a group, with its recipes translated to the language of the cookbook when they
+// have a translation to it, and fingerprints it
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - language: the language of the cookbook
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the group
+//   - []*internalrouterapiv1recipe.Recipe: the recipes of the group, in order
+//   - string: the fingerprint of the content
+//   - error: an error if the group does not exist, is not owned by the user or the content could not be loaded
+func (g *Generator) load(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+	language string,
+) (*internalrouterapiv1recipe.Group, []*internalrouterapiv1recipe.Recipe, string, error) {
+	group, recipes, err := g.service.ListGroupRecipes(ctx, ownerID, groupID, language)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err = g.service.TranslateRecipes(ctx, []string{language}, recipes...); err != nil {
+		return nil, nil, "", err
+	}
+	fingerprint, err := Fingerprint(group, recipes, language)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return group, recipes, fingerprint, nil
+}
+
+// generate generates the PDF of a claimed job and stores the result
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+func (g *Generator) generate(ctx context.Context, jobID int) {
+	jobCtx, cancel := context.WithTimeout(ctx, JobTimeout)
+	defer cancel()
+
+	fingerprint, pdf, err := g.render(jobCtx, jobID)
+	if errors.Is(err, internalsqliterecipes.ErrGroupNotFound) ||
+		errors.Is(err, internalsqliterecipes.ErrCookbookNotFound) {
+		// The group was deleted along with its jobs
+		return
+	}
+	if ctx.Err() != nil {
+		// The job is interrupted by the shutdown and queued again on the next start
+		return
+	}
+	if err != nil && g.logger != nil {
+		g.logger.Error(
+			"Failed to generate cookbook",
+			slog.Int("job_id", jobID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if finishErr := g.service.FinishCookbookJob(ctx, jobID, fingerprint, pdf, err); finishErr != nil &&
+		g.logger != nil {
+		g.logger.Error(
+			"Failed to store cookbook",
+			slog.Int("job_id", jobID),
+			slog.String("error", finishErr.Error()),
+		)
+	}
+}
+
+// render loads the current content of the group of a job and renders it
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - string: the fingerprint of the rendered content
+//   - []byte: the PDF
+//   - error: an error if the content could not be loaded or rendered
+func (g *Generator) render(ctx context.Context, jobID int) (string, []byte, error) {
+	job, err := g.service.GetCookbookJob(ctx, jobID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Load the content, which may have changed since the job was queued
+	group, recipes, fingerprint, err := g.load(ctx, job.OwnerID, job.GroupID, job.Language)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Load the images, a recipe whose image cannot be loaded is rendered without it
+	images := make(map[int][]byte)
+	for _, recipe := range recipes {
+		if recipe.ImageURL == "" || g.fetcher == nil {
+			continue
+		}
+		image, imageErr := loadImage(ctx, g.fetcher, recipe.ImageURL)
+		if imageErr != nil {
+			if g.logger != nil {
+				g.logger.Warn(
+					"Failed to load recipe image",
+					slog.Int("recipe_id", recipe.ID),
+					slog.String("error", imageErr.Error()),
+				)
+			}
+			continue
+		}
+		images[recipe.ID] = image
+	}
+
+	pdf, err := Render(group, recipes, images)
+	return fingerprint, pdf, err
+}
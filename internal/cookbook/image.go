@@ -0,0 +1,59 @@
+package cookbook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+)
+
+// loadImage downloads a recipe image and re-encodes it as a JPEG, so the PDF only embeds images it can read
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - fetcher: the fetcher used to download the image
+//   - rawURL: the URL of the image
+//
+// Returns:
+//
+//   - []byte: the JPEG image
+//   - error: an error if the image could not be downloaded or decoded
+func loadImage(
+	ctx context.Context,
+	fetcher internalimporter.RecipeFetcher,
+	rawURL string,
+) ([]byte, error) {
+	imageURL, err := internalimporter.ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fetcher.Fetch(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the dimensions before decoding, a small file can expand to a huge bitmap
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrInvalidImageDimension
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	var buffer bytes.Buffer
+	if err = jpeg.Encode(&buffer, decoded, &jpeg.Options{Quality: ImageQuality}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
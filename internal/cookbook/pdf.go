@@ -0,0 +1,313 @@
+package cookbook
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+
+	internalexporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/exporter"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// pageMargin is the margin of the pages, in millimeters
+	pageMargin = 20.0
+
+	// maxImageHeight is the maximum height of a recipe image, in millimeters
+	maxImageHeight = 90.0
+
+	// fontFamily is the font family of the cookbook, a core font so the PDF needs no font files
+	fontFamily = "Helvetica"
+)
+
+// Render renders a group of recipes as a PDF cookbook with a cover page, a table of contents, one recipe per page
+// and page numbers
+//
+// Parameters:
+//
+//   - group: the group
+//   - recipes: the recipes of the group, in order
+//   - images: the JPEG images of the recipes, keyed by recipe ID
+//
+// Returns:
+//
+//   - []byte: the PDF
+//   - error: an error if the PDF could not be rendered
+func Render(
+	group *internalrouterapiv1recipe.Group,
+	recipes []*internalrouterapiv1recipe.Recipe,
+	images map[int][]byte,
+) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(group.Title, true)
+	pdf.SetCreator("uru-mobiles-recipes-api", true)
+	pdf.AliasNbPages("{nb}")
+
+	// The core fonts use the cp1252 encoding, which covers the Spanish and most western European characters
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pageWidth, pageHeight := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*pageMargin
+
+	// Number every page but the cover
+	pdf.SetFooterFunc(
+		func() {
+			if pdf.PageNo() == 1 {
+				return
+			}
+			pdf.SetY(-pageMargin + 5)
+			pdf.SetFont(fontFamily, "I", 9)
+			pdf.SetTextColor(120, 120, 120)
+			pdf.CellFormat(
+				0, 10,
+				fmt.Sprintf("%d / {nb}", pdf.PageNo()),
+				"", 0, "C", false, 0, "",
+			)
+			pdf.SetTextColor(0, 0, 0)
+		},
+	)
+
+	renderCover(pdf, tr, group, len(recipes), pageHeight)
+
+	// Render the table of contents, the page numbers are aliases replaced once the recipes are rendered
+	pdf.AddPage()
+	pdf.SetFont(fontFamily, "B", 20)
+	pdf.CellFormat(0, 12, tr("Contents"), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetFont(fontFamily, "", 12)
+	links := make([]int, len(recipes))
+	for i, recipe := range recipes {
+		links[i] = pdf.AddLink()
+		pageColumnWidth := 15.0
+		pdf.CellFormat(
+			contentWidth-pageColumnWidth, 8,
+			fitText(pdf, tr(recipe.Name), contentWidth-pageColumnWidth-2),
+			"", 0, "L", false, links[i], "",
+		)
+		pdf.CellFormat(
+			pageColumnWidth, 8,
+			pageAlias(i),
+			"", 1, "R", false, links[i], "",
+		)
+	}
+
+	// Render the recipes
+	for i, recipe := range recipes {
+		pdf.AddPage()
+		pdf.SetLink(links[i], 0, -1)
+		pdf.RegisterAlias(pageAlias(i), strconv.Itoa(pdf.PageNo()))
+		renderRecipe(pdf, tr, recipe, images[recipe.ID], contentWidth)
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// pageAlias returns the alias of the page number of a recipe in the table of contents
+//
+// Parameters:
+//
+//   - index: the index of the recipe
+//
+// Returns:
+//
+//   - string: the alias
+func pageAlias(index int) string {
+	return fmt.Sprintf("{p%d}", index)
+}
+
+// renderCover renders the cover page
+//
+// Parameters:
+//
+//   - pdf: the PDF
+//   - tr: the text translator to the font encoding
+//   - group: the group
+//   - recipesCount: the number of recipes
+//   - pageHeight: the height of the page
+func renderCover(
+	pdf *gofpdf.Fpdf,
+	tr func(string) string,
+	group *internalrouterapiv1recipe.Group,
+	recipesCount int,
+	pageHeight float64,
+) {
+	pdf.AddPage()
+	pdf.SetY(pageHeight / 3)
+	pdf.SetFont(fontFamily, "B", 30)
+	pdf.MultiCell(0, 14, tr(group.Title), "", "C", false)
+	if group.Description != "" {
+		pdf.Ln(6)
+		pdf.SetFont(fontFamily, "I", 13)
+		pdf.MultiCell(0, 7, tr(group.Description), "", "C", false)
+	}
+
+	pdf.Ln(12)
+	pdf.SetFont(fontFamily, "", 11)
+	pdf.SetTextColor(100, 100, 100)
+	count := fmt.Sprintf("%d recipes", recipesCount)
+	if recipesCount == 1 {
+		count = "1 recipe"
+	}
+	pdf.CellFormat(0, 6, count, "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, time.Now().UTC().Format("January 2, 2006"), "", 1, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// renderRecipe renders a recipe starting at the current page
+//
+// Parameters:
+//
+//   - pdf: the PDF
+//   - tr: the text translator to the font encoding
+//   - recipe: the recipe
+//   - image: the JPEG image of the recipe, nil if it has none
+//   - contentWidth: the width of the page content
+func renderRecipe(
+	pdf *gofpdf.Fpdf,
+	tr func(string) string,
+	recipe *internalrouterapiv1recipe.Recipe,
+	image []byte,
+	contentWidth float64,
+) {
+	// Render the title
+	pdf.SetFont(fontFamily, "B", 20)
+	pdf.MultiCell(0, 9, tr(recipe.Name), "", "L", false)
+	pdf.Ln(3)
+
+	// Render the image, scaled to fit the content width and the maximum height
+	if image != nil {
+		name := fmt.Sprintf("recipe-%d", recipe.ID)
+		options := gofpdf.ImageOptions{ImageType: "JPG"}
+		info := pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(image))
+		if info != nil && pdf.Ok() {
+			width, height := info.Extent()
+			scale := contentWidth / width
+			if height*scale > maxImageHeight {
+				scale = maxImageHeight / height
+			}
+			width, height = width*scale, height*scale
+			pdf.ImageOptions(
+				name,
+				pageMargin+(contentWidth-width)/2,
+				pdf.GetY(),
+				width,
+				height,
+				true,
+				options,
+				0,
+				"",
+			)
+			pdf.Ln(4)
+		}
+	}
+
+	// Render the description and the details
+	if recipe.Description != "" {
+		pdf.SetFont(fontFamily, "I", 11)
+		pdf.MultiCell(0, 6, tr(recipe.Description), "", "L", false)
+		pdf.Ln(2)
+	}
+	if details := recipeDetails(recipe); details != "" {
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.MultiCell(0, 5, tr(details), "", "L", false)
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	// Render the ingredients and the steps
+	if len(recipe.Ingredients) > 0 {
+		renderHeading(pdf, tr("Ingredients"))
+		pdf.SetFont(fontFamily, "", 11)
+		for _, ingredient := range recipe.Ingredients {
+			pdf.MultiCell(
+				0, 6,
+				tr("•  "+internalexporter.FormatIngredient(ingredient)),
+				"", "L", false,
+			)
+		}
+	}
+	if len(recipe.Steps) > 0 {
+		renderHeading(pdf, tr("Steps"))
+		pdf.SetFont(fontFamily, "", 11)
+		for i, step := range recipe.Steps {
+			pdf.MultiCell(0, 6, tr(fmt.Sprintf("%d.  %s", i+1, step)), "", "L", false)
+			pdf.Ln(1.5)
+		}
+	}
+}
+
+// renderHeading renders a section heading of a recipe
+//
+// Parameters:
+//
+//   - pdf: the PDF
+//   - heading: the heading, already translated to the font encoding
+func renderHeading(pdf *gofpdf.Fpdf, heading string) {
+	pdf.Ln(5)
+	pdf.SetFont(fontFamily, "B", 14)
+	pdf.CellFormat(0, 8, heading, "B", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
+
+// recipeDetails formats the times, servings, difficulty and tags of a recipe in a single line
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//
+// Returns:
+//
+//   - string: the details
+func recipeDetails(recipe *internalrouterapiv1recipe.Recipe) string {
+	var details []string
+	if recipe.PreparationTime > 0 {
+		details = append(details, "Preparation: "+internalexporter.HumanDuration(recipe.PreparationTime))
+	}
+	if recipe.CookingTime > 0 {
+		details = append(details, "Cooking: "+internalexporter.HumanDuration(recipe.CookingTime))
+	}
+	if recipe.Servings > 0 {
+		details = append(details, fmt.Sprintf("Servings: %d", recipe.Servings))
+	}
+	if recipe.Difficulty != "" {
+		details = append(details, "Difficulty: "+recipe.Difficulty)
+	}
+	var tags []string
+	for _, tag := range recipe.Tags {
+		tags = append(tags, tag.Name)
+	}
+	if len(tags) > 0 {
+		details = append(details, strings.Join(tags, ", "))
+	}
+	return strings.Join(details, "   |   ")
+}
+
+// fitText shortens a text with an ellipsis until it fits the given width with the current font
+//
+// Parameters:
+//
+//   - pdf: the PDF
+//   - text: the text, already translated to the font encoding
+//   - width: the available width
+//
+// Returns:
+//
+//   - string: the text that fits
+func fitText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
@@ -27,6 +27,15 @@ const (
 
 	// EnvRateLimiterPeriod is the environment variable for the rate limiter period
 	EnvRateLimiterPeriod = "RATE_LIMITER_PERIOD"
+
+	// EnvPublicRateLimiterMaxRequests is the environment variable for the public endpoints rate limiter max requests
+	EnvPublicRateLimiterMaxRequests = "PUBLIC_RATE_LIMITER_MAX_REQUESTS"
+
+	// EnvPublicRateLimiterPeriod is the environment variable for the public endpoints rate limiter period
+	EnvPublicRateLimiterPeriod = "PUBLIC_RATE_LIMITER_PERIOD"
+
+	// PublicRateLimiterKeyPrefix is the prefix that keeps the public endpoints rate limiter counters apart
+	PublicRateLimiterKeyPrefix = "public"
 )
 
 var (
@@ -48,11 +57,20 @@ var (
 	// RateLimiterPeriod is the rate limiter period in seconds
 	RateLimiterPeriod time.Duration
 
+	// PublicRateLimiterMaxRequests is the public endpoints rate limiter max requests
+	PublicRateLimiterMaxRequests int
+
+	// PublicRateLimiterPeriod is the public endpoints rate limiter period in seconds
+	PublicRateLimiterPeriod time.Duration
+
 	// Client is the Redis client
 	Client *redis.Client
 
 	// RateLimiter is the Redis rate limiter client
 	RateLimiter goratelimiter.RateLimiter
+
+	// PublicRateLimiter is the Redis rate limiter client for the endpoints that need no session
+	PublicRateLimiter goratelimiter.RateLimiter
 )
 
 // Load initializes the Redis client
@@ -115,4 +133,38 @@ func Load() {
 		panic(err)
 	}
 	RateLimiter = rateLimiter
+
+	// Load the public endpoints rate limiter max requests
+	if err = internalloader.Loader.LoadIntVariable(
+		EnvPublicRateLimiterMaxRequests,
+		&PublicRateLimiterMaxRequests,
+	); err != nil {
+		panic(err)
+	}
+
+	// Load the public endpoints rate limiter period
+	if err = internalloader.Loader.LoadDurationVariable(
+		EnvPublicRateLimiterPeriod,
+		&PublicRateLimiterPeriod,
+	); err != nil {
+		panic(err)
+	}
+
+	// Create the public endpoints rate limiter
+	publicRateLimiter, err := goratelimiter.NewDefaultRateLimiter(
+		Client,
+		PublicRateLimiterMaxRequests,
+		PublicRateLimiterPeriod,
+	)
+	if err != nil {
+		panic(err)
+	}
+	prefixedPublicRateLimiter, err := NewPrefixedRateLimiter(
+		publicRateLimiter,
+		PublicRateLimiterKeyPrefix,
+	)
+	if err != nil {
+		panic(err)
+	}
+	PublicRateLimiter = prefixedPublicRateLimiter
 }
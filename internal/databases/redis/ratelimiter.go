@@ -0,0 +1,54 @@
+package redis
+
+import (
+	goratelimiter "github.com/ralvarezdev/go-rate-limiter/redis"
+	gostringsadd "github.com/ralvarezdev/go-strings/add"
+)
+
+type (
+	// PrefixedRateLimiter is a rate limiter that keeps its counters apart from the ones of other rate limiters sharing
+	// the same Redis database, by prefixing the IP address of the client
+	PrefixedRateLimiter struct {
+		rateLimiter goratelimiter.RateLimiter
+		prefix      string
+	}
+)
+
+// NewPrefixedRateLimiter creates a new prefixed rate limiter
+//
+// Parameters:
+//
+//   - rateLimiter: the rate limiter that keeps the counters
+//   - prefix: the prefix added to the IP address of the client
+//
+// Returns:
+//
+//   - *PrefixedRateLimiter: the prefixed rate limiter
+//   - error: an error if the rate limiter is nil
+func NewPrefixedRateLimiter(
+	rateLimiter goratelimiter.RateLimiter,
+	prefix string,
+) (*PrefixedRateLimiter, error) {
+	// Check if the rate limiter is nil
+	if rateLimiter == nil {
+		return nil, goratelimiter.ErrNilRateLimiter
+	}
+
+	return &PrefixedRateLimiter{
+		rateLimiter: rateLimiter,
+		prefix:      prefix,
+	}, nil
+}
+
+// Limit limits the rate of requests of a client
+//
+// Parameters:
+//
+//   - ip: the IP address of the client
+//
+// Returns:
+//
+//   - error: an error if the rate limit is exceeded or the counter could not be updated
+func (p *PrefixedRateLimiter) Limit(ip string) error {
+	return p.rateLimiter.Limit(gostringsadd.Prefixes(ip, goratelimiter.KeySeparator, p.prefix))
+}
@@ -6,6 +6,8 @@ import (
 
 	godatabasessql "github.com/ralvarezdev/go-databases/sql"
 	gojwtsyncsqlite "github.com/ralvarezdev/go-jwt/sync/sqlite"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
 )
 
 const (
@@ -18,6 +20,9 @@ const (
 	// RabbitMQConsumerDataSourceName is the data source name for RabbitMQ SQLite connection
 	RabbitMQConsumerDataSourceName = "file:rabbitmq_consumer.db?cache=shared&_journal_mode=WAL"
 
+	// RecipesDataSourceName is the data source name for the recipes SQLite connection
+	RecipesDataSourceName = "file:recipes.db?cache=shared&_journal_mode=WAL&_foreign_keys=on"
+
 	// MaxOpenConnections is the maximum number of open connections to the SQLite database
 	MaxOpenConnections = 10
 
@@ -52,6 +57,16 @@ var (
 		ConnectionMaxIdleTime: ConnectionMaxIdleTime,
 	}
 
+	// RecipesConfig is the recipes config
+	RecipesConfig = godatabasessql.Config{
+		DriverName:            DriverName,
+		DataSourceName:        RecipesDataSourceName,
+		MaxOpenConnections:    MaxOpenConnections,
+		MaxIdleConnections:    MaxIdleConnections,
+		ConnectionMaxLifetime: ConnectionMaxLifetime,
+		ConnectionMaxIdleTime: ConnectionMaxIdleTime,
+	}
+
 	// SyncSQLiteService is the JWT sync SQLite service
 	SyncSQLiteService godatabasessql.Service
 
@@ -60,6 +75,12 @@ var (
 
 	// TokenValidatorService is the JWT token validator SQLite service
 	TokenValidatorService godatabasessql.Service
+
+	// RecipesSQLiteService is the recipes SQLite service
+	RecipesSQLiteService godatabasessql.Service
+
+	// RecipesService is the recipes service
+	RecipesService *internalsqliterecipes.Service
 )
 
 // Load initializes the SQLite handlers and services
@@ -95,4 +116,23 @@ func Load(logger *slog.Logger) {
 		panic(err)
 	}
 	TokenValidatorService = tokenValidatorService
+
+	// Initialize the recipes SQLite service
+	recipesSQLiteService, err := godatabasessql.NewDefaultService(
+		&RecipesConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
+	RecipesSQLiteService = recipesSQLiteService
+
+	// Initialize the recipes service
+	recipesService, err := internalsqliterecipes.NewService(
+		RecipesSQLiteService,
+		logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+	RecipesService = recipesService
 }
@@ -0,0 +1,199 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// RecipeActivity is the count of one kind of activity on a recipe during a bucket of time
+	RecipeActivity struct {
+		RecipeID int
+		Kind     internalrouterapiv1recipe.ActivityKind
+		Bucket   int64 // start of the bucket, as a Unix timestamp
+		Count    int64
+	}
+)
+
+// UpsertRecipeActivity stores activity counters, keeping the highest count of each one. The counters of deleted
+// recipes are skipped
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - activity: the counters
+//
+// Returns:
+//
+//   - error: an error if the counters could not be stored
+func (d *Service) UpsertRecipeActivity(
+	ctx context.Context,
+	activity []*RecipeActivity,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+	if len(activity) == 0 {
+		return nil
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			stmt, err := tx.PrepareContext(ctx, UpsertRecipeActivityQuery)
+			if err != nil {
+				return err
+			}
+			defer stmt.Close()
+
+			for _, counter := range activity {
+				if _, err = stmt.ExecContext(
+					ctx,
+					counter.Kind,
+					counter.Bucket,
+					counter.Count,
+					counter.RecipeID,
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil,
+	); err != nil {
+		d.logError("Failed to store recipe activity", err)
+		return err
+	}
+	return nil
+}
+
+// ListRecipeActivity lists the activity counters of the buckets that start at or after the given time
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - since: the start of the oldest bucket, as a Unix timestamp
+//
+// Returns:
+//
+//   - []*RecipeActivity: the counters
+//   - error: an error if the counters could not be listed
+func (d *Service) ListRecipeActivity(
+	ctx context.Context,
+	since int64,
+) ([]*RecipeActivity, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListRecipeActivityQuery, since)
+	if err != nil {
+		d.logError("Failed to list recipe activity", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	activity := make([]*RecipeActivity, 0)
+	for rows.Next() {
+		var counter RecipeActivity
+		if err = rows.Scan(
+			&counter.RecipeID,
+			&counter.Kind,
+			&counter.Bucket,
+			&counter.Count,
+		); err != nil {
+			return nil, err
+		}
+		activity = append(activity, &counter)
+	}
+	return activity, rows.Err()
+}
+
+// DeleteRecipeActivity deletes the activity counters of the buckets that start before the given time
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - before: the start of the oldest bucket to keep, as a Unix timestamp
+//
+// Returns:
+//
+//   - error: an error if the counters could not be deleted
+func (d *Service) DeleteRecipeActivity(ctx context.Context, before int64) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.ExecContext(ctx, DeleteRecipeActivityQuery, before); err != nil {
+		d.logError("Failed to delete recipe activity", err)
+		return err
+	}
+	return nil
+}
+
+// ListPublicRecipesByIDs lists the public recipes with the given IDs, in the given order. The IDs of the recipes that
+// no longer exist or are not public are skipped
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - language: the language used to localize the tags
+//   - recipeIDs: the IDs of the recipes
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the recipes could not be listed
+func (d *Service) ListPublicRecipesByIDs(
+	ctx context.Context,
+	language string,
+	recipeIDs []int,
+) ([]*internalrouterapiv1recipe.Recipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+	if len(recipeIDs) == 0 {
+		return []*internalrouterapiv1recipe.Recipe{}, nil
+	}
+
+	encodedRecipeIDs, err := json.Marshal(recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	recipes, err := d.queryRecipes(ctx, language, &ListPublicRecipesByIDsQuery, string(encodedRecipeIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the given order
+	recipesByID := make(map[int]*internalrouterapiv1recipe.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		recipesByID[recipe.ID] = recipe
+	}
+	ordered := make([]*internalrouterapiv1recipe.Recipe, 0, len(recipes))
+	for _, recipeID := range recipeIDs {
+		if recipe, ok := recipesByID[recipeID]; ok {
+			ordered = append(ordered, recipe)
+		}
+	}
+	return ordered, nil
+}
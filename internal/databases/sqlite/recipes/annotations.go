@@ -0,0 +1,420 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// MaxRecipeAnnotations is the maximum number of annotations of a user on a recipe
+	MaxRecipeAnnotations = 100
+)
+
+// annotationAnchor gets the text an annotation is anchored to: the text of the annotated step or the name of the
+// annotated ingredient
+//
+// Parameters:
+//
+//   - recipe: the annotated recipe
+//   - target: the target of the annotation
+//   - position: the position of the step or ingredient, starting at 1, or 0 for the whole recipe
+//
+// Returns:
+//
+//   - string: the anchor, empty for the whole recipe
+//   - error: an error if the position does not match the target
+func annotationAnchor(
+	recipe *internalrouterapiv1recipe.Recipe,
+	target internalrouterapiv1recipe.AnnotationTarget,
+	position int,
+) (string, error) {
+	switch target {
+	case internalrouterapiv1recipe.AnnotationTargetRecipe:
+		if position != 0 {
+			return "", ErrInvalidAnnotationPosition
+		}
+		return "", nil
+	case internalrouterapiv1recipe.AnnotationTargetStep:
+		if position < 1 || position > len(recipe.Steps) {
+			return "", ErrInvalidAnnotationPosition
+		}
+		return recipe.Steps[position-1], nil
+	case internalrouterapiv1recipe.AnnotationTargetIngredient:
+		if position < 1 || position > len(recipe.Ingredients) {
+			return "", ErrInvalidAnnotationPosition
+		}
+		return recipe.Ingredients[position-1].Name, nil
+	default:
+		return "", ErrInvalidAnnotationTarget
+	}
+}
+
+// matchesAnchor checks if a step or ingredient of a recipe is the one an annotation is anchored to. Steps must keep
+// their text, ingredients their normalized name
+//
+// Parameters:
+//
+//   - recipe: the annotated recipe
+//   - target: the target of the annotation
+//   - index: the index of the step or ingredient
+//   - anchor: the anchor of the annotation
+//
+// Returns:
+//
+//   - bool: true if the step or ingredient matches the anchor
+func matchesAnchor(
+	recipe *internalrouterapiv1recipe.Recipe,
+	target internalrouterapiv1recipe.AnnotationTarget,
+	index int,
+	anchor string,
+) bool {
+	switch target {
+	case internalrouterapiv1recipe.AnnotationTargetStep:
+		return strings.TrimSpace(recipe.Steps[index]) == strings.TrimSpace(anchor)
+	case internalrouterapiv1recipe.AnnotationTargetIngredient:
+		return Slugify(recipe.Ingredients[index].Name) == Slugify(anchor)
+	default:
+		return false
+	}
+}
+
+// resolveAnnotation moves an annotation to the current position of the step or ingredient it is anchored to, or
+// marks it as outdated if the recipe no longer has it
+//
+// Parameters:
+//
+//   - recipe: the annotated recipe
+//   - annotation: the annotation
+//   - anchor: the anchor of the annotation
+func resolveAnnotation(
+	recipe *internalrouterapiv1recipe.Recipe,
+	annotation *internalrouterapiv1recipe.Annotation,
+	anchor string,
+) {
+	var count int
+	switch annotation.Target {
+	case internalrouterapiv1recipe.AnnotationTargetStep:
+		count = len(recipe.Steps)
+	case internalrouterapiv1recipe.AnnotationTargetIngredient:
+		count = len(recipe.Ingredients)
+	default:
+		return
+	}
+
+	// Keep the position if the step or ingredient did not move
+	if annotation.Position >= 1 && annotation.Position <= count &&
+		matchesAnchor(recipe, annotation.Target, annotation.Position-1, anchor) {
+		return
+	}
+
+	// Look for it elsewhere
+	for i := 0; i < count; i++ {
+		if matchesAnchor(recipe, annotation.Target, i, anchor) {
+			annotation.Position = i + 1
+			return
+		}
+	}
+	annotation.Outdated = true
+}
+
+// checkAnnotationContent checks that an annotation has a note or a substitute, and that only ingredient annotations
+// have a substitute
+//
+// Parameters:
+//
+//   - target: the target of the annotation
+//   - annotation: the annotation
+//
+// Returns:
+//
+//   - error: an error if the content is not valid
+func checkAnnotationContent(
+	target internalrouterapiv1recipe.AnnotationTarget,
+	annotation *internalrouterapiv1recipe.Annotation,
+) error {
+	if strings.TrimSpace(annotation.Note) == "" && strings.TrimSpace(annotation.Substitute) == "" {
+		return ErrEmptyAnnotation
+	}
+	if annotation.Substitute != "" && target != internalrouterapiv1recipe.AnnotationTargetIngredient {
+		return ErrInvalidAnnotationSubstitute
+	}
+	return nil
+}
+
+// CreateRecipeAnnotation attaches a private annotation of a user to a recipe they can read, one of its steps or one
+// of its ingredients
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//   - annotation: the annotation
+//
+// Returns:
+//
+//   - int: the ID of the created annotation
+//   - error: an error if the recipe does not exist, is private to another user or the annotation is not valid
+func (d *Service) CreateRecipeAnnotation(
+	ctx context.Context,
+	userID string,
+	recipeID int,
+	annotation *internalrouterapiv1recipe.Annotation,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the annotation is nil
+	if annotation == nil {
+		return 0, ErrNilAnnotation
+	}
+
+	// Validate the annotation
+	if !annotation.Target.IsValid() {
+		return 0, ErrInvalidAnnotationTarget
+	}
+	if err := checkAnnotationContent(annotation.Target, annotation); err != nil {
+		return 0, err
+	}
+
+	var annotationID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Get the recipe
+			recipe, scanErr := scanRecipe(tx.QueryRowContext(ctx, GetRecipeQuery, recipeID, userID))
+			if scanErr != nil {
+				if errors.Is(scanErr, sql.ErrNoRows) {
+					return ErrRecipeNotFound
+				}
+				return scanErr
+			}
+			if !canReadRecipe(userID, recipe.OwnerID, recipe.Visibility) {
+				return ErrRecipeNotFound
+			}
+
+			// Anchor the annotation
+			anchor, anchorErr := annotationAnchor(recipe, annotation.Target, annotation.Position)
+			if anchorErr != nil {
+				return anchorErr
+			}
+
+			// Check the number of annotations
+			var count int
+			if countErr := tx.QueryRowContext(
+				ctx,
+				CountRecipeAnnotationsQuery,
+				userID,
+				recipeID,
+			).Scan(&count); countErr != nil {
+				return countErr
+			}
+			if count >= MaxRecipeAnnotations {
+				return ErrInvalidRecipeAnnotationCount
+			}
+
+			// Insert the annotation
+			return tx.QueryRowContext(
+				ctx,
+				InsertRecipeAnnotationQuery,
+				userID,
+				recipeID,
+				annotation.Target,
+				annotation.Position,
+				anchor,
+				annotation.Note,
+				annotation.Substitute,
+			).Scan(&annotationID)
+		}, nil,
+	); err != nil {
+		d.logError("Failed to create recipe annotation", err)
+		return 0, err
+	}
+	return annotationID, nil
+}
+
+// UpdateRecipeAnnotation updates the note and substitute of a private annotation of a user on a recipe. The
+// annotated step or ingredient does not change
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//   - annotation: the annotation with the new content
+//
+// Returns:
+//
+//   - error: an error if the user has no such annotation or it is not valid
+func (d *Service) UpdateRecipeAnnotation(
+	ctx context.Context,
+	userID string,
+	recipeID int,
+	annotation *internalrouterapiv1recipe.Annotation,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Check if the annotation is nil
+	if annotation == nil {
+		return ErrNilAnnotation
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Get the target of the annotation
+			var target internalrouterapiv1recipe.AnnotationTarget
+			if scanErr := tx.QueryRowContext(
+				ctx,
+				GetRecipeAnnotationTargetQuery,
+				annotation.ID,
+				recipeID,
+				userID,
+			).Scan(&target); scanErr != nil {
+				if errors.Is(scanErr, sql.ErrNoRows) {
+					return ErrAnnotationNotFound
+				}
+				return scanErr
+			}
+
+			// Validate the content
+			if checkErr := checkAnnotationContent(target, annotation); checkErr != nil {
+				return checkErr
+			}
+
+			// Update the annotation
+			_, execErr := tx.ExecContext(
+				ctx,
+				UpdateRecipeAnnotationQuery,
+				annotation.Note,
+				annotation.Substitute,
+				annotation.ID,
+				recipeID,
+				userID,
+			)
+			return execErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to update recipe annotation", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteRecipeAnnotation deletes a private annotation of a user on a recipe
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//   - annotationID: the ID of the annotation
+//
+// Returns:
+//
+//   - error: an error if the user has no such annotation
+func (d *Service) DeleteRecipeAnnotation(
+	ctx context.Context,
+	userID string,
+	recipeID, annotationID int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	// Delete the annotation
+	result, err := db.ExecContext(ctx, DeleteRecipeAnnotationQuery, annotationID, recipeID, userID)
+	if err != nil {
+		d.logError("Failed to delete recipe annotation", err)
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAnnotationNotFound
+	}
+	return nil
+}
+
+// AnnotateRecipe merges the private annotations of a user into a recipe they read, moving each one to the current
+// position of its step or ingredient
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user that reads the recipe
+//   - recipe: the recipe, whose annotations are set
+//
+// Returns:
+//
+//   - error: an error if the annotations could not be listed
+func (d *Service) AnnotateRecipe(
+	ctx context.Context,
+	userID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return ErrNilRecipe
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, ListRecipeAnnotationsQuery, userID, recipe.ID)
+	if err != nil {
+		d.logError("Failed to list recipe annotations", err)
+		return err
+	}
+	defer rows.Close()
+
+	annotations := make([]internalrouterapiv1recipe.Annotation, 0)
+	for rows.Next() {
+		var annotation internalrouterapiv1recipe.Annotation
+		var anchor string
+		if err = rows.Scan(
+			&annotation.ID,
+			&annotation.Target,
+			&annotation.Position,
+			&anchor,
+			&annotation.Note,
+			&annotation.Substitute,
+			&annotation.UpdatedAt,
+		); err != nil {
+			return err
+		}
+		resolveAnnotation(recipe, &annotation, anchor)
+		annotations = append(annotations, annotation)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	recipe.Annotations = annotations
+	return nil
+}
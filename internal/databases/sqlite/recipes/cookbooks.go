@@ -0,0 +1,349 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// scanCookbookJob scans a cookbook job row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the scanned job
+//   - error: an error if the row could not be scanned
+func scanCookbookJob(row scanner) (*internalrouterapiv1recipe.CookbookJob, error) {
+	var job internalrouterapiv1recipe.CookbookJob
+	var finishedAt sql.NullTime
+	if err := row.Scan(
+		&job.ID,
+		&job.GroupID,
+		&job.OwnerID,
+		&job.Language,
+		&job.Status,
+		&job.Error,
+		&job.CreatedAt,
+		&finishedAt,
+	); err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
+
+// FindCookbookJob finds the latest job of a group generated, or being generated, from the given content
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - groupID: the ID of the group
+//   - language: the language of the cookbook
+//   - fingerprint: the fingerprint of the group content
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the job, or nil if there is none
+//   - error: an error if the job could not be queried
+func (d *Service) FindCookbookJob(
+	ctx context.Context,
+	groupID int,
+	language, fingerprint string,
+) (*internalrouterapiv1recipe.CookbookJob, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	row, err := d.QueryRowWithCtx(ctx, &FindCookbookJobQuery, groupID, language, fingerprint)
+	if err != nil {
+		d.logError("Failed to query cookbook job", err)
+		return nil, err
+	}
+	job, err := scanCookbookJob(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		d.logError("Failed to find cookbook job", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// CreateCookbookJob queues a cookbook job for a group, deleting the cookbooks generated from older content
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - language: the language of the cookbook
+//   - fingerprint: the fingerprint of the group content
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the pending job
+//   - error: an error if the job could not be created
+func (d *Service) CreateCookbookJob(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+	language, fingerprint string,
+) (*internalrouterapiv1recipe.CookbookJob, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	var job *internalrouterapiv1recipe.CookbookJob
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Delete the stale cookbooks
+			if _, execErr := tx.ExecContext(
+				ctx,
+				DeleteStaleCookbookJobsQuery,
+				groupID,
+				language,
+				fingerprint,
+			); execErr != nil {
+				return execErr
+			}
+
+			// Insert the job
+			var scanErr error
+			job, scanErr = scanCookbookJob(
+				tx.QueryRowContext(
+					ctx,
+					InsertCookbookJobQuery,
+					groupID,
+					ownerID,
+					language,
+					fingerprint,
+				),
+			)
+			return scanErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to create cookbook job", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetCookbookJob gets a cookbook job
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the job
+//   - error: an error if the job does not exist
+func (d *Service) GetCookbookJob(
+	ctx context.Context,
+	jobID int,
+) (*internalrouterapiv1recipe.CookbookJob, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	row, err := d.QueryRowWithCtx(ctx, &GetCookbookJobQuery, jobID)
+	if err != nil {
+		d.logError("Failed to query cookbook job", err)
+		return nil, err
+	}
+	job, err := scanCookbookJob(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCookbookNotFound
+		}
+		d.logError("Failed to get cookbook job", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetOwnedCookbookJob gets a cookbook job of a group owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the job
+//   - error: an error if the job does not exist or is not owned by the user
+func (d *Service) GetOwnedCookbookJob(
+	ctx context.Context,
+	ownerID string,
+	jobID int,
+) (*internalrouterapiv1recipe.CookbookJob, error) {
+	job, err := d.GetCookbookJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.OwnerID != ownerID {
+		return nil, ErrCookbookNotOwned
+	}
+	return job, nil
+}
+
+// GetCookbookPDF gets the PDF of a finished cookbook job of a group owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookbookJob: the job
+//   - []byte: the PDF
+//   - error: an error if the job does not exist, is not owned by the user or is not done
+func (d *Service) GetCookbookPDF(
+	ctx context.Context,
+	ownerID string,
+	jobID int,
+) (*internalrouterapiv1recipe.CookbookJob, []byte, error) {
+	job, err := d.GetOwnedCookbookJob(ctx, ownerID, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != internalrouterapiv1recipe.JobStatusDone {
+		return nil, nil, ErrCookbookNotReady
+	}
+
+	// Get the PDF
+	row, err := d.QueryRowWithCtx(ctx, &GetCookbookPDFQuery, jobID)
+	if err != nil {
+		d.logError("Failed to query cookbook PDF", err)
+		return nil, nil, err
+	}
+	var pdf []byte
+	if err = row.Scan(&pdf); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrCookbookNotFound
+		}
+		d.logError("Failed to get cookbook PDF", err)
+		return nil, nil, err
+	}
+	return job, pdf, nil
+}
+
+// ClaimCookbookJob marks the oldest pending cookbook job as running
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - int: the ID of the claimed job, or 0 if there are no pending jobs
+//   - error: an error if the job could not be claimed
+func (d *Service) ClaimCookbookJob(ctx context.Context) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	row, err := d.QueryRowWithCtx(ctx, &ClaimCookbookJobQuery)
+	if err != nil {
+		d.logError("Failed to query pending cookbook job", err)
+		return 0, err
+	}
+	var jobID int
+	if err = row.Scan(&jobID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		d.logError("Failed to claim cookbook job", err)
+		return 0, err
+	}
+	return jobID, nil
+}
+
+// ResetRunningCookbookJobs returns the cookbook jobs interrupted by a shutdown to the queue
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the jobs could not be reset
+func (d *Service) ResetRunningCookbookJobs(ctx context.Context) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if _, err := d.ExecWithCtx(ctx, &ResetRunningCookbookJobsQuery); err != nil {
+		d.logError("Failed to reset running cookbook jobs", err)
+		return err
+	}
+	return nil
+}
+
+// FinishCookbookJob stores the result of a cookbook job
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//   - fingerprint: the fingerprint of the content the PDF was generated from
+//   - pdf: the generated PDF, nil if the job failed
+//   - jobErr: the error that made the job fail, nil if it succeeded
+//
+// Returns:
+//
+//   - error: an error if the result could not be stored
+func (d *Service) FinishCookbookJob(
+	ctx context.Context,
+	jobID int,
+	fingerprint string,
+	pdf []byte,
+	jobErr error,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	status := internalrouterapiv1recipe.JobStatusDone
+	var message string
+	if jobErr != nil {
+		status = internalrouterapiv1recipe.JobStatusFailed
+		message = jobErr.Error()
+		pdf = nil
+	}
+
+	if _, err := d.ExecWithCtx(
+		ctx,
+		&FinishCookbookJobQuery,
+		status,
+		message,
+		pdf,
+		fingerprint,
+		jobID,
+	); err != nil {
+		d.logError("Failed to finish cookbook job", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,479 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// MaxCookRating is the highest rating of a cook log
+	MaxCookRating = 5
+
+	// MaxCookPhotos is the maximum number of photos of a cook log
+	MaxCookPhotos = 10
+
+	// MaxCookSubstitutions is the maximum number of substitutions of a cook log
+	MaxCookSubstitutions = 50
+)
+
+// scanCookLog scans a cook log row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookLog: the scanned cook log
+//   - error: an error if the row could not be scanned
+func scanCookLog(row scanner) (*internalrouterapiv1recipe.CookLog, error) {
+	var cook internalrouterapiv1recipe.CookLog
+	var recipeID sql.NullInt64
+	var substitutions, photos string
+	if err := row.Scan(
+		&cook.ID,
+		&cook.UserID,
+		&recipeID,
+		&cook.RecipeName,
+		&cook.CookedOn,
+		&cook.Notes,
+		&substitutions,
+		&cook.Rating,
+		&photos,
+		&cook.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if recipeID.Valid {
+		id := int(recipeID.Int64)
+		cook.RecipeID = &id
+	}
+	if err := json.Unmarshal([]byte(substitutions), &cook.Substitutions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(photos), &cook.Photos); err != nil {
+		return nil, err
+	}
+	return &cook, nil
+}
+
+// prepareCookLog validates a cook log and encodes its substitutions and photos. The date defaults to today
+//
+// Parameters:
+//
+//   - cook: the cook log, whose date is set if empty
+//
+// Returns:
+//
+//   - string: the substitutions, encoded as a JSON array
+//   - string: the photo URLs, encoded as a JSON array
+//   - error: an error if the cook log is not valid
+func prepareCookLog(cook *internalrouterapiv1recipe.CookLog) (string, string, error) {
+	// Check the date, a day ahead is allowed for the users ahead of UTC
+	now := time.Now().UTC()
+	if cook.CookedOn == "" {
+		cook.CookedOn = now.Format(time.DateOnly)
+	}
+	cookedOn, err := time.Parse(time.DateOnly, cook.CookedOn)
+	if err != nil || cookedOn.After(now.AddDate(0, 0, 1)) {
+		return "", "", ErrInvalidCookDate
+	}
+
+	// Check the rating
+	if cook.Rating < 0 || cook.Rating > MaxCookRating {
+		return "", "", ErrInvalidCookRating
+	}
+
+	// Check the substitutions
+	substitutions := cook.Substitutions
+	if substitutions == nil {
+		substitutions = []internalrouterapiv1recipe.CookSubstitution{}
+	}
+	if len(substitutions) > MaxCookSubstitutions {
+		return "", "", ErrInvalidCookSubstitutionsCount
+	}
+	for _, substitution := range substitutions {
+		if strings.TrimSpace(substitution.Ingredient) == "" || strings.TrimSpace(substitution.Substitute) == "" {
+			return "", "", ErrEmptyCookSubstitution
+		}
+	}
+
+	// Check the photos
+	photos := cook.Photos
+	if photos == nil {
+		photos = []string{}
+	}
+	if len(photos) > MaxCookPhotos {
+		return "", "", ErrInvalidCookPhotosCount
+	}
+	for _, photo := range photos {
+		parsedURL, parseErr := url.Parse(photo)
+		if parseErr != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return "", "", ErrInvalidCookPhotoURL
+		}
+	}
+
+	encodedSubstitutions, err := json.Marshal(substitutions)
+	if err != nil {
+		return "", "", err
+	}
+	encodedPhotos, err := json.Marshal(photos)
+	if err != nil {
+		return "", "", err
+	}
+	return string(encodedSubstitutions), string(encodedPhotos), nil
+}
+
+// LogCook logs that a user cooked a recipe they can read
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user that cooked the recipe
+//   - recipeID: the ID of the recipe
+//   - cook: the cook log, cooked today unless a date is set
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookLog: the created cook log
+//   - error: an error if the recipe does not exist, is private to another user or the cook log is not valid
+func (d *Service) LogCook(
+	ctx context.Context,
+	userID string,
+	recipeID int,
+	cook *internalrouterapiv1recipe.CookLog,
+) (*internalrouterapiv1recipe.CookLog, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check if the cook log is nil
+	if cook == nil {
+		return nil, ErrNilCookLog
+	}
+
+	// Validate the cook log
+	substitutions, photos, err := prepareCookLog(cook)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *internalrouterapiv1recipe.CookLog
+	if err = d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check if the user can read the recipe
+			if checkErr := checkRecipeVisibility(ctx, tx, recipeID, userID); checkErr != nil {
+				return checkErr
+			}
+
+			// Insert the cook log
+			result, execErr := tx.ExecContext(
+				ctx,
+				InsertCookLogQuery,
+				userID,
+				cook.CookedOn,
+				cook.Notes,
+				substitutions,
+				cook.Rating,
+				photos,
+				recipeID,
+			)
+			if execErr != nil {
+				return execErr
+			}
+			cookID, idErr := result.LastInsertId()
+			if idErr != nil {
+				return idErr
+			}
+
+			var scanErr error
+			created, scanErr = scanCookLog(tx.QueryRowContext(ctx, GetCookLogQuery, cookID))
+			return scanErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to log cook", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateCookLog updates the date, notes, substitutions, rating and photos of a cook log of the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user that cooked the recipe
+//   - cook: the cook log with the new content
+//
+// Returns:
+//
+//   - error: an error if the cook log does not exist, belongs to another user or is not valid
+func (d *Service) UpdateCookLog(
+	ctx context.Context,
+	userID string,
+	cook *internalrouterapiv1recipe.CookLog,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Check if the cook log is nil
+	if cook == nil {
+		return ErrNilCookLog
+	}
+
+	// Validate the cook log
+	substitutions, photos, err := prepareCookLog(cook)
+	if err != nil {
+		return err
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	// Update the cook log
+	result, err := db.ExecContext(
+		ctx,
+		UpdateCookLogQuery,
+		cook.CookedOn,
+		cook.Notes,
+		substitutions,
+		cook.Rating,
+		photos,
+		cook.ID,
+		userID,
+	)
+	if err != nil {
+		d.logError("Failed to update cook log", err)
+		return err
+	}
+	return checkAffectedCookLog(ctx, db, result, cook.ID, userID)
+}
+
+// DeleteCookLog deletes a cook log of the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user that cooked the recipe
+//   - cookID: the ID of the cook log
+//
+// Returns:
+//
+//   - error: an error if the cook log does not exist or belongs to another user
+func (d *Service) DeleteCookLog(
+	ctx context.Context,
+	userID string,
+	cookID int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	// Delete the cook log
+	result, err := db.ExecContext(ctx, DeleteCookLogQuery, cookID, userID)
+	if err != nil {
+		d.logError("Failed to delete cook log", err)
+		return err
+	}
+	return checkAffectedCookLog(ctx, db, result, cookID, userID)
+}
+
+// checkAffectedCookLog checks that a write over a cook log of the given user affected a row
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the cook log, either the database or a transaction
+//   - result: the result of the write
+//   - cookID: the ID of the cook log
+//   - userID: the ID of the user that tried to write the cook log
+//
+// Returns:
+//
+//   - error: ErrCookLogNotFound or ErrCookLogNotOwned if no row was affected
+func checkAffectedCookLog(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	result sql.Result,
+	cookID int,
+	userID string,
+) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	cook, err := scanCookLog(q.QueryRowContext(ctx, GetCookLogQuery, cookID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCookLogNotFound
+		}
+		return err
+	}
+	if cook.UserID != userID {
+		return ErrCookLogNotOwned
+	}
+	return ErrCookLogNotFound
+}
+
+// queryCookLogs runs a query that returns cook log rows
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - query: the query to run
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.CookLog: the cook logs
+//   - error: an error if the cook logs could not be queried
+func (d *Service) queryCookLogs(
+	ctx context.Context,
+	query *string,
+	params ...any,
+) ([]*internalrouterapiv1recipe.CookLog, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to list cook logs", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	cooks := make([]*internalrouterapiv1recipe.CookLog, 0)
+	for rows.Next() {
+		cook, scanErr := scanCookLog(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		cooks = append(cooks, cook)
+	}
+	return cooks, rows.Err()
+}
+
+// ListCookLogs lists the cooking journal of a user, most recently cooked first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - limit: the maximum number of cook logs to return
+//   - offset: the number of cook logs to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.CookLog: the cook logs
+//   - error: an error if the cook logs could not be listed
+func (d *Service) ListCookLogs(
+	ctx context.Context,
+	userID string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.CookLog, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryCookLogs(ctx, &ListCookLogsByUserIDQuery, userID, limit, offset)
+}
+
+// ListRecipeCookLogs gets the cook stats of a recipe the given user can read and lists the times the user cooked it,
+// most recently cooked first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//   - limit: the maximum number of cook logs to return
+//   - offset: the number of cook logs to skip
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookStats: the cook stats of the recipe
+//   - []*internalrouterapiv1recipe.CookLog: the cook logs of the user
+//   - error: an error if the recipe does not exist, is private to another user or the cook logs could not be listed
+func (d *Service) ListRecipeCookLogs(
+	ctx context.Context,
+	userID string,
+	recipeID int,
+	limit, offset int,
+) (*internalrouterapiv1recipe.CookStats, []*internalrouterapiv1recipe.CookLog, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check if the user can read the recipe
+	if err = checkRecipeVisibility(ctx, db, recipeID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	// Get the stats
+	stats := internalrouterapiv1recipe.CookStats{RecipeID: recipeID}
+	var averageRating sql.NullFloat64
+	var lastCookedOn sql.NullString
+	if err = db.QueryRowContext(ctx, GetRecipeCookStatsQuery, recipeID, userID).Scan(
+		&stats.CookCount,
+		&stats.CookUserCount,
+		&stats.RatingCount,
+		&averageRating,
+		&stats.MyCookCount,
+		&lastCookedOn,
+	); err != nil {
+		d.logError("Failed to get recipe cook stats", err)
+		return nil, nil, err
+	}
+	if averageRating.Valid {
+		stats.AverageRating = &averageRating.Float64
+	}
+	if lastCookedOn.Valid {
+		stats.MyLastCookedOn = &lastCookedOn.String
+	}
+
+	// List the cook logs of the user
+	cooks, err := d.queryCookLogs(ctx, &ListRecipeCookLogsByUserIDQuery, userID, recipeID, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &stats, cooks, nil
+}
@@ -0,0 +1,108 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// GetUserDiets gets the dietary preferences of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Diet: the diets of the user, empty if they have not set any
+//   - error: an error if the diets could not be read
+func (d *Service) GetUserDiets(
+	ctx context.Context,
+	userID string,
+) ([]internalrouterapiv1recipe.Diet, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	var encodedDiets string
+	if err = db.QueryRowContext(ctx, GetUserDietsQuery, userID).Scan(&encodedDiets); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []internalrouterapiv1recipe.Diet{}, nil
+		}
+		d.logError("Failed to get user diets", err)
+		return nil, err
+	}
+
+	diets := make([]internalrouterapiv1recipe.Diet, 0)
+	if err = json.Unmarshal([]byte(encodedDiets), &diets); err != nil {
+		return nil, err
+	}
+	return diets, nil
+}
+
+// SetUserDiets replaces the dietary preferences of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - diets: the diets of the user, repeated ones are stored once
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Diet: the stored diets
+//   - error: an error if a diet is not valid or the diets could not be stored
+func (d *Service) SetUserDiets(
+	ctx context.Context,
+	userID string,
+	diets []internalrouterapiv1recipe.Diet,
+) ([]internalrouterapiv1recipe.Diet, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Validate and deduplicate the diets
+	seen := make(map[internalrouterapiv1recipe.Diet]bool, len(diets))
+	uniqueDiets := make([]internalrouterapiv1recipe.Diet, 0, len(diets))
+	for _, diet := range diets {
+		if !diet.IsValid() {
+			return nil, ErrInvalidDiet
+		}
+		if seen[diet] {
+			continue
+		}
+		seen[diet] = true
+		uniqueDiets = append(uniqueDiets, diet)
+	}
+
+	encodedDiets, err := json.Marshal(uniqueDiets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = db.ExecContext(ctx, UpsertUserDietsQuery, userID, string(encodedDiets)); err != nil {
+		d.logError("Failed to set user diets", err)
+		return nil, err
+	}
+	return uniqueDiets, nil
+}
@@ -0,0 +1,429 @@
+package recipes
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// ShingleSize is the number of characters of each name shingle
+	ShingleSize = 3
+
+	// DuplicateThreshold is the minimum similarity of two fingerprints for their recipes to be likely duplicates
+	DuplicateThreshold = 0.55
+)
+
+type (
+	// Fingerprint is the normalized form of a recipe compared to find likely duplicates: the shingles of its name and
+	// the set of its ingredient names, both without accents, case or punctuation
+	Fingerprint struct {
+		Shingles    []string `json:"shingles"`
+		Ingredients []string `json:"ingredients"`
+	}
+)
+
+// NewFingerprint computes the fingerprint of a recipe
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//
+// Returns:
+//
+//   - *Fingerprint: the fingerprint
+func NewFingerprint(recipe *internalrouterapiv1recipe.Recipe) *Fingerprint {
+	// Split the name into overlapping shingles, so reordered or misspelled words still match
+	name := []rune(strings.ReplaceAll(Slugify(recipe.Name), "-", " "))
+	shingles := make([]string, 0, len(name))
+	if len(name) > 0 && len(name) < ShingleSize {
+		shingles = append(shingles, string(name))
+	}
+	for i := 0; i+ShingleSize <= len(name); i++ {
+		shingles = append(shingles, string(name[i:i+ShingleSize]))
+	}
+	slices.Sort(shingles)
+
+	// Keep the set of ingredient names, ignoring quantities and units
+	ingredients := make([]string, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		if slug := Slugify(ingredient.Name); slug != "" {
+			ingredients = append(ingredients, slug)
+		}
+	}
+	slices.Sort(ingredients)
+
+	return &Fingerprint{
+		Shingles:    slices.Compact(shingles),
+		Ingredients: slices.Compact(ingredients),
+	}
+}
+
+// jaccard computes the Jaccard index of two sorted sets
+//
+// Parameters:
+//
+//   - a: the first set
+//   - b: the second set
+//
+// Returns:
+//
+//   - float64: the size of the intersection over the size of the union, 0 if both sets are empty
+func jaccard(a, b []string) float64 {
+	var shared, i, j int
+	for i < len(a) && j < len(b) {
+		switch strings.Compare(a[i], b[j]) {
+		case 0:
+			shared++
+			i++
+			j++
+		case -1:
+			i++
+		default:
+			j++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// Similarity computes how similar two fingerprints are, averaging the similarity of their names and their
+// ingredients. When neither recipe lists ingredients, only the names are compared
+//
+// Parameters:
+//
+//   - other: the other fingerprint
+//
+// Returns:
+//
+//   - float64: the similarity, from 0 to 1
+func (f *Fingerprint) Similarity(other *Fingerprint) float64 {
+	nameSimilarity := jaccard(f.Shingles, other.Shingles)
+	if len(f.Ingredients) == 0 && len(other.Ingredients) == 0 {
+		return nameSimilarity
+	}
+	return (nameSimilarity + jaccard(f.Ingredients, other.Ingredients)) / 2
+}
+
+// encodeFingerprint computes and encodes the fingerprint of a recipe to store it
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//
+// Returns:
+//
+//   - string: the fingerprint, encoded as a JSON object
+//   - error: an error if the fingerprint could not be encoded
+func encodeFingerprint(recipe *internalrouterapiv1recipe.Recipe) (string, error) {
+	encodedFingerprint, err := json.Marshal(NewFingerprint(recipe))
+	if err != nil {
+		return "", err
+	}
+	return string(encodedFingerprint), nil
+}
+
+// backfillFingerprints computes the fingerprints of the recipes created before they were kept, so they are found as
+// duplicates too
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the recipes could not be listed or updated
+func (d *Service) backfillFingerprints(ctx context.Context) error {
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// List the recipes without a fingerprint
+			rows, err := tx.QueryContext(ctx, ListUnfingerprintedRecipesQuery)
+			if err != nil {
+				return err
+			}
+			recipes := make([]*internalrouterapiv1recipe.Recipe, 0)
+			for rows.Next() {
+				var recipe internalrouterapiv1recipe.Recipe
+				var ingredients string
+				if err = rows.Scan(&recipe.ID, &recipe.Name, &ingredients); err != nil {
+					rows.Close()
+					return err
+				}
+				if err = json.Unmarshal([]byte(ingredients), &recipe.Ingredients); err != nil {
+					rows.Close()
+					return err
+				}
+				recipes = append(recipes, &recipe)
+			}
+			rows.Close()
+			if err = rows.Err(); err != nil {
+				return err
+			}
+
+			// Store their fingerprints
+			for _, recipe := range recipes {
+				fingerprint, fingerprintErr := encodeFingerprint(recipe)
+				if fingerprintErr != nil {
+					return fingerprintErr
+				}
+				if _, err = tx.ExecContext(ctx, SetRecipeFingerprintQuery, fingerprint, recipe.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil,
+	); err != nil {
+		d.logError("Failed to backfill recipe fingerprints", err)
+		return err
+	}
+	return nil
+}
+
+// findDuplicateRecipes finds the recipes of a user likely to be duplicates of a recipe, most similar first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to list the recipes, either the database or a transaction
+//   - ownerID: the ID of the user
+//   - recipe: the recipe, whose ID is left out of the comparison when set
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Duplicate: the likely duplicates
+//   - error: an error if the recipes could not be listed
+func findDuplicateRecipes(
+	ctx context.Context,
+	q interface {
+		QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	},
+	ownerID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+) ([]*internalrouterapiv1recipe.Duplicate, error) {
+	rows, err := q.QueryContext(ctx, ListRecipeFingerprintsByOwnerIDQuery, ownerID, recipe.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fingerprint := NewFingerprint(recipe)
+	duplicates := make([]*internalrouterapiv1recipe.Duplicate, 0)
+	for rows.Next() {
+		var duplicate internalrouterapiv1recipe.Duplicate
+		var encodedFingerprint string
+		if err = rows.Scan(&duplicate.ID, &duplicate.Name, &encodedFingerprint); err != nil {
+			return nil, err
+		}
+
+		var other Fingerprint
+		if err = json.Unmarshal([]byte(encodedFingerprint), &other); err != nil {
+			return nil, err
+		}
+		if duplicate.Similarity = fingerprint.Similarity(&other); duplicate.Similarity >= DuplicateThreshold {
+			duplicates = append(duplicates, &duplicate)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(
+		duplicates, func(a, b *internalrouterapiv1recipe.Duplicate) int {
+			if c := cmp.Compare(b.Similarity, a.Similarity); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.ID, a.ID)
+		},
+	)
+	return duplicates, nil
+}
+
+// FindDuplicateRecipes finds the recipes of a user likely to be duplicates of a recipe, most similar first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - recipe: the recipe, whose ID is left out of the comparison when set
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Duplicate: the likely duplicates
+//   - error: an error if the recipes could not be listed
+func (d *Service) FindDuplicateRecipes(
+	ctx context.Context,
+	ownerID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+) ([]*internalrouterapiv1recipe.Duplicate, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return nil, ErrNilRecipe
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates, err := findDuplicateRecipes(ctx, db, ownerID, recipe)
+	if err != nil {
+		d.logError("Failed to find duplicate recipes", err)
+		return nil, err
+	}
+	return duplicates, nil
+}
+
+// mergeRecipeContent merges the content of a duplicate into a recipe. The fields of the recipe are kept, the empty
+// ones are taken from the duplicate, and the ingredients of the duplicate missing from the recipe are appended
+//
+// Parameters:
+//
+//   - recipe: the recipe that is kept
+//   - duplicate: the duplicate merged into it
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the merged recipe
+func mergeRecipeContent(
+	recipe, duplicate *internalrouterapiv1recipe.Recipe,
+) *internalrouterapiv1recipe.Recipe {
+	merged := *recipe
+	if merged.Description == "" {
+		merged.Description = duplicate.Description
+	}
+	if merged.PreparationTime == 0 {
+		merged.PreparationTime = duplicate.PreparationTime
+	}
+	if merged.CookingTime == 0 {
+		merged.CookingTime = duplicate.CookingTime
+	}
+	if len(merged.Steps) == 0 {
+		merged.Steps = duplicate.Steps
+	}
+	if merged.Servings == 0 {
+		merged.Servings = duplicate.Servings
+	}
+	if merged.Difficulty == "" {
+		merged.Difficulty = duplicate.Difficulty
+	}
+	if merged.ImageURL == "" {
+		merged.ImageURL = duplicate.ImageURL
+	}
+
+	// Append the missing ingredients, compared by their normalized names
+	names := make(map[string]bool, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		names[Slugify(ingredient.Name)] = true
+	}
+	merged.Ingredients = slices.Clone(recipe.Ingredients)
+	for _, ingredient := range duplicate.Ingredients {
+		if name := Slugify(ingredient.Name); !names[name] {
+			names[name] = true
+			merged.Ingredients = append(merged.Ingredients, ingredient)
+		}
+	}
+	return &merged
+}
+
+// MergeRecipes merges a duplicate into a recipe, both owned by the given user, and deletes the duplicate. The recipe
+// keeps its content and visibility, filling its empty fields and adding the missing ingredients from the duplicate,
+// which is recorded as a new revision. It also takes the tags of the duplicate, its place in the groups and meal
+// plans, the users that marked it as a favorite, and its forks
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns both recipes
+//   - recipeID: the ID of the recipe that is kept
+//   - duplicateID: the ID of the duplicate that is merged and deleted
+//
+// Returns:
+//
+//   - error: an error if a recipe could not be found, is not owned by the user or both are the same recipe
+func (d *Service) MergeRecipes(
+	ctx context.Context,
+	ownerID string,
+	recipeID, duplicateID int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Check if both recipes are the same
+	if recipeID == duplicateID {
+		return ErrSelfMerge
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Get both recipes
+			recipes := make([]*internalrouterapiv1recipe.Recipe, 0, 2)
+			for _, id := range []int{recipeID, duplicateID} {
+				if checkErr := checkRecipeOwnership(ctx, tx, id, ownerID); checkErr != nil {
+					return checkErr
+				}
+				recipe, scanErr := scanRecipe(tx.QueryRowContext(ctx, GetRecipeQuery, id, ownerID))
+				if scanErr != nil {
+					return scanErr
+				}
+				recipes = append(recipes, recipe)
+			}
+
+			// Merge the content
+			if updateErr := updateRecipe(
+				ctx,
+				tx,
+				ownerID,
+				mergeRecipeContent(recipes[0], recipes[1]),
+			); updateErr != nil {
+				return updateErr
+			}
+
+			// Take the tags, the group places, the meal plan entries, the favorites and the forks of the duplicate
+			if _, execErr := tx.ExecContext(
+				ctx,
+				MergeRecipeTagsQuery,
+				recipeID,
+				duplicateID,
+				MaxRecipeTags,
+			); execErr != nil {
+				return execErr
+			}
+			for _, query := range []string{
+				MergeRecipeGroupItemsQuery,
+				MergeRecipeMealPlanEntriesQuery,
+				MergeRecipeFavoritesQuery,
+				MergeRecipeForksQuery,
+			} {
+				if _, execErr := tx.ExecContext(ctx, query, recipeID, duplicateID); execErr != nil {
+					return execErr
+				}
+			}
+
+			// Delete the duplicate
+			_, execErr := tx.ExecContext(ctx, DeleteRecipeQuery, duplicateID, ownerID)
+			return execErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to merge recipes", err)
+		return err
+	}
+	return nil
+}
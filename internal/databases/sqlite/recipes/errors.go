@@ -0,0 +1,610 @@
+package recipes
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrNilRecipe         = errors.New("recipe cannot be nil")
+	ErrRecipeNotFound    = errors.New("recipe not found")
+	ErrRecipeNotOwned    = errors.New("recipe is not owned by the user")
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrInvalidTagKind    = errors.New("invalid tag kind")
+	ErrEmptyTagLabel     = errors.New("tag label cannot be empty")
+	ErrEmptyTagQuery     = errors.New("tag query cannot be empty")
+	ErrInvalidTagsCount  = errors.New("too many tags for a recipe")
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrInvalidVisibility = errors.New("invalid visibility, must be private, unlisted or public")
+	ErrEmptySearchQuery  = errors.New("search query cannot be empty")
+	ErrSelfMerge         = errors.New("recipe cannot be merged into itself")
+
+	ErrNilGroup                 = errors.New("group cannot be nil")
+	ErrGroupNotFound            = errors.New("group not found")
+	ErrGroupNotOwned            = errors.New("group is not owned by the user")
+	ErrGroupRecipeNotFound      = errors.New("group recipe not found")
+	ErrInvalidGroupRecipesCount = errors.New("too many recipes for a group")
+	ErrCookbookNotFound         = errors.New("cookbook not found")
+	ErrCookbookNotOwned         = errors.New("cookbook is not owned by the user")
+	ErrCookbookNotReady         = errors.New("cookbook is not ready yet")
+
+	ErrImportNotFound = errors.New("import not found")
+	ErrImportNotOwned = errors.New("import is not owned by the user")
+
+	ErrSelfFollow     = errors.New("users cannot follow themselves")
+	ErrFollowNotFound = errors.New("user is not followed")
+
+	ErrUserNotFound = errors.New("user not found")
+
+	ErrNilCookLog                    = errors.New("cook log cannot be nil")
+	ErrCookLogNotFound               = errors.New("cook log not found")
+	ErrCookLogNotOwned               = errors.New("cook log is not owned by the user")
+	ErrInvalidCookDate               = errors.New("invalid cooked on date, must be YYYY-MM-DD and not in the future")
+	ErrInvalidCookRating             = errors.New("invalid rating, must be between 1 and 5")
+	ErrInvalidCookSubstitutionsCount = errors.New("too many substitutions for a cook log")
+	ErrEmptyCookSubstitution         = errors.New("substitution ingredient and substitute cannot be empty")
+	ErrInvalidCookPhotosCount        = errors.New("too many photos for a cook log")
+	ErrInvalidCookPhotoURL           = errors.New("invalid photo URL, must be an absolute http or https URL")
+
+	ErrNilAnnotation                = errors.New("annotation cannot be nil")
+	ErrAnnotationNotFound           = errors.New("annotation not found")
+	ErrInvalidAnnotationTarget      = errors.New("invalid annotation target, must be recipe, step or ingredient")
+	ErrInvalidAnnotationPosition    = errors.New("invalid position, must be the position of a step or ingredient of the recipe")
+	ErrEmptyAnnotation              = errors.New("annotation must have a note or a substitute")
+	ErrInvalidAnnotationSubstitute  = errors.New("only ingredient annotations can have a substitute")
+	ErrInvalidRecipeAnnotationCount = errors.New("too many annotations on the recipe")
+
+	ErrInvalidDiet = errors.New("invalid diet, must be vegetarian, vegan, gluten_free, dairy_free, egg_free or nut_free")
+
+	ErrNilTranslation                 = errors.New("translation cannot be nil")
+	ErrInvalidLanguage                = errors.New("invalid language, must be an ISO 639 language code such as en or es")
+	ErrTranslationNotFound            = errors.New("translation not found")
+	ErrTranslationOriginalLanguage    = errors.New("the recipe is already written in this language")
+	ErrEmptyTranslationName           = errors.New("translated name cannot be empty")
+	ErrInvalidTranslationIngredients  = errors.New("translated ingredients must match the ingredients of the recipe")
+	ErrInvalidTranslationSteps        = errors.New("translated steps must match the steps of the recipe")
+	ErrInvalidRecipeTranslationsCount = errors.New("too many translations of the recipe")
+
+	ErrNilIngredientPrice           = errors.New("ingredient price cannot be nil")
+	ErrIngredientPriceNotFound      = errors.New("ingredient price not found")
+	ErrEmptyPriceIngredient         = errors.New("ingredient cannot be empty")
+	ErrInvalidPriceQuantity         = errors.New("invalid quantity, must be greater than 0")
+	ErrInvalidPrice                 = errors.New("invalid price, must be 0 or greater")
+	ErrInvalidIngredientPricesCount = errors.New("too many ingredient prices")
+
+	ErrNilMealPlan                     = errors.New("meal plan cannot be nil")
+	ErrMealPlanNotFound                = errors.New("meal plan not found")
+	ErrMealPlanNotOwned                = errors.New("meal plan is not owned by the user")
+	ErrMealPlanRecipeNotFound          = errors.New("meal plan recipe not found")
+	ErrInvalidMealPlanDate             = errors.New("invalid meal plan date, must be YYYY-MM-DD")
+	ErrInvalidMealPlanServings         = errors.New("invalid meal plan servings, must be between 0 and 100")
+	ErrInvalidMealPlanEntriesCount     = errors.New("too many entries for a meal plan")
+	ErrNilShoppingList                 = errors.New("shopping list cannot be nil")
+	ErrShoppingListNotFound            = errors.New("shopping list not found")
+	ErrShoppingListNotOwned            = errors.New("shopping list is not owned by the user")
+	ErrEmptyShoppingListItem           = errors.New("shopping list item name cannot be empty")
+	ErrInvalidShoppingListItemQuantity = errors.New("invalid shopping list item quantity, must be 0 or greater")
+	ErrInvalidShoppingListItemsCount   = errors.New("too many items for a shopping list")
+
+	ErrFavoriteNotFound = errors.New("favorite not found")
+
+	ErrNilSyncChange                = errors.New("sync change cannot be nil")
+	ErrInvalidSyncToken             = errors.New("invalid sync token")
+	ErrInvalidSyncEntity            = errors.New("invalid sync entity, must be recipe, group, meal_plan, shopping_list or favorite")
+	ErrInvalidSyncOperation         = errors.New("invalid sync operation, must be create, update or delete")
+	ErrInvalidFavoriteSyncOperation = errors.New("invalid sync operation, favorites can only be created or deleted")
+	ErrMissingSyncContent           = errors.New("sync change must have the content of the entity")
+	ErrMissingSyncEntityID          = errors.New("sync change must have the ID of the entity")
+	ErrInvalidSyncChangesCount      = errors.New("too many sync changes")
+	ErrVersionMismatch              = errors.New("the resource was modified since the given version")
+)
+
+// ParseError maps a recipes service error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the recipes service error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	switch {
+	case errors.Is(err, ErrRecipeNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.RecipeNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrRecipeNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.RecipeNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrInvalidVisibility):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"visibility",
+			err,
+			internalerrorcodes.InvalidVisibility.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrSelfMerge):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"recipe_id",
+			err,
+			internalerrorcodes.SelfMerge.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrRevisionNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"number",
+			err,
+			internalerrorcodes.RevisionNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrGroupNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.GroupNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrCookbookNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.CookbookNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrImportNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.ImportNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrGroupNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.GroupNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrCookbookNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.CookbookNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrImportNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.ImportNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrCookbookNotReady):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.CookbookNotReady.String(),
+			http.StatusConflict,
+		)
+	case errors.Is(err, ErrGroupRecipeNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"recipe_ids",
+			err,
+			internalerrorcodes.GroupRecipeNotFound.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidGroupRecipesCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"recipe_ids",
+			err,
+			internalerrorcodes.TooManyGroupRecipes.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrSelfFollow):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"user_id",
+			err,
+			internalerrorcodes.SelfFollow.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrFollowNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"user_id",
+			err,
+			internalerrorcodes.FollowNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrCookLogNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.CookLogNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrCookLogNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.CookLogNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrInvalidCookDate):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"cooked_on",
+			err,
+			internalerrorcodes.InvalidCookDate.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidCookRating):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"rating",
+			err,
+			internalerrorcodes.InvalidRating.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidCookSubstitutionsCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"substitutions",
+			err,
+			internalerrorcodes.TooManySubstitutions.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrEmptyCookSubstitution):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"substitutions",
+			err,
+			internalerrorcodes.EmptySubstitution.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidCookPhotosCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"photos",
+			err,
+			internalerrorcodes.TooManyPhotos.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidCookPhotoURL):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"photos",
+			err,
+			internalerrorcodes.InvalidPhotoURL.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrAnnotationNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"annotation_id",
+			err,
+			internalerrorcodes.AnnotationNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrInvalidAnnotationTarget):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"target",
+			err,
+			internalerrorcodes.InvalidAnnotationTarget.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidAnnotationPosition):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"position",
+			err,
+			internalerrorcodes.InvalidAnnotationPosition.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrEmptyAnnotation):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"note",
+			err,
+			internalerrorcodes.EmptyAnnotation.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidRecipeAnnotationCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"note",
+			err,
+			internalerrorcodes.TooManyAnnotations.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidAnnotationSubstitute):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"substitute",
+			err,
+			internalerrorcodes.InvalidAnnotationSubstitute.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidDiet):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"diets",
+			err,
+			internalerrorcodes.InvalidDiet.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidLanguage):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"language",
+			err,
+			internalerrorcodes.InvalidLanguage.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrTranslationOriginalLanguage):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"language",
+			err,
+			internalerrorcodes.TranslationOriginalLanguage.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidRecipeTranslationsCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"language",
+			err,
+			internalerrorcodes.TooManyTranslations.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrTranslationNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"language",
+			err,
+			internalerrorcodes.TranslationNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrEmptyTranslationName):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"name",
+			err,
+			internalerrorcodes.EmptyTranslationName.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidTranslationIngredients):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"ingredients",
+			err,
+			internalerrorcodes.InvalidTranslationIngredients.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidTranslationSteps):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"steps",
+			err,
+			internalerrorcodes.InvalidTranslationSteps.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrIngredientPriceNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"slug",
+			err,
+			internalerrorcodes.IngredientPriceNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrEmptyPriceIngredient):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"ingredient",
+			err,
+			internalerrorcodes.EmptyIngredient.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidIngredientPricesCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"ingredient",
+			err,
+			internalerrorcodes.TooManyPrices.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidPriceQuantity):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"quantity",
+			err,
+			internalerrorcodes.InvalidPriceQuantity.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidPrice):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"price",
+			err,
+			internalerrorcodes.InvalidPrice.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrMealPlanNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.MealPlanNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrMealPlanNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.MealPlanNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrMealPlanRecipeNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"entries",
+			err,
+			internalerrorcodes.MealPlanRecipeNotFound.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidMealPlanDate):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"entries",
+			err,
+			internalerrorcodes.InvalidMealPlanDate.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidMealPlanServings):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"entries",
+			err,
+			internalerrorcodes.InvalidMealPlanServings.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidMealPlanEntriesCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"entries",
+			err,
+			internalerrorcodes.TooManyMealPlanEntries.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrShoppingListNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.ShoppingListNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrShoppingListNotOwned):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.ShoppingListNotOwned.String(),
+			http.StatusForbidden,
+		)
+	case errors.Is(err, ErrEmptyShoppingListItem):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"items",
+			err,
+			internalerrorcodes.EmptyShoppingListItem.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidShoppingListItemQuantity):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"items",
+			err,
+			internalerrorcodes.InvalidShoppingListItemQuantity.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidShoppingListItemsCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"items",
+			err,
+			internalerrorcodes.TooManyShoppingListItems.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrFavoriteNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"id",
+			err,
+			internalerrorcodes.FavoriteNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrUserNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"username",
+			err,
+			internalerrorcodes.UserNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrTagNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"slug",
+			err,
+			internalerrorcodes.TagNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrInvalidTagKind):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"kind",
+			err,
+			internalerrorcodes.InvalidTagKind.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrEmptyTagQuery):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"q",
+			err,
+			internalerrorcodes.EmptyTagQuery.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrEmptySearchQuery):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"q",
+			err,
+			internalerrorcodes.EmptySearchQuery.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrEmptyTagLabel):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"tags",
+			err,
+			internalerrorcodes.EmptyTagLabel.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidTagsCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"tags",
+			err,
+			internalerrorcodes.TooManyTags.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrVersionMismatch):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"If-Match",
+			err,
+			internalerrorcodes.VersionMismatch.String(),
+			http.StatusPreconditionFailed,
+		)
+	case errors.Is(err, ErrInvalidSyncToken):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"since",
+			err,
+			internalerrorcodes.InvalidSyncToken.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidSyncEntity):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"changes",
+			err,
+			internalerrorcodes.InvalidSyncEntity.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidSyncOperation):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"changes",
+			err,
+			internalerrorcodes.InvalidSyncOperation.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidFavoriteSyncOperation):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"changes",
+			err,
+			internalerrorcodes.InvalidFavoriteSyncOperation.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrMissingSyncContent):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"changes",
+			err,
+			internalerrorcodes.MissingSyncContent.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrMissingSyncEntityID):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"changes",
+			err,
+			internalerrorcodes.MissingSyncEntityID.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidSyncChangesCount):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"changes",
+			err,
+			internalerrorcodes.TooManySyncChanges.String(),
+			http.StatusBadRequest,
+		)
+	default:
+		return err
+	}
+}
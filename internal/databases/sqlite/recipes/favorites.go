@@ -0,0 +1,234 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// scanFavorite scans a favorite row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Favorite: the scanned favorite
+//   - error: an error if the row could not be scanned
+func scanFavorite(row scanner) (*internalrouterapiv1recipe.Favorite, error) {
+	var favorite internalrouterapiv1recipe.Favorite
+	if err := row.Scan(
+		&favorite.ID,
+		&favorite.RecipeID,
+		&favorite.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &favorite, nil
+}
+
+// insertFavorite marks a recipe the given user can read as a favorite inside a transaction. Marking a recipe twice
+// has no effect
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Favorite: the favorite
+//   - bool: true if the recipe was not a favorite yet
+//   - error: ErrRecipeNotFound if the user cannot read the recipe, or an error if the favorite could not be stored
+func insertFavorite(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID string,
+	recipeID int,
+) (*internalrouterapiv1recipe.Favorite, bool, error) {
+	// Check the recipe
+	if err := checkRecipeVisibility(ctx, tx, recipeID, userID); err != nil {
+		return nil, false, err
+	}
+
+	// Mark the recipe
+	result, err := tx.ExecContext(ctx, InsertFavoriteQuery, userID, recipeID)
+	if err != nil {
+		return nil, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Get the favorite, which is the one already stored if the recipe was a favorite
+	favorite, err := scanFavorite(tx.QueryRowContext(ctx, GetFavoriteByRecipeIDQuery, userID, recipeID))
+	if err != nil {
+		return nil, false, err
+	}
+	return favorite, affected > 0, nil
+}
+
+// AddFavorite marks a recipe the given user can read as a favorite. Marking a recipe twice has no effect
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Favorite: the favorite
+//   - bool: true if the recipe was not a favorite yet
+//   - error: ErrRecipeNotFound if the user cannot read the recipe, or an error if the favorite could not be stored
+func (d *Service) AddFavorite(
+	ctx context.Context,
+	userID string,
+	recipeID int,
+) (*internalrouterapiv1recipe.Favorite, bool, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, false, godatabases.ErrNilService
+	}
+
+	var favorite *internalrouterapiv1recipe.Favorite
+	var created bool
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			var err error
+			favorite, created, err = insertFavorite(ctx, tx, userID, recipeID)
+			return err
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrRecipeNotFound) {
+			d.logError("Failed to add favorite", err)
+		}
+		return nil, false, err
+	}
+	return favorite, created, nil
+}
+
+// GetFavorite gets a favorite of the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - favoriteID: the ID of the favorite
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Favorite: the favorite
+//   - error: ErrFavoriteNotFound if the user has no such favorite
+func (d *Service) GetFavorite(
+	ctx context.Context,
+	userID string,
+	favoriteID int,
+) (*internalrouterapiv1recipe.Favorite, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	favorite, err := scanFavorite(db.QueryRowContext(ctx, GetFavoriteQuery, favoriteID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFavoriteNotFound
+		}
+		d.logError("Failed to get favorite", err)
+		return nil, err
+	}
+	return favorite, nil
+}
+
+// RemoveFavorite unmarks a recipe as a favorite of the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - recipeID: the ID of the recipe
+//
+// Returns:
+//
+//   - error: ErrFavoriteNotFound if the recipe is not a favorite of the user
+func (d *Service) RemoveFavorite(
+	ctx context.Context,
+	userID string,
+	recipeID int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(ctx, DeleteFavoriteQuery, userID, recipeID)
+	if err != nil {
+		d.logError("Failed to remove favorite", err)
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrFavoriteNotFound
+	}
+	return nil
+}
+
+// ListFavoriteRecipes lists the favorite recipes of a user, most recently marked first. Favorites made private by
+// their owners are left out
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the favorite recipes
+//   - error: an error if the recipes could not be listed
+func (d *Service) ListFavoriteRecipes(
+	ctx context.Context,
+	userID string,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Recipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryRecipes(
+		ctx,
+		language,
+		&ListFavoriteRecipesQuery,
+		userID,
+		limit,
+		offset,
+	)
+}
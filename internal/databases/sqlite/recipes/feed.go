@@ -0,0 +1,247 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// RecordFeedEvent records the publication of a recipe as a feed event. Nothing is recorded if the recipe is not public
+// or was already announced, so hiding and publishing a recipe again does not announce it twice
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipeID: the ID of the recipe
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.FeedEvent: the recorded event without its recipe, or nil if nothing was recorded
+//   - error: an error if the event could not be recorded
+func (d *Service) RecordFeedEvent(
+	ctx context.Context,
+	recipeID int,
+) (*internalrouterapiv1recipe.FeedEvent, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	var event internalrouterapiv1recipe.FeedEvent
+	if err = db.QueryRowContext(ctx, InsertFeedEventQuery, recipeID).Scan(
+		&event.ID,
+		&event.Kind,
+		&event.ActorID,
+		&event.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		d.logError("Failed to record feed event", err)
+		return nil, err
+	}
+	return &event, nil
+}
+
+// queryFeedEventIDs runs a query that returns feed event IDs
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - query: the query to run
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []int: the feed event IDs
+//   - error: an error if the feed event IDs could not be queried
+func (d *Service) queryFeedEventIDs(
+	ctx context.Context,
+	query *string,
+	params ...any,
+) ([]int, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to query feed event IDs", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventIDs := make([]int, 0)
+	for rows.Next() {
+		var eventID int
+		if err = rows.Scan(&eventID); err != nil {
+			return nil, err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+	return eventIDs, rows.Err()
+}
+
+// ListActorFeedEventIDs lists the IDs of the latest feed events of a user, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - actorID: the ID of the user
+//   - limit: the maximum number of events to return
+//
+// Returns:
+//
+//   - []int: the feed event IDs
+//   - error: an error if the feed events could not be listed
+func (d *Service) ListActorFeedEventIDs(
+	ctx context.Context,
+	actorID string,
+	limit int,
+) ([]int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryFeedEventIDs(ctx, &ListActorFeedEventIDsQuery, actorID, limit)
+}
+
+// ListPopularFolloweesFeedEventIDs lists the IDs of the feed events older than a cursor of the users a user follows
+// that have more followers than the given threshold, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user that reads the feed
+//   - maxFollowers: the number of followers above which the events of a user are not fanned out
+//   - before: the ID of the oldest event already read
+//   - limit: the maximum number of events to return
+//
+// Returns:
+//
+//   - []int: the feed event IDs
+//   - error: an error if the feed events could not be listed
+func (d *Service) ListPopularFolloweesFeedEventIDs(
+	ctx context.Context,
+	userID string,
+	maxFollowers int,
+	before int,
+	limit int,
+) ([]int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryFeedEventIDs(
+		ctx,
+		&ListPopularFolloweesFeedEventIDsQuery,
+		userID,
+		maxFollowers,
+		before,
+		limit,
+	)
+}
+
+// ListFeedEvents lists the feed events with the given IDs whose recipe is still public, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user that reads the feed
+//   - eventIDs: the IDs of the feed events
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.FeedEvent: the feed events with their recipes
+//   - error: an error if the feed events could not be listed
+func (d *Service) ListFeedEvents(
+	ctx context.Context,
+	userID string,
+	eventIDs []int,
+	language string,
+) ([]*internalrouterapiv1recipe.FeedEvent, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	events := make([]*internalrouterapiv1recipe.FeedEvent, 0)
+	if len(eventIDs) == 0 {
+		return events, nil
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the events
+	encodedEventIDs, err := json.Marshal(eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, ListFeedEventsQuery, string(encodedEventIDs))
+	if err != nil {
+		d.logError("Failed to query feed events", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipeIDs := make([]int, 0)
+	for rows.Next() {
+		var event internalrouterapiv1recipe.FeedEvent
+		var recipeID int
+		if err = rows.Scan(
+			&event.ID,
+			&event.Kind,
+			&event.ActorID,
+			&recipeID,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+		recipeIDs = append(recipeIDs, recipeID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	// Load their recipes
+	encodedRecipeIDs, err := json.Marshal(recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	recipes, err := d.queryRecipes(ctx, language, &ListRecipesByIDsQuery, string(encodedRecipeIDs), userID)
+	if err != nil {
+		return nil, err
+	}
+	recipesByID := make(map[int]*internalrouterapiv1recipe.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		recipesByID[recipe.ID] = recipe
+	}
+	for i, event := range events {
+		event.Recipe = recipesByID[recipeIDs[i]]
+	}
+	return events, nil
+}
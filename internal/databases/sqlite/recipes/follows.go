@@ -0,0 +1,253 @@
+package recipes
+
+import (
+	"context"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// Follow makes a user follow another user. Following a user twice has no effect
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - followerID: the ID of the user that follows
+//   - followeeID: the ID of the user to follow
+//
+// Returns:
+//
+//   - error: an error if the users are the same or the follow could not be stored
+func (d *Service) Follow(
+	ctx context.Context,
+	followerID, followeeID string,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Check the users
+	if followerID == followeeID {
+		return ErrSelfFollow
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.ExecContext(ctx, InsertFollowQuery, followerID, followeeID); err != nil {
+		d.logError("Failed to follow user", err)
+		return err
+	}
+	return nil
+}
+
+// Unfollow makes a user stop following another user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - followerID: the ID of the user that follows
+//   - followeeID: the ID of the followed user
+//
+// Returns:
+//
+//   - error: an error if the user was not followed or the follow could not be deleted
+func (d *Service) Unfollow(
+	ctx context.Context,
+	followerID, followeeID string,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(ctx, DeleteFollowQuery, followerID, followeeID)
+	if err != nil {
+		d.logError("Failed to unfollow user", err)
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrFollowNotFound
+	}
+	return nil
+}
+
+// queryFollows runs a query that returns follow rows
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - query: the query to run
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Follow: the follows
+//   - error: an error if the follows could not be queried
+func (d *Service) queryFollows(
+	ctx context.Context,
+	query *string,
+	params ...any,
+) ([]*internalrouterapiv1recipe.Follow, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to query follows", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	follows := make([]*internalrouterapiv1recipe.Follow, 0)
+	for rows.Next() {
+		var follow internalrouterapiv1recipe.Follow
+		if err = rows.Scan(&follow.UserID, &follow.CreatedAt); err != nil {
+			return nil, err
+		}
+		follows = append(follows, &follow)
+	}
+	return follows, rows.Err()
+}
+
+// ListFollowees lists the users a user follows, most recently followed first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - limit: the maximum number of users to return
+//   - offset: the number of users to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Follow: the followed users
+//   - error: an error if the users could not be listed
+func (d *Service) ListFollowees(
+	ctx context.Context,
+	userID string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Follow, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryFollows(ctx, &ListFolloweesQuery, userID, limit, offset)
+}
+
+// ListFollowers lists the followers of a user, most recent first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - limit: the maximum number of users to return
+//   - offset: the number of users to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Follow: the followers
+//   - error: an error if the users could not be listed
+func (d *Service) ListFollowers(
+	ctx context.Context,
+	userID string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Follow, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryFollows(ctx, &ListFollowersQuery, userID, limit, offset)
+}
+
+// CountFollowers counts the followers of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - int: the number of followers
+//   - error: an error if the followers could not be counted
+func (d *Service) CountFollowers(ctx context.Context, userID string) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err = db.QueryRowContext(ctx, CountFollowersQuery, userID).Scan(&count); err != nil {
+		d.logError("Failed to count followers", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListFollowerIDs lists the IDs of every follower of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - []string: the IDs of the followers
+//   - error: an error if the followers could not be listed
+func (d *Service) ListFollowerIDs(ctx context.Context, userID string) ([]string, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListFollowerIDsQuery, userID)
+	if err != nil {
+		d.logError("Failed to list follower IDs", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	followerIDs := make([]string, 0)
+	for rows.Next() {
+		var followerID string
+		if err = rows.Scan(&followerID); err != nil {
+			return nil, err
+		}
+		followerIDs = append(followerIDs, followerID)
+	}
+	return followerIDs, rows.Err()
+}
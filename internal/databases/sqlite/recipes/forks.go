@@ -0,0 +1,148 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// ForkRecipe copies a recipe readable by the given user into their library, linked to the original and attributed to
+// the chain of recipes it descends from. The fork starts private. The canonical tags are kept, while the user tags of
+// the original author become tags of the user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that forks the recipe
+//   - recipeID: the ID of the recipe to fork
+//
+// Returns:
+//
+//   - int: the ID of the fork
+//   - error: an error if the recipe could not be found or forked
+func (d *Service) ForkRecipe(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	var forkID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Get the original recipe
+			original, scanErr := scanRecipe(tx.QueryRowContext(ctx, GetRecipeQuery, recipeID, ownerID))
+			if scanErr != nil {
+				if errors.Is(scanErr, sql.ErrNoRows) {
+					return ErrRecipeNotFound
+				}
+				return scanErr
+			}
+			if !canReadRecipe(ownerID, original.OwnerID, original.Visibility) {
+				return ErrRecipeNotFound
+			}
+
+			// Get the labels of its tags
+			rows, queryErr := tx.QueryContext(ctx, ListRecipeTagLabelsQuery, recipeID)
+			if queryErr != nil {
+				return queryErr
+			}
+			defer rows.Close()
+
+			tags := make([]string, 0)
+			for rows.Next() {
+				var label string
+				if scanErr = rows.Scan(&label); scanErr != nil {
+					return scanErr
+				}
+				tags = append(tags, label)
+			}
+			if queryErr = rows.Err(); queryErr != nil {
+				return queryErr
+			}
+
+			// Attribute the fork to the original, followed by the recipes the original descends from
+			fork := *original
+			fork.Visibility = internalrouterapiv1recipe.VisibilityPrivate
+			fork.ForkedFrom = &original.ID
+			fork.Attribution = append(
+				[]internalrouterapiv1recipe.Attribution{
+					{
+						RecipeID: original.ID,
+						OwnerID:  original.OwnerID,
+						Name:     original.Name,
+					},
+				},
+				original.Attribution...,
+			)
+
+			var insertErr error
+			forkID, insertErr = insertRecipe(ctx, tx, ownerID, &fork, tags)
+			return insertErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to fork recipe", err)
+		return 0, err
+	}
+	return forkID, nil
+}
+
+// ListRecipeForks lists the recipes forked from a recipe owned by the given user, newest first. Only the public forks
+// are listed, while the count includes every fork
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of forks to return
+//   - offset: the number of forks to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the forks
+//   - int: the total number of forks
+//   - error: an error if the recipe could not be found, is not owned by the user or the forks could not be listed
+func (d *Service) ListRecipeForks(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Recipe, int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, 0, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Check if the user owns the recipe
+	if err = checkRecipeOwnership(ctx, db, recipeID, ownerID); err != nil {
+		return nil, 0, err
+	}
+
+	// Count and list the forks
+	var count int
+	if err = db.QueryRowContext(ctx, CountRecipeForksQuery, recipeID, ownerID).Scan(&count); err != nil {
+		d.logError("Failed to count recipe forks", err)
+		return nil, 0, err
+	}
+	forks, err := d.queryRecipes(ctx, language, &ListRecipeForksQuery, recipeID, ownerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return forks, count, nil
+}
@@ -0,0 +1,675 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// MaxGroupRecipes is the maximum number of recipes of a group
+	MaxGroupRecipes = 200
+)
+
+// scanGroup scans a recipe group row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the scanned group
+//   - error: an error if the row could not be scanned
+func scanGroup(row scanner) (*internalrouterapiv1recipe.Group, error) {
+	var group internalrouterapiv1recipe.Group
+	if err := row.Scan(
+		&group.ID,
+		&group.OwnerID,
+		&group.Title,
+		&group.Description,
+		&group.Visibility,
+	); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// listGroupRecipeIDs lists the IDs of the recipes of a group, in order
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - query: the query that lists the recipe IDs
+//   - groupID: the ID of the group
+//
+// Returns:
+//
+//   - []int: the recipe IDs
+//   - error: an error if the IDs could not be listed
+func (d *Service) listGroupRecipeIDs(ctx context.Context, query *string, groupID int) (
+	[]int,
+	error,
+) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, *query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipeIDs := make([]int, 0)
+	for rows.Next() {
+		var recipeID int
+		if err = rows.Scan(&recipeID); err != nil {
+			return nil, err
+		}
+		recipeIDs = append(recipeIDs, recipeID)
+	}
+	return recipeIDs, rows.Err()
+}
+
+// setGroupRecipes replaces the recipes of a group inside a transaction, keeping their order and dropping duplicates
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - recipeIDs: the IDs of the recipes
+//
+// Returns:
+//
+//   - error: an error if a recipe could not be read by the user or the recipes could not be set
+func setGroupRecipes(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	groupID int,
+	recipeIDs []int,
+) error {
+	if len(recipeIDs) > MaxGroupRecipes {
+		return ErrInvalidGroupRecipesCount
+	}
+
+	// Remove the current recipes
+	if _, err := tx.ExecContext(ctx, DeleteGroupItemsQuery, groupID); err != nil {
+		return err
+	}
+
+	seen := make(map[int]struct{}, len(recipeIDs))
+	for _, recipeID := range recipeIDs {
+		if _, ok := seen[recipeID]; ok {
+			continue
+		}
+		seen[recipeID] = struct{}{}
+
+		// Check that the recipe exists and the user can read it
+		if err := checkRecipeVisibility(ctx, tx, recipeID, ownerID); err != nil {
+			if errors.Is(err, ErrRecipeNotFound) {
+				return fmt.Errorf("%w: %d", ErrGroupRecipeNotFound, recipeID)
+			}
+			return err
+		}
+
+		// Add the recipe at the end of the group
+		if _, err := tx.ExecContext(
+			ctx,
+			InsertGroupItemQuery,
+			groupID,
+			recipeID,
+			len(seen),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertGroup inserts a recipe group and adds its recipes inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the group
+//   - group: the group to insert
+//
+// Returns:
+//
+//   - int: the ID of the inserted group
+//   - error: an error if the group could not be inserted
+func insertGroup(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	group *internalrouterapiv1recipe.Group,
+) (int, error) {
+	// Check the visibility, new groups are private unless told otherwise
+	visibility := group.Visibility
+	if visibility == "" {
+		visibility = internalrouterapiv1recipe.VisibilityPrivate
+	}
+	if !visibility.IsValid() {
+		return 0, ErrInvalidVisibility
+	}
+
+	// Insert the group
+	result, err := tx.ExecContext(
+		ctx,
+		InsertGroupQuery,
+		ownerID,
+		group.Title,
+		group.Description,
+		visibility,
+	)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	// Add the recipes
+	return int(id), setGroupRecipes(ctx, tx, ownerID, int(id), group.RecipeIDs)
+}
+
+// CreateGroup creates a recipe group owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - group: the group to create
+//
+// Returns:
+//
+//   - int: the ID of the created group
+//   - error: an error if the group could not be created
+func (d *Service) CreateGroup(
+	ctx context.Context,
+	ownerID string,
+	group *internalrouterapiv1recipe.Group,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the group is nil
+	if group == nil {
+		return 0, ErrNilGroup
+	}
+
+	var groupID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			var insertErr error
+			groupID, insertErr = insertGroup(ctx, tx, ownerID, group)
+			return insertErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to create group", err)
+		return 0, err
+	}
+	return groupID, nil
+}
+
+// GetGroup gets a recipe group owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the group with its recipe IDs
+//   - error: an error if the group does not exist or is not owned by the user
+func (d *Service) GetGroup(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+) (*internalrouterapiv1recipe.Group, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the group
+	row, err := d.QueryRowWithCtx(ctx, &GetGroupQuery, groupID)
+	if err != nil {
+		d.logError("Failed to query group", err)
+		return nil, err
+	}
+	group, err := scanGroup(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGroupNotFound
+		}
+		d.logError("Failed to get group", err)
+		return nil, err
+	}
+	if group.OwnerID != ownerID {
+		return nil, ErrGroupNotOwned
+	}
+
+	// Get the recipe IDs
+	if group.RecipeIDs, err = d.listGroupRecipeIDs(ctx, &ListGroupRecipeIDsQuery, groupID); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// ListGroups lists the recipe groups of a user, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - limit: the maximum number of groups
+//   - offset: the number of groups to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Group: the groups with their recipe IDs
+//   - error: an error if the groups could not be listed
+func (d *Service) ListGroups(
+	ctx context.Context,
+	ownerID string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Group, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryGroups(
+		ctx,
+		&ListGroupsByOwnerIDQuery,
+		&ListGroupRecipeIDsQuery,
+		ownerID,
+		limit,
+		offset,
+	)
+}
+
+// queryGroups runs a query that returns recipe group rows and loads their recipe IDs
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - query: the query to run
+//   - recipeIDsQuery: the query that lists the recipe IDs of each group
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Group: the groups with their recipe IDs
+//   - error: an error if the groups could not be queried
+func (d *Service) queryGroups(
+	ctx context.Context,
+	query *string,
+	recipeIDsQuery *string,
+	params ...any,
+) ([]*internalrouterapiv1recipe.Group, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the groups
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to list groups", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]*internalrouterapiv1recipe.Group, 0)
+	for rows.Next() {
+		group, scanErr := scanGroup(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		groups = append(groups, group)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load the recipe IDs of each group
+	for _, group := range groups {
+		if group.RecipeIDs, err = d.listGroupRecipeIDs(ctx, recipeIDsQuery, group.ID); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// ListGroupRecipes lists the recipes of a group owned by the given user, in order. The recipes other users made
+// private since they were added are left out
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the group
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the group does not exist or is not owned by the user
+func (d *Service) ListGroupRecipes(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+	language string,
+) (*internalrouterapiv1recipe.Group, []*internalrouterapiv1recipe.Recipe, error) {
+	// Get the group
+	group, err := d.GetGroup(ctx, ownerID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get the recipes
+	recipes, err := d.queryRecipes(ctx, language, &ListGroupRecipesQuery, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, recipes, nil
+}
+
+// UpdateGroup replaces the content and the recipes of a group owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - group: the group with the new content
+//   - versions: the versions the update may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the group
+//   - error: an error if the group changed since the version or could not be updated
+func (d *Service) UpdateGroup(
+	ctx context.Context,
+	ownerID string,
+	group *internalrouterapiv1recipe.Group,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the group is nil
+	if group == nil {
+		return 0, ErrNilGroup
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the update was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityGroup,
+				group.ID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Update the group
+			if err := updateGroup(ctx, tx, ownerID, group); err != nil {
+				return err
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityGroup,
+				group.ID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to update group", err)
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// updateGroup replaces the content and the recipes of a group owned by the given user inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the group
+//   - group: the group with the new content
+//
+// Returns:
+//
+//   - error: an error if the group could not be updated
+func updateGroup(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	group *internalrouterapiv1recipe.Group,
+) error {
+	// Update the group
+	result, err := tx.ExecContext(
+		ctx,
+		UpdateGroupQuery,
+		group.Title,
+		group.Description,
+		group.ID,
+		ownerID,
+	)
+	if err != nil {
+		return err
+	}
+	if err = checkAffectedGroup(ctx, tx, result, group.ID, ownerID); err != nil {
+		return err
+	}
+
+	// Replace the recipes
+	return setGroupRecipes(ctx, tx, ownerID, group.ID, group.RecipeIDs)
+}
+
+// SetGroupVisibility sets the visibility of a recipe group owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - visibility: the new visibility
+//   - versions: the versions the change may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the group
+//   - error: an error if the visibility is invalid, the group changed since the version or could not be updated
+func (d *Service) SetGroupVisibility(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+	visibility internalrouterapiv1recipe.Visibility,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check the visibility
+	if !visibility.IsValid() {
+		return 0, ErrInvalidVisibility
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the change was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityGroup,
+				groupID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Update the group
+			result, err := tx.ExecContext(ctx, SetGroupVisibilityQuery, visibility, groupID, ownerID)
+			if err != nil {
+				return err
+			}
+			if err = checkAffectedGroup(ctx, tx, result, groupID, ownerID); err != nil {
+				return err
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityGroup,
+				groupID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrGroupNotFound) && !errors.Is(err, ErrGroupNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to set group visibility", err)
+		}
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// DeleteGroup deletes a recipe group owned by the given user, along with its cookbooks
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the group
+//   - groupID: the ID of the group
+//   - versions: the versions the deletion may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - error: an error if the group changed since the version or could not be deleted
+func (d *Service) DeleteGroup(
+	ctx context.Context,
+	ownerID string,
+	groupID int,
+	versions []int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the deletion was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityGroup,
+				groupID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Delete the group
+			result, err := tx.ExecContext(ctx, DeleteGroupQuery, groupID, ownerID)
+			if err != nil {
+				return err
+			}
+			return checkAffectedGroup(ctx, tx, result, groupID, ownerID)
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrGroupNotFound) && !errors.Is(err, ErrGroupNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to delete group", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkAffectedGroup checks that a write over a group owned by the given user affected a row
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the group, either the database or a transaction
+//   - result: the result of the write
+//   - groupID: the ID of the group
+//   - ownerID: the ID of the user that tried to write the group
+//
+// Returns:
+//
+//   - error: ErrGroupNotFound or ErrGroupNotOwned if no row was affected
+func checkAffectedGroup(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	result sql.Result,
+	groupID int,
+	ownerID string,
+) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	group, err := scanGroup(q.QueryRowContext(ctx, GetGroupQuery, groupID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrGroupNotFound
+		}
+		return err
+	}
+	if group.OwnerID != ownerID {
+		return ErrGroupNotOwned
+	}
+	return ErrGroupNotFound
+}
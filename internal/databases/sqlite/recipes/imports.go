@@ -0,0 +1,445 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// scanImportJob scans a bulk import job row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ImportJob: the scanned job
+//   - error: an error if the row could not be scanned
+func scanImportJob(row scanner) (*internalrouterapiv1recipe.ImportJob, error) {
+	var job internalrouterapiv1recipe.ImportJob
+	var finishedAt sql.NullTime
+	if err := row.Scan(
+		&job.ID,
+		&job.OwnerID,
+		&job.Format,
+		&job.Status,
+		&job.Error,
+		&job.Total,
+		&job.Imported,
+		&job.Failed,
+		&job.CreatedAt,
+		&finishedAt,
+	); err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
+
+// CreateImportJob queues a bulk import job
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user the recipes are imported for
+//   - format: the format of the archive
+//   - archive: the archive
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ImportJob: the pending job
+//   - error: an error if the job could not be created
+func (d *Service) CreateImportJob(
+	ctx context.Context,
+	ownerID, format string,
+	archive []byte,
+) (*internalrouterapiv1recipe.ImportJob, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Insert the job
+	row, err := d.QueryRowWithCtx(ctx, &InsertImportJobQuery, ownerID, format, archive)
+	if err != nil {
+		d.logError("Failed to query import job insertion", err)
+		return nil, err
+	}
+	var jobID int
+	if err = row.Scan(&jobID); err != nil {
+		d.logError("Failed to create import job", err)
+		return nil, err
+	}
+	return d.GetImportJob(ctx, jobID)
+}
+
+// GetImportJob gets a bulk import job without its report
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ImportJob: the job
+//   - error: an error if the job does not exist
+func (d *Service) GetImportJob(
+	ctx context.Context,
+	jobID int,
+) (*internalrouterapiv1recipe.ImportJob, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	row, err := d.QueryRowWithCtx(ctx, &GetImportJobQuery, jobID)
+	if err != nil {
+		d.logError("Failed to query import job", err)
+		return nil, err
+	}
+	job, err := scanImportJob(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImportNotFound
+		}
+		d.logError("Failed to get import job", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetOwnedImportJob gets a bulk import job of the given user with its report
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that queued the job
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ImportJob: the job
+//   - error: an error if the job does not exist or is not owned by the user
+func (d *Service) GetOwnedImportJob(
+	ctx context.Context,
+	ownerID string,
+	jobID int,
+) (*internalrouterapiv1recipe.ImportJob, error) {
+	job, err := d.GetImportJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.OwnerID != ownerID {
+		return nil, ErrImportNotOwned
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the report
+	rows, err := db.QueryContext(ctx, ListImportJobItemsQuery, jobID)
+	if err != nil {
+		d.logError("Failed to list import job items", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	job.Items = make([]internalrouterapiv1recipe.ImportItem, 0)
+	for rows.Next() {
+		var item internalrouterapiv1recipe.ImportItem
+		if err = rows.Scan(
+			&item.Index,
+			&item.Name,
+			&item.RecipeID,
+			&item.Error,
+			&item.DuplicateOf,
+		); err != nil {
+			return nil, err
+		}
+		job.Items = append(job.Items, item)
+	}
+	return job, rows.Err()
+}
+
+// GetImportArchive gets the archive of a bulk import job and the position of the last processed entry, so an
+// interrupted job resumes where it stopped
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//
+// Returns:
+//
+//   - []byte: the archive
+//   - int: the position of the last processed entry, 0 if none was processed
+//   - error: an error if the job does not exist
+func (d *Service) GetImportArchive(ctx context.Context, jobID int) (
+	[]byte,
+	int,
+	error,
+) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, 0, godatabases.ErrNilService
+	}
+
+	row, err := d.QueryRowWithCtx(ctx, &GetImportArchiveQuery, jobID)
+	if err != nil {
+		d.logError("Failed to query import archive", err)
+		return nil, 0, err
+	}
+	var archive []byte
+	var processed int
+	if err = row.Scan(&archive, &processed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, ErrImportNotFound
+		}
+		d.logError("Failed to get import archive", err)
+		return nil, 0, err
+	}
+	return archive, processed, nil
+}
+
+// SetImportJobTotal sets the number of entries of a bulk import job once its archive is read
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//   - total: the number of entries
+//
+// Returns:
+//
+//   - error: an error if the total could not be set
+func (d *Service) SetImportJobTotal(ctx context.Context, jobID, total int) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if _, err := d.ExecWithCtx(ctx, &SetImportJobTotalQuery, total, jobID); err != nil {
+		d.logError("Failed to set import job total", err)
+		return err
+	}
+	return nil
+}
+
+// ImportRecipe creates a recipe read from a bulk import archive and reports it in the same transaction, so a
+// resumed job never imports an entry twice. Likely duplicates are still imported, the report points to the most
+// similar recipe already in the library so the user can merge them
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user the recipe is imported for
+//   - jobID: the ID of the job
+//   - position: the position of the entry in the archive
+//   - recipe: the recipe to create
+//   - tags: the tag labels to attach to the recipe
+//
+// Returns:
+//
+//   - int: the ID of the created recipe
+//   - error: an error if the recipe could not be created
+func (d *Service) ImportRecipe(
+	ctx context.Context,
+	ownerID string,
+	jobID, position int,
+	recipe *internalrouterapiv1recipe.Recipe,
+	tags []string,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return 0, ErrNilRecipe
+	}
+
+	var recipeID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Look for a likely duplicate
+			duplicates, findErr := findDuplicateRecipes(ctx, tx, ownerID, recipe)
+			if findErr != nil {
+				return findErr
+			}
+			var duplicateOf *int
+			if len(duplicates) > 0 {
+				duplicateOf = &duplicates[0].ID
+			}
+
+			// Insert the recipe
+			var insertErr error
+			recipeID, insertErr = insertRecipe(ctx, tx, ownerID, recipe, tags)
+			if insertErr != nil {
+				return insertErr
+			}
+
+			// Report the entry
+			_, execErr := tx.ExecContext(
+				ctx,
+				InsertImportJobItemQuery,
+				jobID,
+				position,
+				recipe.Name,
+				recipeID,
+				"",
+				duplicateOf,
+			)
+			return execErr
+		}, nil,
+	); err != nil {
+		return 0, err
+	}
+	return recipeID, nil
+}
+
+// ReportImportFailure reports an entry of a bulk import archive that could not be imported
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//   - position: the position of the entry in the archive
+//   - name: the name of the entry
+//   - entryErr: the error that made the entry fail
+//
+// Returns:
+//
+//   - error: an error if the failure could not be reported
+func (d *Service) ReportImportFailure(
+	ctx context.Context,
+	jobID, position int,
+	name string,
+	entryErr error,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if _, err := d.ExecWithCtx(
+		ctx,
+		&InsertImportJobItemQuery,
+		jobID,
+		position,
+		name,
+		nil,
+		entryErr.Error(),
+		nil,
+	); err != nil {
+		d.logError("Failed to report import failure", err)
+		return err
+	}
+	return nil
+}
+
+// ClaimImportJob marks the oldest pending bulk import job as running
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - int: the ID of the claimed job, or 0 if there are no pending jobs
+//   - error: an error if the job could not be claimed
+func (d *Service) ClaimImportJob(ctx context.Context) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	row, err := d.QueryRowWithCtx(ctx, &ClaimImportJobQuery)
+	if err != nil {
+		d.logError("Failed to query pending import job", err)
+		return 0, err
+	}
+	var jobID int
+	if err = row.Scan(&jobID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		d.logError("Failed to claim import job", err)
+		return 0, err
+	}
+	return jobID, nil
+}
+
+// ResetRunningImportJobs returns the bulk import jobs interrupted by a shutdown to the queue
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the jobs could not be reset
+func (d *Service) ResetRunningImportJobs(ctx context.Context) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if _, err := d.ExecWithCtx(ctx, &ResetRunningImportJobsQuery); err != nil {
+		d.logError("Failed to reset running import jobs", err)
+		return err
+	}
+	return nil
+}
+
+// FinishImportJob stores the result of a bulk import job and drops its archive
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - jobID: the ID of the job
+//   - jobErr: the error that made the whole job fail, such as an unreadable archive, nil if it succeeded
+//
+// Returns:
+//
+//   - error: an error if the result could not be stored
+func (d *Service) FinishImportJob(
+	ctx context.Context,
+	jobID int,
+	jobErr error,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	status := internalrouterapiv1recipe.JobStatusDone
+	var message string
+	if jobErr != nil {
+		status = internalrouterapiv1recipe.JobStatusFailed
+		message = jobErr.Error()
+	}
+
+	if _, err := d.ExecWithCtx(
+		ctx,
+		&FinishImportJobQuery,
+		status,
+		message,
+		jobID,
+	); err != nil {
+		d.logError("Failed to finish import job", err)
+		return err
+	}
+	return nil
+}
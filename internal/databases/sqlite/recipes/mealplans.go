@@ -0,0 +1,715 @@
+package recipes
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// MaxMealPlanEntries is the maximum number of entries of a meal plan
+	MaxMealPlanEntries = 200
+
+	// MaxMealPlanServings is the maximum number of servings of a meal plan entry
+	MaxMealPlanServings = 100
+)
+
+type (
+	// mealPlanRecipe is the part of a recipe of a meal plan needed to scale and price it
+	mealPlanRecipe struct {
+		servings    int
+		ingredients []internalrouterapiv1recipe.Ingredient
+	}
+)
+
+// scanMealPlan scans a meal plan row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.MealPlan: the scanned meal plan
+//   - error: an error if the row could not be scanned
+func scanMealPlan(row scanner) (*internalrouterapiv1recipe.MealPlan, error) {
+	var plan internalrouterapiv1recipe.MealPlan
+	if err := row.Scan(
+		&plan.ID,
+		&plan.OwnerID,
+		&plan.Title,
+	); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// scaleIngredients scales the amounts of the ingredients of a recipe from its servings to other servings. The
+// ingredients are kept as they are if either number of servings is unknown
+//
+// Parameters:
+//
+//   - ingredients: the ingredients of the recipe
+//   - from: the servings of the recipe
+//   - to: the servings to scale the ingredients to
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Ingredient: the scaled ingredients
+func scaleIngredients(
+	ingredients []internalrouterapiv1recipe.Ingredient,
+	from, to int,
+) []internalrouterapiv1recipe.Ingredient {
+	scaled := slices.Clone(ingredients)
+	if from <= 0 || to <= 0 || from == to {
+		return scaled
+	}
+	for i := range scaled {
+		scaled[i].Quantity = scaled[i].Quantity * float64(to) / float64(from)
+	}
+	return scaled
+}
+
+// prepareMealPlanEntries checks the entries of a meal plan and sorts them by date, keeping the order of the entries
+// of the same date
+//
+// Parameters:
+//
+//   - entries: the entries of the meal plan
+//
+// Returns:
+//
+//   - error: an error if an entry is not valid or there are too many entries
+func prepareMealPlanEntries(entries []internalrouterapiv1recipe.MealPlanEntry) error {
+	if len(entries) > MaxMealPlanEntries {
+		return ErrInvalidMealPlanEntriesCount
+	}
+
+	for i := range entries {
+		// Check the date
+		plannedOn, err := time.Parse(time.DateOnly, entries[i].Date)
+		if err != nil {
+			return ErrInvalidMealPlanDate
+		}
+		entries[i].Date = plannedOn.Format(time.DateOnly)
+
+		// Check the servings
+		if entries[i].Servings < 0 || entries[i].Servings > MaxMealPlanServings {
+			return ErrInvalidMealPlanServings
+		}
+		entries[i].Meal = strings.TrimSpace(entries[i].Meal)
+	}
+
+	slices.SortStableFunc(
+		entries, func(a, b internalrouterapiv1recipe.MealPlanEntry) int {
+			return cmp.Compare(a.Date, b.Date)
+		},
+	)
+	return nil
+}
+
+// listMealPlanEntries lists the entries of a meal plan, in order
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - planID: the ID of the meal plan
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.MealPlanEntry: the entries
+//   - error: an error if the entries could not be listed
+func (d *Service) listMealPlanEntries(ctx context.Context, planID int) (
+	[]internalrouterapiv1recipe.MealPlanEntry,
+	error,
+) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListMealPlanEntriesQuery, planID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]internalrouterapiv1recipe.MealPlanEntry, 0)
+	for rows.Next() {
+		var entry internalrouterapiv1recipe.MealPlanEntry
+		if err = rows.Scan(
+			&entry.Date,
+			&entry.Meal,
+			&entry.RecipeID,
+			&entry.Servings,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// listMealPlanRecipes lists the servings and ingredients of the recipes of a meal plan that the given user can read
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - planID: the ID of the meal plan
+//
+// Returns:
+//
+//   - map[int]*mealPlanRecipe: the recipes by ID
+//   - error: an error if the recipes could not be listed
+func (d *Service) listMealPlanRecipes(ctx context.Context, ownerID string, planID int) (
+	map[int]*mealPlanRecipe,
+	error,
+) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListMealPlanRecipeIngredientsQuery, planID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipes := make(map[int]*mealPlanRecipe)
+	for rows.Next() {
+		var recipeID int
+		var recipe mealPlanRecipe
+		var ingredients string
+		if err = rows.Scan(&recipeID, &recipe.servings, &ingredients); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(ingredients), &recipe.ingredients); err != nil {
+			return nil, err
+		}
+		recipes[recipeID] = &recipe
+	}
+	return recipes, rows.Err()
+}
+
+// setMealPlanEntries replaces the entries of a meal plan inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the meal plan
+//   - planID: the ID of the meal plan
+//   - entries: the entries, already checked and sorted
+//
+// Returns:
+//
+//   - error: an error if a recipe could not be read by the user or the entries could not be set
+func setMealPlanEntries(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	planID int,
+	entries []internalrouterapiv1recipe.MealPlanEntry,
+) error {
+	// Remove the current entries
+	if _, err := tx.ExecContext(ctx, DeleteMealPlanEntriesQuery, planID); err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		// Check that the recipe exists and the user can read it
+		if err := checkRecipeVisibility(ctx, tx, entry.RecipeID, ownerID); err != nil {
+			if errors.Is(err, ErrRecipeNotFound) {
+				return fmt.Errorf("%w: %d", ErrMealPlanRecipeNotFound, entry.RecipeID)
+			}
+			return err
+		}
+
+		// Add the entry
+		if _, err := tx.ExecContext(
+			ctx,
+			InsertMealPlanEntryQuery,
+			planID,
+			i+1,
+			entry.Date,
+			entry.Meal,
+			entry.RecipeID,
+			entry.Servings,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertMealPlan inserts a meal plan and adds its entries inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the meal plan
+//   - plan: the meal plan to insert
+//
+// Returns:
+//
+//   - int: the ID of the inserted meal plan
+//   - error: an error if the meal plan is not valid or could not be inserted
+func insertMealPlan(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	plan *internalrouterapiv1recipe.MealPlan,
+) (int, error) {
+	// Check the entries
+	if err := prepareMealPlanEntries(plan.Entries); err != nil {
+		return 0, err
+	}
+
+	// Insert the meal plan
+	result, err := tx.ExecContext(ctx, InsertMealPlanQuery, ownerID, plan.Title)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	// Add the entries
+	return int(id), setMealPlanEntries(ctx, tx, ownerID, int(id), plan.Entries)
+}
+
+// CreateMealPlan creates a meal plan owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - plan: the meal plan to create
+//
+// Returns:
+//
+//   - int: the ID of the created meal plan
+//   - error: an error if the meal plan could not be created
+func (d *Service) CreateMealPlan(
+	ctx context.Context,
+	ownerID string,
+	plan *internalrouterapiv1recipe.MealPlan,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the meal plan is nil
+	if plan == nil {
+		return 0, ErrNilMealPlan
+	}
+
+	var planID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			var insertErr error
+			planID, insertErr = insertMealPlan(ctx, tx, ownerID, plan)
+			return insertErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to create meal plan", err)
+		return 0, err
+	}
+	return planID, nil
+}
+
+// GetMealPlan gets a meal plan owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - planID: the ID of the meal plan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.MealPlan: the meal plan with its entries
+//   - error: an error if the meal plan does not exist or is not owned by the user
+func (d *Service) GetMealPlan(
+	ctx context.Context,
+	ownerID string,
+	planID int,
+) (*internalrouterapiv1recipe.MealPlan, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the meal plan
+	row, err := d.QueryRowWithCtx(ctx, &GetMealPlanQuery, planID)
+	if err != nil {
+		d.logError("Failed to query meal plan", err)
+		return nil, err
+	}
+	plan, err := scanMealPlan(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMealPlanNotFound
+		}
+		d.logError("Failed to get meal plan", err)
+		return nil, err
+	}
+	if plan.OwnerID != ownerID {
+		return nil, ErrMealPlanNotOwned
+	}
+
+	// Get the entries
+	if plan.Entries, err = d.listMealPlanEntries(ctx, planID); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ListMealPlans lists the meal plans of a user, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - limit: the maximum number of meal plans
+//   - offset: the number of meal plans to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.MealPlan: the meal plans with their entries
+//   - error: an error if the meal plans could not be listed
+func (d *Service) ListMealPlans(
+	ctx context.Context,
+	ownerID string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.MealPlan, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the meal plans
+	rows, err := db.QueryContext(ctx, ListMealPlansByOwnerIDQuery, ownerID, limit, offset)
+	if err != nil {
+		d.logError("Failed to list meal plans", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := make([]*internalrouterapiv1recipe.MealPlan, 0)
+	for rows.Next() {
+		plan, scanErr := scanMealPlan(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		plans = append(plans, plan)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load the entries of each meal plan
+	for _, plan := range plans {
+		if plan.Entries, err = d.listMealPlanEntries(ctx, plan.ID); err != nil {
+			return nil, err
+		}
+	}
+	return plans, nil
+}
+
+// UpdateMealPlan replaces the title and the entries of a meal plan owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - plan: the meal plan with the new content
+//   - versions: the versions the update may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the meal plan
+//   - error: an error if the meal plan is not valid, changed since the version or could not be updated
+func (d *Service) UpdateMealPlan(
+	ctx context.Context,
+	ownerID string,
+	plan *internalrouterapiv1recipe.MealPlan,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the meal plan is nil
+	if plan == nil {
+		return 0, ErrNilMealPlan
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the update was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityMealPlan,
+				plan.ID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Update the meal plan
+			if err := updateMealPlan(ctx, tx, ownerID, plan); err != nil {
+				return err
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityMealPlan,
+				plan.ID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to update meal plan", err)
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// updateMealPlan replaces the title and the entries of a meal plan owned by the given user inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the meal plan
+//   - plan: the meal plan with the new content
+//
+// Returns:
+//
+//   - error: an error if the meal plan is not valid or could not be updated
+func updateMealPlan(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	plan *internalrouterapiv1recipe.MealPlan,
+) error {
+	// Check the entries
+	if err := prepareMealPlanEntries(plan.Entries); err != nil {
+		return err
+	}
+
+	// Update the meal plan
+	result, err := tx.ExecContext(ctx, UpdateMealPlanQuery, plan.Title, plan.ID, ownerID)
+	if err != nil {
+		return err
+	}
+	if err = checkAffectedMealPlan(ctx, tx, result, plan.ID, ownerID); err != nil {
+		return err
+	}
+
+	// Replace the entries
+	return setMealPlanEntries(ctx, tx, ownerID, plan.ID, plan.Entries)
+}
+
+// DeleteMealPlan deletes a meal plan owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - planID: the ID of the meal plan
+//   - versions: the versions the deletion may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - error: an error if the meal plan changed since the version or could not be deleted
+func (d *Service) DeleteMealPlan(
+	ctx context.Context,
+	ownerID string,
+	planID int,
+	versions []int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the deletion was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityMealPlan,
+				planID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Delete the meal plan
+			result, err := tx.ExecContext(ctx, DeleteMealPlanQuery, planID, ownerID)
+			if err != nil {
+				return err
+			}
+			return checkAffectedMealPlan(ctx, tx, result, planID, ownerID)
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrMealPlanNotFound) && !errors.Is(err, ErrMealPlanNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to delete meal plan", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkAffectedMealPlan checks that a write over a meal plan owned by the given user affected a row
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the meal plan, either the database or a transaction
+//   - result: the result of the write
+//   - planID: the ID of the meal plan
+//   - ownerID: the ID of the user that tried to write the meal plan
+//
+// Returns:
+//
+//   - error: ErrMealPlanNotFound or ErrMealPlanNotOwned if no row was affected
+func checkAffectedMealPlan(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	result sql.Result,
+	planID int,
+	ownerID string,
+) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	plan, err := scanMealPlan(q.QueryRowContext(ctx, GetMealPlanQuery, planID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMealPlanNotFound
+		}
+		return err
+	}
+	if plan.OwnerID != ownerID {
+		return ErrMealPlanNotOwned
+	}
+	return ErrMealPlanNotFound
+}
+
+// EstimateMealPlanCost estimates the cost of a meal plan owned by the given user, and of each of its entries, with
+// the price list of the user. The ingredients of each entry are scaled to its servings before they are priced. The
+// entries whose recipe was made private by its owner since it was planned are reported as unavailable
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - planID: the ID of the meal plan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.MealPlanCost: the cost of the meal plan
+//   - error: an error if the meal plan does not exist or is not owned by the user
+func (d *Service) EstimateMealPlanCost(
+	ctx context.Context,
+	ownerID string,
+	planID int,
+) (*internalrouterapiv1recipe.MealPlanCost, error) {
+	// Get the meal plan
+	plan, err := d.GetMealPlan(ctx, ownerID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the recipes and the price list
+	recipes, err := d.listMealPlanRecipes(ctx, ownerID, planID)
+	if err != nil {
+		d.logError("Failed to list meal plan recipes", err)
+		return nil, err
+	}
+	prices, err := d.ListIngredientPrices(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var total float64
+	cost := &internalrouterapiv1recipe.MealPlanCost{
+		MealPlanID: plan.ID,
+		Complete:   true,
+		Entries:    make([]internalrouterapiv1recipe.MealPlanEntryCost, 0, len(plan.Entries)),
+	}
+	for i, entry := range plan.Entries {
+		entryCost := internalrouterapiv1recipe.MealPlanEntryCost{
+			Position: i + 1,
+			RecipeID: entry.RecipeID,
+			Servings: entry.Servings,
+			Missing:  []internalrouterapiv1recipe.MissingPrice{},
+		}
+
+		// Price the ingredients of the recipe, scaled to the servings of the entry
+		recipe, ok := recipes[entry.RecipeID]
+		if !ok {
+			entryCost.Unavailable = true
+		} else {
+			if entryCost.Servings == 0 {
+				entryCost.Servings = recipe.servings
+			}
+			var entryTotal float64
+			entryTotal, _, entryCost.Missing = EstimateCost(
+				scaleIngredients(recipe.ingredients, recipe.servings, entryCost.Servings),
+				prices,
+			)
+			entryCost.Total = roundCost(entryTotal)
+			entryCost.Complete = len(entryCost.Missing) == 0
+			total += entryTotal
+		}
+		cost.Complete = cost.Complete && entryCost.Complete
+		cost.Entries = append(cost.Entries, entryCost)
+	}
+	cost.Total = roundCost(total)
+	return cost, nil
+}
@@ -0,0 +1,108 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type (
+	// column is a column introduced after its table was first created
+	column struct {
+		table string
+		name  string
+		query string
+	}
+
+	// migration is a step that adds the columns introduced by the same change, which the CREATE statements of the
+	// tables already have
+	migration struct {
+		columns []column
+	}
+)
+
+var (
+	// migrations are the steps that bring the tables of a database created by an earlier version up to date, in the
+	// order their columns were introduced. Steps must only be appended, as the user_version pragma of the database
+	// keeps how many of them were applied
+	migrations = []migration{
+		{
+			columns: []column{
+				{table: "recipes", name: "ingredients", query: AddRecipeIngredientsColumnQuery},
+				{table: "recipes", name: "source_url", query: AddRecipeSourceURLColumnQuery},
+			},
+		},
+		{columns: []column{{table: "recipes", name: "image_url", query: AddRecipeImageURLColumnQuery}}},
+		{
+			columns: []column{
+				{table: "recipes", name: "forked_from", query: AddRecipeForkedFromColumnQuery},
+				{table: "recipes", name: "attribution", query: AddRecipeAttributionColumnQuery},
+			},
+		},
+		{columns: []column{{table: "recipes", name: "visibility", query: AddRecipeVisibilityColumnQuery}}},
+		{columns: []column{{table: "recipe_groups", name: "visibility", query: AddRecipeGroupVisibilityColumnQuery}}},
+		{
+			columns: []column{
+				{table: "recipes", name: "fingerprint", query: AddRecipeFingerprintColumnQuery},
+				{table: "import_job_items", name: "duplicate_of", query: AddImportJobItemDuplicateOfColumnQuery},
+			},
+		},
+		{columns: []column{{table: "recipes", name: "language", query: AddRecipeLanguageColumnQuery}}},
+	}
+)
+
+// migrate applies the migrations the database is missing, each one in a transaction along with the new schema
+// version. A column the table already has is not added again, as it is in the CREATE statement of the tables created
+// after it was introduced
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - db: the database connection
+//
+// Returns:
+//
+//   - error: an error if the schema version could not be read or a migration could not be applied
+func (d *Service) migrate(ctx context.Context, db *sql.DB) error {
+	// Get the number of migrations applied
+	var version int
+	if err := db.QueryRowContext(ctx, GetSchemaVersionQuery).Scan(&version); err != nil {
+		d.logError("Failed to get schema version", err)
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		step := migrations[i]
+		if err := d.CreateTransaction(
+			ctx, func(tx *sql.Tx) error {
+				for _, column := range step.columns {
+					// Check if the column was already added
+					var exists bool
+					if err := tx.QueryRowContext(
+						ctx,
+						CheckColumnExistsQuery,
+						column.table,
+						column.name,
+					).Scan(&exists); err != nil {
+						return err
+					}
+
+					// Add the column
+					if !exists {
+						if _, err := tx.ExecContext(ctx, column.query); err != nil {
+							return err
+						}
+					}
+				}
+
+				// Count the migration as applied
+				_, err := tx.ExecContext(ctx, fmt.Sprintf(SetSchemaVersionQuery, i+1))
+				return err
+			}, nil,
+		); err != nil {
+			d.logError(fmt.Sprintf("Failed to apply migration %d", i+1), err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internalunits "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/units"
+)
+
+const (
+	// MaxIngredientPrices is the maximum number of ingredient prices on the price list of a user
+	MaxIngredientPrices = 500
+)
+
+// roundCost rounds a cost to cents
+//
+// Parameters:
+//
+//   - cost: the cost
+//
+// Returns:
+//
+//   - float64: the rounded cost
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
+
+// SetIngredientPrice sets the price a user pays for an amount of an ingredient, replacing the previous price of the
+// ingredient
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - price: the ingredient price, whose slug is set
+//
+// Returns:
+//
+//   - error: an error if the price is not valid or the price list is full
+func (d *Service) SetIngredientPrice(
+	ctx context.Context,
+	userID string,
+	price *internalrouterapiv1recipe.IngredientPrice,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Check if the price is nil
+	if price == nil {
+		return ErrNilIngredientPrice
+	}
+
+	// Validate the price
+	price.Ingredient = strings.TrimSpace(price.Ingredient)
+	price.Slug = Slugify(price.Ingredient)
+	if price.Slug == "" {
+		return ErrEmptyPriceIngredient
+	}
+	if price.Quantity <= 0 || math.IsInf(price.Quantity, 0) || math.IsNaN(price.Quantity) {
+		return ErrInvalidPriceQuantity
+	}
+	if price.Price < 0 || math.IsInf(price.Price, 0) || math.IsNaN(price.Price) {
+		return ErrInvalidPrice
+	}
+	price.Unit = strings.TrimSpace(price.Unit)
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the number of prices
+			var count int
+			if err := tx.QueryRowContext(
+				ctx,
+				CountOtherIngredientPricesQuery,
+				userID,
+				price.Slug,
+			).Scan(&count); err != nil {
+				return err
+			}
+			if count >= MaxIngredientPrices {
+				return ErrInvalidIngredientPricesCount
+			}
+
+			// Store the price
+			_, err := tx.ExecContext(
+				ctx,
+				UpsertIngredientPriceQuery,
+				userID,
+				price.Slug,
+				price.Ingredient,
+				price.Quantity,
+				price.Unit,
+				price.Price,
+			)
+			return err
+		}, nil,
+	); err != nil {
+		d.logError("Failed to set ingredient price", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteIngredientPrice deletes the price of an ingredient from the price list of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - slug: the slug of the ingredient
+//
+// Returns:
+//
+//   - error: an error if the user has no price for the ingredient
+func (d *Service) DeleteIngredientPrice(
+	ctx context.Context,
+	userID, slug string,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	// Delete the price
+	result, err := db.ExecContext(ctx, DeleteIngredientPriceQuery, userID, Slugify(slug))
+	if err != nil {
+		d.logError("Failed to delete ingredient price", err)
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrIngredientPriceNotFound
+	}
+	return nil
+}
+
+// ListIngredientPrices lists the price list of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.IngredientPrice: the ingredient prices, sorted by slug
+//   - error: an error if the prices could not be listed
+func (d *Service) ListIngredientPrices(
+	ctx context.Context,
+	userID string,
+) ([]*internalrouterapiv1recipe.IngredientPrice, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListIngredientPricesQuery, userID)
+	if err != nil {
+		d.logError("Failed to list ingredient prices", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make([]*internalrouterapiv1recipe.IngredientPrice, 0)
+	for rows.Next() {
+		var price internalrouterapiv1recipe.IngredientPrice
+		if err = rows.Scan(
+			&price.Ingredient,
+			&price.Slug,
+			&price.Quantity,
+			&price.Unit,
+			&price.Price,
+			&price.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		prices = append(prices, &price)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// EstimateCost estimates the cost of a list of ingredients with a price list. Each ingredient is priced by the
+// longest run of words of its name that is on the price list, so "unsalted butter" takes the price of butter unless
+// it has its own, and its amount is converted to the unit of the price. The ingredients that cannot be priced are
+// reported instead of counted as free
+//
+// Parameters:
+//
+//   - ingredients: the ingredients
+//   - prices: the ingredient prices
+//
+// Returns:
+//
+//   - float64: the cost of the priced ingredients, not rounded
+//   - []internalrouterapiv1recipe.IngredientCost: the cost of each priced ingredient
+//   - []internalrouterapiv1recipe.MissingPrice: the ingredients that could not be priced
+func EstimateCost(
+	ingredients []internalrouterapiv1recipe.Ingredient,
+	prices []*internalrouterapiv1recipe.IngredientPrice,
+) (float64, []internalrouterapiv1recipe.IngredientCost, []internalrouterapiv1recipe.MissingPrice) {
+	// Index the prices by slug
+	pricesBySlug := make(map[string]*internalrouterapiv1recipe.IngredientPrice, len(prices))
+	for _, price := range prices {
+		pricesBySlug[price.Slug] = price
+	}
+
+	var total float64
+	items := make([]internalrouterapiv1recipe.IngredientCost, 0, len(ingredients))
+	missing := make([]internalrouterapiv1recipe.MissingPrice, 0)
+	for i, ingredient := range ingredients {
+		// Find the price of the ingredient
+		var price *internalrouterapiv1recipe.IngredientPrice
+		for _, run := range SlugRuns(Slugify(ingredient.Name)) {
+			if price = pricesBySlug[run]; price != nil {
+				break
+			}
+		}
+
+		// Convert the amount of the ingredient to the unit of the price
+		var reason internalrouterapiv1recipe.MissingPriceReason
+		var quantity float64
+		switch {
+		case price == nil:
+			reason = internalrouterapiv1recipe.MissingPriceReasonNoPrice
+		case ingredient.Quantity <= 0:
+			reason = internalrouterapiv1recipe.MissingPriceReasonNoQuantity
+		default:
+			var err error
+			quantity, err = internalunits.Convert(ingredient.Quantity, ingredient.Unit, price.Unit)
+			switch {
+			case errors.Is(err, internalunits.ErrQuantityOutOfRange):
+				reason = internalrouterapiv1recipe.MissingPriceReasonNoQuantity
+			case err != nil:
+				reason = internalrouterapiv1recipe.MissingPriceReasonIncompatibleUnit
+			}
+		}
+		if reason != "" {
+			missing = append(
+				missing, internalrouterapiv1recipe.MissingPrice{
+					Position:   i + 1,
+					Ingredient: ingredient.Name,
+					Reason:     reason,
+				},
+			)
+			continue
+		}
+
+		cost := quantity / price.Quantity * price.Price
+		total += cost
+		items = append(
+			items, internalrouterapiv1recipe.IngredientCost{
+				Position:   i + 1,
+				Ingredient: ingredient.Name,
+				PriceSlug:  price.Slug,
+				Cost:       roundCost(cost),
+			},
+		)
+	}
+	return total, items, missing
+}
+
+// EstimateRecipeCost estimates the cost of a recipe, and of each serving, with the price list of a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user whose prices are used
+//   - recipe: the recipe, already checked to be readable by the user
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.RecipeCost: the cost of the recipe
+//   - error: an error if the price list could not be read
+func (d *Service) EstimateRecipeCost(
+	ctx context.Context,
+	userID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+) (*internalrouterapiv1recipe.RecipeCost, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return nil, ErrNilRecipe
+	}
+
+	// Get the price list
+	prices, err := d.ListIngredientPrices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, items, missing := EstimateCost(recipe.Ingredients, prices)
+	cost := &internalrouterapiv1recipe.RecipeCost{
+		RecipeID: recipe.ID,
+		Servings: recipe.Servings,
+		Total:    roundCost(total),
+		Complete: len(missing) == 0,
+		Items:    items,
+		Missing:  missing,
+	}
+	if recipe.Servings > 0 {
+		cost.PerServing = roundCost(total / float64(recipe.Servings))
+	}
+	return cost, nil
+}
@@ -0,0 +1,171 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// UpsertUserProfile stores the profile snapshot of a user, replacing the previous one. The snapshot of any other user
+// that still holds the username is deleted, since usernames are unique on the auth service
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - username: the username of the user
+//   - profile: the public fields of the auth service profile, encoded as a JSON object
+//
+// Returns:
+//
+//   - error: an error if the snapshot could not be stored
+func (d *Service) UpsertUserProfile(
+	ctx context.Context,
+	userID, username string,
+	profile []byte,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Release the username from any stale snapshot
+			if _, err := tx.ExecContext(
+				ctx,
+				DeleteUserProfileByUsernameQuery,
+				username,
+				userID,
+			); err != nil {
+				return err
+			}
+
+			// Store the snapshot
+			_, err := tx.ExecContext(
+				ctx,
+				UpsertUserProfileQuery,
+				userID,
+				username,
+				string(profile),
+			)
+			return err
+		}, nil,
+	); err != nil {
+		d.logError("Failed to store user profile", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteUserProfile deletes the profile snapshot of a user, if any
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - error: an error if the snapshot could not be deleted
+func (d *Service) DeleteUserProfile(ctx context.Context, userID string) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.ExecContext(ctx, DeleteUserProfileQuery, userID); err != nil {
+		d.logError("Failed to delete user profile", err)
+		return err
+	}
+	return nil
+}
+
+// GetUserProfile gets the public profile of a user by username, merging the profile snapshot with the public recipes,
+// public groups and follow counts of the user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - username: the username of the user, case-insensitive
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes and groups to list
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.UserProfile: the profile
+//   - error: an error if there is no snapshot for the username or the profile could not be read
+func (d *Service) GetUserProfile(
+	ctx context.Context,
+	username, language string,
+	limit int,
+) (*internalrouterapiv1recipe.UserProfile, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the snapshot and the counts
+	var profile internalrouterapiv1recipe.UserProfile
+	var encodedProfile string
+	if err = db.QueryRowContext(ctx, GetUserProfileQuery, username).Scan(
+		&profile.UserID,
+		&profile.Username,
+		&encodedProfile,
+		&profile.UpdatedAt,
+		&profile.RecipeCount,
+		&profile.GroupCount,
+		&profile.FollowerCount,
+		&profile.FollowingCount,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		d.logError("Failed to get user profile", err)
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(encodedProfile), &profile.Profile); err != nil {
+		return nil, err
+	}
+
+	// Get the latest public recipes
+	if profile.Recipes, err = d.queryRecipes(
+		ctx,
+		language,
+		&ListPublicRecipesByOwnerIDQuery,
+		profile.UserID,
+		limit,
+	); err != nil {
+		return nil, err
+	}
+
+	// Get the public groups, listing only their public recipes
+	if profile.Groups, err = d.queryGroups(
+		ctx,
+		&ListPublicGroupsByOwnerIDQuery,
+		&ListGroupPublicRecipeIDsQuery,
+		profile.UserID,
+		limit,
+		0,
+	); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
@@ -0,0 +1,118 @@
+package recipes
+
+import (
+	"context"
+	"strings"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+var (
+	// likeEscaper escapes the LIKE wildcards of a search query, so they are matched literally
+	likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+// ListPublicRecipes lists the public recipes, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the recipes could not be listed
+func (d *Service) ListPublicRecipes(
+	ctx context.Context,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Recipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryRecipes(
+		ctx,
+		language,
+		&ListPublicRecipesQuery,
+		limit,
+		offset,
+	)
+}
+
+// SearchPublicRecipes lists the public recipes whose name, description or ingredient names contain the given text. The
+// recipes whose name matches come first, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - query: the text to search for
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the query is empty or the recipes could not be listed
+func (d *Service) SearchPublicRecipes(
+	ctx context.Context,
+	query string,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Recipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Normalize the query
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	return d.queryRecipes(
+		ctx,
+		language,
+		&SearchPublicRecipesQuery,
+		"%"+likeEscaper.Replace(query)+"%",
+		limit,
+		offset,
+	)
+}
+
+// GetPublicRecipe gets a public recipe by its ID. Unlisted recipes are hidden too, since they are only meant to be
+// reached through the links their owners share
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipeID: the ID of the recipe
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the recipe
+//   - error: an error if the recipe could not be found or is not public
+func (d *Service) GetPublicRecipe(
+	ctx context.Context,
+	recipeID int,
+	language string,
+) (*internalrouterapiv1recipe.Recipe, error) {
+	// Get the recipe as an anonymous user
+	recipe, err := d.GetRecipe(ctx, "", recipeID, language)
+	if err != nil {
+		return nil, err
+	}
+	if recipe.Visibility != internalrouterapiv1recipe.VisibilityPublic {
+		return nil, ErrRecipeNotFound
+	}
+	return recipe, nil
+}
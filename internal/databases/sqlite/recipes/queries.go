@@ -0,0 +1,1711 @@
+package recipes
+
+const (
+	// CreateRecipesTableQuery is the SQL query to create the recipes table
+	CreateRecipesTableQuery = `
+CREATE TABLE IF NOT EXISTS recipes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	preparation_time INTEGER NOT NULL,
+	cooking_time INTEGER NOT NULL,
+	ingredients TEXT NOT NULL DEFAULT '[]',
+	steps TEXT NOT NULL,
+	servings INTEGER NOT NULL,
+	difficulty TEXT NOT NULL,
+	source_url TEXT NOT NULL DEFAULT '',
+	image_url TEXT NOT NULL DEFAULT '',
+	visibility TEXT NOT NULL DEFAULT 'private',
+	forked_from INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+	attribution TEXT NOT NULL DEFAULT '[]',
+	fingerprint TEXT NOT NULL DEFAULT '{}',
+	language TEXT NOT NULL DEFAULT 'en',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS recipes_owner_id_idx ON recipes (owner_id);
+`
+
+	// GetSchemaVersionQuery is the SQL query to get the number of migrations applied to the database
+	GetSchemaVersionQuery = `
+PRAGMA user_version;
+`
+
+	// SetSchemaVersionQuery is the SQL query format to set the number of migrations applied to the database, pragmas
+	// take no parameters
+	SetSchemaVersionQuery = `
+PRAGMA user_version = %d;
+`
+
+	// CheckColumnExistsQuery is the SQL query to check if a table has a column
+	CheckColumnExistsQuery = `
+SELECT EXISTS (SELECT 1 FROM pragma_table_info(?) WHERE name = ?);
+`
+
+	// CreateRecipeColumnIndexesQuery is the SQL query to create the indexes on the columns added to the recipes table
+	// by the migrations, which must run before it on the databases that lack them
+	CreateRecipeColumnIndexesQuery = `
+CREATE INDEX IF NOT EXISTS recipes_forked_from_idx ON recipes (forked_from);
+CREATE INDEX IF NOT EXISTS recipes_visibility_idx ON recipes (visibility);
+`
+
+	// AddRecipeIngredientsColumnQuery is the SQL query to add the ingredients column to the recipes table, the recipes
+	// created before it have none
+	AddRecipeIngredientsColumnQuery = `
+ALTER TABLE recipes ADD COLUMN ingredients TEXT NOT NULL DEFAULT '[]';
+`
+
+	// AddRecipeSourceURLColumnQuery is the SQL query to add the source URL column to the recipes table
+	AddRecipeSourceURLColumnQuery = `
+ALTER TABLE recipes ADD COLUMN source_url TEXT NOT NULL DEFAULT '';
+`
+
+	// AddRecipeImageURLColumnQuery is the SQL query to add the image URL column to the recipes table
+	AddRecipeImageURLColumnQuery = `
+ALTER TABLE recipes ADD COLUMN image_url TEXT NOT NULL DEFAULT '';
+`
+
+	// AddRecipeForkedFromColumnQuery is the SQL query to add the column with the recipe a recipe was forked from to the
+	// recipes table
+	AddRecipeForkedFromColumnQuery = `
+ALTER TABLE recipes ADD COLUMN forked_from INTEGER REFERENCES recipes (id) ON DELETE SET NULL;
+`
+
+	// AddRecipeAttributionColumnQuery is the SQL query to add the attribution column to the recipes table. The
+	// attribution keeps the chain of recipes a fork descends from, so it survives the deletion of any of them
+	AddRecipeAttributionColumnQuery = `
+ALTER TABLE recipes ADD COLUMN attribution TEXT NOT NULL DEFAULT '[]';
+`
+
+	// AddRecipeVisibilityColumnQuery is the SQL query to add the visibility column to the recipes table, the recipes
+	// created before it stay private
+	AddRecipeVisibilityColumnQuery = `
+ALTER TABLE recipes ADD COLUMN visibility TEXT NOT NULL DEFAULT 'private';
+`
+
+	// AddRecipeGroupVisibilityColumnQuery is the SQL query to add the visibility column to the recipe groups table, the
+	// groups created before it stay private
+	AddRecipeGroupVisibilityColumnQuery = `
+ALTER TABLE recipe_groups ADD COLUMN visibility TEXT NOT NULL DEFAULT 'private';
+`
+
+	// AddRecipeFingerprintColumnQuery is the SQL query to add the fingerprint column to the recipes table. The
+	// fingerprint is compared to find likely duplicates, it is computed for the recipes created before it on connection
+	AddRecipeFingerprintColumnQuery = `
+ALTER TABLE recipes ADD COLUMN fingerprint TEXT NOT NULL DEFAULT '{}';
+`
+
+	// AddImportJobItemDuplicateOfColumnQuery is the SQL query to add the column with the recipe an imported entry was
+	// skipped as a duplicate of to the import job items table
+	AddImportJobItemDuplicateOfColumnQuery = `
+ALTER TABLE import_job_items ADD COLUMN duplicate_of INTEGER;
+`
+
+	// AddRecipeLanguageColumnQuery is the SQL query to add the language column to the recipes table, the recipes
+	// created before it are taken as written in English
+	AddRecipeLanguageColumnQuery = `
+ALTER TABLE recipes ADD COLUMN language TEXT NOT NULL DEFAULT 'en';
+`
+
+	// ListUnfingerprintedRecipesQuery is the SQL query to list the recipes created before their fingerprint was kept
+	ListUnfingerprintedRecipesQuery = `
+SELECT id, name, ingredients FROM recipes WHERE fingerprint = '{}';
+`
+
+	// SetRecipeFingerprintQuery is the SQL query to set the fingerprint of a recipe
+	SetRecipeFingerprintQuery = `
+UPDATE recipes SET fingerprint = ? WHERE id = ?;
+`
+
+	// CreateRecipeRevisionsTableQuery is the SQL query to create the recipe revisions table, which keeps a snapshot of
+	// the content of a recipe after each edit
+	CreateRecipeRevisionsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_revisions (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	number INTEGER NOT NULL,
+	author_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	preparation_time INTEGER NOT NULL,
+	cooking_time INTEGER NOT NULL,
+	ingredients TEXT NOT NULL,
+	steps TEXT NOT NULL,
+	servings INTEGER NOT NULL,
+	difficulty TEXT NOT NULL,
+	image_url TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (recipe_id, number)
+);
+`
+
+	// BackfillRecipeRevisionsQuery is the SQL query to record the current content of the recipes created before the
+	// revisions were kept as their first revision
+	BackfillRecipeRevisionsQuery = `
+INSERT INTO recipe_revisions (
+	recipe_id, number, author_id, name, description, preparation_time, cooking_time, ingredients, steps, servings,
+	difficulty, image_url, created_at
+)
+SELECT id, 1, owner_id, name, description, preparation_time, cooking_time, ingredients, steps, servings, difficulty,
+	image_url, updated_at
+FROM recipes r
+WHERE NOT EXISTS (SELECT 1 FROM recipe_revisions v WHERE v.recipe_id = r.id);
+`
+
+	// CreateTagsTableQuery is the SQL query to create the tags table. Canonical tags have an empty owner ID
+	CreateTagsTableQuery = `
+CREATE TABLE IF NOT EXISTS tags (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	slug TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	owner_id TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL,
+	UNIQUE (kind, slug, owner_id)
+);
+`
+
+	// CreateTagTranslationsTableQuery is the SQL query to create the tag translations table
+	CreateTagTranslationsTableQuery = `
+CREATE TABLE IF NOT EXISTS tag_translations (
+	tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	language TEXT NOT NULL,
+	name TEXT NOT NULL,
+	PRIMARY KEY (tag_id, language)
+);
+`
+
+	// CreateTagSynonymsTableQuery is the SQL query to create the tag synonyms table. Synonyms are stored as slugs
+	CreateTagSynonymsTableQuery = `
+CREATE TABLE IF NOT EXISTS tag_synonyms (
+	tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	language TEXT NOT NULL,
+	synonym TEXT NOT NULL,
+	PRIMARY KEY (tag_id, language, synonym)
+);
+CREATE INDEX IF NOT EXISTS tag_synonyms_synonym_idx ON tag_synonyms (synonym);
+`
+
+	// CreateRecipeTagsTableQuery is the SQL query to create the recipe tags table
+	CreateRecipeTagsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_tags (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	PRIMARY KEY (recipe_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_tags_tag_id_idx ON recipe_tags (tag_id);
+`
+
+	// CreateRecipeGroupsTableQuery is the SQL query to create the recipe groups table
+	CreateRecipeGroupsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_groups (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	visibility TEXT NOT NULL DEFAULT 'private',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS recipe_groups_owner_id_idx ON recipe_groups (owner_id);
+`
+
+	// CreateRecipeGroupItemsTableQuery is the SQL query to create the recipe group items table, which keeps the
+	// order of the recipes of each group
+	CreateRecipeGroupItemsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_group_items (
+	group_id INTEGER NOT NULL REFERENCES recipe_groups (id) ON DELETE CASCADE,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	PRIMARY KEY (group_id, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_group_items_recipe_id_idx ON recipe_group_items (recipe_id);
+`
+
+	// CreateCookbookJobsTableQuery is the SQL query to create the cookbook jobs table. The fingerprint identifies the
+	// content the PDF was generated from, so a finished job is reused until its group or recipes change
+	CreateCookbookJobsTableQuery = `
+CREATE TABLE IF NOT EXISTS cookbook_jobs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	group_id INTEGER NOT NULL REFERENCES recipe_groups (id) ON DELETE CASCADE,
+	owner_id TEXT NOT NULL,
+	language TEXT NOT NULL,
+	fingerprint TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	pdf BLOB,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	finished_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS cookbook_jobs_group_id_idx ON cookbook_jobs (group_id, language, fingerprint);
+CREATE INDEX IF NOT EXISTS cookbook_jobs_status_idx ON cookbook_jobs (status);
+`
+
+	// CreateImportJobsTableQuery is the SQL query to create the bulk import jobs table. The archive is dropped once
+	// the job finishes
+	CreateImportJobsTableQuery = `
+CREATE TABLE IF NOT EXISTS import_jobs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	format TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	archive BLOB,
+	total INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	finished_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS import_jobs_status_idx ON import_jobs (status);
+`
+
+	// CreateImportJobItemsTableQuery is the SQL query to create the bulk import job items table, which holds the
+	// per-entry report of each job
+	CreateImportJobItemsTableQuery = `
+CREATE TABLE IF NOT EXISTS import_job_items (
+	job_id INTEGER NOT NULL REFERENCES import_jobs (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	recipe_id INTEGER,
+	duplicate_of INTEGER,
+	error TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (job_id, position)
+);
+`
+
+	// CreateFollowsTableQuery is the SQL query to create the follows table, the graph of the users that follow other
+	// users
+	CreateFollowsTableQuery = `
+CREATE TABLE IF NOT EXISTS follows (
+	follower_id TEXT NOT NULL,
+	followee_id TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (follower_id, followee_id),
+	CHECK (follower_id != followee_id)
+);
+CREATE INDEX IF NOT EXISTS follows_followee_id_idx ON follows (followee_id);
+`
+
+	// CreateFeedEventsTableQuery is the SQL query to create the feed events table, which keeps the activity shown in
+	// the feeds. A recipe is announced once, the first time it is published
+	CreateFeedEventsTableQuery = `
+CREATE TABLE IF NOT EXISTS feed_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	actor_id TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS feed_events_recipe_id_idx ON feed_events (recipe_id);
+CREATE INDEX IF NOT EXISTS feed_events_actor_id_idx ON feed_events (actor_id, id);
+`
+
+	// CreateUserProfilesTableQuery is the SQL query to create the user profiles table, which keeps a snapshot of the
+	// public fields of the auth service profile of each user, so public profiles are served without calling it
+	CreateUserProfilesTableQuery = `
+CREATE TABLE IF NOT EXISTS user_profiles (
+	user_id TEXT PRIMARY KEY,
+	username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+	profile TEXT NOT NULL DEFAULT '{}',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+	// CreateRecipeActivityTableQuery is the SQL query to create the recipe activity table, which keeps a copy of the
+	// time-bucketed activity counters of the recipes kept on Redis, so they survive a Redis flush. The bucket is the
+	// start of the period it counts, as a Unix timestamp
+	CreateRecipeActivityTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_activity (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	kind TEXT NOT NULL,
+	bucket INTEGER NOT NULL,
+	count INTEGER NOT NULL,
+	PRIMARY KEY (bucket, kind, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_activity_recipe_id_idx ON recipe_activity (recipe_id);
+`
+
+	// CreateRecipeSimilaritiesTableQuery is the SQL query to create the recipe similarities table, which keeps the
+	// public recipes most similar to each recipe, as computed by the last recommendations run
+	CreateRecipeSimilaritiesTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_similarities (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	similar_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	score REAL NOT NULL,
+	PRIMARY KEY (recipe_id, similar_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_similarities_similar_id_idx ON recipe_similarities (similar_id);
+`
+
+	// CreateRecipeRecommendationsTableQuery is the SQL query to create the recipe recommendations table, which keeps
+	// the public recipes recommended to each user, as computed by the last recommendations run
+	CreateRecipeRecommendationsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_recommendations (
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	score REAL NOT NULL,
+	PRIMARY KEY (user_id, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_recommendations_recipe_id_idx ON recipe_recommendations (recipe_id);
+`
+
+	// CreateCookLogsTableQuery is the SQL query to create the cook logs table, the journal of the times each user
+	// cooked a recipe. The recipe name is kept so the journal survives the deletion of the recipe, and the cooked on
+	// date is stored as YYYY-MM-DD text
+	CreateCookLogsTableQuery = `
+CREATE TABLE IF NOT EXISTS cook_logs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+	recipe_name TEXT NOT NULL,
+	cooked_on TEXT NOT NULL,
+	notes TEXT NOT NULL DEFAULT '',
+	substitutions TEXT NOT NULL DEFAULT '[]',
+	rating INTEGER NOT NULL DEFAULT 0,
+	photos TEXT NOT NULL DEFAULT '[]',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS cook_logs_user_id_idx ON cook_logs (user_id, cooked_on);
+CREATE INDEX IF NOT EXISTS cook_logs_recipe_id_idx ON cook_logs (recipe_id);
+`
+
+	// CreateRecipeAnnotationsTableQuery is the SQL query to create the recipe annotations table, the private notes of
+	// each user on a recipe, one of its steps or one of its ingredients. The anchor keeps the text of the annotated
+	// step or the name of the annotated ingredient, so the annotation follows it when the recipe is edited
+	CreateRecipeAnnotationsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_annotations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	target TEXT NOT NULL,
+	position INTEGER NOT NULL DEFAULT 0,
+	anchor TEXT NOT NULL DEFAULT '',
+	note TEXT NOT NULL DEFAULT '',
+	substitute TEXT NOT NULL DEFAULT '',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS recipe_annotations_user_id_idx ON recipe_annotations (user_id, recipe_id);
+CREATE INDEX IF NOT EXISTS recipe_annotations_recipe_id_idx ON recipe_annotations (recipe_id);
+`
+
+	// CreateUserDietsTableQuery is the SQL query to create the user diets table, the dietary preferences of each
+	// user, encoded as a JSON array
+	CreateUserDietsTableQuery = `
+CREATE TABLE IF NOT EXISTS user_diets (
+	user_id TEXT PRIMARY KEY,
+	diets TEXT NOT NULL DEFAULT '[]',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+	// CreateRecipeTranslationsTableQuery is the SQL query to create the recipe translations table. The ingredients
+	// keep only the translated names, in recipe order, since the amounts do not change
+	CreateRecipeTranslationsTableQuery = `
+CREATE TABLE IF NOT EXISTS recipe_translations (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	language TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	ingredients TEXT NOT NULL DEFAULT '[]',
+	steps TEXT NOT NULL DEFAULT '[]',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (recipe_id, language)
+);
+`
+
+	// CreateIngredientPricesTableQuery is the SQL query to create the ingredient prices table, the price list of each
+	// user, with the price paid for an amount of each ingredient
+	CreateIngredientPricesTableQuery = `
+CREATE TABLE IF NOT EXISTS ingredient_prices (
+	user_id TEXT NOT NULL,
+	slug TEXT NOT NULL,
+	ingredient TEXT NOT NULL,
+	quantity REAL NOT NULL,
+	unit TEXT NOT NULL DEFAULT '',
+	price REAL NOT NULL,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (user_id, slug)
+);
+`
+
+	// CreateMealPlansTableQuery is the SQL query to create the meal plans table
+	CreateMealPlansTableQuery = `
+CREATE TABLE IF NOT EXISTS meal_plans (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS meal_plans_owner_id_idx ON meal_plans (owner_id);
+`
+
+	// CreateMealPlanEntriesTableQuery is the SQL query to create the meal plan entries table, the recipes planned for
+	// each date of a meal plan in the order they were sent
+	CreateMealPlanEntriesTableQuery = `
+CREATE TABLE IF NOT EXISTS meal_plan_entries (
+	plan_id INTEGER NOT NULL REFERENCES meal_plans (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	planned_on TEXT NOT NULL,
+	meal TEXT NOT NULL DEFAULT '',
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	servings INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (plan_id, position)
+);
+CREATE INDEX IF NOT EXISTS meal_plan_entries_recipe_id_idx ON meal_plan_entries (recipe_id);
+`
+
+	// CreateShoppingListsTableQuery is the SQL query to create the shopping lists table, whose items are stored as a
+	// JSON array
+	CreateShoppingListsTableQuery = `
+CREATE TABLE IF NOT EXISTS shopping_lists (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	items TEXT NOT NULL DEFAULT '[]',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS shopping_lists_owner_id_idx ON shopping_lists (owner_id);
+`
+
+	// CreateFavoritesTableQuery is the SQL query to create the favorites table, the recipes each user marked as a
+	// favorite. Favorites have their own ID, so their creation and deletion can be synced with the offline clients
+	CreateFavoritesTableQuery = `
+CREATE TABLE IF NOT EXISTS favorites (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (user_id, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS favorites_recipe_id_idx ON favorites (recipe_id);
+`
+
+	// CreateSyncChangesTableQuery is the SQL query to create the sync changes table and the triggers that fill it. Each
+	// recipe, group, meal plan, shopping list and favorite keeps a single row, replaced on every change so its sequence
+	// is both the version of the entity and the position of its latest change in the log read by the sync endpoint.
+	// Deleted entities keep their row as a tombstone. Changes to the tags or translations of a recipe, the recipes of a
+	// group or the entries of a meal plan are changes to the recipe, the group or the meal plan, but not once it is
+	// deleted, so the cascades do not bring the tombstone back. Translations are upserted, and the DO UPDATE of an
+	// upsert overrides the OR REPLACE of the triggers it fires, so their row is replaced by hand
+	CreateSyncChangesTableQuery = `
+CREATE TABLE IF NOT EXISTS sync_changes (
+	sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	entity TEXT NOT NULL,
+	entity_id INTEGER NOT NULL,
+	deleted INTEGER NOT NULL DEFAULT 0,
+	UNIQUE (entity, entity_id)
+);
+CREATE INDEX IF NOT EXISTS sync_changes_owner_id_idx ON sync_changes (owner_id, sequence);
+CREATE TRIGGER IF NOT EXISTS recipes_sync_insert_trg AFTER INSERT ON recipes
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'recipe', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipes_sync_update_trg AFTER UPDATE ON recipes
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'recipe', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipes_sync_delete_trg AFTER DELETE ON recipes
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted) VALUES (OLD.owner_id, 'recipe', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_tags_sync_insert_trg AFTER INSERT ON recipe_tags
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = NEW.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_tags_sync_delete_trg AFTER DELETE ON recipe_tags
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = OLD.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_translations_sync_insert_trg AFTER INSERT ON recipe_translations
+BEGIN
+	DELETE FROM sync_changes WHERE entity = 'recipe' AND entity_id IN (SELECT id FROM recipes WHERE id = NEW.recipe_id);
+	INSERT INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = NEW.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_translations_sync_update_trg AFTER UPDATE ON recipe_translations
+BEGIN
+	DELETE FROM sync_changes WHERE entity = 'recipe' AND entity_id IN (SELECT id FROM recipes WHERE id = NEW.recipe_id);
+	INSERT INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = NEW.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_translations_sync_delete_trg AFTER DELETE ON recipe_translations
+BEGIN
+	DELETE FROM sync_changes WHERE entity = 'recipe' AND entity_id IN (SELECT id FROM recipes WHERE id = OLD.recipe_id);
+	INSERT INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = OLD.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_groups_sync_insert_trg AFTER INSERT ON recipe_groups
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'group', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_groups_sync_update_trg AFTER UPDATE ON recipe_groups
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'group', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_groups_sync_delete_trg AFTER DELETE ON recipe_groups
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted) VALUES (OLD.owner_id, 'group', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_group_items_sync_insert_trg AFTER INSERT ON recipe_group_items
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'group', id FROM recipe_groups WHERE id = NEW.group_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_group_items_sync_delete_trg AFTER DELETE ON recipe_group_items
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'group', id FROM recipe_groups WHERE id = OLD.group_id;
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plans_sync_insert_trg AFTER INSERT ON meal_plans
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'meal_plan', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plans_sync_update_trg AFTER UPDATE ON meal_plans
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'meal_plan', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plans_sync_delete_trg AFTER DELETE ON meal_plans
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted)
+	VALUES (OLD.owner_id, 'meal_plan', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plan_entries_sync_insert_trg AFTER INSERT ON meal_plan_entries
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'meal_plan', id FROM meal_plans WHERE id = NEW.plan_id;
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plan_entries_sync_update_trg AFTER UPDATE ON meal_plan_entries
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'meal_plan', id FROM meal_plans WHERE id = NEW.plan_id;
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plan_entries_sync_delete_trg AFTER DELETE ON meal_plan_entries
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'meal_plan', id FROM meal_plans WHERE id = OLD.plan_id;
+END;
+CREATE TRIGGER IF NOT EXISTS shopping_lists_sync_insert_trg AFTER INSERT ON shopping_lists
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'shopping_list', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS shopping_lists_sync_update_trg AFTER UPDATE ON shopping_lists
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'shopping_list', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS shopping_lists_sync_delete_trg AFTER DELETE ON shopping_lists
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted)
+	VALUES (OLD.owner_id, 'shopping_list', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS favorites_sync_insert_trg AFTER INSERT ON favorites
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.user_id, 'favorite', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS favorites_sync_delete_trg AFTER DELETE ON favorites
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted) VALUES (OLD.user_id, 'favorite', OLD.id, 1);
+END;
+`
+
+	// BackfillSyncChangesQuery is the SQL query to record the recipes, groups, meal plans, shopping lists and favorites
+	// created before their changes were logged, so the first sync of their owners gets them
+	BackfillSyncChangesQuery = `
+INSERT OR IGNORE INTO sync_changes (owner_id, entity, entity_id) SELECT owner_id, 'recipe', id FROM recipes;
+INSERT OR IGNORE INTO sync_changes (owner_id, entity, entity_id) SELECT owner_id, 'group', id FROM recipe_groups;
+INSERT OR IGNORE INTO sync_changes (owner_id, entity, entity_id) SELECT owner_id, 'meal_plan', id FROM meal_plans;
+INSERT OR IGNORE INTO sync_changes (owner_id, entity, entity_id)
+SELECT owner_id, 'shopping_list', id FROM shopping_lists;
+INSERT OR IGNORE INTO sync_changes (owner_id, entity, entity_id) SELECT user_id, 'favorite', id FROM favorites;
+`
+)
+
+var (
+	// InsertRecipeQuery is the SQL query to insert a new recipe
+	InsertRecipeQuery = `
+INSERT INTO recipes (
+	owner_id, name, description, preparation_time, cooking_time, ingredients, steps, servings, difficulty, source_url,
+	image_url, visibility, forked_from, attribution, fingerprint, language
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+`
+
+	// UpdateRecipeQuery is the SQL query to update a recipe owned by the given user
+	UpdateRecipeQuery = `
+UPDATE recipes
+SET name = ?, description = ?, preparation_time = ?, cooking_time = ?, ingredients = ?, steps = ?, servings = ?,
+	difficulty = ?, image_url = ?, fingerprint = ?, language = COALESCE(NULLIF(?, ''), language),
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = ? AND owner_id = ?;
+`
+
+	// DeleteRecipeQuery is the SQL query to delete a recipe owned by the given user
+	DeleteRecipeQuery = `
+DELETE FROM recipes WHERE id = ? AND owner_id = ?;
+`
+
+	// GetRecipeQuery is the SQL query to get a recipe by its ID, counting the forks the given user can see
+	GetRecipeQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = ?2 OR f.visibility = 'public'))
+FROM recipes r
+WHERE r.id = ?1;
+`
+
+	// InsertRecipeRevisionQuery is the SQL query to record the current content of a recipe as its next revision,
+	// unless it matches the latest revision
+	InsertRecipeRevisionQuery = `
+INSERT INTO recipe_revisions (
+	recipe_id, number, author_id, name, description, preparation_time, cooking_time, ingredients, steps, servings,
+	difficulty, image_url
+)
+SELECT r.id, COALESCE(l.number, 0) + 1, ?2, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients,
+	r.steps, r.servings, r.difficulty, r.image_url
+FROM recipes r
+LEFT JOIN recipe_revisions l ON l.recipe_id = r.id
+	AND l.number = (SELECT MAX(number) FROM recipe_revisions WHERE recipe_id = r.id)
+WHERE r.id = ?1 AND (
+	l.number IS NULL OR l.name != r.name OR l.description != r.description
+	OR l.preparation_time != r.preparation_time OR l.cooking_time != r.cooking_time
+	OR l.ingredients != r.ingredients OR l.steps != r.steps OR l.servings != r.servings
+	OR l.difficulty != r.difficulty OR l.image_url != r.image_url
+);
+`
+
+	// GetLatestRecipeRevisionNumberQuery is the SQL query to get the number of the latest revision of a recipe
+	GetLatestRecipeRevisionNumberQuery = `
+SELECT COALESCE(MAX(number), 0) FROM recipe_revisions WHERE recipe_id = ?;
+`
+
+	// GetRecipeRevisionQuery is the SQL query to get a revision of a recipe by its number
+	GetRecipeRevisionQuery = `
+SELECT recipe_id, number, author_id, name, description, preparation_time, cooking_time, ingredients, steps, servings,
+	difficulty, image_url, created_at
+FROM recipe_revisions
+WHERE recipe_id = ? AND number = ?;
+`
+
+	// ListRecipeRevisionsQuery is the SQL query to list the revisions of a recipe, newest first
+	ListRecipeRevisionsQuery = `
+SELECT recipe_id, number, author_id, name, description, preparation_time, cooking_time, ingredients, steps, servings,
+	difficulty, image_url, created_at
+FROM recipe_revisions
+WHERE recipe_id = ?
+ORDER BY number DESC
+LIMIT ? OFFSET ?;
+`
+
+	// ListRecipeForksQuery is the SQL query to list the recipes forked from a recipe that are owned by the given user
+	// or public, newest first
+	ListRecipeForksQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = ?2 OR f.visibility = 'public'))
+FROM recipes r
+WHERE r.forked_from = ?1 AND (r.owner_id = ?2 OR r.visibility = 'public')
+ORDER BY r.id DESC
+LIMIT ?3 OFFSET ?4;
+`
+
+	// CountRecipeForksQuery is the SQL query to count the recipes forked from a recipe that are owned by the given user
+	// or public
+	CountRecipeForksQuery = `
+SELECT COUNT(*) FROM recipes WHERE forked_from = ? AND (owner_id = ? OR visibility = 'public');
+`
+
+	// ListRecipeTagLabelsQuery is the SQL query to list the labels that tag a copy of a recipe with the same tags,
+	// the slug of the canonical tags and the name of the user tags
+	ListRecipeTagLabelsQuery = `
+SELECT CASE WHEN t.kind = 'user' THEN t.name ELSE t.slug END
+FROM recipe_tags rt
+INNER JOIN tags t ON t.id = rt.tag_id
+WHERE rt.recipe_id = ?
+ORDER BY t.kind, t.slug;
+`
+
+	// GetRecipeVisibilityQuery is the SQL query to get the owner ID and the visibility of a recipe
+	GetRecipeVisibilityQuery = `
+SELECT owner_id, visibility FROM recipes WHERE id = ?;
+`
+
+	// SetRecipeVisibilityQuery is the SQL query to set the visibility of a recipe owned by the given user
+	SetRecipeVisibilityQuery = `
+UPDATE recipes SET visibility = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND owner_id = ?;
+`
+
+	// GetRecipeOwnerIDQuery is the SQL query to get the owner ID of a recipe
+	GetRecipeOwnerIDQuery = `
+SELECT owner_id FROM recipes WHERE id = ?;
+`
+
+	// ListRecipesByOwnerIDQuery is the SQL query to list the recipes of a user
+	ListRecipesByOwnerIDQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = ?1 OR f.visibility = 'public'))
+FROM recipes r
+WHERE r.owner_id = ?1
+ORDER BY r.id DESC
+LIMIT ?2 OFFSET ?3;
+`
+
+	// ListPublicRecipesQuery is the SQL query to list the public recipes
+	ListPublicRecipesQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND f.visibility = 'public')
+FROM recipes r
+WHERE r.visibility = 'public'
+ORDER BY r.id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// SearchPublicRecipesQuery is the SQL query to list the public recipes whose name, description or ingredient names
+	// match a LIKE pattern
+	SearchPublicRecipesQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND f.visibility = 'public')
+FROM recipes r
+WHERE r.visibility = 'public' AND (
+	r.name LIKE ?1 ESCAPE '\' OR r.description LIKE ?1 ESCAPE '\' OR EXISTS (
+		SELECT 1 FROM json_each(r.ingredients) i WHERE json_extract(i.value, '$.name') LIKE ?1 ESCAPE '\'
+	)
+)
+ORDER BY r.name LIKE ?1 ESCAPE '\' DESC, r.id DESC
+LIMIT ?2 OFFSET ?3;
+`
+
+	// ListRecipesByTagIDQuery is the SQL query to list the recipes tagged with a tag that are owned by the given user
+	// or public
+	ListRecipesByTagIDQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = ?2 OR f.visibility = 'public'))
+FROM recipes r
+INNER JOIN recipe_tags rt ON rt.recipe_id = r.id
+WHERE rt.tag_id = ?1 AND (r.owner_id = ?2 OR r.visibility = 'public')
+ORDER BY r.id DESC
+LIMIT ?3 OFFSET ?4;
+`
+
+	// InsertCanonicalTagQuery is the SQL query to insert a canonical tag if it does not exist yet
+	InsertCanonicalTagQuery = `
+INSERT INTO tags (slug, kind, owner_id, name) VALUES (?, ?, '', ?)
+ON CONFLICT (kind, slug, owner_id) DO UPDATE SET name = excluded.name
+RETURNING id;
+`
+
+	// InsertUserTagQuery is the SQL query to insert a user tag if it does not exist yet
+	InsertUserTagQuery = `
+INSERT INTO tags (slug, kind, owner_id, name) VALUES (?, 'user', ?, ?)
+ON CONFLICT (kind, slug, owner_id) DO UPDATE SET slug = excluded.slug
+RETURNING id;
+`
+
+	// UpsertTagTranslationQuery is the SQL query to insert or update a tag translation
+	UpsertTagTranslationQuery = `
+INSERT INTO tag_translations (tag_id, language, name) VALUES (?, ?, ?)
+ON CONFLICT (tag_id, language) DO UPDATE SET name = excluded.name;
+`
+
+	// InsertTagSynonymQuery is the SQL query to insert a tag synonym
+	InsertTagSynonymQuery = `
+INSERT OR IGNORE INTO tag_synonyms (tag_id, language, synonym) VALUES (?, ?, ?);
+`
+
+	// ResolveTagIDQuery is the SQL query to resolve a slug to a tag ID. Canonical tags are matched by slug or synonym
+	// before the user's own tags
+	ResolveTagIDQuery = `
+SELECT id FROM (
+	SELECT t.id, 0 AS priority FROM tags t WHERE t.owner_id = '' AND t.slug = ?1
+	UNION ALL
+	SELECT ts.tag_id, 1 AS priority FROM tag_synonyms ts WHERE ts.synonym = ?1
+	UNION ALL
+	SELECT t.id, 2 AS priority FROM tags t WHERE t.kind = 'user' AND t.owner_id = ?2 AND t.slug = ?1
+)
+ORDER BY priority
+LIMIT 1;
+`
+
+	// GetTagQuery is the SQL query to get a tag visible to a user by its kind and slug, localized to a language
+	GetTagQuery = `
+SELECT t.id, t.slug, t.kind, t.owner_id, COALESCE(tt.name, t.name)
+FROM tags t
+LEFT JOIN tag_translations tt ON tt.tag_id = t.id AND tt.language = ?3
+WHERE t.kind = ?1 AND t.slug = ?2 AND (t.owner_id = '' OR t.owner_id = ?4);
+`
+
+	// ListTagsQuery is the SQL query to list the tags visible to a user with their recipe count, optionally filtered by
+	// kind and localized to a language. Only the recipes the user can read are counted
+	ListTagsQuery = `
+SELECT t.id, t.slug, t.kind, t.owner_id, COALESCE(tt.name, t.name), COUNT(r.id)
+FROM tags t
+LEFT JOIN tag_translations tt ON tt.tag_id = t.id AND tt.language = ?2
+LEFT JOIN recipe_tags rt ON rt.tag_id = t.id
+LEFT JOIN recipes r ON r.id = rt.recipe_id AND (r.owner_id = ?3 OR r.visibility = 'public')
+WHERE (t.owner_id = '' OR t.owner_id = ?3) AND (?1 = '' OR t.kind = ?1)
+GROUP BY t.id
+ORDER BY t.kind, t.slug;
+`
+
+	// AutocompleteTagsQuery is the SQL query to list the tags visible to a user whose slug, localized name or synonyms
+	// start with the given prefix
+	AutocompleteTagsQuery = `
+SELECT t.id, t.slug, t.kind, t.owner_id, COALESCE(tt.name, t.name)
+FROM tags t
+LEFT JOIN tag_translations tt ON tt.tag_id = t.id AND tt.language = ?3
+WHERE (t.owner_id = '' OR t.owner_id = ?4) AND (?1 = '' OR t.kind = ?1)
+	AND (
+		t.slug LIKE ?2 || '%'
+		OR EXISTS (
+			SELECT 1 FROM tag_synonyms ts WHERE ts.tag_id = t.id AND ts.synonym LIKE ?2 || '%'
+		)
+		OR EXISTS (
+			SELECT 1 FROM tag_translations tn WHERE tn.tag_id = t.id AND tn.name LIKE ?5 || '%'
+		)
+	)
+ORDER BY t.owner_id, t.slug
+LIMIT ?6;
+`
+
+	// ListRecipeTagsQuery is the SQL query to list the tags of a recipe localized to a language
+	ListRecipeTagsQuery = `
+SELECT t.id, t.slug, t.kind, t.owner_id, COALESCE(tt.name, t.name)
+FROM recipe_tags rt
+INNER JOIN tags t ON t.id = rt.tag_id
+LEFT JOIN tag_translations tt ON tt.tag_id = t.id AND tt.language = ?
+WHERE rt.recipe_id = ?
+ORDER BY t.kind, t.slug;
+`
+
+	// DeleteRecipeTagsQuery is the SQL query to remove all the tags of a recipe
+	DeleteRecipeTagsQuery = `
+DELETE FROM recipe_tags WHERE recipe_id = ?;
+`
+
+	// InsertRecipeTagQuery is the SQL query to tag a recipe
+	InsertRecipeTagQuery = `
+INSERT OR IGNORE INTO recipe_tags (recipe_id, tag_id) VALUES (?, ?);
+`
+
+	// InsertGroupQuery is the SQL query to insert a new recipe group
+	InsertGroupQuery = `
+INSERT INTO recipe_groups (owner_id, title, description, visibility) VALUES (?, ?, ?, ?);
+`
+
+	// UpdateGroupQuery is the SQL query to update a recipe group owned by the given user
+	UpdateGroupQuery = `
+UPDATE recipe_groups
+SET title = ?, description = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ? AND owner_id = ?;
+`
+
+	// DeleteGroupQuery is the SQL query to delete a recipe group owned by the given user
+	DeleteGroupQuery = `
+DELETE FROM recipe_groups WHERE id = ? AND owner_id = ?;
+`
+
+	// GetGroupQuery is the SQL query to get a recipe group by its ID
+	GetGroupQuery = `
+SELECT id, owner_id, title, description, visibility FROM recipe_groups WHERE id = ?;
+`
+
+	// ListGroupsByOwnerIDQuery is the SQL query to list the recipe groups of a user
+	ListGroupsByOwnerIDQuery = `
+SELECT id, owner_id, title, description, visibility
+FROM recipe_groups
+WHERE owner_id = ?
+ORDER BY id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// ListPublicGroupsByOwnerIDQuery is the SQL query to list the public recipe groups of a user
+	ListPublicGroupsByOwnerIDQuery = `
+SELECT id, owner_id, title, description, visibility
+FROM recipe_groups
+WHERE owner_id = ? AND visibility = 'public'
+ORDER BY id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// SetGroupVisibilityQuery is the SQL query to set the visibility of a recipe group owned by the given user
+	SetGroupVisibilityQuery = `
+UPDATE recipe_groups SET visibility = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND owner_id = ?;
+`
+
+	// ListGroupRecipeIDsQuery is the SQL query to list the IDs of the recipes of a group that its owner can read, in
+	// order
+	ListGroupRecipeIDsQuery = `
+SELECT gi.recipe_id
+FROM recipe_group_items gi
+INNER JOIN recipe_groups g ON g.id = gi.group_id
+INNER JOIN recipes r ON r.id = gi.recipe_id
+WHERE gi.group_id = ? AND (r.owner_id = g.owner_id OR r.visibility != 'private')
+ORDER BY gi.position;
+`
+
+	// ListGroupPublicRecipeIDsQuery is the SQL query to list the IDs of the public recipes of a group, in order
+	ListGroupPublicRecipeIDsQuery = `
+SELECT gi.recipe_id
+FROM recipe_group_items gi
+INNER JOIN recipes r ON r.id = gi.recipe_id
+WHERE gi.group_id = ? AND r.visibility = 'public'
+ORDER BY gi.position;
+`
+
+	// ListGroupRecipesQuery is the SQL query to list the recipes of a group that its owner can read, in order
+	ListGroupRecipesQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = g.owner_id OR f.visibility = 'public'))
+FROM recipes r
+INNER JOIN recipe_group_items gi ON gi.recipe_id = r.id
+INNER JOIN recipe_groups g ON g.id = gi.group_id
+WHERE gi.group_id = ? AND (r.owner_id = g.owner_id OR r.visibility != 'private')
+ORDER BY gi.position;
+`
+
+	// DeleteGroupItemsQuery is the SQL query to remove all the recipes of a group
+	DeleteGroupItemsQuery = `
+DELETE FROM recipe_group_items WHERE group_id = ?;
+`
+
+	// InsertGroupItemQuery is the SQL query to add a recipe to a group
+	InsertGroupItemQuery = `
+INSERT INTO recipe_group_items (group_id, recipe_id, position) VALUES (?, ?, ?);
+`
+
+	// InsertCookbookJobQuery is the SQL query to insert a new pending cookbook job
+	InsertCookbookJobQuery = `
+INSERT INTO cookbook_jobs (group_id, owner_id, language, fingerprint, status) VALUES (?, ?, ?, ?, 'pending')
+RETURNING id, group_id, owner_id, language, status, error, created_at, finished_at;
+`
+
+	// DeleteStaleCookbookJobsQuery is the SQL query to delete the settled jobs of a group generated from other content
+	DeleteStaleCookbookJobsQuery = `
+DELETE FROM cookbook_jobs
+WHERE group_id = ?1 AND language = ?2 AND fingerprint != ?3 AND status IN ('done', 'failed');
+`
+
+	// FindCookbookJobQuery is the SQL query to find the latest job of a group generated from the given content that
+	// has not failed
+	FindCookbookJobQuery = `
+SELECT id, group_id, owner_id, language, status, error, created_at, finished_at
+FROM cookbook_jobs
+WHERE group_id = ? AND language = ? AND fingerprint = ? AND status != 'failed'
+ORDER BY id DESC
+LIMIT 1;
+`
+
+	// GetCookbookJobQuery is the SQL query to get a cookbook job by its ID
+	GetCookbookJobQuery = `
+SELECT id, group_id, owner_id, language, status, error, created_at, finished_at
+FROM cookbook_jobs
+WHERE id = ?;
+`
+
+	// GetCookbookPDFQuery is the SQL query to get the PDF of a cookbook job
+	GetCookbookPDFQuery = `
+SELECT pdf FROM cookbook_jobs WHERE id = ?;
+`
+
+	// ClaimCookbookJobQuery is the SQL query to mark the oldest pending cookbook job as running and return its ID
+	ClaimCookbookJobQuery = `
+UPDATE cookbook_jobs
+SET status = 'running'
+WHERE id = (SELECT id FROM cookbook_jobs WHERE status = 'pending' ORDER BY id LIMIT 1)
+RETURNING id;
+`
+
+	// ResetRunningCookbookJobsQuery is the SQL query to return the jobs interrupted by a shutdown to the queue
+	ResetRunningCookbookJobsQuery = `
+UPDATE cookbook_jobs SET status = 'pending' WHERE status = 'running';
+`
+
+	// FinishCookbookJobQuery is the SQL query to store the result of a cookbook job
+	FinishCookbookJobQuery = `
+UPDATE cookbook_jobs
+SET status = ?, error = ?, pdf = ?, fingerprint = ?, finished_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+`
+
+	// InsertImportJobQuery is the SQL query to insert a new pending bulk import job
+	InsertImportJobQuery = `
+INSERT INTO import_jobs (owner_id, format, archive, status) VALUES (?, ?, ?, 'pending') RETURNING id;
+`
+
+	// GetImportJobQuery is the SQL query to get a bulk import job by its ID, with the number of imported and failed
+	// entries
+	GetImportJobQuery = `
+SELECT j.id, j.owner_id, j.format, j.status, j.error, j.total,
+	(SELECT COUNT(*) FROM import_job_items i WHERE i.job_id = j.id AND i.error = ''),
+	(SELECT COUNT(*) FROM import_job_items i WHERE i.job_id = j.id AND i.error != ''),
+	j.created_at, j.finished_at
+FROM import_jobs j
+WHERE j.id = ?;
+`
+
+	// ListImportJobItemsQuery is the SQL query to list the report of a bulk import job, in archive order
+	ListImportJobItemsQuery = `
+SELECT position, name, COALESCE(recipe_id, 0), error, COALESCE(duplicate_of, 0)
+FROM import_job_items
+WHERE job_id = ?
+ORDER BY position;
+`
+
+	// GetImportArchiveQuery is the SQL query to get the archive of a bulk import job and the position of the last
+	// processed entry
+	GetImportArchiveQuery = `
+SELECT archive, (SELECT COALESCE(MAX(position), 0) FROM import_job_items WHERE job_id = import_jobs.id)
+FROM import_jobs
+WHERE id = ?;
+`
+
+	// SetImportJobTotalQuery is the SQL query to set the number of entries of a bulk import job
+	SetImportJobTotalQuery = `
+UPDATE import_jobs SET total = ? WHERE id = ?;
+`
+
+	// InsertImportJobItemQuery is the SQL query to add an entry to the report of a bulk import job
+	InsertImportJobItemQuery = `
+INSERT INTO import_job_items (job_id, position, name, recipe_id, error, duplicate_of) VALUES (?, ?, ?, ?, ?, ?);
+`
+
+	// ClaimImportJobQuery is the SQL query to mark the oldest pending bulk import job as running and return its ID
+	ClaimImportJobQuery = `
+UPDATE import_jobs
+SET status = 'running'
+WHERE id = (SELECT id FROM import_jobs WHERE status = 'pending' ORDER BY id LIMIT 1)
+RETURNING id;
+`
+
+	// ResetRunningImportJobsQuery is the SQL query to return the jobs interrupted by a shutdown to the queue, they
+	// resume after the last reported entry
+	ResetRunningImportJobsQuery = `
+UPDATE import_jobs SET status = 'pending' WHERE status = 'running';
+`
+
+	// FinishImportJobQuery is the SQL query to store the result of a bulk import job and drop its archive
+	FinishImportJobQuery = `
+UPDATE import_jobs
+SET status = ?, error = ?, archive = NULL, finished_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+`
+
+	// InsertFollowQuery is the SQL query to make a user follow another user, ignored if they already do
+	InsertFollowQuery = `
+INSERT OR IGNORE INTO follows (follower_id, followee_id) VALUES (?, ?);
+`
+
+	// DeleteFollowQuery is the SQL query to make a user stop following another user
+	DeleteFollowQuery = `
+DELETE FROM follows WHERE follower_id = ? AND followee_id = ?;
+`
+
+	// ListFolloweesQuery is the SQL query to list the users a user follows, most recently followed first
+	ListFolloweesQuery = `
+SELECT followee_id, created_at
+FROM follows
+WHERE follower_id = ?
+ORDER BY created_at DESC, followee_id
+LIMIT ? OFFSET ?;
+`
+
+	// ListFollowersQuery is the SQL query to list the followers of a user, most recent first
+	ListFollowersQuery = `
+SELECT follower_id, created_at
+FROM follows
+WHERE followee_id = ?
+ORDER BY created_at DESC, follower_id
+LIMIT ? OFFSET ?;
+`
+
+	// CountFollowersQuery is the SQL query to count the followers of a user
+	CountFollowersQuery = `
+SELECT COUNT(*) FROM follows WHERE followee_id = ?;
+`
+
+	// ListFollowerIDsQuery is the SQL query to list the IDs of every follower of a user
+	ListFollowerIDsQuery = `
+SELECT follower_id FROM follows WHERE followee_id = ?;
+`
+
+	// InsertFeedEventQuery is the SQL query to record the publication of a recipe if it is public and was not
+	// announced before
+	InsertFeedEventQuery = `
+INSERT INTO feed_events (actor_id, kind, recipe_id)
+SELECT owner_id, CASE WHEN forked_from IS NULL THEN 'recipe' ELSE 'fork' END, id
+FROM recipes
+WHERE id = ? AND visibility = 'public'
+ON CONFLICT DO NOTHING
+RETURNING id, kind, actor_id, created_at;
+`
+
+	// ListActorFeedEventIDsQuery is the SQL query to list the IDs of the latest feed events of a user, newest first
+	ListActorFeedEventIDsQuery = `
+SELECT id FROM feed_events WHERE actor_id = ? ORDER BY id DESC LIMIT ?;
+`
+
+	// ListPopularFolloweesFeedEventIDsQuery is the SQL query to list the IDs of the feed events older than a cursor
+	// of the users a user follows that have more followers than a threshold, newest first. Their events are not
+	// fanned out to the feeds of their followers
+	ListPopularFolloweesFeedEventIDsQuery = `
+WITH popular AS (
+	SELECT f.followee_id
+	FROM follows f
+	WHERE f.follower_id = ?1 AND (SELECT COUNT(*) FROM follows c WHERE c.followee_id = f.followee_id) > ?2
+)
+SELECT e.id
+FROM feed_events e
+INNER JOIN popular p ON p.followee_id = e.actor_id
+WHERE e.id < ?3
+ORDER BY e.id DESC
+LIMIT ?4;
+`
+
+	// ListFeedEventsQuery is the SQL query to list the feed events with the given IDs, passed as a JSON array, whose
+	// recipe is still public, newest first
+	ListFeedEventsQuery = `
+SELECT e.id, e.kind, e.actor_id, e.recipe_id, e.created_at
+FROM feed_events e
+INNER JOIN recipes r ON r.id = e.recipe_id
+WHERE e.id IN (SELECT value FROM json_each(?)) AND r.visibility = 'public'
+ORDER BY e.id DESC;
+`
+
+	// ListRecipesByIDsQuery is the SQL query to list the recipes with the given IDs, passed as a JSON array, counting the
+	// forks the given user can see
+	ListRecipesByIDsQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = ?2 OR f.visibility = 'public'))
+FROM recipes r
+WHERE r.id IN (SELECT value FROM json_each(?1));
+`
+
+	// DeleteUserProfileByUsernameQuery is the SQL query to delete the profile snapshot of any other user holding a
+	// username, which happens when a username is released and taken by another user before the old snapshot is
+	// refreshed
+	DeleteUserProfileByUsernameQuery = `
+DELETE FROM user_profiles WHERE username = ? AND user_id != ?;
+`
+
+	// UpsertUserProfileQuery is the SQL query to insert or replace the profile snapshot of a user
+	UpsertUserProfileQuery = `
+INSERT INTO user_profiles (user_id, username, profile)
+VALUES (?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET username = excluded.username, profile = excluded.profile,
+	updated_at = CURRENT_TIMESTAMP;
+`
+
+	// DeleteUserProfileQuery is the SQL query to delete the profile snapshot of a user
+	DeleteUserProfileQuery = `
+DELETE FROM user_profiles WHERE user_id = ?;
+`
+
+	// GetUserProfileQuery is the SQL query to get the profile snapshot of a user by username, along with the counts
+	// shown on the public profile
+	GetUserProfileQuery = `
+SELECT p.user_id, p.username, p.profile, p.updated_at,
+	(SELECT COUNT(*) FROM recipes r WHERE r.owner_id = p.user_id AND r.visibility = 'public'),
+	(SELECT COUNT(*) FROM recipe_groups g WHERE g.owner_id = p.user_id AND g.visibility = 'public'),
+	(SELECT COUNT(*) FROM follows f WHERE f.followee_id = p.user_id),
+	(SELECT COUNT(*) FROM follows f WHERE f.follower_id = p.user_id)
+FROM user_profiles p
+WHERE p.username = ?;
+`
+
+	// ListPublicRecipesByOwnerIDQuery is the SQL query to list the public recipes of a user, newest first
+	ListPublicRecipesByOwnerIDQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND f.visibility = 'public')
+FROM recipes r
+WHERE r.owner_id = ? AND r.visibility = 'public'
+ORDER BY r.id DESC
+LIMIT ?;
+`
+
+	// ListPublicRecipesByIDsQuery is the SQL query to list the public recipes with the given IDs, passed as a JSON
+	// array
+	ListPublicRecipesByIDsQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND f.visibility = 'public')
+FROM recipes r
+WHERE r.id IN (SELECT value FROM json_each(?)) AND r.visibility = 'public';
+`
+
+	// UpsertRecipeActivityQuery is the SQL query to store an activity counter of a recipe, if the recipe still
+	// exists. The highest count is kept, so a counter read right after a Redis flush does not lower it
+	UpsertRecipeActivityQuery = `
+INSERT INTO recipe_activity (recipe_id, kind, bucket, count)
+SELECT id, ?, ?, ? FROM recipes WHERE id = ?
+ON CONFLICT (bucket, kind, recipe_id) DO UPDATE SET count = MAX(count, excluded.count);
+`
+
+	// ListRecipeActivityQuery is the SQL query to list the activity counters of the buckets from the given one
+	ListRecipeActivityQuery = `
+SELECT recipe_id, kind, bucket, count FROM recipe_activity WHERE bucket >= ?;
+`
+
+	// DeleteRecipeActivityQuery is the SQL query to delete the activity counters of the buckets before the given one
+	DeleteRecipeActivityQuery = `
+DELETE FROM recipe_activity WHERE bucket < ?;
+`
+
+	// ListRecipeFeaturesQuery is the SQL query to list the features of every recipe compared by the recommendations:
+	// its ingredients and its canonical tag IDs, as a JSON array
+	ListRecipeFeaturesQuery = `
+SELECT r.id, r.owner_id, r.visibility, r.ingredients,
+	(
+		SELECT json_group_array(rt.tag_id)
+		FROM recipe_tags rt
+		INNER JOIN tags t ON t.id = rt.tag_id
+		WHERE rt.recipe_id = r.id AND t.owner_id = ''
+	)
+FROM recipes r;
+`
+
+	// ListRecipeSignalsQuery is the SQL query to list the recipes each user showed interest in: the ones they own,
+	// forked, added to their groups or cooked without rating them below 3
+	ListRecipeSignalsQuery = `
+SELECT owner_id, id, 'own' FROM recipes
+UNION ALL
+SELECT owner_id, forked_from, 'fork' FROM recipes WHERE forked_from IS NOT NULL
+UNION ALL
+SELECT g.owner_id, gi.recipe_id, 'group'
+FROM recipe_group_items gi
+INNER JOIN recipe_groups g ON g.id = gi.group_id
+UNION ALL
+SELECT user_id, recipe_id, 'cook' FROM cook_logs WHERE recipe_id IS NOT NULL AND (rating = 0 OR rating >= 3);
+`
+
+	// DeleteRecipeSimilaritiesQuery is the SQL query to delete every recipe similarity
+	DeleteRecipeSimilaritiesQuery = `
+DELETE FROM recipe_similarities;
+`
+
+	// InsertRecipeSimilarityQuery is the SQL query to insert a recipe similarity
+	InsertRecipeSimilarityQuery = `
+INSERT INTO recipe_similarities (recipe_id, similar_id, score) VALUES (?, ?, ?);
+`
+
+	// DeleteRecipeRecommendationsQuery is the SQL query to delete every recipe recommendation
+	DeleteRecipeRecommendationsQuery = `
+DELETE FROM recipe_recommendations;
+`
+
+	// InsertRecipeRecommendationQuery is the SQL query to insert a recipe recommendation
+	InsertRecipeRecommendationQuery = `
+INSERT INTO recipe_recommendations (user_id, recipe_id, score) VALUES (?, ?, ?);
+`
+
+	// ListSimilarRecipeIDsQuery is the SQL query to list the IDs and scores of the public recipes most similar to a
+	// recipe, most similar first
+	ListSimilarRecipeIDsQuery = `
+SELECT s.similar_id, s.score
+FROM recipe_similarities s
+INNER JOIN recipes r ON r.id = s.similar_id
+WHERE s.recipe_id = ? AND r.visibility = 'public'
+ORDER BY s.score DESC, s.similar_id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// ListRecommendedRecipeIDsQuery is the SQL query to list the IDs and scores of the public recipes recommended to
+	// a user, best first
+	ListRecommendedRecipeIDsQuery = `
+SELECT rr.recipe_id, rr.score
+FROM recipe_recommendations rr
+INNER JOIN recipes r ON r.id = rr.recipe_id
+WHERE rr.user_id = ? AND r.visibility = 'public' AND r.owner_id != rr.user_id
+ORDER BY rr.score DESC, rr.recipe_id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// ListRecipeFingerprintsByOwnerIDQuery is the SQL query to list the fingerprints of the recipes owned by a user,
+	// but one
+	ListRecipeFingerprintsByOwnerIDQuery = `
+SELECT id, name, fingerprint FROM recipes WHERE owner_id = ? AND id != ?;
+`
+
+	// MergeRecipeTagsQuery is the SQL query to add the tags of a recipe to another one, up to the maximum number of
+	// tags of a recipe
+	MergeRecipeTagsQuery = `
+INSERT OR IGNORE INTO recipe_tags (recipe_id, tag_id)
+SELECT ?1, tag_id
+FROM recipe_tags
+WHERE recipe_id = ?2
+	AND tag_id NOT IN (SELECT tag_id FROM recipe_tags WHERE recipe_id = ?1)
+ORDER BY tag_id
+LIMIT MAX(0, ?3 - (SELECT COUNT(*) FROM recipe_tags WHERE recipe_id = ?1));
+`
+
+	// MergeRecipeGroupItemsQuery is the SQL query to put a recipe in the place of another one in the groups that do
+	// not already hold it
+	MergeRecipeGroupItemsQuery = `
+INSERT OR IGNORE INTO recipe_group_items (group_id, recipe_id, position)
+SELECT group_id, ?1, position
+FROM recipe_group_items
+WHERE recipe_id = ?2;
+`
+
+	// MergeRecipeMealPlanEntriesQuery is the SQL query to plan a recipe in the place of another one in the meal plans
+	MergeRecipeMealPlanEntriesQuery = `
+UPDATE meal_plan_entries SET recipe_id = ?1 WHERE recipe_id = ?2;
+`
+
+	// MergeRecipeFavoritesQuery is the SQL query to mark a recipe as a favorite of the users that marked another one,
+	// keeping when they did
+	MergeRecipeFavoritesQuery = `
+INSERT OR IGNORE INTO favorites (user_id, recipe_id, created_at)
+SELECT user_id, ?1, created_at
+FROM favorites
+WHERE recipe_id = ?2;
+`
+
+	// MergeRecipeForksQuery is the SQL query to point the forks of a recipe to another one
+	MergeRecipeForksQuery = `
+UPDATE recipes SET forked_from = ?1 WHERE forked_from = ?2 AND id != ?1;
+`
+
+	// InsertCookLogQuery is the SQL query to log that a user cooked a recipe, keeping the name of the recipe
+	InsertCookLogQuery = `
+INSERT INTO cook_logs (user_id, recipe_id, recipe_name, cooked_on, notes, substitutions, rating, photos)
+SELECT ?, id, name, ?, ?, ?, ?, ?
+FROM recipes
+WHERE id = ?;
+`
+
+	// GetCookLogQuery is the SQL query to get a cook log by its ID
+	GetCookLogQuery = `
+SELECT id, user_id, recipe_id, recipe_name, cooked_on, notes, substitutions, rating, photos, created_at
+FROM cook_logs
+WHERE id = ?;
+`
+
+	// UpdateCookLogQuery is the SQL query to update a cook log of the given user
+	UpdateCookLogQuery = `
+UPDATE cook_logs
+SET cooked_on = ?, notes = ?, substitutions = ?, rating = ?, photos = ?
+WHERE id = ? AND user_id = ?;
+`
+
+	// DeleteCookLogQuery is the SQL query to delete a cook log of the given user
+	DeleteCookLogQuery = `
+DELETE FROM cook_logs WHERE id = ? AND user_id = ?;
+`
+
+	// ListCookLogsByUserIDQuery is the SQL query to list the cook logs of a user, most recently cooked first
+	ListCookLogsByUserIDQuery = `
+SELECT id, user_id, recipe_id, recipe_name, cooked_on, notes, substitutions, rating, photos, created_at
+FROM cook_logs
+WHERE user_id = ?
+ORDER BY cooked_on DESC, id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// ListRecipeCookLogsByUserIDQuery is the SQL query to list the cook logs of a recipe by a user, most recently
+	// cooked first
+	ListRecipeCookLogsByUserIDQuery = `
+SELECT id, user_id, recipe_id, recipe_name, cooked_on, notes, substitutions, rating, photos, created_at
+FROM cook_logs
+WHERE user_id = ? AND recipe_id = ?
+ORDER BY cooked_on DESC, id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// GetRecipeCookStatsQuery is the SQL query to count the times a recipe was cooked, by anyone and by the given
+	// user, and average its ratings
+	GetRecipeCookStatsQuery = `
+SELECT COUNT(*), COUNT(DISTINCT user_id), COUNT(NULLIF(rating, 0)), AVG(NULLIF(rating, 0)),
+	COUNT(CASE WHEN user_id = ?2 THEN 1 END), MAX(CASE WHEN user_id = ?2 THEN cooked_on END)
+FROM cook_logs
+WHERE recipe_id = ?1;
+`
+
+	// InsertRecipeAnnotationQuery is the SQL query to insert a recipe annotation
+	InsertRecipeAnnotationQuery = `
+INSERT INTO recipe_annotations (user_id, recipe_id, target, position, anchor, note, substitute)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+`
+
+	// GetRecipeAnnotationTargetQuery is the SQL query to get the target of a recipe annotation of the given user
+	GetRecipeAnnotationTargetQuery = `
+SELECT target FROM recipe_annotations WHERE id = ? AND recipe_id = ? AND user_id = ?;
+`
+
+	// UpdateRecipeAnnotationQuery is the SQL query to update the note and substitute of a recipe annotation of the
+	// given user
+	UpdateRecipeAnnotationQuery = `
+UPDATE recipe_annotations
+SET note = ?, substitute = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ? AND recipe_id = ? AND user_id = ?;
+`
+
+	// DeleteRecipeAnnotationQuery is the SQL query to delete a recipe annotation of the given user
+	DeleteRecipeAnnotationQuery = `
+DELETE FROM recipe_annotations WHERE id = ? AND recipe_id = ? AND user_id = ?;
+`
+
+	// CountRecipeAnnotationsQuery is the SQL query to count the annotations of a user on a recipe
+	CountRecipeAnnotationsQuery = `
+SELECT COUNT(*) FROM recipe_annotations WHERE user_id = ? AND recipe_id = ?;
+`
+
+	// ListRecipeAnnotationsQuery is the SQL query to list the annotations of a user on a recipe
+	ListRecipeAnnotationsQuery = `
+SELECT id, target, position, anchor, note, substitute, updated_at
+FROM recipe_annotations
+WHERE user_id = ? AND recipe_id = ?
+ORDER BY id;
+`
+
+	// GetUserDietsQuery is the SQL query to get the dietary preferences of a user
+	GetUserDietsQuery = `
+SELECT diets FROM user_diets WHERE user_id = ?;
+`
+
+	// UpsertUserDietsQuery is the SQL query to insert or replace the dietary preferences of a user
+	UpsertUserDietsQuery = `
+INSERT INTO user_diets (user_id, diets)
+VALUES (?, ?)
+ON CONFLICT (user_id) DO UPDATE SET diets = excluded.diets, updated_at = CURRENT_TIMESTAMP;
+`
+
+	// CountOtherIngredientPricesQuery is the SQL query to count the prices of a user other than the one of the given
+	// ingredient
+	CountOtherIngredientPricesQuery = `
+SELECT COUNT(*) FROM ingredient_prices WHERE user_id = ? AND slug != ?;
+`
+
+	// UpsertIngredientPriceQuery is the SQL query to insert or replace the price of an ingredient of a user
+	UpsertIngredientPriceQuery = `
+INSERT INTO ingredient_prices (user_id, slug, ingredient, quantity, unit, price)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id, slug) DO UPDATE SET ingredient = excluded.ingredient, quantity = excluded.quantity,
+	unit = excluded.unit, price = excluded.price, updated_at = CURRENT_TIMESTAMP;
+`
+
+	// DeleteIngredientPriceQuery is the SQL query to delete the price of an ingredient of a user
+	DeleteIngredientPriceQuery = `
+DELETE FROM ingredient_prices WHERE user_id = ? AND slug = ?;
+`
+
+	// ListIngredientPricesQuery is the SQL query to list the price list of a user
+	ListIngredientPricesQuery = `
+SELECT ingredient, slug, quantity, unit, price, updated_at
+FROM ingredient_prices
+WHERE user_id = ?
+ORDER BY slug;
+`
+
+	// InsertMealPlanQuery is the SQL query to insert a new meal plan
+	InsertMealPlanQuery = `
+INSERT INTO meal_plans (owner_id, title) VALUES (?, ?);
+`
+
+	// UpdateMealPlanQuery is the SQL query to update a meal plan owned by the given user
+	UpdateMealPlanQuery = `
+UPDATE meal_plans SET title = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND owner_id = ?;
+`
+
+	// DeleteMealPlanQuery is the SQL query to delete a meal plan owned by the given user
+	DeleteMealPlanQuery = `
+DELETE FROM meal_plans WHERE id = ? AND owner_id = ?;
+`
+
+	// GetMealPlanQuery is the SQL query to get a meal plan by its ID
+	GetMealPlanQuery = `
+SELECT id, owner_id, title FROM meal_plans WHERE id = ?;
+`
+
+	// ListMealPlansByOwnerIDQuery is the SQL query to list the meal plans of a user
+	ListMealPlansByOwnerIDQuery = `
+SELECT id, owner_id, title
+FROM meal_plans
+WHERE owner_id = ?
+ORDER BY id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// ListMealPlanEntriesQuery is the SQL query to list the entries of a meal plan, in order
+	ListMealPlanEntriesQuery = `
+SELECT planned_on, meal, recipe_id, servings
+FROM meal_plan_entries
+WHERE plan_id = ?
+ORDER BY position;
+`
+
+	// DeleteMealPlanEntriesQuery is the SQL query to remove all the entries of a meal plan
+	DeleteMealPlanEntriesQuery = `
+DELETE FROM meal_plan_entries WHERE plan_id = ?;
+`
+
+	// InsertMealPlanEntryQuery is the SQL query to add an entry to a meal plan
+	InsertMealPlanEntryQuery = `
+INSERT INTO meal_plan_entries (plan_id, position, planned_on, meal, recipe_id, servings) VALUES (?, ?, ?, ?, ?, ?);
+`
+
+	// ListMealPlanRecipeIngredientsQuery is the SQL query to list the servings and ingredients of the recipes of a
+	// meal plan that the given user can read
+	ListMealPlanRecipeIngredientsQuery = `
+SELECT r.id, r.servings, r.ingredients
+FROM recipes r
+WHERE r.id IN (SELECT recipe_id FROM meal_plan_entries WHERE plan_id = ?1)
+	AND (r.owner_id = ?2 OR r.visibility != 'private');
+`
+
+	// InsertShoppingListQuery is the SQL query to insert a new shopping list
+	InsertShoppingListQuery = `
+INSERT INTO shopping_lists (owner_id, title, items) VALUES (?, ?, ?);
+`
+
+	// UpdateShoppingListQuery is the SQL query to update a shopping list owned by the given user
+	UpdateShoppingListQuery = `
+UPDATE shopping_lists SET title = ?, items = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND owner_id = ?;
+`
+
+	// DeleteShoppingListQuery is the SQL query to delete a shopping list owned by the given user
+	DeleteShoppingListQuery = `
+DELETE FROM shopping_lists WHERE id = ? AND owner_id = ?;
+`
+
+	// GetShoppingListQuery is the SQL query to get a shopping list by its ID
+	GetShoppingListQuery = `
+SELECT id, owner_id, title, items FROM shopping_lists WHERE id = ?;
+`
+
+	// ListShoppingListsByOwnerIDQuery is the SQL query to list the shopping lists of a user
+	ListShoppingListsByOwnerIDQuery = `
+SELECT id, owner_id, title, items
+FROM shopping_lists
+WHERE owner_id = ?
+ORDER BY id DESC
+LIMIT ? OFFSET ?;
+`
+
+	// InsertFavoriteQuery is the SQL query to mark a recipe as a favorite of a user, ignored if it already is
+	InsertFavoriteQuery = `
+INSERT OR IGNORE INTO favorites (user_id, recipe_id) VALUES (?, ?);
+`
+
+	// GetFavoriteQuery is the SQL query to get a favorite of a user
+	GetFavoriteQuery = `
+SELECT id, recipe_id, created_at FROM favorites WHERE id = ? AND user_id = ?;
+`
+
+	// GetFavoriteByRecipeIDQuery is the SQL query to get the favorite a user marked a recipe as
+	GetFavoriteByRecipeIDQuery = `
+SELECT id, recipe_id, created_at FROM favorites WHERE user_id = ? AND recipe_id = ?;
+`
+
+	// DeleteFavoriteQuery is the SQL query to unmark a recipe as a favorite of a user
+	DeleteFavoriteQuery = `
+DELETE FROM favorites WHERE user_id = ? AND recipe_id = ?;
+`
+
+	// DeleteFavoriteByIDQuery is the SQL query to delete a favorite of a user by its ID
+	DeleteFavoriteByIDQuery = `
+DELETE FROM favorites WHERE id = ? AND user_id = ?;
+`
+
+	// ListFavoriteRecipesQuery is the SQL query to list the favorite recipes of a user the user can still read, most
+	// recently marked first
+	ListFavoriteRecipesQuery = `
+SELECT r.id, r.owner_id, r.name, r.description, r.preparation_time, r.cooking_time, r.ingredients, r.steps,
+	r.servings, r.difficulty, r.source_url, r.image_url, r.visibility, r.forked_from,
+	r.attribution, r.language,
+	(SELECT COUNT(*) FROM recipes f WHERE f.forked_from = r.id AND (f.owner_id = ?1 OR f.visibility = 'public'))
+FROM recipes r
+INNER JOIN favorites fav ON fav.recipe_id = r.id
+WHERE fav.user_id = ?1 AND (r.owner_id = ?1 OR r.visibility = 'public')
+ORDER BY fav.id DESC
+LIMIT ?2 OFFSET ?3;
+`
+
+	// CountOtherRecipeTranslationsQuery is the SQL query to count the translations of a recipe other than the one to
+	// the given language
+	CountOtherRecipeTranslationsQuery = `
+SELECT COUNT(*) FROM recipe_translations WHERE recipe_id = ? AND language != ?;
+`
+
+	// UpsertRecipeTranslationQuery is the SQL query to insert or replace the translation of a recipe to a language
+	UpsertRecipeTranslationQuery = `
+INSERT INTO recipe_translations (recipe_id, language, name, description, ingredients, steps)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (recipe_id, language) DO UPDATE SET name = excluded.name, description = excluded.description,
+	ingredients = excluded.ingredients, steps = excluded.steps, updated_at = CURRENT_TIMESTAMP;
+`
+
+	// DeleteRecipeTranslationQuery is the SQL query to delete the translation of a recipe to a language
+	DeleteRecipeTranslationQuery = `
+DELETE FROM recipe_translations WHERE recipe_id = ? AND language = ?;
+`
+
+	// ListRecipeTranslationsQuery is the SQL query to list the translations of the given recipes, passed as a JSON
+	// array of IDs
+	ListRecipeTranslationsQuery = `
+SELECT recipe_id, language, name, description, ingredients, steps, updated_at
+FROM recipe_translations
+WHERE recipe_id IN (SELECT value FROM json_each(?))
+ORDER BY recipe_id, language;
+`
+
+	// GetLatestSyncSequenceQuery is the SQL query to get the sequence of the latest logged change
+	GetLatestSyncSequenceQuery = `
+SELECT COALESCE(MAX(sequence), 0) FROM sync_changes;
+`
+
+	// ListSyncChangesQuery is the SQL query to list the latest change of each synced entity of a user logged between
+	// two sequences, oldest first
+	ListSyncChangesQuery = `
+SELECT sequence, entity, entity_id, deleted
+FROM sync_changes
+WHERE owner_id = ? AND sequence > ? AND sequence <= ?
+ORDER BY sequence
+LIMIT ?;
+`
+
+	// GetSyncVersionQuery is the SQL query to get the version of a synced entity owned by the given user and whether it
+	// was deleted
+	GetSyncVersionQuery = `
+SELECT sequence, deleted FROM sync_changes WHERE entity = ? AND entity_id = ? AND owner_id = ?;
+`
+
+	// CreateSyncSavepointQuery is the SQL query to mark the point a pushed change is applied from, to undo only that
+	// change if it fails
+	CreateSyncSavepointQuery = `
+SAVEPOINT sync_change;
+`
+
+	// RollbackSyncSavepointQuery is the SQL query to undo a pushed change that failed
+	RollbackSyncSavepointQuery = `
+ROLLBACK TO sync_change;
+`
+
+	// ReleaseSyncSavepointQuery is the SQL query to keep a pushed change, releasing its savepoint
+	ReleaseSyncSavepointQuery = `
+RELEASE sync_change;
+`
+
+	// GetVersionQuery is the SQL query to get the version of a synced entity that was not deleted
+	GetVersionQuery = `
+SELECT sequence FROM sync_changes WHERE entity = ? AND entity_id = ? AND deleted = 0;
+`
+)
@@ -0,0 +1,361 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// RecipeSignalKind is how a user showed interest in a recipe
+	RecipeSignalKind string
+
+	// RecipeFeatures are the features of a recipe compared by the recommendations
+	RecipeFeatures struct {
+		ID          int
+		OwnerID     string
+		Visibility  internalrouterapiv1recipe.Visibility
+		Ingredients []string // ingredient names, as written
+		TagIDs      []int    // canonical tags only, user tags are personal
+	}
+
+	// RecipeSignal is a recipe a user showed interest in
+	RecipeSignal struct {
+		UserID   string
+		RecipeID int
+		Kind     RecipeSignalKind
+	}
+
+	// ScoredRecipeID is the ID of a recipe ranked by a score
+	ScoredRecipeID struct {
+		ID    int
+		Score float64
+	}
+)
+
+const (
+	// RecipeSignalKindOwn is the signal of a recipe owned by the user
+	RecipeSignalKindOwn RecipeSignalKind = "own"
+
+	// RecipeSignalKindFork is the signal of a recipe forked by the user
+	RecipeSignalKindFork RecipeSignalKind = "fork"
+
+	// RecipeSignalKindGroup is the signal of a recipe added by the user to one of their groups
+	RecipeSignalKindGroup RecipeSignalKind = "group"
+
+	// RecipeSignalKindCook is the signal of a recipe cooked by the user, unless they rated it below 3
+	RecipeSignalKindCook RecipeSignalKind = "cook"
+)
+
+// ListRecipeFeatures lists the features of every recipe
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - []*RecipeFeatures: the features
+//   - error: an error if the features could not be listed
+func (d *Service) ListRecipeFeatures(ctx context.Context) ([]*RecipeFeatures, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListRecipeFeaturesQuery)
+	if err != nil {
+		d.logError("Failed to list recipe features", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	features := make([]*RecipeFeatures, 0)
+	for rows.Next() {
+		var recipe RecipeFeatures
+		var encodedIngredients, encodedTagIDs string
+		if err = rows.Scan(
+			&recipe.ID,
+			&recipe.OwnerID,
+			&recipe.Visibility,
+			&encodedIngredients,
+			&encodedTagIDs,
+		); err != nil {
+			return nil, err
+		}
+
+		var ingredients []internalrouterapiv1recipe.Ingredient
+		if err = json.Unmarshal([]byte(encodedIngredients), &ingredients); err != nil {
+			return nil, err
+		}
+		recipe.Ingredients = make([]string, 0, len(ingredients))
+		for _, ingredient := range ingredients {
+			recipe.Ingredients = append(recipe.Ingredients, ingredient.Name)
+		}
+		if err = json.Unmarshal([]byte(encodedTagIDs), &recipe.TagIDs); err != nil {
+			return nil, err
+		}
+		features = append(features, &recipe)
+	}
+	return features, rows.Err()
+}
+
+// ListRecipeSignals lists the recipes every user showed interest in
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - []*RecipeSignal: the signals
+//   - error: an error if the signals could not be listed
+func (d *Service) ListRecipeSignals(ctx context.Context) ([]*RecipeSignal, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, ListRecipeSignalsQuery)
+	if err != nil {
+		d.logError("Failed to list recipe signals", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	signals := make([]*RecipeSignal, 0)
+	for rows.Next() {
+		var signal RecipeSignal
+		if err = rows.Scan(&signal.UserID, &signal.RecipeID, &signal.Kind); err != nil {
+			return nil, err
+		}
+		signals = append(signals, &signal)
+	}
+	return signals, rows.Err()
+}
+
+// ReplaceRecommendations replaces the stored recipe similarities and recommendations with the ones of a new run
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - similarities: the recipes most similar to each recipe, by recipe ID
+//   - recommendations: the recipes recommended to each user, by user ID
+//
+// Returns:
+//
+//   - error: an error if they could not be stored
+func (d *Service) ReplaceRecommendations(
+	ctx context.Context,
+	similarities map[int][]*ScoredRecipeID,
+	recommendations map[string][]*ScoredRecipeID,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Replace the similarities
+			if _, err := tx.ExecContext(ctx, DeleteRecipeSimilaritiesQuery); err != nil {
+				return err
+			}
+			stmt, err := tx.PrepareContext(ctx, InsertRecipeSimilarityQuery)
+			if err != nil {
+				return err
+			}
+			defer stmt.Close()
+			for recipeID, similar := range similarities {
+				for _, scored := range similar {
+					if _, err = stmt.ExecContext(ctx, recipeID, scored.ID, scored.Score); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Replace the recommendations
+			if _, err = tx.ExecContext(ctx, DeleteRecipeRecommendationsQuery); err != nil {
+				return err
+			}
+			recommendationStmt, err := tx.PrepareContext(ctx, InsertRecipeRecommendationQuery)
+			if err != nil {
+				return err
+			}
+			defer recommendationStmt.Close()
+			for userID, recommended := range recommendations {
+				for _, scored := range recommended {
+					if _, err = recommendationStmt.ExecContext(ctx, userID, scored.ID, scored.Score); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}, nil,
+	); err != nil {
+		d.logError("Failed to store recommendations", err)
+		return err
+	}
+	return nil
+}
+
+// queryScoredRecipes runs a query that returns recipe IDs with their scores and loads the public ones, in order
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - language: the language used to localize the tags
+//   - query: the query to run
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.ScoredRecipe: the recipes with their scores
+//   - error: an error if the recipes could not be queried
+func (d *Service) queryScoredRecipes(
+	ctx context.Context,
+	language string,
+	query *string,
+	params ...any,
+) ([]*internalrouterapiv1recipe.ScoredRecipe, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to list scored recipes", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipeIDs := make([]int, 0)
+	scoresByID := make(map[int]float64)
+	for rows.Next() {
+		var scored ScoredRecipeID
+		if err = rows.Scan(&scored.ID, &scored.Score); err != nil {
+			return nil, err
+		}
+		recipeIDs = append(recipeIDs, scored.ID)
+		scoresByID[scored.ID] = scored.Score
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load the recipes
+	recipes, err := d.ListPublicRecipesByIDs(ctx, language, recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	scoredRecipes := make([]*internalrouterapiv1recipe.ScoredRecipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		scoredRecipes = append(
+			scoredRecipes, &internalrouterapiv1recipe.ScoredRecipe{
+				Recipe: recipe,
+				Score:  scoresByID[recipe.ID],
+			},
+		)
+	}
+	return scoredRecipes, nil
+}
+
+// ListSimilarRecipes lists the public recipes most similar to a recipe the given user can read
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - viewerID: the ID of the user that reads the recipe
+//   - recipeID: the ID of the recipe
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.ScoredRecipe: the recipes with their similarity, most similar first
+//   - error: an error if the recipe does not exist, is private to another user or the recipes could not be listed
+func (d *Service) ListSimilarRecipes(
+	ctx context.Context,
+	viewerID string,
+	recipeID int,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.ScoredRecipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the user can read the recipe
+	if err = checkRecipeVisibility(ctx, db, recipeID, viewerID); err != nil {
+		return nil, err
+	}
+
+	return d.queryScoredRecipes(
+		ctx,
+		language,
+		&ListSimilarRecipeIDsQuery,
+		recipeID,
+		limit,
+		offset,
+	)
+}
+
+// ListRecommendedRecipes lists the public recipes recommended to a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.ScoredRecipe: the recipes with their scores, best first
+//   - error: an error if the recipes could not be listed
+func (d *Service) ListRecommendedRecipes(
+	ctx context.Context,
+	userID, language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.ScoredRecipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryScoredRecipes(
+		ctx,
+		language,
+		&ListRecommendedRecipeIDsQuery,
+		userID,
+		limit,
+		offset,
+	)
+}
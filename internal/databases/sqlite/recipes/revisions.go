@@ -0,0 +1,244 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// scanRevision scans a recipe revision row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Revision: the scanned revision
+//   - error: an error if the row could not be scanned
+func scanRevision(row scanner) (*internalrouterapiv1recipe.Revision, error) {
+	var revision internalrouterapiv1recipe.Revision
+	var ingredients, steps string
+	if err := row.Scan(
+		&revision.RecipeID,
+		&revision.Number,
+		&revision.AuthorID,
+		&revision.Name,
+		&revision.Description,
+		&revision.PreparationTime,
+		&revision.CookingTime,
+		&ingredients,
+		&steps,
+		&revision.Servings,
+		&revision.Difficulty,
+		&revision.ImageURL,
+		&revision.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	// Decode the ingredients and the steps
+	if err := json.Unmarshal([]byte(ingredients), &revision.Ingredients); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(steps), &revision.Steps); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// insertRecipeRevision records the current content of a recipe as its next revision inside a transaction. Nothing is
+// recorded if the content matches the latest revision
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - recipeID: the ID of the recipe
+//   - authorID: the ID of the user that made the edit
+//
+// Returns:
+//
+//   - error: an error if the revision could not be recorded
+func insertRecipeRevision(
+	ctx context.Context,
+	tx *sql.Tx,
+	recipeID int,
+	authorID string,
+) error {
+	_, err := tx.ExecContext(ctx, InsertRecipeRevisionQuery, recipeID, authorID)
+	return err
+}
+
+// ListRecipeRevisions lists the revisions of a recipe readable by the given user, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - viewerID: the ID of the user that reads the recipe
+//   - recipeID: the ID of the recipe
+//   - limit: the maximum number of revisions to return
+//   - offset: the number of revisions to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Revision: the revisions
+//   - error: an error if the recipe could not be found or the revisions could not be listed
+func (d *Service) ListRecipeRevisions(
+	ctx context.Context,
+	viewerID string,
+	recipeID int,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Revision, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the user can read the recipe
+	if err = checkRecipeVisibility(ctx, db, recipeID, viewerID); err != nil {
+		return nil, err
+	}
+
+	// Query the revisions
+	rows, err := db.QueryContext(ctx, ListRecipeRevisionsQuery, recipeID, limit, offset)
+	if err != nil {
+		d.logError("Failed to query recipe revisions", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]*internalrouterapiv1recipe.Revision, 0)
+	for rows.Next() {
+		revision, scanErr := scanRevision(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+// GetRecipeRevision gets a revision of a recipe readable by the given user by its number
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - viewerID: the ID of the user that reads the recipe
+//   - recipeID: the ID of the recipe
+//   - number: the number of the revision
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Revision: the revision
+//   - error: an error if the recipe or the revision could not be found
+func (d *Service) GetRecipeRevision(
+	ctx context.Context,
+	viewerID string,
+	recipeID, number int,
+) (*internalrouterapiv1recipe.Revision, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the user can read the recipe
+	if err = checkRecipeVisibility(ctx, db, recipeID, viewerID); err != nil {
+		return nil, err
+	}
+
+	// Get the revision
+	revision, err := scanRevision(db.QueryRowContext(ctx, GetRecipeRevisionQuery, recipeID, number))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		d.logError("Failed to get recipe revision", err)
+		return nil, err
+	}
+	return revision, nil
+}
+
+// RevertRecipe restores the content of a revision of a recipe owned by the given user, which is recorded as a new
+// revision so the history is kept
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - number: the number of the revision to restore
+//
+// Returns:
+//
+//   - int: the number of the latest revision, which holds the restored content
+//   - error: an error if the recipe or the revision could not be found, or the recipe is not owned by the user
+func (d *Service) RevertRecipe(
+	ctx context.Context,
+	ownerID string,
+	recipeID, number int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	var latest int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check if the user owns the recipe
+			if checkErr := checkRecipeOwnership(ctx, tx, recipeID, ownerID); checkErr != nil {
+				return checkErr
+			}
+
+			// Get the revision to restore
+			revision, scanErr := scanRevision(tx.QueryRowContext(ctx, GetRecipeRevisionQuery, recipeID, number))
+			if scanErr != nil {
+				if errors.Is(scanErr, sql.ErrNoRows) {
+					return ErrRevisionNotFound
+				}
+				return scanErr
+			}
+
+			// Restore its content
+			if updateErr := updateRecipe(
+				ctx, tx, ownerID, &internalrouterapiv1recipe.Recipe{
+					ID:              recipeID,
+					Name:            revision.Name,
+					Description:     revision.Description,
+					PreparationTime: revision.PreparationTime,
+					CookingTime:     revision.CookingTime,
+					Ingredients:     revision.Ingredients,
+					Steps:           revision.Steps,
+					Servings:        revision.Servings,
+					Difficulty:      revision.Difficulty,
+					ImageURL:        revision.ImageURL,
+				},
+			); updateErr != nil {
+				return updateErr
+			}
+			return tx.QueryRowContext(ctx, GetLatestRecipeRevisionNumberQuery, recipeID).Scan(&latest)
+		}, nil,
+	); err != nil {
+		d.logError("Failed to revert recipe", err)
+		return 0, err
+	}
+	return latest, nil
+}
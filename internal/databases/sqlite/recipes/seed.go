@@ -0,0 +1,207 @@
+package recipes
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// CanonicalTag is a canonical tag seeded into the database with its translations and synonyms
+	CanonicalTag struct {
+		Kind         internalrouterapiv1recipe.TagKind
+		Name         string
+		Translations []internalrouterapiv1recipe.TagTranslation
+	}
+)
+
+var (
+	// CanonicalTags are the cuisine and course tags seeded on connection. The slug is derived from the name
+	CanonicalTags = []CanonicalTag{
+		// Cuisines
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Venezuelan",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Venezuelan", Synonyms: []string{"Venezuela"}},
+				{Language: "es", Name: "Venezolana", Synonyms: []string{"Venezolano", "Criolla"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Colombian",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Colombian", Synonyms: []string{"Colombia"}},
+				{Language: "es", Name: "Colombiana", Synonyms: []string{"Colombiano"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Peruvian",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Peruvian", Synonyms: []string{"Peru"}},
+				{Language: "es", Name: "Peruana", Synonyms: []string{"Peruano"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Mexican",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Mexican", Synonyms: []string{"Mexico", "Tex-Mex"}},
+				{Language: "es", Name: "Mexicana", Synonyms: []string{"Mexicano"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Argentinian",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Argentinian", Synonyms: []string{"Argentine", "Argentina"}},
+				{Language: "es", Name: "Argentina", Synonyms: []string{"Argentino"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Spanish",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Spanish", Synonyms: []string{"Spain"}},
+				{Language: "es", Name: "Española", Synonyms: []string{"Español", "España"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Italian",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Italian", Synonyms: []string{"Italy"}},
+				{Language: "es", Name: "Italiana", Synonyms: []string{"Italiano"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "French",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "French", Synonyms: []string{"France"}},
+				{Language: "es", Name: "Francesa", Synonyms: []string{"Francés"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Mediterranean",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Mediterranean"},
+				{Language: "es", Name: "Mediterránea", Synonyms: []string{"Mediterráneo"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "American",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "American", Synonyms: []string{"USA"}},
+				{Language: "es", Name: "Estadounidense", Synonyms: []string{"Americana"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Chinese",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Chinese", Synonyms: []string{"China"}},
+				{Language: "es", Name: "China", Synonyms: []string{"Chino"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Japanese",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Japanese", Synonyms: []string{"Japan"}},
+				{Language: "es", Name: "Japonesa", Synonyms: []string{"Japonés"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Indian",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Indian", Synonyms: []string{"India"}},
+				{Language: "es", Name: "India", Synonyms: []string{"Hindú"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCuisine,
+			Name: "Thai",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Thai", Synonyms: []string{"Thailand"}},
+				{Language: "es", Name: "Tailandesa", Synonyms: []string{"Tailandés"}},
+			},
+		},
+
+		// Courses
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Breakfast",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Breakfast", Synonyms: []string{"Brunch"}},
+				{Language: "es", Name: "Desayuno"},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Starter",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Starter", Synonyms: []string{"Appetizer", "Entrée"}},
+				{Language: "es", Name: "Entrada", Synonyms: []string{"Aperitivo", "Entrante"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Soup",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Soup", Synonyms: []string{"Stew"}},
+				{Language: "es", Name: "Sopa", Synonyms: []string{"Crema", "Caldo"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Salad",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Salad"},
+				{Language: "es", Name: "Ensalada"},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Main",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Main", Synonyms: []string{"Main course", "Main dish"}},
+				{Language: "es", Name: "Plato principal", Synonyms: []string{"Plato fuerte", "Segundo"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Side",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Side", Synonyms: []string{"Side dish"}},
+				{Language: "es", Name: "Acompañante", Synonyms: []string{"Guarnición", "Contorno"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Dessert",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Dessert", Synonyms: []string{"Sweet"}},
+				{Language: "es", Name: "Postre", Synonyms: []string{"Dulce"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Snack",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Snack"},
+				{Language: "es", Name: "Merienda", Synonyms: []string{"Pasapalo", "Tentempié"}},
+			},
+		},
+		{
+			Kind: internalrouterapiv1recipe.TagKindCourse,
+			Name: "Drink",
+			Translations: []internalrouterapiv1recipe.TagTranslation{
+				{Language: "en", Name: "Drink", Synonyms: []string{"Beverage"}},
+				{Language: "es", Name: "Bebida", Synonyms: []string{"Trago"}},
+			},
+		},
+	}
+)
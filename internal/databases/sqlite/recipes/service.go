@@ -0,0 +1,945 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+	godatabasessql "github.com/ralvarezdev/go-databases/sql"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Service is the recipes SQLite service
+	Service struct {
+		godatabasessql.Service
+		logger *slog.Logger
+	}
+
+	// scanner is the common interface of *sql.Row and *sql.Rows
+	scanner interface {
+		Scan(dest ...any) error
+	}
+)
+
+// NewService creates a new recipes Service
+//
+// Parameters:
+//
+//   - service: the SQL connection service
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Service: the Service instance
+//   - error: an error if the service is nil
+func NewService(
+	service godatabasessql.Service,
+	logger *slog.Logger,
+) (*Service, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "recipes_sqlite_service"),
+		)
+	}
+
+	return &Service{
+		Service: service,
+		logger:  logger,
+	}, nil
+}
+
+// Connect opens the database connection, ensures the tables exist and are up to date and seeds the canonical tags
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the connection could not be opened
+func (d *Service) Connect(ctx context.Context) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Connect to the database
+	db, err := d.Service.Connect()
+	if err != nil {
+		if d.logger != nil {
+			d.logger.Error(
+				"Failed to connect to database",
+				slog.String("error", err.Error()),
+			)
+		}
+		return err
+	}
+
+	// Ensure the tables exist
+	for _, query := range []string{
+		CreateRecipesTableQuery,
+		CreateRecipeRevisionsTableQuery,
+		CreateTagsTableQuery,
+		CreateTagTranslationsTableQuery,
+		CreateTagSynonymsTableQuery,
+		CreateRecipeTagsTableQuery,
+		CreateRecipeGroupsTableQuery,
+		CreateRecipeGroupItemsTableQuery,
+		CreateCookbookJobsTableQuery,
+		CreateImportJobsTableQuery,
+		CreateImportJobItemsTableQuery,
+		CreateFollowsTableQuery,
+		CreateFeedEventsTableQuery,
+		CreateUserProfilesTableQuery,
+		CreateRecipeActivityTableQuery,
+		CreateRecipeSimilaritiesTableQuery,
+		CreateRecipeRecommendationsTableQuery,
+		CreateCookLogsTableQuery,
+		CreateRecipeAnnotationsTableQuery,
+		CreateUserDietsTableQuery,
+		CreateIngredientPricesTableQuery,
+		CreateMealPlansTableQuery,
+		CreateMealPlanEntriesTableQuery,
+		CreateShoppingListsTableQuery,
+		CreateFavoritesTableQuery,
+		CreateRecipeTranslationsTableQuery,
+		CreateSyncChangesTableQuery,
+	} {
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	// Add the columns introduced after the tables were created
+	if err = d.migrate(ctx, db); err != nil {
+		return err
+	}
+
+	// Index the columns the migrations may have added
+	if _, err = db.ExecContext(ctx, CreateRecipeColumnIndexesQuery); err != nil {
+		return err
+	}
+
+	// Compute the fingerprints of the recipes created before they were kept
+	if err = d.backfillFingerprints(ctx); err != nil {
+		return err
+	}
+
+	// Backfill the records of the rows created before they were kept
+	for _, query := range []string{
+		BackfillRecipeRevisionsQuery,
+		BackfillSyncChangesQuery,
+	} {
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	// Seed the canonical tags
+	return d.seedCanonicalTags(ctx)
+}
+
+// logError logs an error if the logger is set
+//
+// Parameters:
+//
+//   - msg: the log message
+//   - err: the error to log
+func (d *Service) logError(msg string, err error) {
+	if d.logger != nil {
+		d.logger.Error(msg, slog.String("error", err.Error()))
+	}
+}
+
+// scanRecipe scans a recipe row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the scanned recipe
+//   - error: an error if the row could not be scanned
+func scanRecipe(row scanner) (*internalrouterapiv1recipe.Recipe, error) {
+	var recipe internalrouterapiv1recipe.Recipe
+	var ingredients, steps, attribution string
+	var forkedFrom sql.NullInt64
+	if err := row.Scan(
+		&recipe.ID,
+		&recipe.OwnerID,
+		&recipe.Name,
+		&recipe.Description,
+		&recipe.PreparationTime,
+		&recipe.CookingTime,
+		&ingredients,
+		&steps,
+		&recipe.Servings,
+		&recipe.Difficulty,
+		&recipe.SourceURL,
+		&recipe.ImageURL,
+		&recipe.Visibility,
+		&forkedFrom,
+		&attribution,
+		&recipe.Language,
+		&recipe.ForkCount,
+	); err != nil {
+		return nil, err
+	}
+	if forkedFrom.Valid {
+		forkedFromID := int(forkedFrom.Int64)
+		recipe.ForkedFrom = &forkedFromID
+	}
+
+	// Decode the ingredients, the steps and the attribution
+	if err := json.Unmarshal([]byte(ingredients), &recipe.Ingredients); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(steps), &recipe.Steps); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(attribution), &recipe.Attribution); err != nil {
+		return nil, err
+	}
+	return &recipe, nil
+}
+
+// encodeRecipeContent encodes the ingredients and the steps of a recipe as stored in their JSON columns
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//
+// Returns:
+//
+//   - string: the encoded ingredients
+//   - string: the encoded steps
+//   - error: an error if the content could not be encoded
+func encodeRecipeContent(recipe *internalrouterapiv1recipe.Recipe) (
+	string,
+	string,
+	error,
+) {
+	ingredients := recipe.Ingredients
+	if ingredients == nil {
+		ingredients = []internalrouterapiv1recipe.Ingredient{}
+	}
+	encodedIngredients, err := json.Marshal(ingredients)
+	if err != nil {
+		return "", "", err
+	}
+
+	steps := recipe.Steps
+	if steps == nil {
+		steps = []string{}
+	}
+	encodedSteps, err := json.Marshal(steps)
+	if err != nil {
+		return "", "", err
+	}
+	return string(encodedIngredients), string(encodedSteps), nil
+}
+
+// queryRecipes runs a query that returns recipe rows and loads their tags
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - language: the language used to localize the tags
+//   - query: the query to run
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the recipes could not be queried
+func (d *Service) queryRecipes(
+	ctx context.Context,
+	language string,
+	query *string,
+	params ...any,
+) ([]*internalrouterapiv1recipe.Recipe, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the recipes
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to query recipes", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipes := make([]*internalrouterapiv1recipe.Recipe, 0)
+	for rows.Next() {
+		recipe, scanErr := scanRecipe(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		recipes = append(recipes, recipe)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load the tags of each recipe
+	for _, recipe := range recipes {
+		if recipe.Tags, err = d.ListRecipeTags(ctx, recipe.ID, language); err != nil {
+			return nil, err
+		}
+	}
+	return recipes, nil
+}
+
+// insertRecipe inserts a recipe, records its first revision and tags it inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipe: the recipe to insert
+//   - tags: the tag labels to attach to the recipe
+//
+// Returns:
+//
+//   - int: the ID of the inserted recipe
+//   - error: an error if the recipe could not be inserted
+func insertRecipe(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+	tags []string,
+) (int, error) {
+	// Check the visibility, new recipes are private unless told otherwise
+	visibility := recipe.Visibility
+	if visibility == "" {
+		visibility = internalrouterapiv1recipe.VisibilityPrivate
+	}
+	if !visibility.IsValid() {
+		return 0, ErrInvalidVisibility
+	}
+
+	// Encode the ingredients, the steps and the attribution
+	ingredients, steps, err := encodeRecipeContent(recipe)
+	if err != nil {
+		return 0, err
+	}
+	attribution := recipe.Attribution
+	if attribution == nil {
+		attribution = []internalrouterapiv1recipe.Attribution{}
+	}
+	encodedAttribution, err := json.Marshal(attribution)
+	if err != nil {
+		return 0, err
+	}
+	fingerprint, err := encodeFingerprint(recipe)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check the language, recipes are written in the default language unless told otherwise
+	language := DefaultLanguage
+	if recipe.Language != "" {
+		if language = NormalizeLanguage(recipe.Language); language == "" {
+			return 0, ErrInvalidLanguage
+		}
+	}
+
+	// Insert the recipe
+	result, err := tx.ExecContext(
+		ctx,
+		InsertRecipeQuery,
+		ownerID,
+		recipe.Name,
+		recipe.Description,
+		recipe.PreparationTime,
+		recipe.CookingTime,
+		ingredients,
+		steps,
+		recipe.Servings,
+		recipe.Difficulty,
+		recipe.SourceURL,
+		recipe.ImageURL,
+		visibility,
+		recipe.ForkedFrom,
+		string(encodedAttribution),
+		fingerprint,
+		language,
+	)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	// Record the content as the first revision
+	if err = insertRecipeRevision(ctx, tx, int(id), ownerID); err != nil {
+		return 0, err
+	}
+
+	// Tag the recipe
+	return int(id), setRecipeTags(ctx, tx, int(id), ownerID, tags)
+}
+
+// CreateRecipe creates a recipe owned by the given user and tags it
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipe: the recipe to create
+//   - tags: the tag labels to attach to the recipe
+//
+// Returns:
+//
+//   - int: the ID of the created recipe
+//   - error: an error if the recipe could not be created
+func (d *Service) CreateRecipe(
+	ctx context.Context,
+	ownerID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+	tags []string,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return 0, ErrNilRecipe
+	}
+
+	var recipeID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			var insertErr error
+			recipeID, insertErr = insertRecipe(ctx, tx, ownerID, recipe, tags)
+			return insertErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to create recipe", err)
+		return 0, err
+	}
+	return recipeID, nil
+}
+
+// GetRecipe gets a recipe readable by the given user by its ID with its tags
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - viewerID: the ID of the user that reads the recipe
+//   - recipeID: the ID of the recipe
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the recipe
+//   - error: an error if the recipe could not be found or is private to another user
+func (d *Service) GetRecipe(
+	ctx context.Context,
+	viewerID string,
+	recipeID int,
+	language string,
+) (*internalrouterapiv1recipe.Recipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the recipe
+	row, err := d.QueryRowWithCtx(ctx, &GetRecipeQuery, recipeID, viewerID)
+	if err != nil {
+		d.logError("Failed to query recipe", err)
+		return nil, err
+	}
+	recipe, err := scanRecipe(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecipeNotFound
+		}
+		d.logError("Failed to get recipe", err)
+		return nil, err
+	}
+	if !canReadRecipe(viewerID, recipe.OwnerID, recipe.Visibility) {
+		return nil, ErrRecipeNotFound
+	}
+
+	// Load the tags
+	if recipe.Tags, err = d.ListRecipeTags(ctx, recipe.ID, language); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// ListRecipesByOwnerID lists the recipes owned by a user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the recipes could not be listed
+func (d *Service) ListRecipesByOwnerID(
+	ctx context.Context,
+	ownerID string,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.Recipe, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryRecipes(
+		ctx,
+		language,
+		&ListRecipesByOwnerIDQuery,
+		ownerID,
+		limit,
+		offset,
+	)
+}
+
+// canReadRecipe checks if a user can read a recipe. Private recipes are hidden from any user but their owner
+//
+// Parameters:
+//
+//   - viewerID: the ID of the user that reads the recipe
+//   - ownerID: the ID of the user that owns the recipe
+//   - visibility: the visibility of the recipe
+//
+// Returns:
+//
+//   - bool: true if the user can read the recipe
+func canReadRecipe(
+	viewerID, ownerID string,
+	visibility internalrouterapiv1recipe.Visibility,
+) bool {
+	return ownerID == viewerID || visibility != internalrouterapiv1recipe.VisibilityPrivate
+}
+
+// checkRecipeVisibility checks that a recipe exists and can be read by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the recipe, either the database or a transaction
+//   - recipeID: the ID of the recipe
+//   - viewerID: the ID of the user that reads the recipe
+//
+// Returns:
+//
+//   - error: ErrRecipeNotFound if the recipe does not exist or is private to another user
+func checkRecipeVisibility(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	recipeID int,
+	viewerID string,
+) error {
+	var ownerID string
+	var visibility internalrouterapiv1recipe.Visibility
+	if err := q.QueryRowContext(ctx, GetRecipeVisibilityQuery, recipeID).Scan(&ownerID, &visibility); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRecipeNotFound
+		}
+		return err
+	}
+	if !canReadRecipe(viewerID, ownerID, visibility) {
+		return ErrRecipeNotFound
+	}
+	return nil
+}
+
+// CheckRecipeOwnership checks that a recipe exists and is owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - recipeID: the ID of the recipe
+//
+// Returns:
+//
+//   - error: ErrRecipeNotFound or ErrRecipeNotOwned if the user does not own the recipe
+func (d *Service) CheckRecipeOwnership(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return err
+	}
+	return checkRecipeOwnership(ctx, db, recipeID, ownerID)
+}
+
+// SetRecipeVisibility sets the visibility of a recipe owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - visibility: the new visibility
+//   - versions: the versions the change may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the recipe
+//   - error: an error if the visibility is invalid, the recipe changed since the version or could not be updated
+func (d *Service) SetRecipeVisibility(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+	visibility internalrouterapiv1recipe.Visibility,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check the visibility
+	if !visibility.IsValid() {
+		return 0, ErrInvalidVisibility
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the change was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Update the recipe
+			result, err := tx.ExecContext(ctx, SetRecipeVisibilityQuery, visibility, recipeID, ownerID)
+			if err != nil {
+				return err
+			}
+			if err = checkAffectedRecipe(ctx, tx, result, recipeID, ownerID); err != nil {
+				return err
+			}
+
+			// Get the new version
+			newVersion, _, err = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+			)
+			return err
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrRecipeNotFound) && !errors.Is(err, ErrRecipeNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to set recipe visibility", err)
+		}
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// checkRecipeOwnership returns the error that explains why a write over a recipe affected no rows
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the recipe, either the database or a transaction
+//   - recipeID: the ID of the recipe
+//   - ownerID: the ID of the user that tried to write the recipe
+//
+// Returns:
+//
+//   - error: ErrRecipeNotFound, ErrRecipeNotOwned or nil if the user owns the recipe
+func checkRecipeOwnership(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	recipeID int,
+	ownerID string,
+) error {
+	var recipeOwnerID string
+	if err := q.QueryRowContext(ctx, GetRecipeOwnerIDQuery, recipeID).Scan(&recipeOwnerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRecipeNotFound
+		}
+		return err
+	}
+	if recipeOwnerID != ownerID {
+		return ErrRecipeNotOwned
+	}
+	return nil
+}
+
+// UpdateRecipe updates the content of a recipe owned by the given user and records it as a new revision
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipe: the recipe with the new content
+//   - versions: the versions the update may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the recipe
+//   - error: an error if the recipe changed since the version or could not be updated
+func (d *Service) UpdateRecipe(
+	ctx context.Context,
+	ownerID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return 0, ErrNilRecipe
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the update was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipe.ID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Update the recipe
+			if err := updateRecipe(ctx, tx, ownerID, recipe); err != nil {
+				return err
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipe.ID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to update recipe", err)
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// updateRecipe updates the content of a recipe owned by the given user and records it as a new revision inside a
+// transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipe: the recipe with the new content
+//
+// Returns:
+//
+//   - error: an error if the recipe could not be updated
+func updateRecipe(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	recipe *internalrouterapiv1recipe.Recipe,
+) error {
+	// Encode the ingredients and the steps
+	ingredients, steps, err := encodeRecipeContent(recipe)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := encodeFingerprint(recipe)
+	if err != nil {
+		return err
+	}
+
+	// Check the language, which is kept if not set
+	var language string
+	if recipe.Language != "" {
+		if language = NormalizeLanguage(recipe.Language); language == "" {
+			return ErrInvalidLanguage
+		}
+	}
+
+	// Update the recipe
+	result, err := tx.ExecContext(
+		ctx,
+		UpdateRecipeQuery,
+		recipe.Name,
+		recipe.Description,
+		recipe.PreparationTime,
+		recipe.CookingTime,
+		ingredients,
+		steps,
+		recipe.Servings,
+		recipe.Difficulty,
+		recipe.ImageURL,
+		fingerprint,
+		language,
+		recipe.ID,
+		ownerID,
+	)
+	if err != nil {
+		return err
+	}
+	if err = checkAffectedRecipe(ctx, tx, result, recipe.ID, ownerID); err != nil {
+		return err
+	}
+
+	// Drop the translation to the language the recipe is now written in
+	if language != "" {
+		if _, err = tx.ExecContext(ctx, DeleteRecipeTranslationQuery, recipe.ID, language); err != nil {
+			return err
+		}
+	}
+
+	// Record the new content
+	return insertRecipeRevision(ctx, tx, recipe.ID, ownerID)
+}
+
+// DeleteRecipe deletes a recipe owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - versions: the versions the deletion may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - error: an error if the recipe changed since the version or could not be deleted
+func (d *Service) DeleteRecipe(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+	versions []int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the deletion was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Delete the recipe
+			result, err := tx.ExecContext(ctx, DeleteRecipeQuery, recipeID, ownerID)
+			if err != nil {
+				return err
+			}
+			return checkAffectedRecipe(ctx, tx, result, recipeID, ownerID)
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrRecipeNotFound) && !errors.Is(err, ErrRecipeNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to delete recipe", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkAffectedRecipe checks that a write over a recipe owned by the given user affected a row
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the recipe, either the database or a transaction
+//   - result: the result of the write
+//   - recipeID: the ID of the recipe
+//   - ownerID: the ID of the user that tried to write the recipe
+//
+// Returns:
+//
+//   - error: ErrRecipeNotFound or ErrRecipeNotOwned if no row was affected
+func checkAffectedRecipe(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	result sql.Result,
+	recipeID int,
+	ownerID string,
+) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	if err = checkRecipeOwnership(ctx, q, recipeID, ownerID); err != nil {
+		return err
+	}
+	return ErrRecipeNotFound
+}
@@ -0,0 +1,618 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internalunits "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/units"
+)
+
+const (
+	// MaxShoppingListItems is the maximum number of items of a shopping list
+	MaxShoppingListItems = 500
+)
+
+// scanShoppingList scans a shopping list row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShoppingList: the scanned shopping list
+//   - error: an error if the row could not be scanned
+func scanShoppingList(row scanner) (*internalrouterapiv1recipe.ShoppingList, error) {
+	var list internalrouterapiv1recipe.ShoppingList
+	var items string
+	if err := row.Scan(
+		&list.ID,
+		&list.OwnerID,
+		&list.Title,
+		&items,
+	); err != nil {
+		return nil, err
+	}
+
+	// Decode the items
+	if err := json.Unmarshal([]byte(items), &list.Items); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// encodeShoppingListItems checks the items of a shopping list and encodes them as stored in their JSON column
+//
+// Parameters:
+//
+//   - items: the items of the shopping list
+//
+// Returns:
+//
+//   - string: the encoded items
+//   - error: an error if an item is not valid or there are too many items
+func encodeShoppingListItems(items []internalrouterapiv1recipe.ShoppingListItem) (string, error) {
+	if len(items) > MaxShoppingListItems {
+		return "", ErrInvalidShoppingListItemsCount
+	}
+	if items == nil {
+		items = []internalrouterapiv1recipe.ShoppingListItem{}
+	}
+
+	for i := range items {
+		items[i].Name = strings.TrimSpace(items[i].Name)
+		if items[i].Name == "" {
+			return "", ErrEmptyShoppingListItem
+		}
+		if items[i].Quantity < 0 || math.IsInf(items[i].Quantity, 0) || math.IsNaN(items[i].Quantity) {
+			return "", ErrInvalidShoppingListItemQuantity
+		}
+		items[i].Unit = strings.TrimSpace(items[i].Unit)
+	}
+
+	encodedItems, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	return string(encodedItems), nil
+}
+
+// mealPlanShoppingItems lists the ingredients of the entries of a meal plan as shopping list items, scaled to the
+// servings of each entry. The amounts of the same ingredient are added up when their units convert to each other,
+// and the ingredients without an amount are listed once. The recipes that are not available are left out
+//
+// Parameters:
+//
+//   - entries: the entries of the meal plan
+//   - recipes: the recipes of the meal plan by ID
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.ShoppingListItem: the items, in the order they first appear in the meal plan
+func mealPlanShoppingItems(
+	entries []internalrouterapiv1recipe.MealPlanEntry,
+	recipes map[int]*mealPlanRecipe,
+) []internalrouterapiv1recipe.ShoppingListItem {
+	items := make([]internalrouterapiv1recipe.ShoppingListItem, 0)
+	positionsBySlug := make(map[string][]int)
+	for _, entry := range entries {
+		recipe, ok := recipes[entry.RecipeID]
+		if !ok {
+			continue
+		}
+
+		for _, ingredient := range scaleIngredients(recipe.ingredients, recipe.servings, entry.Servings) {
+			slug := Slugify(ingredient.Name)
+
+			// Add the amount to an item of the same ingredient whose unit it converts to
+			merged := false
+			for _, position := range positionsBySlug[slug] {
+				item := &items[position]
+				if ingredient.Quantity <= 0 || item.Quantity <= 0 {
+					if ingredient.Quantity <= 0 && item.Quantity <= 0 {
+						merged = true
+						break
+					}
+					continue
+				}
+				quantity, err := internalunits.Convert(ingredient.Quantity, ingredient.Unit, item.Unit)
+				if err == nil {
+					item.Quantity += quantity
+					merged = true
+					break
+				}
+			}
+			if merged {
+				continue
+			}
+
+			positionsBySlug[slug] = append(positionsBySlug[slug], len(items))
+			items = append(
+				items, internalrouterapiv1recipe.ShoppingListItem{
+					Quantity: max(ingredient.Quantity, 0),
+					Unit:     ingredient.Unit,
+					Name:     ingredient.Name,
+				},
+			)
+		}
+	}
+	return items
+}
+
+// insertShoppingList inserts a shopping list inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the shopping list
+//   - list: the shopping list to insert
+//
+// Returns:
+//
+//   - int: the ID of the inserted shopping list
+//   - error: an error if the shopping list is not valid or could not be inserted
+func insertShoppingList(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	list *internalrouterapiv1recipe.ShoppingList,
+) (int, error) {
+	// Check the items
+	items, err := encodeShoppingListItems(list.Items)
+	if err != nil {
+		return 0, err
+	}
+
+	// Insert the shopping list
+	result, err := tx.ExecContext(ctx, InsertShoppingListQuery, ownerID, list.Title, items)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// CreateShoppingList creates a shopping list owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the shopping list
+//   - list: the shopping list to create
+//
+// Returns:
+//
+//   - int: the ID of the created shopping list
+//   - error: an error if the shopping list could not be created
+func (d *Service) CreateShoppingList(
+	ctx context.Context,
+	ownerID string,
+	list *internalrouterapiv1recipe.ShoppingList,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the shopping list is nil
+	if list == nil {
+		return 0, ErrNilShoppingList
+	}
+
+	var listID int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			var insertErr error
+			listID, insertErr = insertShoppingList(ctx, tx, ownerID, list)
+			return insertErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to create shopping list", err)
+		return 0, err
+	}
+	return listID, nil
+}
+
+// CreateMealPlanShoppingList creates a shopping list owned by the given user with the ingredients of one of their
+// meal plans, scaled to the servings of each entry and added up by ingredient
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the meal plan
+//   - planID: the ID of the meal plan
+//   - title: the title of the shopping list, empty for the title of the meal plan
+//
+// Returns:
+//
+//   - int: the ID of the created shopping list
+//   - error: an error if the meal plan does not exist, is not owned by the user or has too many ingredients
+func (d *Service) CreateMealPlanShoppingList(
+	ctx context.Context,
+	ownerID string,
+	planID int,
+	title string,
+) (int, error) {
+	// Get the meal plan
+	plan, err := d.GetMealPlan(ctx, ownerID, planID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get the recipes
+	recipes, err := d.listMealPlanRecipes(ctx, ownerID, planID)
+	if err != nil {
+		d.logError("Failed to list meal plan recipes", err)
+		return 0, err
+	}
+
+	// Create the shopping list
+	if title = strings.TrimSpace(title); title == "" {
+		title = plan.Title
+	}
+	return d.CreateShoppingList(
+		ctx, ownerID, &internalrouterapiv1recipe.ShoppingList{
+			Title: title,
+			Items: mealPlanShoppingItems(plan.Entries, recipes),
+		},
+	)
+}
+
+// GetShoppingList gets a shopping list owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the shopping list
+//   - listID: the ID of the shopping list
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShoppingList: the shopping list
+//   - error: an error if the shopping list does not exist or is not owned by the user
+func (d *Service) GetShoppingList(
+	ctx context.Context,
+	ownerID string,
+	listID int,
+) (*internalrouterapiv1recipe.ShoppingList, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the shopping list
+	row, err := d.QueryRowWithCtx(ctx, &GetShoppingListQuery, listID)
+	if err != nil {
+		d.logError("Failed to query shopping list", err)
+		return nil, err
+	}
+	list, err := scanShoppingList(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrShoppingListNotFound
+		}
+		d.logError("Failed to get shopping list", err)
+		return nil, err
+	}
+	if list.OwnerID != ownerID {
+		return nil, ErrShoppingListNotOwned
+	}
+	return list, nil
+}
+
+// ListShoppingLists lists the shopping lists of a user, newest first
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - limit: the maximum number of shopping lists
+//   - offset: the number of shopping lists to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.ShoppingList: the shopping lists
+//   - error: an error if the shopping lists could not be listed
+func (d *Service) ListShoppingLists(
+	ctx context.Context,
+	ownerID string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.ShoppingList, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the shopping lists
+	rows, err := db.QueryContext(ctx, ListShoppingListsByOwnerIDQuery, ownerID, limit, offset)
+	if err != nil {
+		d.logError("Failed to list shopping lists", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := make([]*internalrouterapiv1recipe.ShoppingList, 0)
+	for rows.Next() {
+		list, scanErr := scanShoppingList(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		lists = append(lists, list)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// UpdateShoppingList replaces the title and the items of a shopping list owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the shopping list
+//   - list: the shopping list with the new content
+//   - versions: the versions the update may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the shopping list
+//   - error: an error if the shopping list is not valid, changed since the version or could not be updated
+func (d *Service) UpdateShoppingList(
+	ctx context.Context,
+	ownerID string,
+	list *internalrouterapiv1recipe.ShoppingList,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the shopping list is nil
+	if list == nil {
+		return 0, ErrNilShoppingList
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the update was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityShoppingList,
+				list.ID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Update the shopping list
+			if err := updateShoppingList(ctx, tx, ownerID, list); err != nil {
+				return err
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityShoppingList,
+				list.ID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to update shopping list", err)
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// updateShoppingList replaces the title and the items of a shopping list owned by the given user inside a
+// transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the shopping list
+//   - list: the shopping list with the new content
+//
+// Returns:
+//
+//   - error: an error if the shopping list is not valid or could not be updated
+func updateShoppingList(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	list *internalrouterapiv1recipe.ShoppingList,
+) error {
+	// Check the items
+	items, err := encodeShoppingListItems(list.Items)
+	if err != nil {
+		return err
+	}
+
+	// Update the shopping list
+	result, err := tx.ExecContext(ctx, UpdateShoppingListQuery, list.Title, items, list.ID, ownerID)
+	if err != nil {
+		return err
+	}
+	return checkAffectedShoppingList(ctx, tx, result, list.ID, ownerID)
+}
+
+// DeleteShoppingList deletes a shopping list owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the shopping list
+//   - listID: the ID of the shopping list
+//   - versions: the versions the deletion may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - error: an error if the shopping list changed since the version or could not be deleted
+func (d *Service) DeleteShoppingList(
+	ctx context.Context,
+	ownerID string,
+	listID int,
+	versions []int,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the deletion was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityShoppingList,
+				listID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Delete the shopping list
+			result, err := tx.ExecContext(ctx, DeleteShoppingListQuery, listID, ownerID)
+			if err != nil {
+				return err
+			}
+			return checkAffectedShoppingList(ctx, tx, result, listID, ownerID)
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrShoppingListNotFound) && !errors.Is(err, ErrShoppingListNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to delete shopping list", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkAffectedShoppingList checks that a write over a shopping list owned by the given user affected a row
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to check the shopping list, either the database or a transaction
+//   - result: the result of the write
+//   - listID: the ID of the shopping list
+//   - ownerID: the ID of the user that tried to write the shopping list
+//
+// Returns:
+//
+//   - error: ErrShoppingListNotFound or ErrShoppingListNotOwned if no row was affected
+func checkAffectedShoppingList(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	result sql.Result,
+	listID int,
+	ownerID string,
+) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	list, err := scanShoppingList(q.QueryRowContext(ctx, GetShoppingListQuery, listID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrShoppingListNotFound
+		}
+		return err
+	}
+	if list.OwnerID != ownerID {
+		return ErrShoppingListNotOwned
+	}
+	return ErrShoppingListNotFound
+}
+
+// EstimateShoppingListCost estimates the cost of a shopping list owned by the given user, and of the items not
+// checked yet, with the price list of the user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the shopping list
+//   - listID: the ID of the shopping list
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShoppingListCost: the cost of the shopping list
+//   - error: an error if the shopping list does not exist or is not owned by the user
+func (d *Service) EstimateShoppingListCost(
+	ctx context.Context,
+	ownerID string,
+	listID int,
+) (*internalrouterapiv1recipe.ShoppingListCost, error) {
+	// Get the shopping list
+	list, err := d.GetShoppingList(ctx, ownerID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the price list
+	prices, err := d.ListIngredientPrices(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Price the items as ingredients, in the same order
+	ingredients := make([]internalrouterapiv1recipe.Ingredient, len(list.Items))
+	for i, item := range list.Items {
+		ingredients[i] = internalrouterapiv1recipe.Ingredient{
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+			Name:     item.Name,
+		}
+	}
+	total, items, missing := EstimateCost(ingredients, prices)
+
+	// Add up the cost of the items not checked yet
+	var remaining float64
+	for _, item := range items {
+		if !list.Items[item.Position-1].Checked {
+			remaining += item.Cost
+		}
+	}
+	return &internalrouterapiv1recipe.ShoppingListCost{
+		ShoppingListID: list.ID,
+		Total:          roundCost(total),
+		Remaining:      roundCost(remaining),
+		Complete:       len(missing) == 0,
+		Items:          items,
+		Missing:        missing,
+	}, nil
+}
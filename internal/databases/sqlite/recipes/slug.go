@@ -0,0 +1,69 @@
+package recipes
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify normalizes a label into a canonical slug: lowercase ASCII words without accents separated by hyphens
+//
+// Parameters:
+//
+//   - label: the label to normalize
+//
+// Returns:
+//
+//   - string: the slug, empty if the label has no letters or digits
+func Slugify(label string) string {
+	// Remove the accents from the label
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, label)
+	if err != nil {
+		normalized = label
+	}
+
+	// Replace every run of non-alphanumeric characters with a single hyphen
+	var builder strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(normalized) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+			builder.WriteRune(r)
+			pendingHyphen = false
+			continue
+		}
+		pendingHyphen = true
+	}
+	return builder.String()
+}
+
+// SlugRuns lists the runs of consecutive words of a slug, the whole slug first, then longest first and, among runs of
+// the same length, the last one first, since the main word of an ingredient name usually comes last ("chicken
+// broth"). It is used to match an ingredient name against a list of known ingredients
+//
+// Parameters:
+//
+//   - slug: the slug
+//
+// Returns:
+//
+//   - []string: the runs of words, joined by hyphens
+func SlugRuns(slug string) []string {
+	if slug == "" {
+		return nil
+	}
+	words := strings.Split(slug, "-")
+	runs := make([]string, 0, len(words)*(len(words)+1)/2)
+	for length := len(words); length > 0; length-- {
+		for start := len(words) - length; start >= 0; start-- {
+			runs = append(runs, strings.Join(words[start:start+length], "-"))
+		}
+	}
+	return runs
+}
@@ -0,0 +1,823 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// syncLogEntry is the latest change of a synced entity logged in the sync changes table
+	syncLogEntry struct {
+		sequence int
+		entity   internalrouterapiv1recipe.SyncEntity
+		entityID int
+		deleted  bool
+	}
+)
+
+const (
+	// MaxSyncChanges is the maximum number of changes pushed at once
+	MaxSyncChanges = 100
+)
+
+// EncodeSyncToken encodes the sequence of a logged change as the opaque token clients send back to sync
+//
+// Parameters:
+//
+//   - sequence: the sequence of the change
+//
+// Returns:
+//
+//   - string: the sync token
+func EncodeSyncToken(sequence int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(sequence)))
+}
+
+// DecodeSyncToken decodes a sync token into the sequence of the logged change it was issued for
+//
+// Parameters:
+//
+//   - token: the sync token, empty to sync from the start
+//
+// Returns:
+//
+//   - int: the sequence of the change, 0 if the token is empty
+//   - error: ErrInvalidSyncToken if the token was not issued by EncodeSyncToken
+func DecodeSyncToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidSyncToken
+	}
+	sequence, err := strconv.Atoi(string(decoded))
+	if err != nil || sequence < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+	return sequence, nil
+}
+
+// getSyncVersion gets the version of a synced entity owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to get the version, either the database or a transaction
+//   - ownerID: the ID of the user that owns the entity
+//   - entity: the kind of entity
+//   - entityID: the ID of the entity
+//
+// Returns:
+//
+//   - int: the version of the entity, 0 if the user owns no such entity
+//   - bool: true if the entity was deleted
+//   - error: an error if the version could not be read
+func getSyncVersion(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	ownerID string,
+	entity internalrouterapiv1recipe.SyncEntity,
+	entityID int,
+) (int, bool, error) {
+	var version int
+	var deleted bool
+	if err := q.QueryRowContext(ctx, GetSyncVersionQuery, entity, entityID, ownerID).Scan(
+		&version,
+		&deleted,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return version, deleted, nil
+}
+
+// checkVersion checks that a write over a synced entity owned by the given user is made over its current version,
+// which must be one of the versions given. Entities the user does not own are left for the write to report
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - q: the querier used to get the version, either the database or a transaction
+//   - ownerID: the ID of the user that owns the entity
+//   - entity: the kind of entity
+//   - entityID: the ID of the entity
+//   - versions: the versions the write may be made over, as listed in the If-Match header, empty to skip the check
+//
+// Returns:
+//
+//   - error: ErrVersionMismatch if the current version is none of the versions
+func checkVersion(
+	ctx context.Context,
+	q interface {
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	},
+	ownerID string,
+	entity internalrouterapiv1recipe.SyncEntity,
+	entityID int,
+	versions []int,
+) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	current, deleted, err := getSyncVersion(ctx, q, ownerID, entity, entityID)
+	if err != nil {
+		return err
+	}
+	if current != 0 && !deleted && !slices.Contains(versions, current) {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// GetVersion gets the version of a synced entity, which changes on every write to the entity. It must be read before
+// the entity, so the version is never newer than the content it is sent with
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - entity: the kind of entity
+//   - entityID: the ID of the entity
+//
+// Returns:
+//
+//   - int: the version of the entity, 0 if it does not exist
+//   - error: an error if the version could not be read
+func (d *Service) GetVersion(
+	ctx context.Context,
+	entity internalrouterapiv1recipe.SyncEntity,
+	entityID int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int
+	if err = db.QueryRowContext(ctx, GetVersionQuery, entity, entityID).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		d.logError("Failed to get version", err)
+		return 0, err
+	}
+	return version, nil
+}
+
+// ListSyncChanges lists the recipes, groups, meal plans, shopping lists and favorites of a user created, updated or
+// deleted since a sync token. Each entity is listed once, with its current content, no matter how many times it
+// changed
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - token: the token of the previous sync, empty to get the whole library
+//   - limit: the maximum number of changed entities
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.SyncDelta: the changed entities and the token of the next sync
+//   - error: an error if the token is invalid or the changes could not be listed
+func (d *Service) ListSyncChanges(
+	ctx context.Context,
+	ownerID string,
+	token string,
+	limit int,
+	language string,
+) (*internalrouterapiv1recipe.SyncDelta, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Decode the token
+	since, err := DecodeSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest change, the changes logged while the delta is read are left for the next sync
+	var latest int
+	if err = db.QueryRowContext(ctx, GetLatestSyncSequenceQuery).Scan(&latest); err != nil {
+		d.logError("Failed to get latest sync sequence", err)
+		return nil, err
+	}
+
+	// Send the whole library again if the token is ahead of the log, such as after the database was restored
+	delta := &internalrouterapiv1recipe.SyncDelta{
+		Recipes:       make([]*internalrouterapiv1recipe.SyncRecipe, 0),
+		Groups:        make([]*internalrouterapiv1recipe.SyncGroup, 0),
+		MealPlans:     make([]*internalrouterapiv1recipe.SyncMealPlan, 0),
+		ShoppingLists: make([]*internalrouterapiv1recipe.SyncShoppingList, 0),
+		Favorites:     make([]*internalrouterapiv1recipe.SyncFavorite, 0),
+	}
+	if since > latest {
+		since = 0
+		delta.Reset = true
+	}
+
+	// List one more change than asked for to know if there are more
+	rows, err := db.QueryContext(ctx, ListSyncChangesQuery, ownerID, since, latest, limit+1)
+	if err != nil {
+		d.logError("Failed to list sync changes", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]syncLogEntry, 0)
+	for rows.Next() {
+		var c syncLogEntry
+		if err = rows.Scan(&c.sequence, &c.entity, &c.entityID, &c.deleted); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Issue the token of the next sync
+	if len(changes) > limit {
+		changes = changes[:limit]
+		delta.HasMore = true
+		delta.Token = EncodeSyncToken(changes[len(changes)-1].sequence)
+	} else {
+		delta.Token = EncodeSyncToken(latest)
+	}
+
+	// Load the current content of the entities, those deleted since the change was listed are sent as deleted
+	for _, c := range changes {
+		switch c.entity {
+		case internalrouterapiv1recipe.SyncEntityRecipe:
+			syncRecipe := &internalrouterapiv1recipe.SyncRecipe{
+				ID:      c.entityID,
+				Version: c.sequence,
+				Deleted: c.deleted,
+			}
+			if !c.deleted {
+				syncRecipe.Recipe, err = d.GetRecipe(ctx, ownerID, c.entityID, language)
+				if errors.Is(err, ErrRecipeNotFound) {
+					syncRecipe.Deleted = true
+				} else if err != nil {
+					return nil, err
+				}
+			}
+			delta.Recipes = append(delta.Recipes, syncRecipe)
+		case internalrouterapiv1recipe.SyncEntityGroup:
+			syncGroup := &internalrouterapiv1recipe.SyncGroup{
+				ID:      c.entityID,
+				Version: c.sequence,
+				Deleted: c.deleted,
+			}
+			if !c.deleted {
+				syncGroup.Group, err = d.GetGroup(ctx, ownerID, c.entityID)
+				if errors.Is(err, ErrGroupNotFound) {
+					syncGroup.Deleted = true
+				} else if err != nil {
+					return nil, err
+				}
+			}
+			delta.Groups = append(delta.Groups, syncGroup)
+		case internalrouterapiv1recipe.SyncEntityMealPlan:
+			syncMealPlan := &internalrouterapiv1recipe.SyncMealPlan{
+				ID:      c.entityID,
+				Version: c.sequence,
+				Deleted: c.deleted,
+			}
+			if !c.deleted {
+				syncMealPlan.MealPlan, err = d.GetMealPlan(ctx, ownerID, c.entityID)
+				if errors.Is(err, ErrMealPlanNotFound) {
+					syncMealPlan.Deleted = true
+				} else if err != nil {
+					return nil, err
+				}
+			}
+			delta.MealPlans = append(delta.MealPlans, syncMealPlan)
+		case internalrouterapiv1recipe.SyncEntityShoppingList:
+			syncShoppingList := &internalrouterapiv1recipe.SyncShoppingList{
+				ID:      c.entityID,
+				Version: c.sequence,
+				Deleted: c.deleted,
+			}
+			if !c.deleted {
+				syncShoppingList.ShoppingList, err = d.GetShoppingList(ctx, ownerID, c.entityID)
+				if errors.Is(err, ErrShoppingListNotFound) {
+					syncShoppingList.Deleted = true
+				} else if err != nil {
+					return nil, err
+				}
+			}
+			delta.ShoppingLists = append(delta.ShoppingLists, syncShoppingList)
+		case internalrouterapiv1recipe.SyncEntityFavorite:
+			syncFavorite := &internalrouterapiv1recipe.SyncFavorite{
+				ID:      c.entityID,
+				Version: c.sequence,
+				Deleted: c.deleted,
+			}
+			if !c.deleted {
+				syncFavorite.Favorite, err = d.GetFavorite(ctx, ownerID, c.entityID)
+				if errors.Is(err, ErrFavoriteNotFound) {
+					syncFavorite.Deleted = true
+				} else if err != nil {
+					return nil, err
+				}
+			}
+			delta.Favorites = append(delta.Favorites, syncFavorite)
+		}
+	}
+	return delta, nil
+}
+
+// checkSyncChange checks that a pushed change has what its operation needs
+//
+// Parameters:
+//
+//   - change: the change
+//
+// Returns:
+//
+//   - error: an error if the change is not valid
+func checkSyncChange(change *internalrouterapiv1recipe.SyncChange) error {
+	if change == nil {
+		return ErrNilSyncChange
+	}
+	if !change.Entity.IsValid() {
+		return ErrInvalidSyncEntity
+	}
+	if !change.Operation.IsValid() {
+		return ErrInvalidSyncOperation
+	}
+	if change.Entity == internalrouterapiv1recipe.SyncEntityFavorite &&
+		change.Operation == internalrouterapiv1recipe.SyncOperationUpdate {
+		return ErrInvalidFavoriteSyncOperation
+	}
+
+	// Check the ID of the entity, which is assigned by the server on create
+	if change.Operation != internalrouterapiv1recipe.SyncOperationCreate && change.ID <= 0 {
+		return ErrMissingSyncEntityID
+	}
+
+	// Check the content, which is not needed to delete, and is the recipe to mark as a favorite
+	if change.Operation == internalrouterapiv1recipe.SyncOperationDelete {
+		return nil
+	}
+	var missing bool
+	switch change.Entity {
+	case internalrouterapiv1recipe.SyncEntityRecipe:
+		missing = change.Recipe == nil
+	case internalrouterapiv1recipe.SyncEntityGroup:
+		missing = change.Group == nil
+	case internalrouterapiv1recipe.SyncEntityMealPlan:
+		missing = change.MealPlan == nil
+	case internalrouterapiv1recipe.SyncEntityShoppingList:
+		missing = change.ShoppingList == nil
+	case internalrouterapiv1recipe.SyncEntityFavorite:
+		missing = change.RecipeID <= 0
+	}
+	if missing {
+		return ErrMissingSyncContent
+	}
+	return nil
+}
+
+// applySyncRecipeChange applies a pushed change to a recipe owned by the given user inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the recipe
+//   - change: the change
+//
+// Returns:
+//
+//   - int: the ID of the recipe
+//   - error: an error if the change could not be applied
+func applySyncRecipeChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	change *internalrouterapiv1recipe.SyncChange,
+) (int, error) {
+	switch change.Operation {
+	case internalrouterapiv1recipe.SyncOperationCreate:
+		return insertRecipe(ctx, tx, ownerID, change.Recipe, change.Tags)
+	case internalrouterapiv1recipe.SyncOperationDelete:
+		result, err := tx.ExecContext(ctx, DeleteRecipeQuery, change.ID, ownerID)
+		if err != nil {
+			return 0, err
+		}
+		return change.ID, checkAffectedRecipe(ctx, tx, result, change.ID, ownerID)
+	}
+
+	// Update the content
+	change.Recipe.ID = change.ID
+	if err := updateRecipe(ctx, tx, ownerID, change.Recipe); err != nil {
+		return 0, err
+	}
+
+	// Set the visibility, which is kept if not set
+	if visibility := change.Recipe.Visibility; visibility != "" {
+		if !visibility.IsValid() {
+			return 0, ErrInvalidVisibility
+		}
+		if _, err := tx.ExecContext(ctx, SetRecipeVisibilityQuery, visibility, change.ID, ownerID); err != nil {
+			return 0, err
+		}
+	}
+
+	// Replace the tags, which are kept if not set
+	if change.Tags != nil {
+		if err := setRecipeTags(ctx, tx, change.ID, ownerID, change.Tags); err != nil {
+			return 0, err
+		}
+	}
+	return change.ID, nil
+}
+
+// applySyncGroupChange applies a pushed change to a recipe group owned by the given user inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the group
+//   - change: the change
+//
+// Returns:
+//
+//   - int: the ID of the group
+//   - error: an error if the change could not be applied
+func applySyncGroupChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	change *internalrouterapiv1recipe.SyncChange,
+) (int, error) {
+	switch change.Operation {
+	case internalrouterapiv1recipe.SyncOperationCreate:
+		return insertGroup(ctx, tx, ownerID, change.Group)
+	case internalrouterapiv1recipe.SyncOperationDelete:
+		result, err := tx.ExecContext(ctx, DeleteGroupQuery, change.ID, ownerID)
+		if err != nil {
+			return 0, err
+		}
+		return change.ID, checkAffectedGroup(ctx, tx, result, change.ID, ownerID)
+	}
+
+	// Update the content and the recipes
+	change.Group.ID = change.ID
+	if err := updateGroup(ctx, tx, ownerID, change.Group); err != nil {
+		return 0, err
+	}
+
+	// Set the visibility, which is kept if not set
+	if visibility := change.Group.Visibility; visibility != "" {
+		if !visibility.IsValid() {
+			return 0, ErrInvalidVisibility
+		}
+		if _, err := tx.ExecContext(ctx, SetGroupVisibilityQuery, visibility, change.ID, ownerID); err != nil {
+			return 0, err
+		}
+	}
+	return change.ID, nil
+}
+
+// applySyncMealPlanChange applies a pushed change to a meal plan owned by the given user inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the meal plan
+//   - change: the change
+//
+// Returns:
+//
+//   - int: the ID of the meal plan
+//   - error: an error if the change could not be applied
+func applySyncMealPlanChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	change *internalrouterapiv1recipe.SyncChange,
+) (int, error) {
+	switch change.Operation {
+	case internalrouterapiv1recipe.SyncOperationCreate:
+		return insertMealPlan(ctx, tx, ownerID, change.MealPlan)
+	case internalrouterapiv1recipe.SyncOperationDelete:
+		result, err := tx.ExecContext(ctx, DeleteMealPlanQuery, change.ID, ownerID)
+		if err != nil {
+			return 0, err
+		}
+		return change.ID, checkAffectedMealPlan(ctx, tx, result, change.ID, ownerID)
+	}
+
+	// Update the content and the entries
+	change.MealPlan.ID = change.ID
+	return change.ID, updateMealPlan(ctx, tx, ownerID, change.MealPlan)
+}
+
+// applySyncShoppingListChange applies a pushed change to a shopping list owned by the given user inside a transaction
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - ownerID: the ID of the user that owns the shopping list
+//   - change: the change
+//
+// Returns:
+//
+//   - int: the ID of the shopping list
+//   - error: an error if the change could not be applied
+func applySyncShoppingListChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	ownerID string,
+	change *internalrouterapiv1recipe.SyncChange,
+) (int, error) {
+	switch change.Operation {
+	case internalrouterapiv1recipe.SyncOperationCreate:
+		return insertShoppingList(ctx, tx, ownerID, change.ShoppingList)
+	case internalrouterapiv1recipe.SyncOperationDelete:
+		result, err := tx.ExecContext(ctx, DeleteShoppingListQuery, change.ID, ownerID)
+		if err != nil {
+			return 0, err
+		}
+		return change.ID, checkAffectedShoppingList(ctx, tx, result, change.ID, ownerID)
+	}
+
+	// Update the content and the items
+	change.ShoppingList.ID = change.ID
+	return change.ID, updateShoppingList(ctx, tx, ownerID, change.ShoppingList)
+}
+
+// applySyncFavoriteChange applies a pushed change to a favorite of the given user inside a transaction. Marking a
+// recipe that is already a favorite results in the favorite already stored
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - userID: the ID of the user
+//   - change: the change, either a create or a delete
+//
+// Returns:
+//
+//   - int: the ID of the favorite
+//   - error: an error if the change could not be applied
+func applySyncFavoriteChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID string,
+	change *internalrouterapiv1recipe.SyncChange,
+) (int, error) {
+	if change.Operation == internalrouterapiv1recipe.SyncOperationCreate {
+		favorite, _, err := insertFavorite(ctx, tx, userID, change.RecipeID)
+		if err != nil {
+			return 0, err
+		}
+		return favorite.ID, nil
+	}
+
+	// Delete the favorite
+	result, err := tx.ExecContext(ctx, DeleteFavoriteByIDQuery, change.ID, userID)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, ErrFavoriteNotFound
+	}
+	return change.ID, nil
+}
+
+// PushSyncChanges applies the changes a user made offline, in order. A change to an entity that changed on the server
+// since the version it was made over is not applied but reported as a conflict, with the current content of the
+// entity, for the client to resolve and push again over the current version. Deleting an entity already deleted is
+// not a conflict. A change that could not be applied, such as one to an entity the user does not own, is undone on
+// its own and reported in its result with the code and message of the error, while the rest are still applied
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user
+//   - changes: the changes
+//   - language: the language used to localize the tags of the conflicting recipes
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.SyncResult: the applied changes with the new versions and the failed ones with
+//     their errors, in order
+//   - []*internalrouterapiv1recipe.SyncConflict: the conflicting changes, in order
+//   - error: an error if a change is not valid or the changes could not be stored, in which case none of them is
+//     applied
+func (d *Service) PushSyncChanges(
+	ctx context.Context,
+	ownerID string,
+	changes []*internalrouterapiv1recipe.SyncChange,
+	language string,
+) ([]*internalrouterapiv1recipe.SyncResult, []*internalrouterapiv1recipe.SyncConflict, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, nil, godatabases.ErrNilService
+	}
+
+	// Check the changes
+	if len(changes) > MaxSyncChanges {
+		return nil, nil, ErrInvalidSyncChangesCount
+	}
+	for _, change := range changes {
+		if err := checkSyncChange(change); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var results []*internalrouterapiv1recipe.SyncResult
+	var conflicts []*internalrouterapiv1recipe.SyncConflict
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			results = make([]*internalrouterapiv1recipe.SyncResult, 0, len(changes))
+			conflicts = make([]*internalrouterapiv1recipe.SyncConflict, 0)
+			for _, change := range changes {
+				// Check the version the change was made over
+				var baseVersion int
+				if change.Operation != internalrouterapiv1recipe.SyncOperationCreate {
+					version, deleted, versionErr := getSyncVersion(ctx, tx, ownerID, change.Entity, change.ID)
+					if versionErr != nil {
+						return versionErr
+					}
+					if deleted && change.Operation == internalrouterapiv1recipe.SyncOperationDelete {
+						results = append(
+							results, &internalrouterapiv1recipe.SyncResult{
+								ClientID:  change.ClientID,
+								Entity:    change.Entity,
+								Operation: change.Operation,
+								ID:        change.ID,
+								Version:   version,
+								Deleted:   true,
+							},
+						)
+						continue
+					}
+					if version != 0 && (deleted || version != change.BaseVersion) {
+						conflicts = append(
+							conflicts, &internalrouterapiv1recipe.SyncConflict{
+								ClientID:    change.ClientID,
+								Entity:      change.Entity,
+								Operation:   change.Operation,
+								ID:          change.ID,
+								BaseVersion: change.BaseVersion,
+								Version:     version,
+								Deleted:     deleted,
+							},
+						)
+						continue
+					}
+					baseVersion = version
+				}
+
+				// Apply the change
+				if _, err := tx.ExecContext(ctx, CreateSyncSavepointQuery); err != nil {
+					return err
+				}
+				var entityID int
+				var applyErr error
+				switch change.Entity {
+				case internalrouterapiv1recipe.SyncEntityRecipe:
+					entityID, applyErr = applySyncRecipeChange(ctx, tx, ownerID, change)
+				case internalrouterapiv1recipe.SyncEntityGroup:
+					entityID, applyErr = applySyncGroupChange(ctx, tx, ownerID, change)
+				case internalrouterapiv1recipe.SyncEntityMealPlan:
+					entityID, applyErr = applySyncMealPlanChange(ctx, tx, ownerID, change)
+				case internalrouterapiv1recipe.SyncEntityShoppingList:
+					entityID, applyErr = applySyncShoppingListChange(ctx, tx, ownerID, change)
+				case internalrouterapiv1recipe.SyncEntityFavorite:
+					entityID, applyErr = applySyncFavoriteChange(ctx, tx, ownerID, change)
+				}
+
+				// Undo only the change that failed and report it, unless the failure is not caused by the change
+				if applyErr != nil {
+					var failErr *gonethttpresponse.FailFieldError
+					if !errors.As(ParseError(applyErr), &failErr) {
+						return applyErr
+					}
+					if _, err := tx.ExecContext(ctx, RollbackSyncSavepointQuery); err != nil {
+						return err
+					}
+					if _, err := tx.ExecContext(ctx, ReleaseSyncSavepointQuery); err != nil {
+						return err
+					}
+					results = append(
+						results, &internalrouterapiv1recipe.SyncResult{
+							ClientID:  change.ClientID,
+							Entity:    change.Entity,
+							Operation: change.Operation,
+							ID:        change.ID,
+							Version:   baseVersion,
+							Code:      failErr.ErrorCode,
+							Error:     applyErr.Error(),
+						},
+					)
+					continue
+				}
+				if _, err := tx.ExecContext(ctx, ReleaseSyncSavepointQuery); err != nil {
+					return err
+				}
+
+				// Get the new version
+				version, deleted, versionErr := getSyncVersion(ctx, tx, ownerID, change.Entity, entityID)
+				if versionErr != nil {
+					return versionErr
+				}
+				results = append(
+					results, &internalrouterapiv1recipe.SyncResult{
+						ClientID:  change.ClientID,
+						Entity:    change.Entity,
+						Operation: change.Operation,
+						ID:        entityID,
+						Version:   version,
+						Deleted:   deleted,
+					},
+				)
+			}
+			return nil
+		}, nil,
+	); err != nil {
+		d.logError("Failed to push sync changes", err)
+		return nil, nil, err
+	}
+
+	// Load the current content of the conflicting entities
+	for _, conflict := range conflicts {
+		if conflict.Deleted {
+			continue
+		}
+
+		var err error
+		switch conflict.Entity {
+		case internalrouterapiv1recipe.SyncEntityRecipe:
+			conflict.Recipe, err = d.GetRecipe(ctx, ownerID, conflict.ID, language)
+			if errors.Is(err, ErrRecipeNotFound) {
+				conflict.Deleted, err = true, nil
+			}
+		case internalrouterapiv1recipe.SyncEntityGroup:
+			conflict.Group, err = d.GetGroup(ctx, ownerID, conflict.ID)
+			if errors.Is(err, ErrGroupNotFound) {
+				conflict.Deleted, err = true, nil
+			}
+		case internalrouterapiv1recipe.SyncEntityMealPlan:
+			conflict.MealPlan, err = d.GetMealPlan(ctx, ownerID, conflict.ID)
+			if errors.Is(err, ErrMealPlanNotFound) {
+				conflict.Deleted, err = true, nil
+			}
+		case internalrouterapiv1recipe.SyncEntityShoppingList:
+			conflict.ShoppingList, err = d.GetShoppingList(ctx, ownerID, conflict.ID)
+			if errors.Is(err, ErrShoppingListNotFound) {
+				conflict.Deleted, err = true, nil
+			}
+		case internalrouterapiv1recipe.SyncEntityFavorite:
+			conflict.Favorite, err = d.GetFavorite(ctx, ownerID, conflict.ID)
+			if errors.Is(err, ErrFavoriteNotFound) {
+				conflict.Deleted, err = true, nil
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return results, conflicts, nil
+}
@@ -0,0 +1,485 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// MaxRecipeTags is the maximum number of tags that can be attached to a recipe
+	MaxRecipeTags = 30
+)
+
+// scanTag scans a tag row
+//
+// Parameters:
+//
+//   - row: the row to scan
+//   - destinations: additional destinations to scan after the tag columns
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Tag: the scanned tag
+//   - error: an error if the row could not be scanned
+func scanTag(row scanner, destinations ...any) (*internalrouterapiv1recipe.Tag, error) {
+	var tag internalrouterapiv1recipe.Tag
+	var ownerID string
+	if err := row.Scan(
+		append(
+			[]any{&tag.ID, &tag.Slug, &tag.Kind, &ownerID, &tag.Name},
+			destinations...,
+		)...,
+	); err != nil {
+		return nil, err
+	}
+	if ownerID != "" {
+		tag.OwnerID = &ownerID
+	}
+	return &tag, nil
+}
+
+// queryTags runs a query that returns tag rows
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - withRecipeCount: whether the rows include the recipe count column
+//   - query: the query to run
+//   - params: the query parameters
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Tag: the tags
+//   - error: an error if the tags could not be queried
+func (d *Service) queryTags(
+	ctx context.Context,
+	withRecipeCount bool,
+	query *string,
+	params ...any,
+) ([]internalrouterapiv1recipe.Tag, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the tags
+	rows, err := db.QueryContext(ctx, *query, params...)
+	if err != nil {
+		d.logError("Failed to query tags", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]internalrouterapiv1recipe.Tag, 0)
+	for rows.Next() {
+		var tag *internalrouterapiv1recipe.Tag
+		var scanErr error
+		if withRecipeCount {
+			var recipeCount int
+			tag, scanErr = scanTag(rows, &recipeCount)
+			if tag != nil {
+				tag.RecipeCount = &recipeCount
+			}
+		} else {
+			tag, scanErr = scanTag(rows)
+		}
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, rows.Err()
+}
+
+// seedCanonicalTags inserts or updates the canonical cuisine and course tags with their translations and synonyms
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the tags could not be seeded
+func (d *Service) seedCanonicalTags(ctx context.Context) error {
+	return d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			for _, canonicalTag := range CanonicalTags {
+				// Insert the tag
+				var tagID int
+				if err := tx.QueryRowContext(
+					ctx,
+					InsertCanonicalTagQuery,
+					Slugify(canonicalTag.Name),
+					canonicalTag.Kind,
+					canonicalTag.Name,
+				).Scan(&tagID); err != nil {
+					return err
+				}
+
+				for _, translation := range canonicalTag.Translations {
+					// Insert the translation
+					if _, err := tx.ExecContext(
+						ctx,
+						UpsertTagTranslationQuery,
+						tagID,
+						translation.Language,
+						translation.Name,
+					); err != nil {
+						return err
+					}
+
+					// Insert the translated name and the synonyms as synonyms, so any of them resolves to the tag
+					for _, synonym := range append([]string{translation.Name}, translation.Synonyms...) {
+						if _, err := tx.ExecContext(
+							ctx,
+							InsertTagSynonymQuery,
+							tagID,
+							translation.Language,
+							Slugify(synonym),
+						); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		}, nil,
+	)
+}
+
+// setRecipeTags replaces the tags of a recipe. Each label is resolved to a canonical tag by slug or synonym, falling
+// back to a free-form user tag that is created on demand
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - tx: the transaction
+//   - recipeID: the ID of the recipe
+//   - ownerID: the ID of the user that owns the recipe
+//   - labels: the tag labels
+//
+// Returns:
+//
+//   - error: an error if the tags could not be set
+func setRecipeTags(
+	ctx context.Context,
+	tx *sql.Tx,
+	recipeID int,
+	ownerID string,
+	labels []string,
+) error {
+	// Check the number of tags
+	if len(labels) > MaxRecipeTags {
+		return ErrInvalidTagsCount
+	}
+
+	// Remove the current tags
+	if _, err := tx.ExecContext(ctx, DeleteRecipeTagsQuery, recipeID); err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		// Normalize the label
+		slug := Slugify(label)
+		if slug == "" {
+			return ErrEmptyTagLabel
+		}
+
+		// Resolve the tag, creating a user tag if there's no match
+		var tagID int
+		err := tx.QueryRowContext(ctx, ResolveTagIDQuery, slug, ownerID).Scan(&tagID)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = tx.QueryRowContext(ctx, InsertUserTagQuery, slug, ownerID, label).Scan(&tagID)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Tag the recipe
+		if _, err = tx.ExecContext(ctx, InsertRecipeTagQuery, recipeID, tagID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRecipeTags replaces the tags of a recipe owned by the given user
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - labels: the tag labels
+//   - versions: the versions the change may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the recipe
+//   - error: an error if the recipe changed since the version or the tags could not be set
+func (d *Service) SetRecipeTags(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+	labels []string,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the user owns the recipe
+			if err := checkRecipeOwnership(ctx, tx, recipeID, ownerID); err != nil {
+				return err
+			}
+
+			// Check the version the change was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+				versions,
+			); err != nil {
+				return err
+			}
+			if err := setRecipeTags(ctx, tx, recipeID, ownerID, labels); err != nil {
+				return err
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		if !errors.Is(err, ErrRecipeNotFound) && !errors.Is(err, ErrRecipeNotOwned) &&
+			!errors.Is(err, ErrVersionMismatch) {
+			d.logError("Failed to set recipe tags", err)
+		}
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// ListRecipeTags lists the tags of a recipe
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipeID: the ID of the recipe
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Tag: the tags
+//   - error: an error if the tags could not be listed
+func (d *Service) ListRecipeTags(
+	ctx context.Context,
+	recipeID int,
+	language string,
+) ([]internalrouterapiv1recipe.Tag, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	return d.queryTags(ctx, false, &ListRecipeTagsQuery, language, recipeID)
+}
+
+// ListTags lists the canonical tags and the user's own tags with the number of recipes the user can read tagged with each
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - kind: the kind of tags to list, empty to list every kind
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Tag: the tags
+//   - error: an error if the tags could not be listed
+func (d *Service) ListTags(
+	ctx context.Context,
+	userID string,
+	kind internalrouterapiv1recipe.TagKind,
+	language string,
+) ([]internalrouterapiv1recipe.Tag, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check the tag kind
+	if kind != "" && !kind.IsValid() {
+		return nil, ErrInvalidTagKind
+	}
+
+	return d.queryTags(ctx, true, &ListTagsQuery, kind, language, userID)
+}
+
+// AutocompleteTags lists the tags visible to a user whose slug, synonyms or localized name start with the query
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - kind: the kind of tags to list, empty to list every kind
+//   - query: the text typed by the user
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of tags to return
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Tag: the matching tags
+//   - error: an error if the tags could not be listed
+func (d *Service) AutocompleteTags(
+	ctx context.Context,
+	userID string,
+	kind internalrouterapiv1recipe.TagKind,
+	query string,
+	language string,
+	limit int,
+) ([]internalrouterapiv1recipe.Tag, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check the tag kind
+	if kind != "" && !kind.IsValid() {
+		return nil, ErrInvalidTagKind
+	}
+
+	// Normalize the query
+	slug := Slugify(query)
+	if slug == "" {
+		return nil, ErrEmptyTagQuery
+	}
+
+	return d.queryTags(
+		ctx,
+		false,
+		&AutocompleteTagsQuery,
+		kind,
+		slug,
+		language,
+		userID,
+		query,
+		limit,
+	)
+}
+
+// GetTag gets a tag visible to a user by its kind and slug
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - kind: the kind of the tag
+//   - slug: the slug of the tag, it's normalized before the lookup
+//   - language: the language used to localize the tag
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Tag: the tag
+//   - error: an error if the tag could not be found
+func (d *Service) GetTag(
+	ctx context.Context,
+	userID string,
+	kind internalrouterapiv1recipe.TagKind,
+	slug string,
+	language string,
+) (*internalrouterapiv1recipe.Tag, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check the tag kind
+	if !kind.IsValid() {
+		return nil, ErrInvalidTagKind
+	}
+
+	// Get the tag
+	row, err := d.QueryRowWithCtx(ctx, &GetTagQuery, kind, Slugify(slug), language, userID)
+	if err != nil {
+		d.logError("Failed to query tag", err)
+		return nil, err
+	}
+	tag, err := scanTag(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTagNotFound
+		}
+		d.logError("Failed to get tag", err)
+		return nil, err
+	}
+	return tag, nil
+}
+
+// ListRecipesByTag lists the recipes tagged with a tag visible to a user, among the recipes owned by the user or
+// public
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - kind: the kind of the tag
+//   - slug: the slug of the tag
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes to return
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Tag: the tag
+//   - []*internalrouterapiv1recipe.Recipe: the recipes
+//   - error: an error if the tag could not be found or the recipes could not be listed
+func (d *Service) ListRecipesByTag(
+	ctx context.Context,
+	userID string,
+	kind internalrouterapiv1recipe.TagKind,
+	slug string,
+	language string,
+	limit, offset int,
+) (*internalrouterapiv1recipe.Tag, []*internalrouterapiv1recipe.Recipe, error) {
+	// Get the tag
+	tag, err := d.GetTag(ctx, userID, kind, slug, language)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// List the recipes
+	recipes, err := d.queryRecipes(
+		ctx,
+		language,
+		&ListRecipesByTagIDQuery,
+		tag.ID,
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tag, recipes, nil
+}
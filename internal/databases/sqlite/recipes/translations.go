@@ -0,0 +1,506 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// DefaultLanguage is the language of the recipes that do not set one
+	DefaultLanguage = "en"
+
+	// MaxRecipeTranslations is the maximum number of translations of a recipe
+	MaxRecipeTranslations = 20
+)
+
+// NormalizeLanguage normalizes a language tag to its lowercase primary language subtag, so "es-VE" becomes "es"
+//
+// Parameters:
+//
+//   - language: the language tag
+//
+// Returns:
+//
+//   - string: the ISO 639 language code, empty if the tag is not valid
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if len(language) < 2 || len(language) > 3 {
+		return ""
+	}
+	for _, r := range language {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return language
+}
+
+// isTranslationOutdated checks if a translation no longer matches the ingredients and steps of its recipe
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//   - translation: the translation of the recipe
+//
+// Returns:
+//
+//   - bool: true if the recipe gained or lost ingredients or steps since it was translated
+func isTranslationOutdated(
+	recipe *internalrouterapiv1recipe.Recipe,
+	translation *internalrouterapiv1recipe.RecipeTranslation,
+) bool {
+	return len(translation.Ingredients) != len(recipe.Ingredients) || len(translation.Steps) != len(recipe.Steps)
+}
+
+// SetRecipeTranslation sets the translation of a recipe owned by the given user to a language, replacing the
+// previous one. It must translate every ingredient and step of the recipe
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - translation: the translation
+//   - versions: the versions of the recipe the translation may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the recipe
+//   - error: an error if the recipe could not be found, is not owned by the user, changed since the version or the
+//     translation is not valid
+func (d *Service) SetRecipeTranslation(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+	translation *internalrouterapiv1recipe.RecipeTranslation,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	// Check if the translation is nil
+	if translation == nil {
+		return 0, ErrNilTranslation
+	}
+
+	// Validate the translation
+	if translation.Language = NormalizeLanguage(translation.Language); translation.Language == "" {
+		return 0, ErrInvalidLanguage
+	}
+	if translation.Name = strings.TrimSpace(translation.Name); translation.Name == "" {
+		return 0, ErrEmptyTranslationName
+	}
+	for _, name := range translation.Ingredients {
+		if strings.TrimSpace(name) == "" {
+			return 0, ErrInvalidTranslationIngredients
+		}
+	}
+	for _, step := range translation.Steps {
+		if strings.TrimSpace(step) == "" {
+			return 0, ErrInvalidTranslationSteps
+		}
+	}
+	if translation.Ingredients == nil {
+		translation.Ingredients = []string{}
+	}
+	if translation.Steps == nil {
+		translation.Steps = []string{}
+	}
+
+	// Encode the ingredients and the steps
+	ingredients, err := json.Marshal(translation.Ingredients)
+	if err != nil {
+		return 0, err
+	}
+	steps, err := json.Marshal(translation.Steps)
+	if err != nil {
+		return 0, err
+	}
+
+	var newVersion int
+	if err = d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the translation was made over
+			if versionErr := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+				versions,
+			); versionErr != nil {
+				return versionErr
+			}
+
+			// Get the recipe
+			recipe, scanErr := scanRecipe(tx.QueryRowContext(ctx, GetRecipeQuery, recipeID, ownerID))
+			if scanErr != nil {
+				if scanErr == sql.ErrNoRows {
+					return ErrRecipeNotFound
+				}
+				return scanErr
+			}
+			if recipe.OwnerID != ownerID {
+				return ErrRecipeNotOwned
+			}
+
+			// Check the translation against the recipe
+			if translation.Language == recipe.Language {
+				return ErrTranslationOriginalLanguage
+			}
+			if len(translation.Ingredients) != len(recipe.Ingredients) {
+				return ErrInvalidTranslationIngredients
+			}
+			if len(translation.Steps) != len(recipe.Steps) {
+				return ErrInvalidTranslationSteps
+			}
+
+			// Check the number of translations
+			var count int
+			if countErr := tx.QueryRowContext(
+				ctx,
+				CountOtherRecipeTranslationsQuery,
+				recipeID,
+				translation.Language,
+			).Scan(&count); countErr != nil {
+				return countErr
+			}
+			if count >= MaxRecipeTranslations {
+				return ErrInvalidRecipeTranslationsCount
+			}
+
+			// Store the translation
+			if _, execErr := tx.ExecContext(
+				ctx,
+				UpsertRecipeTranslationQuery,
+				recipeID,
+				translation.Language,
+				translation.Name,
+				translation.Description,
+				string(ingredients),
+				string(steps),
+			); execErr != nil {
+				return execErr
+			}
+
+			// Get the new version
+			var versionErr error
+			newVersion, _, versionErr = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+			)
+			return versionErr
+		}, nil,
+	); err != nil {
+		d.logError("Failed to set recipe translation", err)
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// DeleteRecipeTranslation deletes the translation of a recipe owned by the given user to a language
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - ownerID: the ID of the user that owns the recipe
+//   - recipeID: the ID of the recipe
+//   - language: the language of the translation
+//   - versions: the versions of the recipe the deletion may be made over, empty to skip the check
+//
+// Returns:
+//
+//   - int: the new version of the recipe
+//   - error: an error if the recipe could not be found, is not owned by the user, changed since the version or has
+//     no such translation
+func (d *Service) DeleteRecipeTranslation(
+	ctx context.Context,
+	ownerID string,
+	recipeID int,
+	language string,
+	versions []int,
+) (int, error) {
+	// Check if the service is nil
+	if d == nil {
+		return 0, godatabases.ErrNilService
+	}
+
+	var newVersion int
+	if err := d.CreateTransaction(
+		ctx, func(tx *sql.Tx) error {
+			// Check the version the deletion was made over
+			if err := checkVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+				versions,
+			); err != nil {
+				return err
+			}
+
+			// Check the ownership of the recipe
+			if err := checkRecipeOwnership(ctx, tx, recipeID, ownerID); err != nil {
+				return err
+			}
+
+			// Delete the translation
+			result, err := tx.ExecContext(
+				ctx,
+				DeleteRecipeTranslationQuery,
+				recipeID,
+				NormalizeLanguage(language),
+			)
+			if err != nil {
+				return err
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return ErrTranslationNotFound
+			}
+
+			// Get the new version
+			newVersion, _, err = getSyncVersion(
+				ctx,
+				tx,
+				ownerID,
+				internalrouterapiv1recipe.SyncEntityRecipe,
+				recipeID,
+			)
+			return err
+		}, nil,
+	); err != nil {
+		d.logError("Failed to delete recipe translation", err)
+		return 0, err
+	}
+	return newVersion, nil
+}
+
+// listTranslations lists the translations of the given recipes
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipeIDs: the IDs of the recipes
+//
+// Returns:
+//
+//   - map[int][]*internalrouterapiv1recipe.RecipeTranslation: the translations of each recipe, sorted by language
+//   - error: an error if the translations could not be listed
+func (d *Service) listTranslations(
+	ctx context.Context,
+	recipeIDs []int,
+) (map[int][]*internalrouterapiv1recipe.RecipeTranslation, error) {
+	// Get the database connection
+	db, err := d.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	encodedIDs, err := json.Marshal(recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, ListRecipeTranslationsQuery, string(encodedIDs))
+	if err != nil {
+		d.logError("Failed to list recipe translations", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := make(map[int][]*internalrouterapiv1recipe.RecipeTranslation)
+	for rows.Next() {
+		var recipeID int
+		var ingredients, steps string
+		var translation internalrouterapiv1recipe.RecipeTranslation
+		if err = rows.Scan(
+			&recipeID,
+			&translation.Language,
+			&translation.Name,
+			&translation.Description,
+			&ingredients,
+			&steps,
+			&translation.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(ingredients), &translation.Ingredients); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(steps), &translation.Steps); err != nil {
+			return nil, err
+		}
+		translations[recipeID] = append(translations[recipeID], &translation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// ListRecipeTranslations lists the translations of a recipe
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipe: the recipe, already checked to be readable
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.RecipeTranslation: the translations, sorted by language
+//   - error: an error if the translations could not be listed
+func (d *Service) ListRecipeTranslations(
+	ctx context.Context,
+	recipe *internalrouterapiv1recipe.Recipe,
+) ([]*internalrouterapiv1recipe.RecipeTranslation, error) {
+	// Check if the service is nil
+	if d == nil {
+		return nil, godatabases.ErrNilService
+	}
+
+	// Check if the recipe is nil
+	if recipe == nil {
+		return nil, ErrNilRecipe
+	}
+
+	translations, err := d.listTranslations(ctx, []int{recipe.ID})
+	if err != nil {
+		return nil, err
+	}
+	recipeTranslations := translations[recipe.ID]
+	if recipeTranslations == nil {
+		recipeTranslations = []*internalrouterapiv1recipe.RecipeTranslation{}
+	}
+	for _, translation := range recipeTranslations {
+		translation.Outdated = isTranslationOutdated(recipe, translation)
+	}
+	return recipeTranslations, nil
+}
+
+// TranslateRecipes serves each recipe in the first of the requested languages it is available in, either its own
+// language or one of its translations. Outdated translations are not served. When none of the languages is available,
+// the recipe is kept in its own language and flagged as a fallback
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - languages: the requested languages, in order of preference, nothing is translated if empty
+//   - recipes: the recipes
+//
+// Returns:
+//
+//   - error: an error if the translations could not be listed
+func (d *Service) TranslateRecipes(
+	ctx context.Context,
+	languages []string,
+	recipes ...*internalrouterapiv1recipe.Recipe,
+) error {
+	// Check if the service is nil
+	if d == nil {
+		return godatabases.ErrNilService
+	}
+
+	if len(recipes) == 0 {
+		return nil
+	}
+
+	// List the translations of the recipes
+	recipeIDs := make([]int, 0, len(recipes))
+	for _, recipe := range recipes {
+		recipeIDs = append(recipeIDs, recipe.ID)
+	}
+	translations, err := d.listTranslations(ctx, recipeIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, recipe := range recipes {
+		// Index the translations that can be served
+		available := make(map[string]*internalrouterapiv1recipe.RecipeTranslation)
+		for _, translation := range translations[recipe.ID] {
+			recipe.Translations = append(recipe.Translations, translation.Language)
+			if !isTranslationOutdated(recipe, translation) {
+				available[translation.Language] = translation
+			}
+		}
+		if len(languages) == 0 {
+			continue
+		}
+
+		// Pick the first requested language available
+		var translation *internalrouterapiv1recipe.RecipeTranslation
+		found := false
+		for _, language := range languages {
+			if language == recipe.Language {
+				found = true
+				break
+			}
+			if translation = available[language]; translation != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			recipe.LanguageFallback = true
+			continue
+		}
+		if translation == nil {
+			continue
+		}
+
+		// Serve the translation
+		recipe.TranslatedFrom = recipe.Language
+		recipe.Language = translation.Language
+		recipe.Name = translation.Name
+		recipe.Description = translation.Description
+		ingredients := make([]internalrouterapiv1recipe.Ingredient, len(recipe.Ingredients))
+		for i, ingredient := range recipe.Ingredients {
+			ingredient.Name = translation.Ingredients[i]
+			ingredients[i] = ingredient
+		}
+		recipe.Ingredients = ingredients
+		recipe.Steps = translation.Steps
+	}
+	return nil
+}
+
+// TranslateScoredRecipes serves each ranked recipe in the first of the requested languages it is available in
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - languages: the requested languages, in order of preference, nothing is translated if empty
+//   - recipes: the ranked recipes
+//
+// Returns:
+//
+//   - error: an error if the translations could not be listed
+func (d *Service) TranslateScoredRecipes(
+	ctx context.Context,
+	languages []string,
+	recipes []*internalrouterapiv1recipe.ScoredRecipe,
+) error {
+	scoredRecipes := make([]*internalrouterapiv1recipe.Recipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		scoredRecipes = append(scoredRecipes, recipe.Recipe)
+	}
+	return d.TranslateRecipes(ctx, languages, scoredRecipes...)
+}
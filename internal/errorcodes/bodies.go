@@ -0,0 +1,17 @@
+package errorcodes
+
+type (
+	// FailBody is the JSend fail body, documented with its stable code
+	FailBody struct {
+		Status string `json:"status"`
+		Code   Code   `json:"code,omitempty"`
+		Data   any    `json:"data,omitempty"`
+	}
+
+	// ErrorBody is the JSend error body, documented with its stable code
+	ErrorBody struct {
+		Status  string `json:"status"`
+		Message string `json:"message,omitempty"`
+		Code    Code   `json:"code,omitempty"`
+	}
+)
@@ -0,0 +1,353 @@
+package errorcodes
+
+type (
+	// Code is the stable, machine-readable code of a fail or error body. Codes are part of the API contract: once
+	// released, a code keeps its meaning and is never renamed or reused, so clients can rely on them instead of the
+	// messages, which are localized and may change
+	Code string
+)
+
+const (
+	// BadRequest is returned when the request cannot be processed as sent
+	BadRequest Code = "bad_request"
+
+	// ValidationFailed is returned when the request body does not pass validation, with the messages per field
+	ValidationFailed Code = "validation_failed"
+
+	// InternalError is returned when an unexpected error happened
+	InternalError Code = "internal_error"
+
+	// ServiceUnavailable is returned when a service this API depends on is unavailable
+	ServiceUnavailable Code = "service_unavailable"
+
+	// Unauthorized is returned when the request needs a logged-in user
+	Unauthorized Code = "unauthorized"
+
+	// SessionExpired is returned when the session tokens are missing, invalid or expired
+	SessionExpired Code = "session_expired"
+
+	// Forbidden is returned when the user is not allowed to do the operation
+	Forbidden Code = "forbidden"
+
+	// NotFound is returned when the resource was not found
+	NotFound Code = "not_found"
+
+	// Conflict is returned when the resource already exists
+	Conflict Code = "conflict"
+
+	// PreconditionFailed is returned when the resource is not in the state the operation needs
+	PreconditionFailed Code = "precondition_failed"
+
+	// NotImplemented is returned when the operation is not available yet
+	NotImplemented Code = "not_implemented"
+
+	// TooManyRequests is returned when the client sent too many requests
+	TooManyRequests Code = "too_many_requests"
+
+	// RequestTimeout is returned when the request was canceled or took too long
+	RequestTimeout Code = "request_timeout"
+
+	// TwoFactorRequired is returned when the log in needs a second factor, with the methods the user can use
+	TwoFactorRequired Code = "two_factor_required"
+
+	// RequiredField is returned when a required field is missing
+	RequiredField Code = "required_field"
+
+	// InvalidContentType is returned when the request body is not JSON
+	InvalidContentType Code = "invalid_content_type"
+
+	// EmptyBody is returned when the request body is empty
+	EmptyBody Code = "empty_body"
+
+	// MalformedJSON is returned when the request body is not valid JSON
+	MalformedJSON Code = "malformed_json"
+
+	// UnknownField is returned when the request body has a field the endpoint does not know
+	UnknownField Code = "unknown_field"
+
+	// InvalidFieldType is returned when a field of the request body has a value of the wrong type
+	InvalidFieldType Code = "invalid_field_type"
+
+	// BodyTooLarge is returned when the request body exceeds the maximum size
+	BodyTooLarge Code = "body_too_large"
+
+	// InvalidPathParameter is returned when a path parameter is not valid
+	InvalidPathParameter Code = "invalid_path_parameter"
+
+	// InvalidQueryParameter is returned when a query parameter is not valid
+	InvalidQueryParameter Code = "invalid_query_parameter"
+
+	// MissingQueryParameter is returned when a required query parameter is missing
+	MissingQueryParameter Code = "missing_query_parameter"
+
+	// RecipeNotFound is returned when the recipe was not found
+	RecipeNotFound Code = "recipe_not_found"
+
+	// RecipeNotOwned is returned when the recipe is not owned by the user
+	RecipeNotOwned Code = "recipe_not_owned"
+
+	// DuplicateRecipe is returned when the library already holds a likely duplicate of the recipe, with the duplicates
+	DuplicateRecipe Code = "duplicate_recipe"
+
+	// InvalidVisibility is returned when the visibility is not private, unlisted or public
+	InvalidVisibility Code = "invalid_visibility"
+
+	// SelfMerge is returned when a recipe is merged into itself
+	SelfMerge Code = "self_merge"
+
+	// RevisionNotFound is returned when the recipe revision was not found
+	RevisionNotFound Code = "revision_not_found"
+
+	// TagNotFound is returned when the tag was not found
+	TagNotFound Code = "tag_not_found"
+
+	// InvalidTagKind is returned when the tag kind is not valid
+	InvalidTagKind Code = "invalid_tag_kind"
+
+	// TooManyTags is returned when a recipe has too many tags
+	TooManyTags Code = "too_many_tags"
+
+	// EmptyTagLabel is returned when a tag label is empty
+	EmptyTagLabel Code = "empty_tag_label"
+
+	// EmptyTagQuery is returned when the tag search query is empty
+	EmptyTagQuery Code = "empty_tag_query"
+
+	// EmptySearchQuery is returned when the search query is empty
+	EmptySearchQuery Code = "empty_search_query"
+
+	// GroupNotFound is returned when the recipe group was not found
+	GroupNotFound Code = "group_not_found"
+
+	// GroupNotOwned is returned when the recipe group is not owned by the user
+	GroupNotOwned Code = "group_not_owned"
+
+	// GroupRecipeNotFound is returned when a recipe of the group was not found
+	GroupRecipeNotFound Code = "group_recipe_not_found"
+
+	// TooManyGroupRecipes is returned when a recipe group has too many recipes
+	TooManyGroupRecipes Code = "too_many_group_recipes"
+
+	// CookbookNotFound is returned when the cookbook was not found
+	CookbookNotFound Code = "cookbook_not_found"
+
+	// CookbookNotOwned is returned when the cookbook is not owned by the user
+	CookbookNotOwned Code = "cookbook_not_owned"
+
+	// CookbookNotReady is returned when the cookbook is still being generated
+	CookbookNotReady Code = "cookbook_not_ready"
+
+	// ImportNotFound is returned when the bulk import was not found
+	ImportNotFound Code = "import_not_found"
+
+	// ImportNotOwned is returned when the bulk import was started by another user
+	ImportNotOwned Code = "import_not_owned"
+
+	// SelfFollow is returned when a user follows themselves
+	SelfFollow Code = "self_follow"
+
+	// FollowNotFound is returned when the user is not followed
+	FollowNotFound Code = "follow_not_found"
+
+	// UserNotFound is returned when the user was not found
+	UserNotFound Code = "user_not_found"
+
+	// CookLogNotFound is returned when the cook log was not found
+	CookLogNotFound Code = "cook_log_not_found"
+
+	// CookLogNotOwned is returned when the cook log is not owned by the user
+	CookLogNotOwned Code = "cook_log_not_owned"
+
+	// InvalidCookDate is returned when the cooked on date is not valid or is in the future
+	InvalidCookDate Code = "invalid_cook_date"
+
+	// InvalidRating is returned when the rating is not between 1 and 5
+	InvalidRating Code = "invalid_rating"
+
+	// TooManySubstitutions is returned when a cook log has too many substitutions
+	TooManySubstitutions Code = "too_many_substitutions"
+
+	// EmptySubstitution is returned when a substitution has no ingredient or substitute
+	EmptySubstitution Code = "empty_substitution"
+
+	// TooManyPhotos is returned when a cook log has too many photos
+	TooManyPhotos Code = "too_many_photos"
+
+	// InvalidPhotoURL is returned when a photo URL is not an absolute http or https URL
+	InvalidPhotoURL Code = "invalid_photo_url"
+
+	// AnnotationNotFound is returned when the annotation was not found
+	AnnotationNotFound Code = "annotation_not_found"
+
+	// InvalidAnnotationTarget is returned when the annotation target is not recipe, step or ingredient
+	InvalidAnnotationTarget Code = "invalid_annotation_target"
+
+	// InvalidAnnotationPosition is returned when the annotated step or ingredient does not exist
+	InvalidAnnotationPosition Code = "invalid_annotation_position"
+
+	// EmptyAnnotation is returned when an annotation has no note or substitute
+	EmptyAnnotation Code = "empty_annotation"
+
+	// TooManyAnnotations is returned when a recipe has too many annotations
+	TooManyAnnotations Code = "too_many_annotations"
+
+	// InvalidAnnotationSubstitute is returned when an annotation that is not on an ingredient has a substitute
+	InvalidAnnotationSubstitute Code = "invalid_annotation_substitute"
+
+	// InvalidDiet is returned when a diet is not known
+	InvalidDiet Code = "invalid_diet"
+
+	// EmptyIngredient is returned when the ingredient is empty
+	EmptyIngredient Code = "empty_ingredient"
+
+	// SubstitutionsNotFound is returned when there are no known substitutions for the ingredient
+	SubstitutionsNotFound Code = "substitutions_not_found"
+
+	// IngredientPriceNotFound is returned when the ingredient price was not found
+	IngredientPriceNotFound Code = "ingredient_price_not_found"
+
+	// InvalidPrice is returned when the price is negative
+	InvalidPrice Code = "invalid_price"
+
+	// InvalidPriceQuantity is returned when the priced quantity is not greater than 0
+	InvalidPriceQuantity Code = "invalid_price_quantity"
+
+	// TooManyPrices is returned when the user has too many ingredient prices
+	TooManyPrices Code = "too_many_prices"
+
+	// MealPlanNotFound is returned when the meal plan was not found
+	MealPlanNotFound Code = "meal_plan_not_found"
+
+	// MealPlanNotOwned is returned when the meal plan is not owned by the user
+	MealPlanNotOwned Code = "meal_plan_not_owned"
+
+	// MealPlanRecipeNotFound is returned when a recipe of the meal plan was not found
+	MealPlanRecipeNotFound Code = "meal_plan_recipe_not_found"
+
+	// InvalidMealPlanDate is returned when the date of a meal plan entry is not YYYY-MM-DD
+	InvalidMealPlanDate Code = "invalid_meal_plan_date"
+
+	// InvalidMealPlanServings is returned when the servings of a meal plan entry are out of range
+	InvalidMealPlanServings Code = "invalid_meal_plan_servings"
+
+	// TooManyMealPlanEntries is returned when a meal plan has too many entries
+	TooManyMealPlanEntries Code = "too_many_meal_plan_entries"
+
+	// ShoppingListNotFound is returned when the shopping list was not found
+	ShoppingListNotFound Code = "shopping_list_not_found"
+
+	// ShoppingListNotOwned is returned when the shopping list is not owned by the user
+	ShoppingListNotOwned Code = "shopping_list_not_owned"
+
+	// EmptyShoppingListItem is returned when the name of a shopping list item is empty
+	EmptyShoppingListItem Code = "empty_shopping_list_item"
+
+	// InvalidShoppingListItemQuantity is returned when the quantity of a shopping list item is negative
+	InvalidShoppingListItemQuantity Code = "invalid_shopping_list_item_quantity"
+
+	// TooManyShoppingListItems is returned when a shopping list has too many items
+	TooManyShoppingListItems Code = "too_many_shopping_list_items"
+
+	// FavoriteNotFound is returned when the favorite was not found
+	FavoriteNotFound Code = "favorite_not_found"
+
+	// InvalidLanguage is returned when the language is not an ISO 639 code
+	InvalidLanguage Code = "invalid_language"
+
+	// TranslationNotFound is returned when the recipe has no translation to the language
+	TranslationNotFound Code = "translation_not_found"
+
+	// TranslationOriginalLanguage is returned when a translation is in the language the recipe is written in
+	TranslationOriginalLanguage Code = "translation_original_language"
+
+	// TooManyTranslations is returned when a recipe has too many translations
+	TooManyTranslations Code = "too_many_translations"
+
+	// EmptyTranslationName is returned when the translated name is empty
+	EmptyTranslationName Code = "empty_translation_name"
+
+	// InvalidTranslationIngredients is returned when the translated ingredients do not match the recipe
+	InvalidTranslationIngredients Code = "invalid_translation_ingredients"
+
+	// InvalidTranslationSteps is returned when the translated steps do not match the recipe
+	InvalidTranslationSteps Code = "invalid_translation_steps"
+
+	// InvalidTrendingWindow is returned when the trending window is not day or week
+	InvalidTrendingWindow Code = "invalid_trending_window"
+
+	// InvalidShareLink is returned when the share link is not valid
+	InvalidShareLink Code = "invalid_share_link"
+
+	// ExpiredShareLink is returned when the share link has expired
+	ExpiredShareLink Code = "expired_share_link"
+
+	// ArchiveTooLarge is returned when the bulk import archive is too large
+	ArchiveTooLarge Code = "archive_too_large"
+
+	// EmptyArchive is returned when the bulk import archive is empty
+	EmptyArchive Code = "empty_archive"
+
+	// InvalidArchiveFormat is returned when the bulk import archive format is not known
+	InvalidArchiveFormat Code = "invalid_archive_format"
+
+	// InvalidExportFormat is returned when the export format is not known
+	InvalidExportFormat Code = "invalid_export_format"
+
+	// AmbiguousImportSource is returned when both a URL and an HTML document are sent to import
+	AmbiguousImportSource Code = "ambiguous_import_source"
+
+	// MissingImportSource is returned when neither a URL nor an HTML document is sent to import
+	MissingImportSource Code = "missing_import_source"
+
+	// DocumentTooLarge is returned when the HTML document to import is too large
+	DocumentTooLarge Code = "document_too_large"
+
+	// ImportFetchFailed is returned when the recipe page to import could not be fetched
+	ImportFetchFailed Code = "import_fetch_failed"
+
+	// ForbiddenImportHost is returned when the host of the URL to import is not allowed
+	ForbiddenImportHost Code = "forbidden_import_host"
+
+	// InvalidImportURL is returned when the URL to import is not an absolute http or https URL
+	InvalidImportURL Code = "invalid_import_url"
+
+	// ImportedRecipeNotFound is returned when the imported page has no schema.org recipe
+	ImportedRecipeNotFound Code = "imported_recipe_not_found"
+
+	// InvalidSyncToken is returned when the sync token was not issued by this API
+	InvalidSyncToken Code = "invalid_sync_token"
+
+	// InvalidSyncEntity is returned when a pushed change is not to a recipe, a group, a meal plan, a shopping list or a
+	// favorite
+	InvalidSyncEntity Code = "invalid_sync_entity"
+
+	// InvalidSyncOperation is returned when a pushed change is not a create, an update or a delete
+	InvalidSyncOperation Code = "invalid_sync_operation"
+
+	// InvalidFavoriteSyncOperation is returned when a pushed change to a favorite is an update
+	InvalidFavoriteSyncOperation Code = "invalid_favorite_sync_operation"
+
+	// MissingSyncContent is returned when a pushed create or update has no content for its entity
+	MissingSyncContent Code = "missing_sync_content"
+
+	// MissingSyncEntityID is returned when a pushed update or delete has no entity ID
+	MissingSyncEntityID Code = "missing_sync_entity_id"
+
+	// TooManySyncChanges is returned when too many changes are pushed at once
+	TooManySyncChanges Code = "too_many_sync_changes"
+
+	// PreconditionRequired is returned when a write has no If-Match header
+	PreconditionRequired Code = "precondition_required"
+
+	// InvalidIfMatch is returned when the If-Match header lists * with entity tags
+	InvalidIfMatch Code = "invalid_if_match"
+
+	// VersionMismatch is returned when the resource was modified since the version the write was made over
+	VersionMismatch Code = "version_mismatch"
+)
+
+const (
+	// GRPCPrefix is the prefix of the codes of the errors returned by the gRPC services with a status code this API
+	// does not map, followed by the name of the gRPC status code, such as grpc_Aborted
+	GRPCPrefix = "grpc_"
+)
@@ -0,0 +1,145 @@
+package errorcodes
+
+import (
+	"slices"
+	"strings"
+)
+
+var (
+	// Codes are all the codes this API returns, besides the ones with the GRPCPrefix
+	Codes = []Code{
+		BadRequest,
+		ValidationFailed,
+		InternalError,
+		ServiceUnavailable,
+		Unauthorized,
+		SessionExpired,
+		Forbidden,
+		NotFound,
+		Conflict,
+		PreconditionFailed,
+		NotImplemented,
+		TooManyRequests,
+		RequestTimeout,
+		TwoFactorRequired,
+		RequiredField,
+		InvalidContentType,
+		EmptyBody,
+		MalformedJSON,
+		UnknownField,
+		InvalidFieldType,
+		BodyTooLarge,
+		InvalidPathParameter,
+		InvalidQueryParameter,
+		MissingQueryParameter,
+		RecipeNotFound,
+		RecipeNotOwned,
+		DuplicateRecipe,
+		InvalidVisibility,
+		SelfMerge,
+		RevisionNotFound,
+		TagNotFound,
+		InvalidTagKind,
+		TooManyTags,
+		EmptyTagLabel,
+		EmptyTagQuery,
+		EmptySearchQuery,
+		GroupNotFound,
+		GroupNotOwned,
+		GroupRecipeNotFound,
+		TooManyGroupRecipes,
+		CookbookNotFound,
+		CookbookNotOwned,
+		CookbookNotReady,
+		ImportNotFound,
+		ImportNotOwned,
+		SelfFollow,
+		FollowNotFound,
+		UserNotFound,
+		CookLogNotFound,
+		CookLogNotOwned,
+		InvalidCookDate,
+		InvalidRating,
+		TooManySubstitutions,
+		EmptySubstitution,
+		TooManyPhotos,
+		InvalidPhotoURL,
+		AnnotationNotFound,
+		InvalidAnnotationTarget,
+		InvalidAnnotationPosition,
+		EmptyAnnotation,
+		TooManyAnnotations,
+		InvalidAnnotationSubstitute,
+		InvalidDiet,
+		EmptyIngredient,
+		SubstitutionsNotFound,
+		IngredientPriceNotFound,
+		InvalidPrice,
+		InvalidPriceQuantity,
+		TooManyPrices,
+		MealPlanNotFound,
+		MealPlanNotOwned,
+		MealPlanRecipeNotFound,
+		InvalidMealPlanDate,
+		InvalidMealPlanServings,
+		TooManyMealPlanEntries,
+		ShoppingListNotFound,
+		ShoppingListNotOwned,
+		EmptyShoppingListItem,
+		InvalidShoppingListItemQuantity,
+		TooManyShoppingListItems,
+		FavoriteNotFound,
+		InvalidLanguage,
+		TranslationNotFound,
+		TranslationOriginalLanguage,
+		TooManyTranslations,
+		EmptyTranslationName,
+		InvalidTranslationIngredients,
+		InvalidTranslationSteps,
+		InvalidTrendingWindow,
+		InvalidShareLink,
+		ExpiredShareLink,
+		ArchiveTooLarge,
+		EmptyArchive,
+		InvalidArchiveFormat,
+		InvalidExportFormat,
+		AmbiguousImportSource,
+		MissingImportSource,
+		DocumentTooLarge,
+		ImportFetchFailed,
+		ForbiddenImportHost,
+		InvalidImportURL,
+		ImportedRecipeNotFound,
+		InvalidSyncToken,
+		InvalidSyncEntity,
+		InvalidSyncOperation,
+		InvalidFavoriteSyncOperation,
+		MissingSyncContent,
+		MissingSyncEntityID,
+		TooManySyncChanges,
+		PreconditionRequired,
+		InvalidIfMatch,
+		VersionMismatch,
+	}
+)
+
+// String returns the code as a string
+//
+// Returns:
+//
+//   - string: the code
+func (c Code) String() string {
+	return string(c)
+}
+
+// IsValid checks if the code is one of the Codes or a gRPC status code with the GRPCPrefix
+//
+// Returns:
+//
+//   - bool: true if the code is returned by this API, false otherwise
+func (c Code) IsValid() bool {
+	if slices.Contains(Codes, c) {
+		return true
+	}
+	return strings.HasPrefix(string(c), GRPCPrefix) && len(c) > len(GRPCPrefix)
+}
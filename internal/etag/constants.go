@@ -0,0 +1,24 @@
+package etag
+
+const (
+	// Header is the header with the entity tag of the resource sent in the response
+	Header = "ETag"
+
+	// IfMatchHeader is the header with the entity tag of the version a write was made over
+	IfMatchHeader = "If-Match"
+
+	// IfNoneMatchHeader is the header with the entity tags of the representations the client already has
+	IfNoneMatchHeader = "If-None-Match"
+
+	// Any is the entity tag that matches any current version of the resource
+	Any = "*"
+
+	// WeakPrefix is the prefix of the weak entity tags
+	WeakPrefix = "W/"
+
+	// DigestSeparator separates the version from the digest of the representation in an entity tag
+	DigestSeparator = "-"
+
+	// DigestLength is the number of bytes of the SHA-256 digest of the representation kept in an entity tag
+	DigestLength = 12
+)
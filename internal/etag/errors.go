@@ -0,0 +1,11 @@
+package etag
+
+import (
+	"errors"
+)
+
+var (
+	ErrMissingIfMatch = errors.New("if-match header is required")
+	ErrInvalidIfMatch = errors.New("invalid if-match header, * cannot be listed with entity tags")
+	ErrUnknownETag    = errors.New("entity tag does not match any version of the resource")
+)
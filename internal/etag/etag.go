@@ -0,0 +1,169 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// Format formats the entity tag of a version of a resource
+//
+// Parameters:
+//
+//   - version: the version of the resource
+//
+// Returns:
+//
+//   - string: the entity tag
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// FormatRepresentation formats the entity tag of a representation of a version of a resource. The digest of the
+// representation follows the version, so the tag changes with the translation, the annotations and the encoding sent,
+// while only the version is compared on writes
+//
+// Parameters:
+//
+//   - r: the HTTP request
+//   - version: the version of the resource
+//   - data: the response data
+//
+// Returns:
+//
+//   - string: the entity tag
+//   - error: an error if the response data could not be encoded
+func FormatRepresentation(r *http.Request, version int, data any) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write(encoded)
+	hash.Write([]byte(r.Header.Get(internalrequest.AcceptHeader)))
+	digest := base64.RawURLEncoding.EncodeToString(hash.Sum(nil)[:DigestLength])
+	return `"` + strconv.Itoa(version) + DigestSeparator + digest + `"`, nil
+}
+
+// parseVersion parses the version of a strong entity tag, ignoring the digest of the representation
+//
+// Parameters:
+//
+//   - tag: the entity tag
+//
+// Returns:
+//
+//   - int: the version
+//   - bool: true if the entity tag is a strong tag issued by this API
+func parseVersion(tag string) (int, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	value, _, _ := strings.Cut(tag[1:len(tag)-1], DigestSeparator)
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// GetIfMatchVersions gets the versions a write may be made over from the If-Match header. As told by RFC 7232, the
+// write is made if any of the entity tags listed is the one of the current version
+//
+// Parameters:
+//
+//   - r: the HTTP request
+//
+// Returns:
+//
+//   - []int: the versions, nil if the write is made over any version
+//   - error: a JSend fail error if the header is missing, not valid or does not match any version
+func GetIfMatchVersions(r *http.Request) ([]int, error) {
+	header := strings.TrimSpace(strings.Join(r.Header.Values(IfMatchHeader), ","))
+	switch header {
+	case "":
+		return nil, gonethttpresponse.NewFailFieldErrorWithCode(
+			IfMatchHeader,
+			ErrMissingIfMatch,
+			internalerrorcodes.PreconditionRequired.String(),
+			http.StatusPreconditionRequired,
+		)
+	case Any:
+		return nil, nil
+	}
+
+	// Weak entity tags and the ones not issued by this API never match on writes
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == Any {
+			return nil, gonethttpresponse.NewFailFieldErrorWithCode(
+				IfMatchHeader,
+				ErrInvalidIfMatch,
+				internalerrorcodes.InvalidIfMatch.String(),
+				http.StatusBadRequest,
+			)
+		}
+		if version, ok := parseVersion(tag); ok {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, gonethttpresponse.NewFailFieldErrorWithCode(
+			IfMatchHeader,
+			ErrUnknownETag,
+			internalerrorcodes.VersionMismatch.String(),
+			http.StatusPreconditionFailed,
+		)
+	}
+	return versions, nil
+}
+
+// SetVersion sets the entity tag of a version of a resource on the response
+//
+// Parameters:
+//
+//   - w: the HTTP response writer
+//   - version: the version of the resource
+func SetVersion(w http.ResponseWriter, version int) {
+	w.Header().Set(Header, Format(version))
+}
+
+// CheckNotModified sets the entity tag of the representation on the response and, if the client already has it as
+// told by the If-None-Match header, responds with 304 Not Modified. Besides the tag of the representation, the tag of
+// its version sent on writes matches, since the client that made the write already has that version
+//
+// Parameters:
+//
+//   - w: the HTTP response writer
+//   - r: the HTTP request
+//   - tag: the entity tag of the representation
+//
+// Returns:
+//
+//   - bool: true if the response was written
+func CheckNotModified(w http.ResponseWriter, r *http.Request, tag string) bool {
+	w.Header().Set(Header, tag)
+
+	// Compare the entity tags weakly, as any tag matches on reads
+	version, _ := parseVersion(tag)
+	for _, value := range r.Header.Values(IfNoneMatchHeader) {
+		for _, candidate := range strings.Split(value, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), WeakPrefix)
+			if candidate == Any || candidate == tag || (version > 0 && candidate == Format(version)) {
+				w.WriteHeader(http.StatusNotModified)
+				return true
+			}
+		}
+	}
+	return false
+}
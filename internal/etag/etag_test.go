@@ -0,0 +1,232 @@
+package etag
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+func TestFormat(t *testing.T) {
+	for version, want := range map[int]string{1: `"1"`, 42: `"42"`, 9223372036854775807: `"9223372036854775807"`} {
+		if tag := Format(version); tag != want {
+			t.Errorf("Format(%d) = %s, want %s", version, tag, want)
+		}
+	}
+}
+
+func TestFormatRepresentation(t *testing.T) {
+	request := func(accept string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if accept != "" {
+			r.Header.Set(internalrequest.AcceptHeader, accept)
+		}
+		return r
+	}
+	tag := func(r *http.Request, version int, data any) string {
+		t.Helper()
+		formatted, err := FormatRepresentation(r, version, data)
+		if err != nil {
+			t.Fatalf("FormatRepresentation() error = %v", err)
+		}
+		return formatted
+	}
+
+	base := tag(request(""), 7, map[string]string{"name": "Arepas"})
+	if version, ok := parseVersion(base); !ok || version != 7 {
+		t.Errorf("parseVersion(%s) = %d, %t, want 7, true", base, version, ok)
+	}
+	if again := tag(request(""), 7, map[string]string{"name": "Arepas"}); again != base {
+		t.Errorf("tag of the same representation = %s, want %s", again, base)
+	}
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		version int
+		data    any
+	}{
+		{name: "other version", r: request(""), version: 8, data: map[string]string{"name": "Arepas"}},
+		{name: "other data", r: request(""), version: 7, data: map[string]string{"name": "Cachapas"}},
+		{name: "other encoding", r: request("application/x-protobuf"), version: 7, data: map[string]string{"name": "Arepas"}},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if other := tag(test.r, test.version, test.data); other == base {
+					t.Errorf("tag = %s, want a tag other than %s", other, base)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"data not encodable", func(t *testing.T) {
+			if _, err := FormatRepresentation(request(""), 7, make(chan int)); err == nil {
+				t.Error("FormatRepresentation() error = nil, want an error")
+			}
+		},
+	)
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		version int
+		ok      bool
+	}{
+		// Valid forms
+		{name: "version", tag: `"3"`, version: 3, ok: true},
+		{name: "version and digest", tag: `"3-AbC_d-e"`, version: 3, ok: true},
+
+		// Malformed forms
+		{name: "empty", tag: ``},
+		{name: "single quote", tag: `"`},
+		{name: "empty tag", tag: `""`},
+		{name: "unquoted", tag: `3`},
+		{name: "weak", tag: `W/"3"`},
+		{name: "not a number", tag: `"abc"`},
+		{name: "zero", tag: `"0"`},
+		{name: "negative", tag: `"-3"`},
+
+		// Limits
+		{name: "largest version", tag: `"9223372036854775807"`, version: 9223372036854775807, ok: true},
+		{name: "overflowing version", tag: `"99999999999999999999"`},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				version, ok := parseVersion(test.tag)
+				if version != test.version || ok != test.ok {
+					t.Errorf("parseVersion(%s) = %d, %t, want %d, %t", test.tag, version, ok, test.version, test.ok)
+				}
+			},
+		)
+	}
+}
+
+func TestGetIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		versions []int
+		err      error
+		status   int
+	}{
+		// Valid forms
+		{name: "entity tag", values: []string{`"5"`}, versions: []int{5}},
+		{name: "entity tag of a representation", values: []string{` "5-AbC" `}, versions: []int{5}},
+		{name: "any version", values: []string{"*"}},
+		{name: "several tags", values: []string{`"5", "6-AbC"`}, versions: []int{5, 6}},
+		{name: "several headers", values: []string{`"5"`, `"6"`}, versions: []int{5, 6}},
+		{name: "weak and unknown tags ignored", values: []string{`W/"4", "abc", "5",,`}, versions: []int{5}},
+
+		// Malformed forms
+		{name: "missing", err: ErrMissingIfMatch, status: http.StatusPreconditionRequired},
+		{name: "blank", values: []string{"  "}, err: ErrMissingIfMatch, status: http.StatusPreconditionRequired},
+		{name: "any version and a tag", values: []string{`*, "5"`}, err: ErrInvalidIfMatch, status: http.StatusBadRequest},
+		{name: "any version in another header", values: []string{`"5"`, "*"}, err: ErrInvalidIfMatch, status: http.StatusBadRequest},
+		{name: "weak tag", values: []string{`W/"5"`}, err: ErrUnknownETag, status: http.StatusPreconditionFailed},
+		{name: "unknown tag", values: []string{`"abc"`}, err: ErrUnknownETag, status: http.StatusPreconditionFailed},
+		{name: "only weak tags", values: []string{`W/"5", W/"6"`}, err: ErrUnknownETag, status: http.StatusPreconditionFailed},
+
+		// Limits
+		{name: "overflowing version", values: []string{`"99999999999999999999"`}, err: ErrUnknownETag, status: http.StatusPreconditionFailed},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodPut, "/", nil)
+				for _, value := range test.values {
+					r.Header.Add(IfMatchHeader, value)
+				}
+
+				versions, err := GetIfMatchVersions(r)
+				if !slices.Equal(versions, test.versions) {
+					t.Errorf("GetIfMatchVersions() = %v, want %v", versions, test.versions)
+				}
+				if test.err == nil {
+					if err != nil {
+						t.Errorf("GetIfMatchVersions() error = %v, want nil", err)
+					}
+					return
+				}
+
+				// The fail errors do not wrap their error, it is compared as is
+				var failErr *gonethttpresponse.FailFieldError
+				if !errors.As(err, &failErr) {
+					t.Fatalf("GetIfMatchVersions() error = %v, want a fail error", err)
+				}
+				if failErr.Err != test.err || failErr.HTTPStatus != test.status || failErr.Field != IfMatchHeader {
+					t.Errorf(
+						"GetIfMatchVersions() error = %v of %s with status %d, want %v of %s with status %d",
+						failErr.Err,
+						failErr.Field,
+						failErr.HTTPStatus,
+						test.err,
+						IfMatchHeader,
+						test.status,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestSetVersion(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetVersion(w, 12)
+	if tag := w.Header().Get(Header); tag != `"12"` {
+		t.Errorf("%s = %s, want %s", Header, tag, `"12"`)
+	}
+}
+
+func TestCheckNotModified(t *testing.T) {
+	const tag = `"4-AbC"`
+	tests := []struct {
+		name     string
+		values   []string
+		modified bool
+	}{
+		{name: "no header", modified: true},
+		{name: "same tag", values: []string{tag}},
+		{name: "weak tag", values: []string{"W/" + tag}},
+		{name: "tag in a list", values: []string{`"3-xyz", ` + tag}},
+		{name: "tag in another header", values: []string{`"3-xyz"`, tag}},
+		{name: "any tag", values: []string{"*"}},
+		{name: "other tag", values: []string{`"4-xyz"`}, modified: true},
+		{name: "tag of the version", values: []string{`"4"`}},
+		{name: "weak tag of the version", values: []string{`W/"4"`}},
+		{name: "tag of another version", values: []string{`"3"`}, modified: true},
+		{name: "malformed list", values: []string{`,,` + strings.Repeat(" ", 10)}, modified: true},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				for _, value := range test.values {
+					r.Header.Add(IfNoneMatchHeader, value)
+				}
+				w := httptest.NewRecorder()
+
+				written := CheckNotModified(w, r, tag)
+				if written == test.modified {
+					t.Errorf("CheckNotModified() = %t, want %t", written, !test.modified)
+				}
+				if got := w.Header().Get(Header); got != tag {
+					t.Errorf("%s = %s, want %s", Header, got, tag)
+				}
+				if !test.modified && w.Code != http.StatusNotModified {
+					t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,166 @@
+package exporter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+var (
+	// fractionGlyphs are the unicode characters of the common fractions, keyed by their value in thousandths
+	fractionGlyphs = map[int]string{
+		125: "⅛",
+		250: "¼",
+		333: "⅓",
+		375: "⅜",
+		500: "½",
+		625: "⅝",
+		667: "⅔",
+		750: "¾",
+		875: "⅞",
+	}
+)
+
+type (
+	// document is the view of a recipe shared by all the export formats
+	document struct {
+		Name            string
+		Description     string
+		PreparationTime int // in minutes
+		CookingTime     int // in minutes
+		TotalTime       int // in minutes
+		Servings        int
+		Difficulty      string
+		Ingredients     []string
+		Steps           []string
+		Cuisines        []string
+		Courses         []string
+		Keywords        []string
+		SourceURL       string
+		ImageURL        string
+	}
+)
+
+// newDocument creates the export view of a recipe
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//
+// Returns:
+//
+//   - *document: the export view
+func newDocument(recipe *internalrouterapiv1recipe.Recipe) *document {
+	doc := &document{
+		Name:            recipe.Name,
+		Description:     recipe.Description,
+		PreparationTime: recipe.PreparationTime,
+		CookingTime:     recipe.CookingTime,
+		TotalTime:       recipe.PreparationTime + recipe.CookingTime,
+		Servings:        recipe.Servings,
+		Difficulty:      recipe.Difficulty,
+		Steps:           recipe.Steps,
+		SourceURL:       recipe.SourceURL,
+		ImageURL:        recipe.ImageURL,
+	}
+	for _, ingredient := range recipe.Ingredients {
+		doc.Ingredients = append(doc.Ingredients, FormatIngredient(ingredient))
+	}
+	for _, tag := range recipe.Tags {
+		switch tag.Kind {
+		case internalrouterapiv1recipe.TagKindCuisine:
+			doc.Cuisines = append(doc.Cuisines, tag.Name)
+		case internalrouterapiv1recipe.TagKindCourse:
+			doc.Courses = append(doc.Courses, tag.Name)
+		default:
+			doc.Keywords = append(doc.Keywords, tag.Name)
+		}
+	}
+	return doc
+}
+
+// FormatIngredient formats an ingredient as a line such as "1 ½ cup flour"
+//
+// Parameters:
+//
+//   - ingredient: the ingredient
+//
+// Returns:
+//
+//   - string: the ingredient line
+func FormatIngredient(ingredient internalrouterapiv1recipe.Ingredient) string {
+	var parts []string
+	if ingredient.Quantity > 0 {
+		parts = append(parts, FormatQuantity(ingredient.Quantity))
+	}
+	if ingredient.Unit != "" {
+		parts = append(parts, ingredient.Unit)
+	}
+	parts = append(parts, ingredient.Name)
+	return strings.Join(parts, " ")
+}
+
+// FormatQuantity formats a quantity, writing the common fractions with their unicode characters
+//
+// Parameters:
+//
+//   - quantity: the quantity
+//
+// Returns:
+//
+//   - string: the formatted quantity
+func FormatQuantity(quantity float64) string {
+	whole, fraction := math.Modf(quantity)
+	if glyph, ok := fractionGlyphs[int(math.Round(fraction*1000))]; ok {
+		if whole == 0 {
+			return glyph
+		}
+		return fmt.Sprintf("%d %s", int(whole), glyph)
+	}
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
+
+// FormatDuration formats a number of minutes as an ISO-8601 duration, such as PT1H30M
+//
+// Parameters:
+//
+//   - minutes: the number of minutes
+//
+// Returns:
+//
+//   - string: the ISO-8601 duration
+func FormatDuration(minutes int) string {
+	hours, minutes := minutes/60, minutes%60
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("PT%dH%dM", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("PT%dH", hours)
+	default:
+		return fmt.Sprintf("PT%dM", minutes)
+	}
+}
+
+// HumanDuration formats a number of minutes for people to read, such as "1 h 30 min"
+//
+// Parameters:
+//
+//   - minutes: the number of minutes
+//
+// Returns:
+//
+//   - string: the readable duration
+func HumanDuration(minutes int) string {
+	hours, minutes := minutes/60, minutes%60
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%d h %d min", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%d h", hours)
+	default:
+		return fmt.Sprintf("%d min", minutes)
+	}
+}
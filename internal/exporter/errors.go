@@ -0,0 +1,36 @@
+package exporter
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrNilRecipe     = errors.New("recipe cannot be nil")
+	ErrInvalidFormat = errors.New("invalid export format, must be jsonld, markdown or html")
+)
+
+// ParseError maps an exporter error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the exporter error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	if errors.Is(err, ErrInvalidFormat) {
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"format",
+			err,
+			internalerrorcodes.InvalidExportFormat.String(),
+			http.StatusBadRequest,
+		)
+	}
+	return err
+}
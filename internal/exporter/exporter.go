@@ -0,0 +1,38 @@
+package exporter
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// Export renders a recipe in the given format. All the formats are rendered from the same view of the recipe
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//   - format: the export format
+//
+// Returns:
+//
+//   - []byte: the exported recipe
+//   - error: an error if the format is unknown or the recipe could not be rendered
+func Export(
+	recipe *internalrouterapiv1recipe.Recipe,
+	format Format,
+) ([]byte, error) {
+	// Check if the recipe is nil
+	if recipe == nil {
+		return nil, ErrNilRecipe
+	}
+
+	doc := newDocument(recipe)
+	switch format {
+	case FormatJSONLD:
+		return renderJSONLD(doc)
+	case FormatMarkdown:
+		return renderMarkdown(doc), nil
+	case FormatHTML:
+		return renderHTML(doc)
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
@@ -0,0 +1,74 @@
+package exporter
+
+type (
+	// Format is the format a recipe is exported to
+	Format string
+)
+
+const (
+	// FormatJSONLD is the schema.org Recipe JSON-LD format
+	FormatJSONLD Format = "jsonld"
+
+	// FormatMarkdown is the plain text Markdown format
+	FormatMarkdown Format = "markdown"
+
+	// FormatHTML is the self-contained printable HTML format
+	FormatHTML Format = "html"
+
+	// DefaultFormat is the format used when none is requested
+	DefaultFormat = FormatJSONLD
+)
+
+// ParseFormat parses an export format, falling back to the default format when it is empty
+//
+// Parameters:
+//
+//   - value: the requested format
+//
+// Returns:
+//
+//   - Format: the format
+//   - error: an error if the format is unknown
+func ParseFormat(value string) (Format, error) {
+	format := Format(value)
+	switch format {
+	case "":
+		return DefaultFormat, nil
+	case FormatJSONLD, FormatMarkdown, FormatHTML:
+		return format, nil
+	default:
+		return "", ErrInvalidFormat
+	}
+}
+
+// ContentType returns the media type of the format
+//
+// Returns:
+//
+//   - string: the media type
+func (f Format) ContentType() string {
+	switch f {
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "application/ld+json; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension of the format
+//
+// Returns:
+//
+//   - string: the file extension, with the leading dot
+func (f Format) Extension() string {
+	switch f {
+	case FormatMarkdown:
+		return ".md"
+	case FormatHTML:
+		return ".html"
+	default:
+		return ".jsonld"
+	}
+}
@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"strings"
+)
+
+var (
+	//go:embed templates/recipe.html
+	templatesFS embed.FS
+
+	// recipeTemplate is the printable HTML template of a recipe, with its stylesheet inlined so the page is
+	// self-contained
+	recipeTemplate = template.Must(
+		template.New("recipe.html").Funcs(
+			template.FuncMap{
+				"duration": HumanDuration,
+				"join": func(values []string) string {
+					return strings.Join(values, ", ")
+				},
+			},
+		).ParseFS(templatesFS, "templates/recipe.html"),
+	)
+)
+
+// renderHTML renders a recipe as a printable HTML page that embeds its JSON-LD
+//
+// Parameters:
+//
+//   - doc: the export view of the recipe
+//
+// Returns:
+//
+//   - []byte: the HTML page
+//   - error: an error if the template could not be executed
+func renderHTML(doc *document) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := recipeTemplate.Execute(
+		&buffer, struct {
+			*document
+			JSONLD *jsonLDRecipe
+		}{
+			document: doc,
+			JSONLD:   newJSONLDRecipe(doc),
+		},
+	); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,99 @@
+package exporter
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type (
+	// jsonLDRecipe is a schema.org Recipe
+	jsonLDRecipe struct {
+		Context            string            `json:"@context"`
+		Type               string            `json:"@type"`
+		Name               string            `json:"name"`
+		Description        string            `json:"description,omitempty"`
+		Image              string            `json:"image,omitempty"`
+		PrepTime           string            `json:"prepTime,omitempty"`
+		CookTime           string            `json:"cookTime,omitempty"`
+		TotalTime          string            `json:"totalTime,omitempty"`
+		RecipeYield        string            `json:"recipeYield,omitempty"`
+		RecipeCuisine      []string          `json:"recipeCuisine,omitempty"`
+		RecipeCategory     []string          `json:"recipeCategory,omitempty"`
+		Keywords           string            `json:"keywords,omitempty"`
+		RecipeIngredient   []string          `json:"recipeIngredient"`
+		RecipeInstructions []jsonLDHowToStep `json:"recipeInstructions"`
+		IsBasedOn          string            `json:"isBasedOn,omitempty"`
+	}
+
+	// jsonLDHowToStep is a schema.org HowToStep
+	jsonLDHowToStep struct {
+		Type     string `json:"@type"`
+		Position int    `json:"position"`
+		Text     string `json:"text"`
+	}
+)
+
+// renderJSONLD renders a recipe as a schema.org Recipe JSON-LD document
+//
+// Parameters:
+//
+//   - doc: the export view of the recipe
+//
+// Returns:
+//
+//   - []byte: the JSON-LD document
+//   - error: an error if the document could not be encoded
+func renderJSONLD(doc *document) ([]byte, error) {
+	return json.MarshalIndent(newJSONLDRecipe(doc), "", "  ")
+}
+
+// newJSONLDRecipe maps the export view of a recipe to a schema.org Recipe
+//
+// Parameters:
+//
+//   - doc: the export view of the recipe
+//
+// Returns:
+//
+//   - *jsonLDRecipe: the schema.org Recipe
+func newJSONLDRecipe(doc *document) *jsonLDRecipe {
+	recipe := &jsonLDRecipe{
+		Context:            "https://schema.org",
+		Type:               "Recipe",
+		Name:               doc.Name,
+		Description:        doc.Description,
+		Image:              doc.ImageURL,
+		RecipeCuisine:      doc.Cuisines,
+		RecipeCategory:     doc.Courses,
+		Keywords:           strings.Join(doc.Keywords, ", "),
+		RecipeIngredient:   doc.Ingredients,
+		RecipeInstructions: make([]jsonLDHowToStep, 0, len(doc.Steps)),
+		IsBasedOn:          doc.SourceURL,
+	}
+	if recipe.RecipeIngredient == nil {
+		recipe.RecipeIngredient = []string{}
+	}
+	if doc.PreparationTime > 0 {
+		recipe.PrepTime = FormatDuration(doc.PreparationTime)
+	}
+	if doc.CookingTime > 0 {
+		recipe.CookTime = FormatDuration(doc.CookingTime)
+	}
+	if doc.TotalTime > 0 {
+		recipe.TotalTime = FormatDuration(doc.TotalTime)
+	}
+	if doc.Servings > 0 {
+		recipe.RecipeYield = strconv.Itoa(doc.Servings)
+	}
+	for i, step := range doc.Steps {
+		recipe.RecipeInstructions = append(
+			recipe.RecipeInstructions, jsonLDHowToStep{
+				Type:     "HowToStep",
+				Position: i + 1,
+				Text:     step,
+			},
+		)
+	}
+	return recipe
+}
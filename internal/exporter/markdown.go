@@ -0,0 +1,83 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderMarkdown renders a recipe as a Markdown document that also reads well as plain text
+//
+// Parameters:
+//
+//   - doc: the export view of the recipe
+//
+// Returns:
+//
+//   - []byte: the Markdown document
+func renderMarkdown(doc *document) []byte {
+	var builder strings.Builder
+
+	// Write the title and the description
+	fmt.Fprintf(&builder, "# %s\n\n", doc.Name)
+	if doc.ImageURL != "" {
+		fmt.Fprintf(&builder, "![%s](<%s>)\n\n", doc.Name, doc.ImageURL)
+	}
+	if doc.Description != "" {
+		fmt.Fprintf(&builder, "%s\n\n", doc.Description)
+	}
+
+	// Write the details
+	var details []string
+	if doc.PreparationTime > 0 {
+		details = append(details, "Preparation: "+HumanDuration(doc.PreparationTime))
+	}
+	if doc.CookingTime > 0 {
+		details = append(details, "Cooking: "+HumanDuration(doc.CookingTime))
+	}
+	if doc.TotalTime > 0 {
+		details = append(details, "Total: "+HumanDuration(doc.TotalTime))
+	}
+	if doc.Servings > 0 {
+		details = append(details, fmt.Sprintf("Servings: %d", doc.Servings))
+	}
+	if doc.Difficulty != "" {
+		details = append(details, "Difficulty: "+doc.Difficulty)
+	}
+	if len(doc.Cuisines) > 0 {
+		details = append(details, "Cuisine: "+strings.Join(doc.Cuisines, ", "))
+	}
+	if len(doc.Courses) > 0 {
+		details = append(details, "Course: "+strings.Join(doc.Courses, ", "))
+	}
+	if len(doc.Keywords) > 0 {
+		details = append(details, "Tags: "+strings.Join(doc.Keywords, ", "))
+	}
+	for _, detail := range details {
+		fmt.Fprintf(&builder, "- %s\n", detail)
+	}
+	if len(details) > 0 {
+		builder.WriteString("\n")
+	}
+
+	// Write the ingredients and the steps
+	if len(doc.Ingredients) > 0 {
+		builder.WriteString("## Ingredients\n\n")
+		for _, ingredient := range doc.Ingredients {
+			fmt.Fprintf(&builder, "- %s\n", ingredient)
+		}
+		builder.WriteString("\n")
+	}
+	if len(doc.Steps) > 0 {
+		builder.WriteString("## Steps\n\n")
+		for i, step := range doc.Steps {
+			fmt.Fprintf(&builder, "%d. %s\n", i+1, step)
+		}
+		builder.WriteString("\n")
+	}
+
+	// Write the source
+	if doc.SourceURL != "" {
+		fmt.Fprintf(&builder, "Source: <%s>\n", doc.SourceURL)
+	}
+	return []byte(strings.TrimRight(builder.String(), "\n") + "\n")
+}
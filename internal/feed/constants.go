@@ -0,0 +1,48 @@
+package feed
+
+import (
+	"log/slog"
+
+	"github.com/go-redis/redis/v8"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+)
+
+const (
+	// KeyPrefix is the prefix of the Redis sorted sets that hold the feeds
+	KeyPrefix = "feed:"
+
+	// MaxLength is the maximum number of events kept in the feed of a user, older events are trimmed
+	MaxLength = 500
+
+	// FanOutMaxFollowers is the number of followers above which the events of a user are not written to the feeds
+	// of their followers, but read from the database when the feeds are read
+	FanOutMaxFollowers = 1000
+
+	// BeforeQueryParameter is the query parameter of the feed cursor, the ID of the oldest event already read
+	BeforeQueryParameter = "before"
+)
+
+var (
+	// Feeds is the activity feed of the users
+	Feeds *Feed
+)
+
+// Load initializes the feed constants
+//
+// Parameters:
+//
+//   - service: The recipes SQLite service
+//   - client: The Redis client
+//   - logger: The logger (optional, can be nil)
+func Load(
+	service *internalsqliterecipes.Service,
+	client *redis.Client,
+	logger *slog.Logger,
+) {
+	feed, err := NewFeed(service, client, FanOutMaxFollowers, logger)
+	if err != nil {
+		panic(err)
+	}
+	Feeds = feed
+}
@@ -0,0 +1,11 @@
+package feed
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilFeed           = errors.New("feed cannot be nil")
+	ErrNilRecipesService = errors.New("recipes service cannot be nil")
+	ErrNilRedisClient    = errors.New("redis client cannot be nil")
+)
@@ -0,0 +1,398 @@
+package feed
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Feed keeps the activity feed of each user in a Redis sorted set of event IDs. The events are written to the
+	// feeds of the followers when they are published (fan-out on write), except for the users with more followers
+	// than a threshold, whose events are read from the database when a feed is read (fan-out on read). When a user
+	// drops back to the threshold, their latest events are written to the feeds of their followers
+	Feed struct {
+		service      *internalsqliterecipes.Service
+		client       *redis.Client
+		maxFollowers int
+		logger       *slog.Logger
+	}
+)
+
+// NewFeed creates a new Feed
+//
+// Parameters:
+//
+//   - service: the recipes SQLite service
+//   - client: the Redis client
+//   - maxFollowers: the number of followers above which the events of a user are not fanned out on write
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Feed: the Feed instance
+//   - error: an error if the service or the client is nil
+func NewFeed(
+	service *internalsqliterecipes.Service,
+	client *redis.Client,
+	maxFollowers int,
+	logger *slog.Logger,
+) (*Feed, error) {
+	// Check if the service or the client is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+	if client == nil {
+		return nil, ErrNilRedisClient
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "feed"),
+		)
+	}
+
+	return &Feed{
+		service:      service,
+		client:       client,
+		maxFollowers: maxFollowers,
+		logger:       logger,
+	}, nil
+}
+
+// logError logs an error if the logger is set
+//
+// Parameters:
+//
+//   - msg: the log message
+//   - err: the error to log
+func (f *Feed) logError(msg string, err error) {
+	if f.logger != nil {
+		f.logger.Error(msg, slog.String("error", err.Error()))
+	}
+}
+
+// key returns the key of the sorted set that holds the feed of a user
+//
+// Parameters:
+//
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - string: the key
+func key(userID string) string {
+	return KeyPrefix + userID
+}
+
+// isPopular checks if the events of a user are read on demand instead of being fanned out
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - bool: true if the user has more followers than the threshold
+//   - error: an error if the followers could not be counted
+func (f *Feed) isPopular(ctx context.Context, userID string) (bool, error) {
+	count, err := f.service.CountFollowers(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return count > f.maxFollowers, nil
+}
+
+// add adds events to the feeds of the given users, trimming them to their maximum length
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userIDs: the IDs of the users
+//   - eventIDs: the IDs of the events
+//
+// Returns:
+//
+//   - error: an error if the feeds could not be updated
+func (f *Feed) add(ctx context.Context, userIDs []string, eventIDs []int) error {
+	if len(userIDs) == 0 || len(eventIDs) == 0 {
+		return nil
+	}
+
+	members := make([]*redis.Z, 0, len(eventIDs))
+	for _, eventID := range eventIDs {
+		members = append(
+			members, &redis.Z{
+				Score:  float64(eventID),
+				Member: strconv.Itoa(eventID),
+			},
+		)
+	}
+
+	_, err := f.client.Pipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			for _, userID := range userIDs {
+				pipe.ZAdd(ctx, key(userID), members...)
+				pipe.ZRemRangeByRank(ctx, key(userID), 0, -MaxLength-1)
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+// Publish announces a recipe to the followers of its owner the first time it becomes public. Failures are logged, the
+// publication of the recipe itself already succeeded
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipeID: the ID of the recipe
+func (f *Feed) Publish(ctx context.Context, recipeID int) {
+	if f == nil {
+		return
+	}
+
+	// Record the event
+	event, err := f.service.RecordFeedEvent(ctx, recipeID)
+	if err != nil || event == nil {
+		return
+	}
+
+	// The events of popular users are read on demand
+	popular, err := f.isPopular(ctx, event.ActorID)
+	if err != nil {
+		f.logError("Failed to count followers", err)
+		return
+	}
+	if popular {
+		return
+	}
+
+	// Write the event to the feeds of the followers
+	followerIDs, err := f.service.ListFollowerIDs(ctx, event.ActorID)
+	if err != nil {
+		f.logError("Failed to list followers", err)
+		return
+	}
+	if err = f.add(ctx, followerIDs, []int{event.ID}); err != nil {
+		f.logError("Failed to fan out feed event", err)
+	}
+}
+
+// Follow makes a user follow another user and fills the feed of the follower with the latest events of the followed
+// user. Failures filling the feed are logged
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - followerID: the ID of the user that follows
+//   - followeeID: the ID of the user to follow
+//
+// Returns:
+//
+//   - error: an error if the follow could not be stored
+func (f *Feed) Follow(ctx context.Context, followerID, followeeID string) error {
+	if f == nil {
+		return ErrNilFeed
+	}
+
+	if err := f.service.Follow(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+
+	// The events of popular users are read on demand
+	popular, err := f.isPopular(ctx, followeeID)
+	if err != nil {
+		f.logError("Failed to count followers", err)
+		return nil
+	}
+	if popular {
+		return nil
+	}
+
+	// Fill the feed of the follower
+	eventIDs, err := f.service.ListActorFeedEventIDs(ctx, followeeID, MaxLength)
+	if err != nil {
+		f.logError("Failed to list feed events", err)
+		return nil
+	}
+	if err = f.add(ctx, []string{followerID}, eventIDs); err != nil {
+		f.logError("Failed to fill feed", err)
+	}
+	return nil
+}
+
+// Unfollow makes a user stop following another user and removes the events of the followed user from the feed of the
+// follower. If the followed user stops being popular, their latest events are written to the feeds of the rest of
+// their followers. Failures updating the feeds are logged
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - followerID: the ID of the user that follows
+//   - followeeID: the ID of the followed user
+//
+// Returns:
+//
+//   - error: an error if the user was not followed or the follow could not be deleted
+func (f *Feed) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	if f == nil {
+		return ErrNilFeed
+	}
+
+	// Check if the followed user is popular before losing the follower
+	popular, err := f.isPopular(ctx, followeeID)
+	if err != nil {
+		f.logError("Failed to count followers", err)
+	}
+
+	if err = f.service.Unfollow(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+
+	// Clean the feed of the follower
+	eventIDs, err := f.service.ListActorFeedEventIDs(ctx, followeeID, MaxLength)
+	if err != nil {
+		f.logError("Failed to list feed events", err)
+		return nil
+	}
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(eventIDs))
+	for _, eventID := range eventIDs {
+		members = append(members, strconv.Itoa(eventID))
+	}
+	if err = f.client.ZRem(ctx, key(followerID), members...).Err(); err != nil {
+		f.logError("Failed to clean feed", err)
+	}
+
+	// The events published while the followed user was popular were only read on demand, and the users that followed
+	// them meanwhile did not get the older ones, so the latest events are fanned out once they stop being popular
+	if popular {
+		f.backfill(ctx, followeeID, eventIDs)
+	}
+	return nil
+}
+
+// backfill writes the latest events of a user to the feeds of their followers if the user is no longer popular.
+// Failures are logged
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - eventIDs: the IDs of the latest events of the user
+func (f *Feed) backfill(ctx context.Context, userID string, eventIDs []int) {
+	popular, err := f.isPopular(ctx, userID)
+	if err != nil {
+		f.logError("Failed to count followers", err)
+		return
+	}
+	if popular {
+		return
+	}
+
+	followerIDs, err := f.service.ListFollowerIDs(ctx, userID)
+	if err != nil {
+		f.logError("Failed to list followers", err)
+		return
+	}
+	if err = f.add(ctx, followerIDs, eventIDs); err != nil {
+		f.logError("Failed to fill feeds", err)
+	}
+}
+
+// Read reads the feed of a user, newest first. The events fanned out to the feed are merged with the events of the
+// popular users the user follows
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - before: the ID of the oldest event already read, or 0 to read from the newest event
+//   - limit: the maximum number of events to return
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.FeedEvent: the events
+//   - int: the cursor of the next page, or 0 if there are no more events
+//   - error: an error if the feed could not be read
+func (f *Feed) Read(
+	ctx context.Context,
+	userID string,
+	before int,
+	limit int,
+	language string,
+) ([]*internalrouterapiv1recipe.FeedEvent, int, error) {
+	if f == nil {
+		return nil, 0, ErrNilFeed
+	}
+
+	if before <= 0 {
+		before = math.MaxInt64
+	}
+
+	// Read the fanned out events
+	members, err := f.client.ZRevRangeByScore(
+		ctx, key(userID), &redis.ZRangeBy{
+			Max:   "(" + strconv.Itoa(before),
+			Min:   "-inf",
+			Count: int64(limit),
+		},
+	).Result()
+	if err != nil {
+		f.logError("Failed to read feed", err)
+		return nil, 0, err
+	}
+	eventIDs := make([]int, 0, len(members)+limit)
+	for _, member := range members {
+		eventID, parseErr := strconv.Atoi(member)
+		if parseErr != nil {
+			continue
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	// Read the events of the popular users
+	popularEventIDs, err := f.service.ListPopularFolloweesFeedEventIDs(
+		ctx,
+		userID,
+		f.maxFollowers,
+		before,
+		limit,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	eventIDs = append(eventIDs, popularEventIDs...)
+
+	// Merge them, newest first
+	slices.SortFunc(
+		eventIDs, func(a, b int) int {
+			return b - a
+		},
+	)
+	eventIDs = slices.Compact(eventIDs)
+	next := 0
+	if len(eventIDs) >= limit {
+		eventIDs = eventIDs[:limit]
+		next = eventIDs[limit-1]
+	}
+
+	events, err := f.service.ListFeedEvents(ctx, userID, eventIDs, language)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, next, nil
+}
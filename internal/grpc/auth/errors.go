@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttp "github.com/ralvarezdev/go-net/http"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+	gonethttpresponsejsendgrpc "github.com/ralvarezdev/go-net/http/response/jsend/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrForbidden          = errors.New("Forbidden")
+	ErrConflict           = errors.New("Conflict")
+	ErrPreconditionFailed = errors.New("Precondition Failed")
+)
+
+type (
+	// statusError is the error body, code and HTTP status a gRPC status code is mapped to
+	statusError struct {
+		err        error
+		code       internalerrorcodes.Code
+		httpStatus int
+	}
+)
+
+var (
+	// statusErrors maps the gRPC status codes returned by the auth service to the errors of this API
+	statusErrors = map[codes.Code]statusError{
+		codes.InvalidArgument: {
+			gonethttp.ErrBadRequest,
+			internalerrorcodes.BadRequest,
+			http.StatusBadRequest,
+		},
+		codes.Unauthenticated: {
+			gonethttp.ErrUnauthorized,
+			internalerrorcodes.Unauthorized,
+			http.StatusUnauthorized,
+		},
+		codes.PermissionDenied: {
+			ErrForbidden,
+			internalerrorcodes.Forbidden,
+			http.StatusForbidden,
+		},
+		codes.NotFound: {
+			gonethttp.ErrNotFound,
+			internalerrorcodes.NotFound,
+			http.StatusNotFound,
+		},
+		codes.AlreadyExists: {
+			ErrConflict,
+			internalerrorcodes.Conflict,
+			http.StatusConflict,
+		},
+		codes.FailedPrecondition: {
+			ErrPreconditionFailed,
+			internalerrorcodes.PreconditionFailed,
+			http.StatusPreconditionFailed,
+		},
+		codes.ResourceExhausted: {
+			gonethttp.ErrTooManyRequests,
+			internalerrorcodes.TooManyRequests,
+			http.StatusTooManyRequests,
+		},
+		codes.Unimplemented: {
+			gonethttp.ErrNotImplemented,
+			internalerrorcodes.NotImplemented,
+			http.StatusNotImplemented,
+		},
+		codes.Unavailable: {
+			gonethttp.ErrServiceUnavailable,
+			internalerrorcodes.ServiceUnavailable,
+			http.StatusServiceUnavailable,
+		},
+		codes.DeadlineExceeded: {
+			gonethttp.ErrRequestTimeout,
+			internalerrorcodes.RequestTimeout,
+			http.StatusGatewayTimeout,
+		},
+	}
+)
+
+// ParseError maps an error returned by the auth service to a JSend error with a stable code. Errors with details,
+// such as field violations, are parsed as fail errors, while the ones with only a status code are mapped through their
+// gRPC status code
+//
+// Parameters:
+//
+//   - err: the auth service error
+//   - parseAsValidations: whether to parse the bad request details as validation errors
+//
+// Returns:
+//
+//   - error: the JSend error
+func ParseError(err error, parseAsValidations bool) error {
+	st, ok := status.FromError(err)
+	if !ok || len(st.Details()) > 0 {
+		return gonethttpresponsejsendgrpc.ParseError(err, parseAsValidations)
+	}
+
+	// Map the gRPC status code
+	statusErr, ok := statusErrors[st.Code()]
+	if !ok {
+		return gonethttpresponsejsendgrpc.ParseError(err, parseAsValidations)
+	}
+	return gonethttpresponse.NewDebugErrorWithCode(
+		err,
+		statusErr.err,
+		statusErr.code.String(),
+		statusErr.httpStatus,
+	)
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"log/slog"
+	"os"
+	"time"
+)
+
+const (
+	// EnvFixturesDir is the environment variable for the directory served by the local fetcher instead of the web.
+	// When it is not set, the recipes are fetched over HTTP
+	EnvFixturesDir = "RECIPE_IMPORT_FIXTURES_DIR"
+
+	// FetchTimeout is the maximum time spent fetching a recipe page
+	FetchTimeout = 10 * time.Second
+
+	// MaxDocumentSize is the maximum size of an imported HTML document, in bytes
+	MaxDocumentSize = 2 << 20
+
+	// MaxSuggestedTags is the maximum number of tag labels suggested for an imported recipe
+	MaxSuggestedTags = 10
+
+	// MaxDurationMinutes is the longest duration parsed, in minutes. Longer durations are clamped to it, so absurd
+	// values cannot overflow the minutes
+	MaxDurationMinutes = 365 * 24 * 60
+)
+
+var (
+	// Fetcher is the fetcher used to download the recipe pages
+	Fetcher RecipeFetcher
+)
+
+// Load initializes the importer constants
+//
+// Parameters:
+//
+//   - logger: The logger (optional, can be nil)
+func Load(logger *slog.Logger) {
+	// Serve the pages from the fixtures directory if it is set
+	if dir, ok := os.LookupEnv(EnvFixturesDir); ok && dir != "" {
+		if logger != nil {
+			logger.Warn(
+				"Recipe import pages are served from a local directory",
+				slog.String("dir", dir),
+			)
+		}
+		Fetcher = NewDirectoryFetcher(dir)
+		return
+	}
+	Fetcher = NewHTTPFetcher(FetchTimeout, MaxDocumentSize)
+}
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// durationRegex matches an ISO-8601 duration, such as PT1H30M or P1DT2H
+	durationRegex = regexp.MustCompile(
+		`^P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`,
+	)
+
+	// durationUnitMinutes is the number of minutes of each unit of durationRegex, in the same order
+	durationUnitMinutes = []float64{7 * 24 * 60, 24 * 60, 60, 1, 1.0 / 60}
+)
+
+// ParseDuration parses an ISO-8601 duration into whole minutes, rounding up and clamping to MaxDurationMinutes. Plain
+// numbers, which some sites emit instead of a duration, are taken as minutes
+//
+// Parameters:
+//
+//   - value: the duration
+//
+// Returns:
+//
+//   - int: the duration in minutes
+//   - bool: false if the value is not a duration
+func ParseDuration(value string) (int, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, false
+	}
+
+	// Take plain numbers as minutes
+	if minutes, err := strconv.Atoi(value); (err == nil || errors.Is(err, strconv.ErrRange)) && minutes >= 0 {
+		return min(minutes, MaxDurationMinutes), true
+	}
+
+	matches := durationRegex.FindStringSubmatch(value)
+	if matches == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, false
+	}
+
+	var minutes float64
+	for i, match := range matches[1:] {
+		if match == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(strings.Replace(match, ",", ".", 1), 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return 0, false
+		}
+		minutes += amount * durationUnitMinutes[i]
+	}
+	return int(math.Ceil(math.Min(minutes, MaxDurationMinutes))), true
+}
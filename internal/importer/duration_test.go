@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		minutes int
+		ok      bool
+	}{
+		// Valid forms
+		{name: "minutes", value: "PT30M", minutes: 30, ok: true},
+		{name: "hours and minutes", value: "PT1H30M", minutes: 90, ok: true},
+		{name: "days and hours", value: "P1DT2H", minutes: 1560, ok: true},
+		{name: "weeks", value: "P1W", minutes: 10080, ok: true},
+		{name: "seconds rounded up", value: "PT90S", minutes: 2, ok: true},
+		{name: "decimal point", value: "PT0.5H", minutes: 30, ok: true},
+		{name: "decimal comma", value: "PT1,5H", minutes: 90, ok: true},
+		{name: "lowercase", value: "pt15m", minutes: 15, ok: true},
+		{name: "surrounding spaces", value: "  PT15M ", minutes: 15, ok: true},
+		{name: "zero", value: "PT0S", minutes: 0, ok: true},
+		{name: "plain minutes", value: "45", minutes: 45, ok: true},
+		{name: "plain zero", value: "0", minutes: 0, ok: true},
+
+		// Malformed forms
+		{name: "empty", value: ""},
+		{name: "blank", value: "   "},
+		{name: "designator only", value: "P"},
+		{name: "time designator only", value: "PT"},
+		{name: "trailing time designator", value: "P1DT"},
+		{name: "missing designator", value: "1H"},
+		{name: "time unit without time designator", value: "P1H"},
+		{name: "units out of order", value: "PT1M30H"},
+		{name: "negative amount", value: "PT-5M"},
+		{name: "negative plain minutes", value: "-5"},
+		{name: "several decimal points", value: "PT1.5.2M"},
+		{name: "text", value: "about an hour"},
+
+		// Limits
+		{name: "longest duration", value: "P52W", minutes: 524160, ok: true},
+		{name: "clamped duration", value: "P53W", minutes: MaxDurationMinutes, ok: true},
+		{name: "clamped overflowing weeks", value: "P99999999999999999999W", minutes: MaxDurationMinutes, ok: true},
+		{
+			name:    "clamped amount out of float range",
+			value:   "PT" + strings.Repeat("9", 400) + "S",
+			minutes: MaxDurationMinutes,
+			ok:      true,
+		},
+		{name: "clamped plain minutes", value: "9223372036854775807", minutes: MaxDurationMinutes, ok: true},
+		{name: "clamped overflowing plain minutes", value: "99999999999999999999", minutes: MaxDurationMinutes, ok: true},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				minutes, ok := ParseDuration(test.value)
+				if minutes != test.minutes || ok != test.ok {
+					t.Errorf(
+						"ParseDuration(%q) = %d, %t, want %d, %t",
+						test.value,
+						minutes,
+						ok,
+						test.minutes,
+						test.ok,
+					)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrMissingSource    = errors.New("either a url or an html document must be provided")
+	ErrAmbiguousSource  = errors.New("only one of url or html can be provided")
+	ErrInvalidURL       = errors.New("url must be an absolute http or https url")
+	ErrForbiddenHost    = errors.New("url host is not allowed")
+	ErrFetchFailed      = errors.New("recipe page could not be fetched")
+	ErrDocumentTooLarge = errors.New("html document is too large")
+	ErrRecipeNotFound   = errors.New("no schema.org recipe found in the html document")
+	ErrNilFetcher       = errors.New("recipe fetcher cannot be nil")
+)
+
+// ParseError maps an importer error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the importer error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	switch {
+	case errors.Is(err, ErrMissingSource):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"url",
+			err,
+			internalerrorcodes.MissingImportSource.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrAmbiguousSource):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"url",
+			err,
+			internalerrorcodes.AmbiguousImportSource.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrInvalidURL):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"url",
+			err,
+			internalerrorcodes.InvalidImportURL.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrForbiddenHost):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"url",
+			err,
+			internalerrorcodes.ForbiddenImportHost.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrFetchFailed):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"url",
+			err,
+			internalerrorcodes.ImportFetchFailed.String(),
+			http.StatusBadGateway,
+		)
+	case errors.Is(err, ErrDocumentTooLarge):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"html",
+			err,
+			internalerrorcodes.DocumentTooLarge.String(),
+			http.StatusRequestEntityTooLarge,
+		)
+	case errors.Is(err, ErrRecipeNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"html",
+			err,
+			internalerrorcodes.ImportedRecipeNotFound.String(),
+			http.StatusUnprocessableEntity,
+		)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,330 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// RecipeType is the schema.org type of a recipe
+	RecipeType = "Recipe"
+)
+
+// extractRecipe finds the first schema.org recipe of an HTML document, looking at the JSON-LD scripts first and
+// then at the microdata. Both are returned as the same JSON-LD shaped map
+//
+// Parameters:
+//
+//   - document: the HTML document
+//
+// Returns:
+//
+//   - map[string]any: the schema.org recipe properties
+//   - error: an error if the document holds no recipe
+func extractRecipe(document []byte) (map[string]any, error) {
+	root, err := html.Parse(bytes.NewReader(document))
+	if err != nil {
+		return nil, ErrRecipeNotFound
+	}
+
+	// Look for the recipe in the JSON-LD scripts
+	var scripts []string
+	var microdata map[string]any
+	walkNodes(
+		root, func(n *html.Node) bool {
+			if n.DataAtom == atom.Script && strings.EqualFold(
+				strings.TrimSpace(getAttr(n, "type")),
+				"application/ld+json",
+			) {
+				scripts = append(scripts, nodeText(n))
+				return false
+			}
+			if microdata == nil && isMicrodataRecipe(n) {
+				microdata = parseMicrodataItem(n)
+				return false
+			}
+			return true
+		},
+	)
+
+	for _, script := range scripts {
+		var data any
+		if err = json.Unmarshal([]byte(script), &data); err != nil {
+			continue
+		}
+		if recipe := findJSONLDRecipe(data); recipe != nil {
+			return recipe, nil
+		}
+	}
+
+	// Fall back to the microdata
+	if microdata != nil {
+		return microdata, nil
+	}
+	return nil, ErrRecipeNotFound
+}
+
+// findJSONLDRecipe finds a recipe in a decoded JSON-LD value, looking into arrays, @graph and mainEntity
+//
+// Parameters:
+//
+//   - data: the decoded JSON-LD value
+//
+// Returns:
+//
+//   - map[string]any: the recipe, or nil if there is none
+func findJSONLDRecipe(data any) map[string]any {
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			if recipe := findJSONLDRecipe(item); recipe != nil {
+				return recipe
+			}
+		}
+	case map[string]any:
+		if hasType(value["@type"], RecipeType) {
+			return value
+		}
+		for _, key := range []string{"@graph", "mainEntity", "mainEntityOfPage"} {
+			if recipe := findJSONLDRecipe(value[key]); recipe != nil {
+				return recipe
+			}
+		}
+	}
+	return nil
+}
+
+// hasType checks if a JSON-LD @type value, a string or an array of strings, includes the given type
+//
+// Parameters:
+//
+//   - value: the @type value
+//   - schemaType: the schema.org type
+//
+// Returns:
+//
+//   - bool: true if the type is included
+func hasType(value any, schemaType string) bool {
+	switch typed := value.(type) {
+	case string:
+		return typed == schemaType || strings.HasSuffix(typed, "/"+schemaType)
+	case []any:
+		for _, item := range typed {
+			if hasType(item, schemaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isMicrodataRecipe checks if a node is the root of a schema.org recipe microdata item
+//
+// Parameters:
+//
+//   - n: the node
+//
+// Returns:
+//
+//   - bool: true if the node is a recipe item
+func isMicrodataRecipe(n *html.Node) bool {
+	if n.Type != html.ElementNode || !hasAttr(n, "itemscope") {
+		return false
+	}
+	for _, itemType := range strings.Fields(getAttr(n, "itemtype")) {
+		if hasType(itemType, RecipeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMicrodataItem collects the properties of a microdata item. Nested items become nested maps and repeated
+// properties become arrays, matching the JSON-LD shape
+//
+// Parameters:
+//
+//   - item: the node with the itemscope attribute
+//
+// Returns:
+//
+//   - map[string]any: the item properties
+func parseMicrodataItem(item *html.Node) map[string]any {
+	properties := map[string]any{}
+	if itemType := getAttr(item, "itemtype"); itemType != "" {
+		properties["@type"] = itemType
+	}
+
+	for child := item.FirstChild; child != nil; child = child.NextSibling {
+		walkNodes(
+			child, func(n *html.Node) bool {
+				if n.Type != html.ElementNode {
+					return true
+				}
+
+				// Get the value of the property, nested items own their descendants
+				names := strings.Fields(getAttr(n, "itemprop"))
+				nested := hasAttr(n, "itemscope")
+				if len(names) > 0 {
+					var value any
+					if nested {
+						value = parseMicrodataItem(n)
+					} else {
+						value = microdataValue(n)
+					}
+					for _, name := range names {
+						addProperty(properties, name, value)
+					}
+				}
+				return !nested
+			},
+		)
+	}
+	return properties
+}
+
+// addProperty adds a value to a property, turning it into an array when it is repeated
+//
+// Parameters:
+//
+//   - properties: the item properties
+//   - name: the property name
+//   - value: the property value
+func addProperty(properties map[string]any, name string, value any) {
+	current, ok := properties[name]
+	if !ok {
+		properties[name] = value
+		return
+	}
+	if values, isArray := current.([]any); isArray {
+		properties[name] = append(values, value)
+		return
+	}
+	properties[name] = []any{current, value}
+}
+
+// microdataValue gets the value of a microdata property as defined by the HTML microdata specification
+//
+// Parameters:
+//
+//   - n: the property node
+//
+// Returns:
+//
+//   - string: the property value
+func microdataValue(n *html.Node) string {
+	if hasAttr(n, "content") {
+		return getAttr(n, "content")
+	}
+	switch n.DataAtom {
+	case atom.A, atom.Area, atom.Link:
+		return getAttr(n, "href")
+	case atom.Img, atom.Audio, atom.Video, atom.Source, atom.Embed, atom.Iframe:
+		return getAttr(n, "src")
+	case atom.Object:
+		return getAttr(n, "data")
+	case atom.Data, atom.Meter:
+		return getAttr(n, "value")
+	case atom.Time:
+		if hasAttr(n, "datetime") {
+			return getAttr(n, "datetime")
+		}
+	}
+	return nodeText(n)
+}
+
+// walkNodes walks a node tree depth-first, skipping the descendants of the nodes for which visit returns false
+//
+// Parameters:
+//
+//   - n: the root node
+//   - visit: the function called for each node
+func walkNodes(n *html.Node, visit func(n *html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		walkNodes(child, visit)
+	}
+}
+
+// nodeText gets the text content of a node, with the whitespace collapsed and the line breaks and block boundaries
+// kept as new lines
+//
+// Parameters:
+//
+//   - n: the node
+//
+// Returns:
+//
+//   - string: the text content
+func nodeText(n *html.Node) string {
+	var builder strings.Builder
+	walkNodes(
+		n, func(node *html.Node) bool {
+			switch {
+			case node.Type == html.TextNode:
+				builder.WriteString(node.Data)
+			case node.DataAtom == atom.Br, node.DataAtom == atom.P, node.DataAtom == atom.Li,
+				node.DataAtom == atom.Div:
+				builder.WriteByte('\n')
+			}
+			return true
+		},
+	)
+
+	// Scripts keep their content as is, it is JSON
+	if n.DataAtom == atom.Script {
+		return builder.String()
+	}
+
+	var lines []string
+	for _, line := range strings.Split(builder.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// getAttr gets the value of an attribute of a node
+//
+// Parameters:
+//
+//   - n: the node
+//   - key: the attribute name
+//
+// Returns:
+//
+//   - string: the attribute value, or an empty string if it is missing
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr checks if a node has an attribute
+//
+// Parameters:
+//
+//   - n: the node
+//   - key: the attribute name
+//
+// Returns:
+//
+//   - bool: true if the attribute is present
+func hasAttr(n *html.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package importer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractRecipe(t *testing.T) {
+	tests := []struct {
+		name       string
+		document   string
+		properties map[string]any
+		err        error
+	}{
+		// JSON-LD
+		{
+			name:       "json-ld recipe",
+			document:   `<script type="application/ld+json">{"@type":"Recipe","name":"Arepas"}</script>`,
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+		{
+			name:       "json-ld type in a different case of the script type",
+			document:   `<script type=" Application/LD+JSON ">{"@type":"Recipe","name":"Arepas"}</script>`,
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+		{
+			name:       "json-ld type as a url",
+			document:   `<script type="application/ld+json">{"@type":"https://schema.org/Recipe","name":"Arepas"}</script>`,
+			properties: map[string]any{"@type": "https://schema.org/Recipe", "name": "Arepas"},
+		},
+		{
+			name:       "json-ld type as an array",
+			document:   `<script type="application/ld+json">{"@type":["Thing","Recipe"],"name":"Arepas"}</script>`,
+			properties: map[string]any{"@type": []any{"Thing", "Recipe"}, "name": "Arepas"},
+		},
+		{
+			name: "json-ld recipe in a graph",
+			document: `<script type="application/ld+json">
+{"@graph":[{"@type":"WebPage","name":"Page"},{"@type":"Recipe","name":"Arepas"}]}
+</script>`,
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+		{
+			name: "json-ld recipe as the main entity",
+			document: `<script type="application/ld+json">
+[{"@type":"WebPage","mainEntity":{"@type":"Recipe","name":"Arepas"}}]
+</script>`,
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+		{
+			name: "json-ld preferred over microdata",
+			document: `<div itemscope itemtype="https://schema.org/Recipe"><h1 itemprop="name">Cachapas</h1></div>
+<script type="application/ld+json">{"@type":"Recipe","name":"Arepas"}</script>`,
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+
+		// Microdata
+		{
+			name: "microdata recipe",
+			document: `<div itemscope itemtype="https://schema.org/Recipe">
+<h1 itemprop="name"> Cachapas </h1>
+<meta itemprop="prepTime" content="PT10M">
+<time itemprop="cookTime" datetime="PT20M">20 minutes</time>
+<img itemprop="image" src="https://example.com/cachapas.jpg">
+<ul><li itemprop="recipeIngredient">2 cups corn</li><li itemprop="recipeIngredient">1 cup cheese</li></ul>
+<div itemprop="recipeInstructions">Blend<br>Cook</div>
+<div itemprop="author" itemscope itemtype="https://schema.org/Person"><span itemprop="name">Ana</span></div>
+</div>`,
+			properties: map[string]any{
+				"@type":              "https://schema.org/Recipe",
+				"name":               "Cachapas",
+				"prepTime":           "PT10M",
+				"cookTime":           "PT20M",
+				"image":              "https://example.com/cachapas.jpg",
+				"recipeIngredient":   []any{"2 cups corn", "1 cup cheese"},
+				"recipeInstructions": "Blend\nCook",
+				"author":             map[string]any{"@type": "https://schema.org/Person", "name": "Ana"},
+			},
+		},
+		{
+			name:       "json-ld without a recipe falls back to microdata",
+			document:   `<script type="application/ld+json">{"@type":"WebPage"}</script><p itemscope itemtype="http://schema.org/Recipe"><span itemprop="name">Cachapas</span></p>`,
+			properties: map[string]any{"@type": "http://schema.org/Recipe", "name": "Cachapas"},
+		},
+
+		// Malformed documents
+		{
+			name: "malformed json-ld skipped",
+			document: `<script type="application/ld+json">{"@type":"Recipe",</script>
+<script type="application/ld+json">{"@type":"Recipe","name":"Arepas"}</script>`,
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+		{
+			name:     "only malformed json-ld",
+			document: `<script type="application/ld+json">{"@type":"Recipe",</script>`,
+			err:      ErrRecipeNotFound,
+		},
+		{
+			name:     "json-ld script of another type",
+			document: `<script type="text/javascript">{"@type":"Recipe","name":"Arepas"}</script>`,
+			err:      ErrRecipeNotFound,
+		},
+		{
+			name:     "microdata item of another type",
+			document: `<div itemscope itemtype="https://schema.org/Person"><span itemprop="name">Ana</span></div>`,
+			err:      ErrRecipeNotFound,
+		},
+		{
+			name:     "no recipe",
+			document: `<html><body><p>Hello</p></body></html>`,
+			err:      ErrRecipeNotFound,
+		},
+		{
+			name:     "empty",
+			document: ``,
+			err:      ErrRecipeNotFound,
+		},
+
+		// Limits
+		{
+			name: "deeply nested recipe",
+			document: strings.Repeat("<div>", 500) +
+				`<script type="application/ld+json">{"@type":"Recipe","name":"Arepas"}</script>` +
+				strings.Repeat("</div>", 500),
+			properties: map[string]any{"@type": "Recipe", "name": "Arepas"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				properties, err := extractRecipe([]byte(test.document))
+				if !errors.Is(err, test.err) {
+					t.Fatalf("extractRecipe() error = %v, want %v", err, test.err)
+				}
+				if !reflect.DeepEqual(properties, test.properties) {
+					t.Errorf("extractRecipe() = %#v, want %#v", properties, test.properties)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+type (
+	// RecipeFetcher downloads the HTML document of a recipe page
+	RecipeFetcher interface {
+		Fetch(ctx context.Context, pageURL *url.URL) ([]byte, error)
+	}
+
+	// HTTPFetcher fetches the recipe pages from the web, refusing to connect to private or loopback addresses
+	HTTPFetcher struct {
+		client  *http.Client
+		maxSize int64
+	}
+
+	// DirectoryFetcher serves the recipe pages from a local directory, used to stub the web in development.
+	// The page https://example.com/recipes/arepas is read from <dir>/example.com/recipes/arepas.html
+	DirectoryFetcher struct {
+		dir string
+	}
+)
+
+// ParseURL parses and checks the URL of a recipe page
+//
+// Parameters:
+//
+//   - rawURL: the raw URL
+//
+// Returns:
+//
+//   - *url.URL: the parsed URL
+//   - error: an error if the URL is not an absolute HTTP or HTTPS URL
+func ParseURL(rawURL string) (*url.URL, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil || pageURL.Host == "" {
+		return nil, ErrInvalidURL
+	}
+	if pageURL.Scheme != "http" && pageURL.Scheme != "https" {
+		return nil, ErrInvalidURL
+	}
+	return pageURL, nil
+}
+
+// NewHTTPFetcher creates a new HTTPFetcher
+//
+// Parameters:
+//
+//   - timeout: the maximum time spent fetching a page
+//   - maxSize: the maximum size of a page, in bytes
+//
+// Returns:
+//
+//   - *HTTPFetcher: the HTTPFetcher instance
+func NewHTTPFetcher(timeout time.Duration, maxSize int64) *HTTPFetcher {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		},
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+		},
+		maxSize: maxSize,
+	}
+}
+
+// checkPublicAddress checks that the resolved address of a connection is a public one
+//
+// Parameters:
+//
+//   - address: the resolved host and port
+//
+// Returns:
+//
+//   - error: an error if the address is private, loopback, link-local or unspecified
+func checkPublicAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrForbiddenHost
+	}
+	ip := net.ParseIP(host)
+	if ip == nil ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsMulticast() {
+		return ErrForbiddenHost
+	}
+	return nil
+}
+
+// Fetch downloads a recipe page
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - pageURL: the URL of the page
+//
+// Returns:
+//
+//   - []byte: the HTML document
+//   - error: an error if the page could not be fetched
+func (h *HTTPFetcher) Fetch(ctx context.Context, pageURL *url.URL) (
+	[]byte,
+	error,
+) {
+	// Check if the fetcher is nil
+	if h == nil {
+		return nil, ErrNilFetcher
+	}
+
+	// Build the request
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		pageURL.String(),
+		nil,
+	)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "uru-mobiles-recipes-api/1.0 (+recipe import)")
+
+	// Fetch the page
+	res, err := h.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrForbiddenHost) {
+			return nil, ErrForbiddenHost
+		}
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrFetchFailed, res.StatusCode)
+	}
+
+	// Read the page, one byte past the limit to detect oversized documents
+	document, err := io.ReadAll(io.LimitReader(res.Body, h.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	if int64(len(document)) > h.maxSize {
+		return nil, ErrDocumentTooLarge
+	}
+	return document, nil
+}
+
+// NewDirectoryFetcher creates a new DirectoryFetcher
+//
+// Parameters:
+//
+//   - dir: the directory that holds the pages
+//
+// Returns:
+//
+//   - *DirectoryFetcher: the DirectoryFetcher instance
+func NewDirectoryFetcher(dir string) *DirectoryFetcher {
+	return &DirectoryFetcher{dir: dir}
+}
+
+// Fetch reads a recipe page from the directory
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - pageURL: the URL of the page
+//
+// Returns:
+//
+//   - []byte: the HTML document
+//   - error: an error if the page does not exist
+func (d *DirectoryFetcher) Fetch(_ context.Context, pageURL *url.URL) (
+	[]byte,
+	error,
+) {
+	// Check if the fetcher is nil
+	if d == nil {
+		return nil, ErrNilFetcher
+	}
+
+	// Map the URL to a file, cleaning the path so it cannot leave the host directory
+	host := pageURL.Hostname()
+	if strings.Trim(host, ".") == "" {
+		return nil, ErrInvalidURL
+	}
+	name := path.Clean("/" + pageURL.Path)
+	if name == "/" {
+		name = "/index"
+	}
+	if path.Ext(name) == "" {
+		name += ".html"
+	}
+	filename := filepath.Join(d.dir, host, filepath.FromSlash(name))
+
+	document, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: status %d", ErrFetchFailed, http.StatusNotFound)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	if len(document) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+	return document, nil
+}
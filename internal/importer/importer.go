@@ -0,0 +1,331 @@
+package importer
+
+import (
+	"context"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+var (
+	// integerRegex matches the first integer of a text, used to read the servings out of a yield like "4 servings"
+	integerRegex = regexp.MustCompile(`\d+`)
+
+	// tagsRegex matches the HTML tags embedded in some JSON-LD texts
+	tagsRegex = regexp.MustCompile(`<[^>]*>`)
+)
+
+type (
+	// Draft is a recipe read from a web page, returned for the user to confirm before it is saved
+	Draft struct {
+		Recipe *internalrouterapiv1recipe.Recipe
+		Tags   []string // labels taken from the cuisine, the category and the keywords
+	}
+)
+
+// Import reads the schema.org recipe of a web page, either fetched from its URL or given as an uploaded HTML document
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - fetcher: the fetcher used to download the page
+//   - rawURL: the URL of the page, empty if the document is given
+//   - document: the HTML document, empty if the URL is given
+//
+// Returns:
+//
+//   - *Draft: the recipe draft
+//   - error: an error if the page could not be fetched or holds no recipe
+func Import(
+	ctx context.Context,
+	fetcher RecipeFetcher,
+	rawURL, document string,
+) (*Draft, error) {
+	// Check the source
+	if rawURL == "" && document == "" {
+		return nil, ErrMissingSource
+	}
+	if rawURL != "" && document != "" {
+		return nil, ErrAmbiguousSource
+	}
+
+	content := []byte(document)
+	if rawURL != "" {
+		// Check if the fetcher is nil
+		if fetcher == nil {
+			return nil, ErrNilFetcher
+		}
+
+		// Fetch the page
+		pageURL, err := ParseURL(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		content, err = fetcher.Fetch(ctx, pageURL)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(content) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	// Extract the recipe
+	properties, err := extractRecipe(content)
+	if err != nil {
+		return nil, err
+	}
+	draft := mapRecipe(properties)
+	if rawURL != "" {
+		draft.Recipe.SourceURL = rawURL
+	} else if sourceURL := textValue(properties["url"]); sourceURL != "" {
+		if _, err = ParseURL(sourceURL); err == nil {
+			draft.Recipe.SourceURL = sourceURL
+		}
+	}
+
+	// Map the image, resolving it against the page URL when it is relative
+	if image := imageValue(properties["image"]); image != "" {
+		imageURL, parseErr := url.Parse(image)
+		if parseErr == nil && draft.Recipe.SourceURL != "" {
+			if baseURL, baseErr := url.Parse(draft.Recipe.SourceURL); baseErr == nil {
+				imageURL = baseURL.ResolveReference(imageURL)
+			}
+		}
+		if parseErr == nil {
+			if _, err = ParseURL(imageURL.String()); err == nil {
+				draft.Recipe.ImageURL = imageURL.String()
+			}
+		}
+	}
+	return draft, nil
+}
+
+// mapRecipe maps the schema.org recipe properties to a recipe draft
+//
+// Parameters:
+//
+//   - properties: the schema.org recipe properties
+//
+// Returns:
+//
+//   - *Draft: the recipe draft
+func mapRecipe(properties map[string]any) *Draft {
+	recipe := &internalrouterapiv1recipe.Recipe{
+		Name:        textValue(properties["name"]),
+		Description: textValue(properties["description"]),
+		Ingredients: []internalrouterapiv1recipe.Ingredient{},
+		Steps:       instructionSteps(properties["recipeInstructions"]),
+		Tags:        []internalrouterapiv1recipe.Tag{},
+		Servings:    yieldServings(properties["recipeYield"]),
+	}
+	if recipe.Name == "" {
+		recipe.Name = textValue(properties["headline"])
+	}
+
+	// Map the ingredients, older pages use the deprecated ingredients property
+	ingredients := listValue(properties["recipeIngredient"])
+	if len(ingredients) == 0 {
+		ingredients = listValue(properties["ingredients"])
+	}
+	for _, line := range ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, ParseIngredient(line))
+	}
+
+	// Map the times, deriving the cooking time from the total time when it is missing
+	prepTime, _ := ParseDuration(textValue(properties["prepTime"]))
+	cookTime, hasCookTime := ParseDuration(textValue(properties["cookTime"]))
+	totalTime, hasTotalTime := ParseDuration(textValue(properties["totalTime"]))
+	if !hasCookTime && hasTotalTime && totalTime > prepTime {
+		cookTime = totalTime - prepTime
+	}
+	recipe.PreparationTime = prepTime
+	recipe.CookingTime = cookTime
+
+	// Suggest the tags, without duplicates
+	tags := []string{}
+	seen := map[string]struct{}{}
+	for _, key := range []string{"recipeCuisine", "recipeCategory", "keywords"} {
+		for _, value := range listValue(properties[key]) {
+			for _, label := range strings.Split(value, ",") {
+				label = strings.TrimSpace(label)
+				normalized := strings.ToLower(label)
+				if _, ok := seen[normalized]; ok || label == "" {
+					continue
+				}
+				if len(tags) == MaxSuggestedTags {
+					break
+				}
+				seen[normalized] = struct{}{}
+				tags = append(tags, label)
+			}
+		}
+	}
+
+	return &Draft{Recipe: recipe, Tags: tags}
+}
+
+// instructionSteps maps the recipeInstructions property, a text, a list of texts or a list of HowToStep and
+// HowToSection items, to the recipe steps
+//
+// Parameters:
+//
+//   - value: the recipeInstructions value
+//
+// Returns:
+//
+//   - []string: the steps
+func instructionSteps(value any) []string {
+	steps := []string{}
+	switch typed := value.(type) {
+	case string:
+		// Split the text in lines, some pages separate the steps with paragraphs or line breaks
+		text := strings.NewReplacer(
+			"</p>", "\n",
+			"<br>", "\n",
+			"<br/>", "\n",
+			"<br />", "\n",
+			"</li>", "\n",
+		).Replace(typed)
+		for _, line := range strings.Split(text, "\n") {
+			if step := cleanText(line); step != "" {
+				steps = append(steps, step)
+			}
+		}
+	case []any:
+		for _, item := range typed {
+			steps = append(steps, instructionSteps(item)...)
+		}
+	case map[string]any:
+		if hasType(typed["@type"], "HowToSection") {
+			return instructionSteps(typed["itemListElement"])
+		}
+		if step := textValue(typed); step != "" {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// yieldServings reads the number of servings of the recipeYield property, such as 4, "4" or "4 servings"
+//
+// Parameters:
+//
+//   - value: the recipeYield value
+//
+// Returns:
+//
+//   - int: the number of servings, or 0 if it is missing
+func yieldServings(value any) int {
+	for _, text := range listValue(value) {
+		if match := integerRegex.FindString(text); match != "" {
+			servings, err := strconv.Atoi(match)
+			if err == nil {
+				return servings
+			}
+		}
+	}
+	return 0
+}
+
+// textValue reads a text out of a schema.org value: a text, a number, a list whose first text is taken, or an
+// item with a text, name or @value property
+//
+// Parameters:
+//
+//   - value: the schema.org value
+//
+// Returns:
+//
+//   - string: the text, or an empty string if there is none
+func textValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return cleanText(typed)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case []any:
+		for _, item := range typed {
+			if text := textValue(item); text != "" {
+				return text
+			}
+		}
+	case map[string]any:
+		for _, key := range []string{"text", "name", "@value"} {
+			if text := textValue(typed[key]); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// imageValue reads the URL of the first image of a schema.org image value: a URL, a list or an ImageObject
+//
+// Parameters:
+//
+//   - value: the schema.org image value
+//
+// Returns:
+//
+//   - string: the image URL, or an empty string if there is none
+func imageValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return strings.TrimSpace(typed)
+	case []any:
+		for _, item := range typed {
+			if image := imageValue(item); image != "" {
+				return image
+			}
+		}
+	case map[string]any:
+		for _, key := range []string{"url", "contentUrl", "@id"} {
+			if image := imageValue(typed[key]); image != "" {
+				return image
+			}
+		}
+	}
+	return ""
+}
+
+// listValue reads a list of texts out of a schema.org value, which can be a single value or a list
+//
+// Parameters:
+//
+//   - value: the schema.org value
+//
+// Returns:
+//
+//   - []string: the texts
+func listValue(value any) []string {
+	items, ok := value.([]any)
+	if !ok {
+		items = []any{value}
+	}
+
+	var texts []string
+	for _, item := range items {
+		if text := textValue(item); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// cleanText removes the HTML tags and entities of a text and collapses its whitespace
+//
+// Parameters:
+//
+//   - text: the text
+//
+// Returns:
+//
+//   - string: the clean text
+func cleanText(text string) string {
+	text = html.UnescapeString(tagsRegex.ReplaceAllString(text, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
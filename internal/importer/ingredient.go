@@ -0,0 +1,193 @@
+package importer
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+var (
+	// quantityRegex matches the leading quantity of an ingredient line: an integer, a decimal, a fraction, a mixed
+	// number or a range, whose lower bound is kept
+	quantityRegex = regexp.MustCompile(
+		`^(?:(\d+)\s*/\s*(\d+)|(\d+(?:[.,]\d+)?)(?:\s+(\d+)\s*/\s*(\d+)|\s*([¼½¾⅓⅔⅛⅜⅝⅞]))?|([¼½¾⅓⅔⅛⅜⅝⅞]))` +
+			`(?:\s*(?:-|–|to|a)\s*\d+(?:[.,/]\d+)?[¼½¾⅓⅔⅛⅜⅝⅞]?)?`,
+	)
+
+	// vulgarFractions are the values of the unicode fraction characters
+	vulgarFractions = map[string]float64{
+		"¼": 0.25,
+		"½": 0.5,
+		"¾": 0.75,
+		"⅓": 1.0 / 3,
+		"⅔": 2.0 / 3,
+		"⅛": 0.125,
+		"⅜": 0.375,
+		"⅝": 0.625,
+		"⅞": 0.875,
+	}
+
+	// units maps the spellings of the units, in English and Spanish, to their abbreviation
+	units = map[string]string{
+		"cup":          "cup",
+		"cups":         "cup",
+		"c":            "cup",
+		"taza":         "cup",
+		"tazas":        "cup",
+		"tablespoon":   "tbsp",
+		"tablespoons":  "tbsp",
+		"tbsp":         "tbsp",
+		"tbs":          "tbsp",
+		"cucharada":    "tbsp",
+		"cucharadas":   "tbsp",
+		"teaspoon":     "tsp",
+		"teaspoons":    "tsp",
+		"tsp":          "tsp",
+		"cucharadita":  "tsp",
+		"cucharaditas": "tsp",
+		"g":            "g",
+		"gr":           "g",
+		"gram":         "g",
+		"grams":        "g",
+		"gramo":        "g",
+		"gramos":       "g",
+		"kg":           "kg",
+		"kilo":         "kg",
+		"kilos":        "kg",
+		"kilogram":     "kg",
+		"kilograms":    "kg",
+		"kilogramo":    "kg",
+		"kilogramos":   "kg",
+		"mg":           "mg",
+		"ml":           "ml",
+		"milliliter":   "ml",
+		"milliliters":  "ml",
+		"millilitre":   "ml",
+		"millilitres":  "ml",
+		"mililitro":    "ml",
+		"mililitros":   "ml",
+		"l":            "l",
+		"liter":        "l",
+		"liters":       "l",
+		"litre":        "l",
+		"litres":       "l",
+		"litro":        "l",
+		"litros":       "l",
+		"oz":           "oz",
+		"ounce":        "oz",
+		"ounces":       "oz",
+		"onza":         "oz",
+		"onzas":        "oz",
+		"fl oz":        "fl oz",
+		"lb":           "lb",
+		"lbs":          "lb",
+		"pound":        "lb",
+		"pounds":       "lb",
+		"libra":        "lb",
+		"libras":       "lb",
+		"pinch":        "pinch",
+		"pinches":      "pinch",
+		"pizca":        "pinch",
+		"pizcas":       "pinch",
+		"clove":        "clove",
+		"cloves":       "clove",
+		"diente":       "clove",
+		"dientes":      "clove",
+		"can":          "can",
+		"cans":         "can",
+		"lata":         "can",
+		"latas":        "can",
+		"slice":        "slice",
+		"slices":       "slice",
+		"rebanada":     "slice",
+		"rebanadas":    "slice",
+	}
+)
+
+// ParseIngredient splits an ingredient line such as "1 1/2 cups of flour" into its quantity, unit and name. Lines
+// without a usable quantity, such as "salt to taste" or one too large to be a number, are kept whole as the name
+//
+// Parameters:
+//
+//   - line: the ingredient line
+//
+// Returns:
+//
+//   - internalrouterapiv1recipe.Ingredient: the ingredient
+func ParseIngredient(line string) internalrouterapiv1recipe.Ingredient {
+	line = strings.Join(strings.Fields(line), " ")
+
+	// Parse the quantity
+	match := quantityRegex.FindStringSubmatch(line)
+	if match == nil {
+		return internalrouterapiv1recipe.Ingredient{Name: line}
+	}
+	quantity := parseFraction(match[1], match[2])
+	if match[3] != "" {
+		quantity, _ = strconv.ParseFloat(strings.Replace(match[3], ",", ".", 1), 64)
+	}
+	quantity += parseFraction(match[4], match[5])
+	quantity += vulgarFractions[match[6]] + vulgarFractions[match[7]]
+	if quantity == 0 || math.IsInf(quantity, 0) {
+		return internalrouterapiv1recipe.Ingredient{Name: line}
+	}
+	line = strings.TrimSpace(line[len(match[0]):])
+
+	// Parse the unit, which can be glued to the quantity as in "200g"
+	var unit string
+	for _, length := range []int{2, 1} {
+		words := strings.SplitN(line, " ", length+1)
+		if len(words) < length {
+			continue
+		}
+		candidate := strings.ToLower(
+			strings.TrimSuffix(
+				strings.Join(words[:length], " "),
+				".",
+			),
+		)
+		if abbreviation, ok := units[candidate]; ok {
+			unit = abbreviation
+			line = strings.TrimSpace(strings.TrimPrefix(line, strings.Join(words[:length], " ")))
+			break
+		}
+	}
+
+	// Drop the "of" and "de" joining the unit and the name
+	if unit != "" {
+		for _, joiner := range []string{"of ", "de "} {
+			line = strings.TrimPrefix(line, joiner)
+		}
+	}
+
+	return internalrouterapiv1recipe.Ingredient{
+		Quantity: math.Round(quantity*1000) / 1000,
+		Unit:     unit,
+		Name:     line,
+	}
+}
+
+// parseFraction parses the numerator and the denominator of a fraction
+//
+// Parameters:
+//
+//   - numerator: the numerator, can be empty
+//   - denominator: the denominator, can be empty
+//
+// Returns:
+//
+//   - float64: the fraction value, or 0 if it is missing or invalid
+func parseFraction(numerator, denominator string) float64 {
+	n, err := strconv.ParseFloat(numerator, 64)
+	if err != nil {
+		return 0
+	}
+	d, err := strconv.ParseFloat(denominator, 64)
+	if err != nil || d == 0 {
+		return 0
+	}
+	return n / d
+}
@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+func TestParseIngredient(t *testing.T) {
+	tooLarge := strings.Repeat("9", 400) + " eggs"
+	tests := []struct {
+		name       string
+		line       string
+		ingredient internalrouterapiv1recipe.Ingredient
+	}{
+		// Valid forms
+		{
+			name:       "integer",
+			line:       "3 eggs",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 3, Name: "eggs"},
+		},
+		{
+			name:       "mixed number with unit and joiner",
+			line:       "1 1/2 cups of flour",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 1.5, Unit: "cup", Name: "flour"},
+		},
+		{
+			name:       "fraction",
+			line:       "1/3 cup oil",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 0.333, Unit: "cup", Name: "oil"},
+		},
+		{
+			name:       "decimal comma in spanish",
+			line:       "1,5 kg de papas",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 1.5, Unit: "kg", Name: "papas"},
+		},
+		{
+			name:       "vulgar fraction",
+			line:       "½ taza de leche",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 0.5, Unit: "cup", Name: "leche"},
+		},
+		{
+			name:       "integer and vulgar fraction",
+			line:       "2½ cups milk",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 2.5, Unit: "cup", Name: "milk"},
+		},
+		{
+			name:       "range keeps the lower bound",
+			line:       "2-3 cloves garlic",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 2, Unit: "clove", Name: "garlic"},
+		},
+		{
+			name:       "unit glued to the quantity",
+			line:       "200g sugar",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 200, Unit: "g", Name: "sugar"},
+		},
+		{
+			name:       "abbreviated unit with a period",
+			line:       "2 Tbsp. butter",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 2, Unit: "tbsp", Name: "butter"},
+		},
+		{
+			name:       "two word unit",
+			line:       "2 fl oz rum",
+			ingredient: internalrouterapiv1recipe.Ingredient{Quantity: 2, Unit: "fl oz", Name: "rum"},
+		},
+
+		// Malformed forms
+		{
+			name:       "no quantity",
+			line:       "  salt   to taste ",
+			ingredient: internalrouterapiv1recipe.Ingredient{Name: "salt to taste"},
+		},
+		{
+			name:       "zero quantity",
+			line:       "0 eggs",
+			ingredient: internalrouterapiv1recipe.Ingredient{Name: "0 eggs"},
+		},
+		{
+			name:       "zero denominator",
+			line:       "1/0 cup flour",
+			ingredient: internalrouterapiv1recipe.Ingredient{Name: "1/0 cup flour"},
+		},
+		{
+			name:       "empty",
+			line:       "",
+			ingredient: internalrouterapiv1recipe.Ingredient{},
+		},
+
+		// Limits
+		{
+			name:       "quantity out of float range",
+			line:       tooLarge,
+			ingredient: internalrouterapiv1recipe.Ingredient{Name: tooLarge},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if ingredient := ParseIngredient(test.line); ingredient != test.ingredient {
+					t.Errorf("ParseIngredient(%q) = %+v, want %+v", test.line, ingredient, test.ingredient)
+				}
+			},
+		)
+	}
+}
@@ -7,7 +7,11 @@ import (
 	goflagsmode "github.com/ralvarezdev/go-flags/mode"
 	goloaderfilesystem "github.com/ralvarezdev/go-loader/filesystem"
 	gonethttphandler "github.com/ralvarezdev/go-net/http/handler"
-	gonethttphandlerjsonjsend "github.com/ralvarezdev/go-net/http/handler/json/jsend"
+	gonethttphandlerjson "github.com/ralvarezdev/go-net/http/handler/json"
+	gonethttpresponsehandlerjsend "github.com/ralvarezdev/go-net/http/response/handler/jsend"
+
+	internalmessages "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/messages"
+	internalnegotiation "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/negotiation"
 )
 
 const (
@@ -31,17 +35,40 @@ var (
 // Parameters:
 //
 //   - mode: the go-flags mode flag to determine if the environment is in debug mode
+//   - messages: the message catalog used to localize the fail and error bodies, also rendered as problem details if
+//     the client asks for them
 //   - logger: the logger instance
-func Load(mode *goflagsmode.Flag, logger *slog.Logger) {
+func Load(mode *goflagsmode.Flag, messages *internalmessages.Catalog, logger *slog.Logger) {
 	// Initialize the handler
-	handler, err := gonethttphandlerjsonjsend.NewHandler(
+	rawErrorHandler, err := internalmessages.NewRawErrorHandler(
+		gonethttpresponsehandlerjsend.NewRawErrorHandler(logger),
+		messages,
+	)
+	if err != nil {
+		panic(err)
+	}
+	handler, err := gonethttphandlerjson.NewHandler(
+		mode,
+		rawErrorHandler,
+	)
+	if err != nil {
+		panic(err)
+	}
+	localizedHandler, err := internalmessages.NewHandler(handler, messages)
+	if err != nil {
+		panic(err)
+	}
+
+	// Encode the success responses in MessagePack when the client asks for it
+	negotiatedHandler, err := internalnegotiation.NewHandler(
 		mode,
-		logger,
+		localizedHandler,
+		internalnegotiation.NewMessagePackEncoder(),
 	)
 	if err != nil {
 		panic(err)
 	}
-	Handler = handler
+	Handler = negotiatedHandler
 
 	// Load swagger.json definitions
 	goModPath, err := goloaderfilesystem.GetExecutableGoModPath()
@@ -2,14 +2,19 @@ package jwt
 
 import (
 	"log/slog"
+	"net/http"
 	"strings"
 
 	godatabasessql "github.com/ralvarezdev/go-databases/sql"
 	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
 	gojwttokenclaims "github.com/ralvarezdev/go-jwt/token/claims"
 	gojwttokenclaimssqlite "github.com/ralvarezdev/go-jwt/token/claims/sqlite"
 	gojwttokenvalidator "github.com/ralvarezdev/go-jwt/token/validator"
+	gonethttp "github.com/ralvarezdev/go-net/http"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
 
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
 	internalloader "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/loader"
 )
 
@@ -32,6 +37,29 @@ var (
 	Validator gojwttokenvalidator.Validator
 )
 
+// GetCtxUserID gets the authenticated user ID from the token claims subject set by the authentication middleware
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//
+// Returns:
+//
+//   - string: The user ID
+//   - error: An unauthorized error if the request has no token claims subject
+func GetCtxUserID(r *http.Request) (string, error) {
+	userID, err := gojwtgrpc.GetCtxTokenClaimsSubject(r.Context())
+	if err != nil {
+		return "", gonethttpresponse.NewDebugErrorWithCode(
+			err,
+			gonethttp.ErrUnauthorized,
+			internalerrorcodes.Unauthorized.String(),
+			http.StatusUnauthorized,
+		)
+	}
+	return userID, nil
+}
+
 // Load initializes the JWT validator
 //
 // Parameters:
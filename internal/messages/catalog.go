@@ -0,0 +1,197 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+type (
+	// Message is an entry of the message catalog. It gives a stable code and the translations of the error messages
+	// it stands for, which may hold fmt verbs such as "%s is required". The values of the verbs are carried over to
+	// the translations in order
+	Message struct {
+		Code         internalerrorcodes.Code `json:"code"`
+		Errors       []string                `json:"errors"`       // error messages as written in the code, fmt verbs included
+		Translations map[string]string       `json:"translations"` // localized messages by ISO 639 language code
+	}
+
+	// Catalog is the message catalog used to give error messages a stable code and localize them
+	Catalog struct {
+		codes    map[internalerrorcodes.Code]*Message
+		errors   map[string]*Message // error messages without verbs, the ones with verbs are only matched as patterns
+		patterns []*pattern
+	}
+
+	// pattern matches the error messages that hold fmt verbs
+	pattern struct {
+		expression *regexp.Regexp
+		message    *Message
+	}
+
+	// catalogFile is the layout of a message catalog data file
+	catalogFile struct {
+		Messages []Message `json:"messages"`
+	}
+)
+
+var (
+	// verbExpression matches the fmt verbs of an error message
+	verbExpression = regexp.MustCompile(`%[sdvq]`)
+)
+
+// NewCatalog creates a new Catalog
+//
+// Parameters:
+//
+//   - messages: the messages
+//
+// Returns:
+//
+//   - *Catalog: the Catalog instance
+//   - error: an error if a message is not valid, its code is not one of the error codes or a code or error message is
+//     repeated
+func NewCatalog(messages []Message) (*Catalog, error) {
+	catalog := &Catalog{
+		codes:  make(map[internalerrorcodes.Code]*Message),
+		errors: make(map[string]*Message),
+	}
+	seen := make(map[string]struct{})
+	for i := range messages {
+		message := &messages[i]
+		if !message.Code.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownCatalogCode, message.Code)
+		}
+		if _, ok := catalog.codes[message.Code]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateCatalogCode, message.Code)
+		}
+		if len(message.Errors) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrMissingCatalogErrors, message.Code)
+		}
+		if message.Translations[internalrequest.DefaultLanguage] == "" {
+			return nil, fmt.Errorf("%w: %q", ErrMissingDefaultMessage, message.Code)
+		}
+		catalog.codes[message.Code] = message
+
+		// Index the error messages, the ones with verbs as patterns, so a text matching them always gives the values of
+		// the verbs
+		verbs := -1
+		for _, errorMessage := range message.Errors {
+			if _, ok := seen[errorMessage]; ok {
+				return nil, fmt.Errorf("%w: %q", ErrDuplicateCatalogError, errorMessage)
+			}
+			seen[errorMessage] = struct{}{}
+
+			count := len(verbExpression.FindAllString(errorMessage, -1))
+			if verbs < 0 || count < verbs {
+				verbs = count
+			}
+			if count == 0 {
+				catalog.errors[errorMessage] = message
+				continue
+			}
+			literals := verbExpression.Split(errorMessage, -1)
+			for j, literal := range literals {
+				literals[j] = regexp.QuoteMeta(literal)
+			}
+			catalog.patterns = append(
+				catalog.patterns, &pattern{
+					expression: regexp.MustCompile("^" + strings.Join(literals, "(.+?)") + "$"),
+					message:    message,
+				},
+			)
+		}
+
+		// Check every error message gives the values of the verbs of the translations
+		for language, translation := range message.Translations {
+			if count := len(verbExpression.FindAllString(translation, -1)); count > verbs {
+				return nil, fmt.Errorf("%w: %q in %s", ErrInvalidCatalogVerbs, message.Code, language)
+			}
+		}
+	}
+	return catalog, nil
+}
+
+// ParseCatalog parses a message catalog from its JSON data
+//
+// Parameters:
+//
+//   - data: the JSON data
+//
+// Returns:
+//
+//   - *Catalog: the Catalog instance
+//   - error: an error if the data is not a valid catalog
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var file catalogFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCatalog, err)
+	}
+	return NewCatalog(file.Messages)
+}
+
+// Lookup finds the message of an error message
+//
+// Parameters:
+//
+//   - errorMessage: the error message
+//
+// Returns:
+//
+//   - *Message: the message, nil if the catalog does not know the error message
+//   - []string: the values of the verbs of the error message
+func (c *Catalog) Lookup(errorMessage string) (*Message, []string) {
+	if c == nil {
+		return nil, nil
+	}
+	if message, ok := c.errors[errorMessage]; ok {
+		return message, nil
+	}
+	for _, p := range c.patterns {
+		if values := p.expression.FindStringSubmatch(errorMessage); values != nil {
+			return p.message, values[1:]
+		}
+	}
+	return nil, nil
+}
+
+// Localize gives the code of an error message and its translation to the first of the languages the catalog has
+//
+// Parameters:
+//
+//   - errorMessage: the error message
+//   - languages: the languages, in order of preference, the default language is used if none is available
+//
+// Returns:
+//
+//   - string: the code of the message, empty if the catalog does not know the error message
+//   - string: the localized message, the error message itself if the catalog does not know it
+func (c *Catalog) Localize(errorMessage string, languages []string) (string, string) {
+	message, values := c.Lookup(errorMessage)
+	if message == nil {
+		return "", errorMessage
+	}
+
+	// Pick the translation
+	translation := message.Translations[internalrequest.DefaultLanguage]
+	for _, language := range languages {
+		if localized, ok := message.Translations[language]; ok {
+			translation = localized
+			break
+		}
+	}
+
+	// Carry over the values of the verbs, in order
+	i := 0
+	return message.Code.String(), verbExpression.ReplaceAllStringFunc(
+		translation, func(string) string {
+			value := values[i]
+			i++
+			return value
+		},
+	)
+}
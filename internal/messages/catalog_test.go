@@ -0,0 +1,375 @@
+package messages
+
+import (
+	"errors"
+	"testing"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// newTestCatalog creates the catalog used by the tests
+//
+// Parameters:
+//
+//   - t: the test
+//
+// Returns:
+//
+//   - *Catalog: the catalog
+func newTestCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	catalog, err := NewCatalog(
+		[]Message{
+			{
+				Code:   internalerrorcodes.RecipeNotFound,
+				Errors: []string{"recipe not found", "no such recipe"},
+				Translations: map[string]string{
+					"en": "The recipe was not found",
+					"es": "No se encontró la receta",
+				},
+			},
+			{
+				Code:   internalerrorcodes.ValidationFailed,
+				Errors: []string{"%s is required", "missing %s field"},
+				Translations: map[string]string{
+					"en": "%s is missing",
+					"es": "Falta %s",
+				},
+			},
+			{
+				Code:   internalerrorcodes.InvalidFieldType,
+				Errors: []string{"%s must be between %d and %d"},
+				Translations: map[string]string{
+					"en": "%s must be from %d to %d",
+					"es": "%s debe estar entre %d y %d",
+				},
+			},
+			{
+				Code:         internalerrorcodes.BadRequest,
+				Errors:       []string{"Bad Request"},
+				Translations: map[string]string{"en": "The request is not valid"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	return catalog
+}
+
+func TestNewCatalog(t *testing.T) {
+	valid := func() Message {
+		return Message{
+			Code:         internalerrorcodes.BadRequest,
+			Errors:       []string{"Bad Request"},
+			Translations: map[string]string{"en": "The request is not valid"},
+		}
+	}
+	tests := []struct {
+		name     string
+		messages func() []Message
+		err      error
+	}{
+		{
+			name: "valid",
+			messages: func() []Message {
+				return []Message{valid()}
+			},
+		},
+		{
+			name: "unknown code",
+			messages: func() []Message {
+				message := valid()
+				message.Code = "no_such_code"
+				return []Message{message}
+			},
+			err: ErrUnknownCatalogCode,
+		},
+		{
+			name: "repeated code",
+			messages: func() []Message {
+				other := valid()
+				other.Errors = []string{"other"}
+				return []Message{valid(), other}
+			},
+			err: ErrDuplicateCatalogCode,
+		},
+		{
+			name: "repeated error message",
+			messages: func() []Message {
+				other := valid()
+				other.Code = internalerrorcodes.InternalError
+				return []Message{valid(), other}
+			},
+			err: ErrDuplicateCatalogError,
+		},
+		{
+			name: "repeated error message with verbs",
+			messages: func() []Message {
+				message := valid()
+				message.Errors = []string{"%s is required", "%s is required"}
+				return []Message{message}
+			},
+			err: ErrDuplicateCatalogError,
+		},
+		{
+			name: "no error messages",
+			messages: func() []Message {
+				message := valid()
+				message.Errors = nil
+				return []Message{message}
+			},
+			err: ErrMissingCatalogErrors,
+		},
+		{
+			name: "no default translation",
+			messages: func() []Message {
+				message := valid()
+				message.Translations = map[string]string{"es": "La solicitud no es válida"}
+				return []Message{message}
+			},
+			err: ErrMissingDefaultMessage,
+		},
+		{
+			name: "translation with more verbs than an error message",
+			messages: func() []Message {
+				message := valid()
+				message.Errors = []string{"%s is required", "value is required"}
+				message.Translations = map[string]string{"en": "%s is missing"}
+				return []Message{message}
+			},
+			err: ErrInvalidCatalogVerbs,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				catalog, err := NewCatalog(test.messages())
+				if !errors.Is(err, test.err) {
+					t.Fatalf("NewCatalog() error = %v, want %v", err, test.err)
+				}
+				if (catalog == nil) != (test.err != nil) {
+					t.Errorf("NewCatalog() = %v, want a catalog only without an error", catalog)
+				}
+			},
+		)
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{name: "valid", data: `{"messages":[{"code":"bad_request","errors":["Bad Request"],"translations":{"en":"Bad"}}]}`},
+		{name: "empty", data: `{"messages":[]}`},
+		{name: "malformed", data: `{"messages":[`, err: ErrInvalidCatalog},
+		{name: "wrong type", data: `{"messages":{}}`, err: ErrInvalidCatalog},
+		{name: "invalid message", data: `{"messages":[{"code":"bad_request","errors":[]}]}`, err: ErrMissingCatalogErrors},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if _, err := ParseCatalog([]byte(test.data)); !errors.Is(err, test.err) {
+					t.Errorf("ParseCatalog() error = %v, want %v", err, test.err)
+				}
+			},
+		)
+	}
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	catalog, err := ParseCatalog(defaultCatalog)
+	if err != nil {
+		t.Fatalf("ParseCatalog(data/messages.json) error = %v", err)
+	}
+
+	// Every message is translated to the same languages
+	var languages map[string]struct{}
+	for code, message := range catalog.codes {
+		current := make(map[string]struct{}, len(message.Translations))
+		for language, translation := range message.Translations {
+			if translation == "" {
+				t.Errorf("message %q has an empty %s translation", code, language)
+			}
+			current[language] = struct{}{}
+		}
+		if languages == nil {
+			languages = current
+			continue
+		}
+		if len(current) != len(languages) {
+			t.Errorf("message %q has %d translations, want %d", code, len(current), len(languages))
+		}
+		for language := range current {
+			if _, ok := languages[language]; !ok {
+				t.Errorf("message %q is translated to %s, which the other messages are not", code, language)
+			}
+		}
+	}
+	if _, ok := languages[internalrequest.DefaultLanguage]; !ok {
+		t.Errorf("catalog has no %s translations", internalrequest.DefaultLanguage)
+	}
+}
+
+func TestCatalogLookup(t *testing.T) {
+	catalog := newTestCatalog(t)
+	tests := []struct {
+		name         string
+		errorMessage string
+		code         internalerrorcodes.Code
+		values       []string
+	}{
+		{name: "exact match", errorMessage: "recipe not found", code: internalerrorcodes.RecipeNotFound},
+		{name: "other exact match", errorMessage: "no such recipe", code: internalerrorcodes.RecipeNotFound},
+		{name: "pattern match", errorMessage: "name is required", code: internalerrorcodes.ValidationFailed, values: []string{"name"}},
+		{
+			name:         "other pattern match",
+			errorMessage: "missing servings field",
+			code:         internalerrorcodes.ValidationFailed,
+			values:       []string{"servings"},
+		},
+		{
+			name:         "pattern match with several verbs",
+			errorMessage: "servings must be between 1 and 100",
+			code:         internalerrorcodes.InvalidFieldType,
+			values:       []string{"servings", "1", "100"},
+		},
+		{
+			name:         "text of a pattern",
+			errorMessage: "%s is required",
+			code:         internalerrorcodes.ValidationFailed,
+			values:       []string{"%s"},
+		},
+		{name: "unknown", errorMessage: "recipe not found!"},
+		{name: "empty", errorMessage: ""},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				message, values := catalog.Lookup(test.errorMessage)
+				var code internalerrorcodes.Code
+				if message != nil {
+					code = message.Code
+				}
+				if code != test.code {
+					t.Errorf("Lookup(%q) code = %q, want %q", test.errorMessage, code, test.code)
+				}
+				if len(values) != len(test.values) {
+					t.Fatalf("Lookup(%q) values = %q, want %q", test.errorMessage, values, test.values)
+				}
+				for i := range values {
+					if values[i] != test.values[i] {
+						t.Errorf("Lookup(%q) values = %q, want %q", test.errorMessage, values, test.values)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestCatalogLocalize(t *testing.T) {
+	catalog := newTestCatalog(t)
+	tests := []struct {
+		name         string
+		errorMessage string
+		languages    []string
+		code         string
+		message      string
+	}{
+		// Exact matches
+		{
+			name:         "default language",
+			errorMessage: "recipe not found",
+			code:         "recipe_not_found",
+			message:      "The recipe was not found",
+		},
+		{
+			name:         "requested language",
+			errorMessage: "recipe not found",
+			languages:    []string{"es"},
+			code:         "recipe_not_found",
+			message:      "No se encontró la receta",
+		},
+		{
+			name:         "first available language",
+			errorMessage: "recipe not found",
+			languages:    []string{"fr", "es", "en"},
+			code:         "recipe_not_found",
+			message:      "No se encontró la receta",
+		},
+		{
+			name:         "fallback to the default language",
+			errorMessage: "recipe not found",
+			languages:    []string{"fr", "pt"},
+			code:         "recipe_not_found",
+			message:      "The recipe was not found",
+		},
+		{
+			name:         "message without the requested language",
+			errorMessage: "Bad Request",
+			languages:    []string{"es"},
+			code:         "bad_request",
+			message:      "The request is not valid",
+		},
+
+		// Pattern matches
+		{
+			name:         "verb substitution",
+			errorMessage: "name is required",
+			languages:    []string{"es"},
+			code:         "validation_failed",
+			message:      "Falta name",
+		},
+		{
+			name:         "several verbs substitution",
+			errorMessage: "servings must be between 1 and 100",
+			languages:    []string{"es"},
+			code:         "invalid_field_type",
+			message:      "servings debe estar entre 1 y 100",
+		},
+		{
+			name:         "text of a pattern",
+			errorMessage: "%s is required",
+			code:         "validation_failed",
+			message:      "%s is missing",
+		},
+
+		// Unknown messages
+		{
+			name:         "unknown",
+			errorMessage: "something else failed",
+			languages:    []string{"es"},
+			message:      "something else failed",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				code, message := catalog.Localize(test.errorMessage, test.languages)
+				if code != test.code || message != test.message {
+					t.Errorf(
+						"Localize(%q, %q) = %q, %q, want %q, %q",
+						test.errorMessage,
+						test.languages,
+						code,
+						message,
+						test.code,
+						test.message,
+					)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"nil catalog", func(t *testing.T) {
+			var nilCatalog *Catalog
+			if code, message := nilCatalog.Localize("recipe not found", nil); code != "" || message != "recipe not found" {
+				t.Errorf("Localize() = %q, %q, want the error message without a code", code, message)
+			}
+		},
+	)
+}
@@ -0,0 +1,45 @@
+package messages
+
+import (
+	gonethttpmiddlewareauth "github.com/ralvarezdev/go-net/http/middleware/auth"
+	gonethttprequest "github.com/ralvarezdev/go-net/http/request"
+	gonethttprequesthandler "github.com/ralvarezdev/go-net/http/request/handler"
+	gonethttpresponsehandlerjsend "github.com/ralvarezdev/go-net/http/response/handler/jsend"
+	gonethttpresponsejsendgrpc "github.com/ralvarezdev/go-net/http/response/jsend/grpc"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+// setLibraryCodes gives a stable code to the errors of the HTTP library, which leaves them without one
+func setLibraryCodes() {
+	// Requests decoding and validation
+	gonethttprequesthandler.ErrCodeValidationFailed = internalerrorcodes.ValidationFailed.String()
+	gonethttprequest.ErrCodeInvalidContentType = internalerrorcodes.InvalidContentType.String()
+	gonethttprequest.ErrCodeEmptyBody = internalerrorcodes.EmptyBody.String()
+	gonethttprequest.ErrCodeSyntaxError = internalerrorcodes.MalformedJSON.String()
+	gonethttprequest.ErrCodeUnmarshalRequestBodyFailed = internalerrorcodes.MalformedJSON.String()
+	gonethttprequest.ErrCodeUnmarshalTypeError = internalerrorcodes.InvalidFieldType.String()
+	gonethttprequest.ErrCodeUnknownField = internalerrorcodes.UnknownField.String()
+	gonethttprequest.ErrCodeMaxBodySizeExceeded = internalerrorcodes.BodyTooLarge.String()
+
+	// Authentication
+	gonethttpmiddlewareauth.ErrCodeInvalidAuthorizationHeader = internalerrorcodes.Unauthorized.String()
+	gonethttpmiddlewareauth.ErrCodeInvalidTokenClaims = internalerrorcodes.SessionExpired.String()
+	gonethttpmiddlewareauth.ErrCodeFailedToRefreshToken = internalerrorcodes.SessionExpired.String()
+
+	// gRPC services
+	gonethttpresponsejsendgrpc.ErrCodeBadRequest = internalerrorcodes.ValidationFailed.String()
+	gonethttpresponsejsendgrpc.ErrCodePreconditionFailure = internalerrorcodes.PreconditionFailed.String()
+	gonethttpresponsejsendgrpc.ErrCodeQuotaFailure = internalerrorcodes.TooManyRequests.String()
+	gonethttpresponsejsendgrpc.ErrCodeRequestInfo = internalerrorcodes.BadRequest.String()
+	gonethttpresponsejsendgrpc.ErrCodeResourceInfo = internalerrorcodes.BadRequest.String()
+	gonethttpresponsejsendgrpc.ErrCodeHelp = internalerrorcodes.BadRequest.String()
+	gonethttpresponsejsendgrpc.ErrCodeLocalizedMessage = internalerrorcodes.BadRequest.String()
+	gonethttpresponsejsendgrpc.ErrCodeCtxCanceled = internalerrorcodes.RequestTimeout.String()
+	gonethttpresponsejsendgrpc.ErrCodeCtxDeadlineExceeded = internalerrorcodes.RequestTimeout.String()
+	gonethttpresponsejsendgrpc.ErrCodeUnknown = internalerrorcodes.InternalError.String()
+	gonethttpresponsejsendgrpc.ErrCodeCCodePrefix = internalerrorcodes.GRPCPrefix
+
+	// Unexpected errors
+	gonethttpresponsehandlerjsend.ErrCodeRequestFatalError = internalerrorcodes.InternalError.String()
+}
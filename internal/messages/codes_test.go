@@ -0,0 +1,45 @@
+package messages
+
+import (
+	"testing"
+
+	gonethttpmiddlewareauth "github.com/ralvarezdev/go-net/http/middleware/auth"
+	gonethttprequest "github.com/ralvarezdev/go-net/http/request"
+	gonethttprequesthandler "github.com/ralvarezdev/go-net/http/request/handler"
+	gonethttpresponsehandlerjsend "github.com/ralvarezdev/go-net/http/response/handler/jsend"
+	gonethttpresponsejsendgrpc "github.com/ralvarezdev/go-net/http/response/jsend/grpc"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+func TestSetLibraryCodes(t *testing.T) {
+	setLibraryCodes()
+
+	codes := map[string]*string{
+		"validation failed":            &gonethttprequesthandler.ErrCodeValidationFailed,
+		"invalid content type":         &gonethttprequest.ErrCodeInvalidContentType,
+		"empty body":                   &gonethttprequest.ErrCodeEmptyBody,
+		"syntax error":                 &gonethttprequest.ErrCodeSyntaxError,
+		"unmarshal request body":       &gonethttprequest.ErrCodeUnmarshalRequestBodyFailed,
+		"unmarshal type error":         &gonethttprequest.ErrCodeUnmarshalTypeError,
+		"unknown field":                &gonethttprequest.ErrCodeUnknownField,
+		"max body size exceeded":       &gonethttprequest.ErrCodeMaxBodySizeExceeded,
+		"invalid authorization header": &gonethttpmiddlewareauth.ErrCodeInvalidAuthorizationHeader,
+		"invalid token claims":         &gonethttpmiddlewareauth.ErrCodeInvalidTokenClaims,
+		"failed to refresh token":      &gonethttpmiddlewareauth.ErrCodeFailedToRefreshToken,
+		"grpc bad request":             &gonethttpresponsejsendgrpc.ErrCodeBadRequest,
+		"grpc precondition failure":    &gonethttpresponsejsendgrpc.ErrCodePreconditionFailure,
+		"grpc quota failure":           &gonethttpresponsejsendgrpc.ErrCodeQuotaFailure,
+		"grpc unknown":                 &gonethttpresponsejsendgrpc.ErrCodeUnknown,
+		"request fatal error":          &gonethttpresponsehandlerjsend.ErrCodeRequestFatalError,
+	}
+	for name, code := range codes {
+		t.Run(
+			name, func(t *testing.T) {
+				if !internalerrorcodes.Code(*code).IsValid() {
+					t.Errorf("code = %q, want one of the error codes", *code)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,52 @@
+package messages
+
+import (
+	_ "embed"
+	"log/slog"
+	"os"
+)
+
+const (
+	// EnvCatalogFile is the environment variable for the path of a JSON file that replaces the built-in message
+	// catalog. When it is not set, the built-in catalog is used
+	EnvCatalogFile = "MESSAGES_CATALOG_FILE"
+)
+
+var (
+	//go:embed data/messages.json
+	defaultCatalog []byte
+
+	// Messages is the message catalog
+	Messages *Catalog
+)
+
+// Load loads the message catalog and gives a stable code to the errors of the HTTP library
+//
+// Parameters:
+//
+//   - logger: The logger (optional, can be nil)
+func Load(logger *slog.Logger) {
+	data := defaultCatalog
+
+	// Read the catalog file if it is set
+	if path, ok := os.LookupEnv(EnvCatalogFile); ok && path != "" {
+		fileData, err := os.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		if logger != nil {
+			logger.Info(
+				"Message catalog loaded from a file",
+				slog.String("path", path),
+			)
+		}
+		data = fileData
+	}
+
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		panic(err)
+	}
+	Messages = catalog
+	setLibraryCodes()
+}
@@ -0,0 +1,17 @@
+package messages
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilCatalog            = errors.New("message catalog cannot be nil")
+	ErrNilRawErrorHandler    = errors.New("raw error handler cannot be nil")
+	ErrInvalidCatalog        = errors.New("invalid message catalog")
+	ErrUnknownCatalogCode    = errors.New("catalog message code is not one of the error codes")
+	ErrDuplicateCatalogCode  = errors.New("catalog message code is repeated")
+	ErrDuplicateCatalogError = errors.New("catalog error message is repeated")
+	ErrMissingCatalogErrors  = errors.New("catalog message must have at least one error message")
+	ErrMissingDefaultMessage = errors.New("catalog message must have a translation to the default language")
+	ErrInvalidCatalogVerbs   = errors.New("catalog translation must have as many verbs as its error messages")
+)
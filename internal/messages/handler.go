@@ -0,0 +1,290 @@
+package messages
+
+import (
+	"net/http"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	gonethttphandler "github.com/ralvarezdev/go-net/http/handler"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalproblem "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/problem"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+type (
+	// RawErrorHandler wraps a raw error handler to localize the fail and error bodies it builds to the languages of
+	// the request. The messages the catalog knows are translated and give their code to the body if it has none
+	RawErrorHandler struct {
+		handler gonethttphandler.RawErrorHandler
+		catalog *Catalog
+	}
+
+	// Handler wraps a handler to localize the fail and error bodies of the responses it writes directly, as done for
+	// the ones built by the RawErrorHandler
+	Handler struct {
+		gonethttphandler.Handler
+		catalog *Catalog
+	}
+
+	// localizedResponse localizes the body of a response when it is encoded, rendering it as problem details if the
+	// client asks for them
+	localizedResponse struct {
+		gonethttpresponse.Response
+		catalog   *Catalog
+		languages []string
+		problem   bool
+		instance  string
+	}
+)
+
+// NewRawErrorHandler creates a new RawErrorHandler
+//
+// Parameters:
+//
+//   - handler: the raw error handler that builds the bodies
+//   - catalog: the message catalog
+//
+// Returns:
+//
+//   - *RawErrorHandler: the RawErrorHandler instance
+//   - error: an error if the handler or the catalog is nil
+func NewRawErrorHandler(
+	handler gonethttphandler.RawErrorHandler,
+	catalog *Catalog,
+) (*RawErrorHandler, error) {
+	if handler == nil {
+		return nil, ErrNilRawErrorHandler
+	}
+	if catalog == nil {
+		return nil, ErrNilCatalog
+	}
+	return &RawErrorHandler{
+		handler: handler,
+		catalog: catalog,
+	}, nil
+}
+
+// HandleRawError handles a raw error, localizing the response built for it
+//
+// Parameters:
+//
+//   - w: the HTTP response writer
+//   - r: the HTTP request
+//   - err: the error
+//   - stackTrace: the stack trace, if any
+//   - handleResponseFn: the function that writes the response
+func (h *RawErrorHandler) HandleRawError(
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+	stackTrace []byte,
+	handleResponseFn func(
+		w http.ResponseWriter,
+		r *http.Request,
+		response gonethttpresponse.Response,
+	),
+) {
+	h.handler.HandleRawError(
+		w, r, err, stackTrace, func(
+			w http.ResponseWriter,
+			r *http.Request,
+			response gonethttpresponse.Response,
+		) {
+			handleResponseFn(w, r, localizeResponse(w, r, response, h.catalog))
+		},
+	)
+}
+
+// NewHandler creates a new Handler
+//
+// Parameters:
+//
+//   - handler: the handler that writes the responses
+//   - catalog: the message catalog
+//
+// Returns:
+//
+//   - *Handler: the Handler instance
+//   - error: an error if the handler or the catalog is nil
+func NewHandler(handler gonethttphandler.Handler, catalog *Catalog) (*Handler, error) {
+	if handler == nil {
+		return nil, gonethttphandler.ErrNilHandler
+	}
+	if catalog == nil {
+		return nil, ErrNilCatalog
+	}
+	return &Handler{
+		Handler: handler,
+		catalog: catalog,
+	}, nil
+}
+
+// HandleResponse writes the response, localizing its body if it is a JSend fail or error body
+//
+// Parameters:
+//
+//   - w: the HTTP response writer
+//   - r: the HTTP request
+//   - response: the response
+func (h *Handler) HandleResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	response gonethttpresponse.Response,
+) {
+	h.Handler.HandleResponse(w, r, localizeResponse(w, r, response, h.catalog))
+}
+
+// localizeResponse wraps a response to localize its body to the languages of the request. When the client asks for
+// problem details and the response is an error, the problem+json content type is set for the encoder to keep it
+//
+// Parameters:
+//
+//   - w: the HTTP response writer
+//   - r: the HTTP request
+//   - response: the response
+//   - catalog: the message catalog
+//
+// Returns:
+//
+//   - gonethttpresponse.Response: the wrapped response, or nil if the response is nil
+func localizeResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	response gonethttpresponse.Response,
+	catalog *Catalog,
+) gonethttpresponse.Response {
+	if response == nil {
+		return nil
+	}
+	problem := response.HTTPStatus() >= http.StatusBadRequest && internalproblem.Accepts(r)
+	if problem {
+		w.Header().Set("Content-Type", internalproblem.ContentType)
+	}
+	return &localizedResponse{
+		Response:  response,
+		catalog:   catalog,
+		languages: internalrequest.GetLanguages(r),
+		problem:   problem,
+		instance:  r.URL.Path,
+	}
+}
+
+// Body returns the localized body of the response
+//
+// Parameters:
+//
+//   - mode: the go-flags mode flag
+//
+// Returns:
+//
+//   - any: the body, a copy with localized messages if it is a JSend fail or error body, rendered as problem details
+//     if the client asks for them
+func (l *localizedResponse) Body(mode *goflagsmode.Flag) any {
+	body := l.localizeBody(mode)
+	if !l.problem {
+		return body
+	}
+	if details := internalproblem.NewDetails(body, l.HTTPStatus(), l.instance); details != nil {
+		return details
+	}
+	return body
+}
+
+// localizeBody returns the body of the response with its messages localized
+//
+// Parameters:
+//
+//   - mode: the go-flags mode flag
+//
+// Returns:
+//
+//   - any: the body, a copy with localized messages if it is a JSend fail or error body
+func (l *localizedResponse) localizeBody(mode *goflagsmode.Flag) any {
+	switch body := l.Response.Body(mode).(type) {
+	case *gonethttpresponsejsend.FailBody:
+		if body == nil {
+			return body
+		}
+		var codes []string
+		localized := *body
+		localized.Data = l.localizeData(body.Data, &codes)
+		if localized.Code == "" {
+			localized.Code = commonCode(codes)
+		}
+		return &localized
+	case *gonethttpresponsejsend.ErrorBody:
+		if body == nil {
+			return body
+		}
+		localized := *body
+		code, message := l.catalog.Localize(body.Message, l.languages)
+		localized.Message = message
+		if localized.Code == "" {
+			localized.Code = code
+		}
+		return &localized
+	default:
+		return body
+	}
+}
+
+// localizeData localizes the messages of the data of a fail body, as built for field errors and failed validations
+//
+// Parameters:
+//
+//   - data: the data
+//   - codes: the codes of the localized messages, appended to
+//
+// Returns:
+//
+//   - any: a copy of the data with localized messages, or the data itself if it holds no messages
+func (l *localizedResponse) localizeData(data any, codes *[]string) any {
+	switch value := data.(type) {
+	case string:
+		code, message := l.catalog.Localize(value, l.languages)
+		*codes = append(*codes, code)
+		return message
+	case []string:
+		messages := make([]string, len(value))
+		for i, message := range value {
+			messages[i] = l.localizeData(message, codes).(string)
+		}
+		return messages
+	case map[string][]string:
+		fields := make(map[string][]string, len(value))
+		for field, messages := range value {
+			fields[field] = l.localizeData(messages, codes).([]string)
+		}
+		return fields
+	case map[string]any:
+		fields := make(map[string]any, len(value))
+		for field, fieldData := range value {
+			fields[field] = l.localizeData(fieldData, codes)
+		}
+		return fields
+	default:
+		return data
+	}
+}
+
+// commonCode returns the code shared by all the localized messages of a body
+//
+// Parameters:
+//
+//   - codes: the codes of the localized messages
+//
+// Returns:
+//
+//   - string: the shared code, or an empty string if there are none or they differ
+func commonCode(codes []string) string {
+	if len(codes) == 0 {
+		return ""
+	}
+	for _, code := range codes[1:] {
+		if code != codes[0] {
+			return ""
+		}
+	}
+	return codes[0]
+}
@@ -0,0 +1,203 @@
+package messages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalproblem "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/problem"
+)
+
+func TestLocalizeData(t *testing.T) {
+	localized := &localizedResponse{catalog: newTestCatalog(t), languages: []string{"es"}}
+	tests := []struct {
+		name  string
+		data  any
+		want  any
+		codes []string
+	}{
+		{
+			name:  "string",
+			data:  "recipe not found",
+			want:  "No se encontró la receta",
+			codes: []string{"recipe_not_found"},
+		},
+		{
+			name:  "unknown string",
+			data:  "something else failed",
+			want:  "something else failed",
+			codes: []string{""},
+		},
+		{
+			name:  "strings",
+			data:  []string{"name is required", "recipe not found"},
+			want:  []string{"Falta name", "No se encontró la receta"},
+			codes: []string{"validation_failed", "recipe_not_found"},
+		},
+		{
+			name:  "messages per field",
+			data:  map[string][]string{"name": {"name is required"}},
+			want:  map[string][]string{"name": {"Falta name"}},
+			codes: []string{"validation_failed"},
+		},
+		{
+			name: "nested fields",
+			data: map[string]any{
+				"name":   "name is required",
+				"nested": map[string]any{"servings": []string{"servings is required"}},
+				"count":  3,
+			},
+			want: map[string]any{
+				"name":   "Falta name",
+				"nested": map[string]any{"servings": []string{"Falta servings"}},
+				"count":  3,
+			},
+			codes: []string{"validation_failed", "validation_failed"},
+		},
+		{
+			name: "other data",
+			data: 42,
+			want: 42,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				var codes []string
+				if got := localized.localizeData(test.data, &codes); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("localizeData() = %v, want %v", got, test.want)
+				}
+				if len(codes) != len(test.codes) {
+					t.Fatalf("localizeData() codes = %q, want %q", codes, test.codes)
+				}
+			},
+		)
+	}
+}
+
+func TestCommonCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []string
+		want  string
+	}{
+		{name: "none"},
+		{name: "single", codes: []string{"validation_failed"}, want: "validation_failed"},
+		{name: "shared", codes: []string{"validation_failed", "validation_failed"}, want: "validation_failed"},
+		{name: "different", codes: []string{"validation_failed", "recipe_not_found"}},
+		{name: "unknown message", codes: []string{"validation_failed", ""}},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := commonCode(test.codes); got != test.want {
+					t.Errorf("commonCode(%q) = %q, want %q", test.codes, got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestLocalizeResponse(t *testing.T) {
+	catalog := newTestCatalog(t)
+	tests := []struct {
+		name        string
+		body        any
+		status      int
+		accept      string
+		want        any
+		contentType string
+	}{
+		{
+			name:   "fail body",
+			body:   &gonethttpresponsejsend.FailBody{Status: gonethttpresponsejsend.StatusFail, Data: map[string][]string{"name": {"name is required"}}},
+			status: http.StatusBadRequest,
+			want: &gonethttpresponsejsend.FailBody{
+				Status: gonethttpresponsejsend.StatusFail,
+				Code:   "validation_failed",
+				Data:   map[string][]string{"name": {"Falta name"}},
+			},
+		},
+		{
+			name:   "error body",
+			body:   &gonethttpresponsejsend.ErrorBody{Status: gonethttpresponsejsend.StatusError, Message: "recipe not found"},
+			status: http.StatusNotFound,
+			want: &gonethttpresponsejsend.ErrorBody{
+				Status:  gonethttpresponsejsend.StatusError,
+				Code:    "recipe_not_found",
+				Message: "No se encontró la receta",
+			},
+		},
+		{
+			name:   "body with a code",
+			body:   &gonethttpresponsejsend.ErrorBody{Status: gonethttpresponsejsend.StatusError, Message: "recipe not found", Code: "forbidden"},
+			status: http.StatusForbidden,
+			want: &gonethttpresponsejsend.ErrorBody{
+				Status:  gonethttpresponsejsend.StatusError,
+				Code:    "forbidden",
+				Message: "No se encontró la receta",
+			},
+		},
+		{
+			name:   "problem details",
+			body:   &gonethttpresponsejsend.ErrorBody{Status: gonethttpresponsejsend.StatusError, Message: "recipe not found"},
+			status: http.StatusNotFound,
+			accept: internalproblem.ContentType,
+			want: &internalproblem.Details{
+				Type:     internalproblem.TypePrefix + "recipe_not_found",
+				Title:    http.StatusText(http.StatusNotFound),
+				Status:   http.StatusNotFound,
+				Detail:   "No se encontró la receta",
+				Instance: "/recipes/1",
+				Code:     "recipe_not_found",
+			},
+			contentType: internalproblem.ContentType,
+		},
+		{
+			name:   "problem details of a success",
+			body:   map[string]string{"name": "Arepas"},
+			status: http.StatusOK,
+			accept: internalproblem.ContentType,
+			want:   map[string]string{"name": "Arepas"},
+		},
+		{
+			name:   "other body",
+			body:   map[string]string{"name": "Arepas"},
+			status: http.StatusOK,
+			want:   map[string]string{"name": "Arepas"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/recipes/1", nil)
+				r.Header.Set("Accept-Language", "es")
+				if test.accept != "" {
+					r.Header.Set("Accept", test.accept)
+				}
+				w := httptest.NewRecorder()
+
+				response := localizeResponse(w, r, gonethttpresponse.NewResponse(test.body, test.status), catalog)
+				if got := response.Body(nil); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("Body() = %#v, want %#v", got, test.want)
+				}
+				if got := w.Header().Get("Content-Type"); got != test.contentType {
+					t.Errorf("Content-Type = %q, want %q", got, test.contentType)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"nil response", func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/recipes/1", nil)
+			if response := localizeResponse(httptest.NewRecorder(), r, nil, catalog); response != nil {
+				t.Errorf("localizeResponse() = %v, want nil", response)
+			}
+		},
+	)
+}
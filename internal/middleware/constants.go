@@ -12,10 +12,8 @@ import (
 	gonethttpmiddlewareauth "github.com/ralvarezdev/go-net/http/middleware/auth"
 	gonethttpmiddlewareauthgrpc "github.com/ralvarezdev/go-net/http/middleware/auth/grpc"
 	gonethttpmiddlewareerrorhandler "github.com/ralvarezdev/go-net/http/middleware/errorhandler"
-	gonethttpmiddlewareratelimiter "github.com/ralvarezdev/go-net/http/middleware/ratelimiter/redis"
 	gonethttpmiddlewaresizelimiter "github.com/ralvarezdev/go-net/http/middleware/sizelimiter"
 	gonethttpmiddlewarevalidator "github.com/ralvarezdev/go-net/http/middleware/validator"
-	gonethttpresponsejsendgrpc "github.com/ralvarezdev/go-net/http/response/jsend/grpc"
 	goratelimiter "github.com/ralvarezdev/go-rate-limiter/redis"
 	pbauth "github.com/ralvarezdev/grpc-auth-proto-go"
 	"google.golang.org/grpc"
@@ -59,10 +57,18 @@ var (
 	// LimitRequests is the API rate limiter middleware function
 	LimitRequests func(next http.Handler) http.Handler
 
+	// LimitPublicRequests is the stricter rate limiter middleware function for the endpoints that need no session,
+	// applied on top of LimitRequests
+	LimitPublicRequests func(next http.Handler) http.Handler
+
 	// Authenticate is the JWT authentication middleware function
 	Authenticate func(
 		method string,
 	) func(next http.Handler) http.Handler
+
+	// AuthenticateAccessToken is the JWT access token authentication middleware function for the endpoints served by
+	// this API that are not proxied to the gRPC auth service
+	AuthenticateAccessToken func(next http.Handler) http.Handler
 )
 
 // RefreshToken is the function to refresh JWT tokens
@@ -97,7 +103,7 @@ func RefreshToken(
 		&pbempty.Empty{},
 		grpc.Header(&header),
 	); err != nil {
-		return nil, gonethttpresponsejsendgrpc.ParseError(err, true)
+		return nil, internalgrpcauth.ParseError(err, true)
 	}
 
 	// Get the refreshed tokens from the context
@@ -124,12 +130,14 @@ func RefreshToken(
 //   - jsonHandler: The JSON handler
 //   - protoJSONHandler: The ProtoJSON handler
 //   - rateLimiter: The rate limiter
+//   - publicRateLimiter: The rate limiter for the endpoints that need no session
 //   - jwtValidator: The JWT validator
 //   - logger: The logger
 func Load(
 	jsonHandler gonethttphandler.Handler,
 	protoJSONHandler gonethttphandler.Handler,
 	rateLimiter goratelimiter.RateLimiter,
+	publicRateLimiter goratelimiter.RateLimiter,
 	jwtValidator gojwttokenvalidor.Validator,
 	logger *slog.Logger,
 ) {
@@ -179,7 +187,7 @@ func Load(
 	ValidateProtoJSON = protoJSONValidator.Validate
 
 	// Create API rate limiter middleware
-	rateLimiterMiddleware, err := gonethttpmiddlewareratelimiter.NewMiddleware(
+	LimitRequests, err = newRateLimiter(
 		jsonHandler,
 		rateLimiter,
 		logger,
@@ -187,7 +195,16 @@ func Load(
 	if err != nil {
 		panic(err)
 	}
-	LimitRequests = rateLimiterMiddleware.Limit()
+
+	// Create the public endpoints rate limiter middleware
+	LimitPublicRequests, err = newRateLimiter(
+		jsonHandler,
+		publicRateLimiter,
+		logger,
+	)
+	if err != nil {
+		panic(err)
+	}
 
 	// Initialize JWT options
 	cookieRefreshTokenName := gojwttoken.RefreshToken.String()
@@ -207,6 +224,9 @@ func Load(
 	if err != nil {
 		panic(err)
 	}
+	AuthenticateAccessToken = authenticator.AuthenticateFromCookie(
+		gojwttoken.AccessToken,
+	)
 
 	// Create JWT authentication middleware
 	grpcAuthenticator, err := gonethttpmiddlewareauthgrpc.NewMiddleware(
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	gonethttp "github.com/ralvarezdev/go-net/http"
+	gonethttphandler "github.com/ralvarezdev/go-net/http/handler"
+	goratelimiter "github.com/ralvarezdev/go-rate-limiter/redis"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+// newRateLimiter creates a middleware that limits the requests of each client IP address. Unlike the go-net rate
+// limiter, which answers in plain text, the exceeded limit is rendered like any other error, with its stable code
+//
+// Parameters:
+//
+//   - responsesHandler: The handler that renders the errors
+//   - rateLimiter: The rate limiter
+//   - logger: The logger (optional, can be nil)
+//
+// Returns:
+//
+//   - func(next http.Handler) http.Handler: The middleware function
+//   - error: An error if the responses handler or the rate limiter is nil
+func newRateLimiter(
+	responsesHandler gonethttphandler.ResponsesHandler,
+	rateLimiter goratelimiter.RateLimiter,
+	logger *slog.Logger,
+) (func(next http.Handler) http.Handler, error) {
+	if responsesHandler == nil {
+		return nil, gonethttphandler.ErrNilHandler
+	}
+	if rateLimiter == nil {
+		return nil, goratelimiter.ErrNilRateLimiter
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				// Limit the number of requests per IP address
+				ip := gonethttp.GetClientIP(r)
+				if err := rateLimiter.Limit(ip); err != nil {
+					if errors.Is(err, goratelimiter.ErrTooManyRequests) {
+						responsesHandler.HandleErrorWithCode(
+							w,
+							r,
+							gonethttp.ErrTooManyRequests,
+							internalerrorcodes.TooManyRequests.String(),
+							http.StatusTooManyRequests,
+						)
+						return
+					}
+
+					if logger != nil {
+						logger.Error(
+							"Error limiting requests",
+							slog.String("ip", ip),
+							slog.String("error", err.Error()),
+						)
+					}
+					responsesHandler.HandleRawError(w, r, err, nil)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			},
+		)
+	}, nil
+}
@@ -0,0 +1,31 @@
+package negotiation
+
+const (
+	// JSONMediaType is the media type of the JSON responses, the default encoding
+	JSONMediaType = "application/json"
+
+	// ProtobufMediaType is the media type of the binary protobuf responses
+	ProtobufMediaType = "application/x-protobuf"
+
+	// MessagePackMediaType is the media type of the MessagePack responses
+	MessagePackMediaType = "application/msgpack"
+
+	// AcceptHeader is the header the responses vary on
+	AcceptHeader = "Accept"
+)
+
+var (
+	// ProtobufMediaTypes are the media types clients use to ask for binary protobuf
+	ProtobufMediaTypes = []string{
+		ProtobufMediaType,
+		"application/protobuf",
+		"application/vnd.google.protobuf",
+	}
+
+	// MessagePackMediaTypes are the media types clients use to ask for MessagePack
+	MessagePackMediaTypes = []string{
+		MessagePackMediaType,
+		"application/x-msgpack",
+		"application/vnd.msgpack",
+	}
+)
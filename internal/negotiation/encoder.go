@@ -0,0 +1,139 @@
+package negotiation
+
+import (
+	"bytes"
+	"fmt"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// Encoder encodes the responses in a media type other than JSON
+	Encoder interface {
+		// MediaType returns the media type of the encoded responses
+		MediaType() string
+
+		// MediaTypes returns the media types of the Accept header the encoder answers to
+		MediaTypes() []string
+
+		// Encode encodes the body of a response, returning ErrUnsupportedBody if the body cannot be encoded
+		Encode(mode *goflagsmode.Flag, response gonethttpresponse.Response) ([]byte, error)
+	}
+
+	// ProtobufEncoder encodes the data of the success responses that hold a protobuf message in binary protobuf. As
+	// protobuf has no envelope, the data is written without the JSend one and the HTTP status tells the outcome
+	ProtobufEncoder struct{}
+
+	// MessagePackEncoder encodes the JSend bodies in MessagePack, keeping the JSON field names
+	MessagePackEncoder struct{}
+)
+
+// NewProtobufEncoder creates a new ProtobufEncoder
+//
+// Returns:
+//
+//   - *ProtobufEncoder: the ProtobufEncoder instance
+func NewProtobufEncoder() *ProtobufEncoder {
+	return &ProtobufEncoder{}
+}
+
+// MediaType returns the media type of the encoded responses
+//
+// Returns:
+//
+//   - string: the binary protobuf media type
+func (p *ProtobufEncoder) MediaType() string {
+	return ProtobufMediaType
+}
+
+// MediaTypes returns the media types of the Accept header the encoder answers to
+//
+// Returns:
+//
+//   - []string: the binary protobuf media types
+func (p *ProtobufEncoder) MediaTypes() []string {
+	return ProtobufMediaTypes
+}
+
+// Encode encodes the protobuf message of a success response
+//
+// Parameters:
+//
+//   - mode: the go-flags mode flag
+//   - response: the response
+//
+// Returns:
+//
+//   - []byte: the encoded message, empty if the response has no data
+//   - error: ErrUnsupportedBody if the response is not a success one or its data is not a protobuf message
+func (p *ProtobufEncoder) Encode(mode *goflagsmode.Flag, response gonethttpresponse.Response) ([]byte, error) {
+	body, ok := response.Body(mode).(*gonethttpresponsejsend.SuccessBody[any])
+	if !ok || body == nil {
+		return nil, ErrUnsupportedBody
+	}
+	if body.Data == nil {
+		return []byte{}, nil
+	}
+	message, ok := body.Data.(proto.Message)
+	if !ok {
+		return nil, ErrUnsupportedBody
+	}
+	encoded, err := proto.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEncodeBodyFailed, err)
+	}
+	return encoded, nil
+}
+
+// NewMessagePackEncoder creates a new MessagePackEncoder
+//
+// Returns:
+//
+//   - *MessagePackEncoder: the MessagePackEncoder instance
+func NewMessagePackEncoder() *MessagePackEncoder {
+	return &MessagePackEncoder{}
+}
+
+// MediaType returns the media type of the encoded responses
+//
+// Returns:
+//
+//   - string: the MessagePack media type
+func (m *MessagePackEncoder) MediaType() string {
+	return MessagePackMediaType
+}
+
+// MediaTypes returns the media types of the Accept header the encoder answers to
+//
+// Returns:
+//
+//   - []string: the MessagePack media types
+func (m *MessagePackEncoder) MediaTypes() []string {
+	return MessagePackMediaTypes
+}
+
+// Encode encodes the body of a response in MessagePack, with the same field names as in JSON
+//
+// Parameters:
+//
+//   - mode: the go-flags mode flag
+//   - response: the response
+//
+// Returns:
+//
+//   - []byte: the encoded body
+//   - error: an error if the body could not be encoded
+func (m *MessagePackEncoder) Encode(mode *goflagsmode.Flag, response gonethttpresponse.Response) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := msgpack.NewEncoder(&buffer)
+	encoder.SetCustomStructTag("json")
+	encoder.UseCompactInts(true)
+	if err := encoder.Encode(response.Body(mode)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEncodeBodyFailed, err)
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,130 @@
+package negotiation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newTestMode creates the mode flag used by the tests
+func newTestMode() *goflagsmode.Flag {
+	return goflagsmode.NewFlag(goflagsmode.Dev, goflagsmode.AllowedModes)
+}
+
+func TestProtobufEncoderEncode(t *testing.T) {
+	message := wrapperspb.String("pancakes")
+	want, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		response gonethttpresponse.Response
+		want     []byte
+		wantErr  error
+	}{
+		{
+			name:     "protobuf message",
+			response: gonethttpresponsejsend.NewSuccessResponse(message, http.StatusOK),
+			want:     want,
+		},
+		{
+			name:     "no data",
+			response: gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusNoContent),
+			want:     []byte{},
+		},
+		{
+			name:     "not a protobuf message",
+			response: gonethttpresponsejsend.NewSuccessResponse(map[string]string{"name": "pancakes"}, http.StatusOK),
+			wantErr:  ErrUnsupportedBody,
+		},
+		{
+			name:     "fail body",
+			response: gonethttpresponsejsend.NewFailResponse(message, http.StatusOK),
+			wantErr:  ErrUnsupportedBody,
+		},
+	}
+	encoder := NewProtobufEncoder()
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				got, err := encoder.Encode(newTestMode(), test.response)
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("Encode() error = %v, want %v", err, test.wantErr)
+				}
+				if test.wantErr != nil {
+					return
+				}
+				if got == nil || string(got) != string(test.want) {
+					t.Errorf("Encode() = %v, want %v", got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestMessagePackEncoderEncode(t *testing.T) {
+	type recipe struct {
+		Name     string `json:"name"`
+		Servings int    `json:"servings"`
+	}
+
+	encoded, err := NewMessagePackEncoder().Encode(
+		newTestMode(),
+		gonethttpresponsejsend.NewSuccessResponse(recipe{Name: "Pancakes", Servings: 2}, http.StatusOK),
+	)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var decoded map[string]any
+	if err = msgpack.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("msgpack.Unmarshal() error = %v", err)
+	}
+	if decoded["status"] != string(gonethttpresponsejsend.StatusSuccess) {
+		t.Errorf("status = %v, want %q", decoded["status"], gonethttpresponsejsend.StatusSuccess)
+	}
+	data, ok := decoded["data"].(map[string]any)
+	if !ok {
+		t.Fatalf("data = %#v, want a map", decoded["data"])
+	}
+	if data["name"] != "Pancakes" {
+		t.Errorf("data.name = %v, want %q", data["name"], "Pancakes")
+	}
+	if servings := fmt.Sprint(data["servings"]); servings != "2" {
+		t.Errorf("data.servings = %s, want 2", servings)
+	}
+}
+
+func TestEncoderMediaTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder Encoder
+		want    string
+	}{
+		{name: "protobuf", encoder: NewProtobufEncoder(), want: ProtobufMediaType},
+		{name: "MessagePack", encoder: NewMessagePackEncoder(), want: MessagePackMediaType},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := test.encoder.MediaType(); got != test.want {
+					t.Errorf("MediaType() = %q, want %q", got, test.want)
+				}
+				mediaTypes := test.encoder.MediaTypes()
+				if len(mediaTypes) == 0 || mediaTypes[0] != test.want {
+					t.Errorf("MediaTypes() = %q, want %q first", mediaTypes, test.want)
+				}
+			},
+		)
+	}
+}
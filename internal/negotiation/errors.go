@@ -0,0 +1,11 @@
+package negotiation
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilEncoder       = errors.New("response encoder cannot be nil")
+	ErrUnsupportedBody  = errors.New("response body cannot be encoded in the negotiated media type")
+	ErrEncodeBodyFailed = errors.New("failed to encode the response body")
+)
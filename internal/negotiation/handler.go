@@ -0,0 +1,125 @@
+package negotiation
+
+import (
+	"errors"
+	"net/http"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	gonethttphandler "github.com/ralvarezdev/go-net/http/handler"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+type (
+	// Handler wraps a handler to encode the success responses in the media type the Accept header asks for, among the
+	// ones of its encoders. JSON stays the default, and the fail and error responses are always written by the wrapped
+	// handler
+	Handler struct {
+		gonethttphandler.Handler
+		mode     *goflagsmode.Flag
+		encoders []Encoder
+	}
+)
+
+// NewHandler creates a new Handler
+//
+// Parameters:
+//
+//   - mode: the go-flags mode flag
+//   - handler: the handler that writes the JSON responses
+//   - encoders: the encoders of the other media types
+//
+// Returns:
+//
+//   - *Handler: the Handler instance
+//   - error: an error if the mode, the handler or an encoder is nil
+func NewHandler(
+	mode *goflagsmode.Flag,
+	handler gonethttphandler.Handler,
+	encoders ...Encoder,
+) (*Handler, error) {
+	if mode == nil {
+		return nil, goflagsmode.ErrNilModeFlag
+	}
+	if handler == nil {
+		return nil, gonethttphandler.ErrNilHandler
+	}
+	for _, encoder := range encoders {
+		if encoder == nil {
+			return nil, ErrNilEncoder
+		}
+	}
+	return &Handler{
+		Handler:  handler,
+		mode:     mode,
+		encoders: encoders,
+	}, nil
+}
+
+// HandleResponse writes the response in the negotiated media type, falling back to JSON if the client does not ask
+// for another one or the body cannot be encoded in it
+//
+// Parameters:
+//
+//   - w: the HTTP response writer
+//   - r: the HTTP request
+//   - response: the response
+func (h *Handler) HandleResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	response gonethttpresponse.Response,
+) {
+	w.Header().Add("Vary", AcceptHeader)
+
+	// Only the success responses are negotiated
+	encoder := h.negotiate(r)
+	if encoder == nil || response == nil || response.HTTPStatus() >= http.StatusBadRequest {
+		h.Handler.HandleResponse(w, r, response)
+		return
+	}
+
+	body, err := encoder.Encode(h.mode, response)
+	if errors.Is(err, ErrUnsupportedBody) {
+		h.Handler.HandleResponse(w, r, response)
+		return
+	}
+	if err != nil {
+		h.HandleRawError(w, r, err, nil)
+		return
+	}
+	w.Header().Set("Content-Type", encoder.MediaType())
+	w.WriteHeader(response.HTTPStatus())
+	_, _ = w.Write(body)
+}
+
+// negotiate picks the encoder of the media type the client prefers. A media type must be listed explicitly in the
+// Accept header with a quality value not lower than the one of JSON to be preferred over it
+//
+// Parameters:
+//
+//   - r: the HTTP request
+//
+// Returns:
+//
+//   - Encoder: the encoder, or nil if JSON must be used
+func (h *Handler) negotiate(r *http.Request) Encoder {
+	if len(h.encoders) == 0 {
+		return nil
+	}
+	mediaTypes := internalrequest.GetAcceptedMediaTypes(r)
+
+	var preferred Encoder
+	bestQuality := mediaTypes[JSONMediaType]
+	for _, encoder := range h.encoders {
+		for _, mediaType := range encoder.MediaTypes() {
+			quality, ok := mediaTypes[mediaType]
+			if !ok || quality <= 0 || quality < bestQuality || (preferred != nil && quality == bestQuality) {
+				continue
+			}
+			preferred = encoder
+			bestQuality = quality
+		}
+	}
+	return preferred
+}
@@ -0,0 +1,235 @@
+package negotiation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	gonethttphandler "github.com/ralvarezdev/go-net/http/handler"
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type (
+	// recordingHandler records the responses and raw errors the negotiation handler leaves to the wrapped handler
+	recordingHandler struct {
+		gonethttphandler.Handler
+		responses int
+		rawErrors int
+	}
+
+	// failingEncoder fails to encode every response
+	failingEncoder struct {
+		MessagePackEncoder
+	}
+)
+
+// HandleResponse records the response and writes its status
+func (h *recordingHandler) HandleResponse(
+	w http.ResponseWriter,
+	_ *http.Request,
+	response gonethttpresponse.Response,
+) {
+	h.responses++
+	w.Header().Set("Content-Type", JSONMediaType)
+	if response != nil {
+		w.WriteHeader(response.HTTPStatus())
+	}
+}
+
+// HandleRawError records the error and writes an internal server error
+func (h *recordingHandler) HandleRawError(
+	w http.ResponseWriter,
+	_ *http.Request,
+	_ error,
+	_ []byte,
+) {
+	h.rawErrors++
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+// Encode fails with an encoding error
+func (f *failingEncoder) Encode(*goflagsmode.Flag, gonethttpresponse.Response) ([]byte, error) {
+	return nil, ErrEncodeBodyFailed
+}
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     *goflagsmode.Flag
+		handler  gonethttphandler.Handler
+		encoders []Encoder
+		wantErr  error
+	}{
+		{name: "valid", mode: newTestMode(), handler: &recordingHandler{}, encoders: []Encoder{NewProtobufEncoder()}},
+		{name: "no encoders", mode: newTestMode(), handler: &recordingHandler{}},
+		{name: "nil mode", handler: &recordingHandler{}, wantErr: goflagsmode.ErrNilModeFlag},
+		{name: "nil handler", mode: newTestMode(), wantErr: gonethttphandler.ErrNilHandler},
+		{
+			name:     "nil encoder",
+			mode:     newTestMode(),
+			handler:  &recordingHandler{},
+			encoders: []Encoder{NewProtobufEncoder(), nil},
+			wantErr:  ErrNilEncoder,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				handler, err := NewHandler(test.mode, test.handler, test.encoders...)
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("NewHandler() error = %v, want %v", err, test.wantErr)
+				}
+				if test.wantErr == nil && handler == nil {
+					t.Error("NewHandler() = nil, want a handler")
+				}
+			},
+		)
+	}
+}
+
+func TestHandlerNegotiate(t *testing.T) {
+	protobufEncoder := NewProtobufEncoder()
+	messagePackEncoder := NewMessagePackEncoder()
+
+	tests := []struct {
+		name   string
+		accept string
+		want   Encoder
+	}{
+		{name: "no header"},
+		{name: "JSON", accept: JSONMediaType},
+		{name: "wildcard", accept: "*/*"},
+		{name: "protobuf", accept: ProtobufMediaType, want: protobufEncoder},
+		{name: "protobuf alias", accept: "application/vnd.google.protobuf", want: protobufEncoder},
+		{name: "MessagePack", accept: MessagePackMediaType, want: messagePackEncoder},
+		{name: "MessagePack alias", accept: "application/x-msgpack", want: messagePackEncoder},
+		{name: "JSON preferred", accept: MessagePackMediaType + ";q=0.5, " + JSONMediaType},
+		{
+			name:   "same quality as JSON",
+			accept: JSONMediaType + ";q=0.8, " + MessagePackMediaType + ";q=0.8",
+			want:   messagePackEncoder,
+		},
+		{name: "rejected", accept: ProtobufMediaType + ";q=0"},
+		{name: "higher quality", accept: ProtobufMediaType + ";q=0.5, " + MessagePackMediaType, want: messagePackEncoder},
+		{name: "same quality", accept: MessagePackMediaType + ", " + ProtobufMediaType, want: protobufEncoder},
+	}
+	handler, err := NewHandler(newTestMode(), &recordingHandler{}, protobufEncoder, messagePackEncoder)
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				if test.accept != "" {
+					r.Header.Set(AcceptHeader, test.accept)
+				}
+				if got := handler.negotiate(r); got != test.want {
+					t.Errorf("negotiate() with %q = %T, want %T", test.accept, got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestHandlerHandleResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		accept          string
+		encoders        []Encoder
+		response        gonethttpresponse.Response
+		wantStatus      int
+		wantContentType string
+		wantResponses   int
+		wantRawErrors   int
+	}{
+		{
+			name:            "JSON",
+			encoders:        []Encoder{NewProtobufEncoder()},
+			response:        gonethttpresponsejsend.NewSuccessResponse(wrapperspb.String("pancakes"), http.StatusOK),
+			wantStatus:      http.StatusOK,
+			wantContentType: JSONMediaType,
+			wantResponses:   1,
+		},
+		{
+			name:            "protobuf",
+			accept:          ProtobufMediaType,
+			encoders:        []Encoder{NewProtobufEncoder()},
+			response:        gonethttpresponsejsend.NewSuccessResponse(wrapperspb.String("pancakes"), http.StatusCreated),
+			wantStatus:      http.StatusCreated,
+			wantContentType: ProtobufMediaType,
+		},
+		{
+			name:            "unsupported body",
+			accept:          ProtobufMediaType,
+			encoders:        []Encoder{NewProtobufEncoder()},
+			response:        gonethttpresponsejsend.NewSuccessResponse(map[string]string{}, http.StatusOK),
+			wantStatus:      http.StatusOK,
+			wantContentType: JSONMediaType,
+			wantResponses:   1,
+		},
+		{
+			name:            "fail response",
+			accept:          MessagePackMediaType,
+			encoders:        []Encoder{NewMessagePackEncoder()},
+			response:        gonethttpresponsejsend.NewFailResponse(map[string]string{}, http.StatusBadRequest),
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: JSONMediaType,
+			wantResponses:   1,
+		},
+		{
+			name:            "nil response",
+			accept:          MessagePackMediaType,
+			encoders:        []Encoder{NewMessagePackEncoder()},
+			wantStatus:      http.StatusOK,
+			wantContentType: JSONMediaType,
+			wantResponses:   1,
+		},
+		{
+			name:          "encoding error",
+			accept:        MessagePackMediaType,
+			encoders:      []Encoder{&failingEncoder{}},
+			response:      gonethttpresponsejsend.NewSuccessResponse(map[string]string{}, http.StatusOK),
+			wantStatus:    http.StatusInternalServerError,
+			wantRawErrors: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				recorder := &recordingHandler{}
+				handler, err := NewHandler(newTestMode(), recorder, test.encoders...)
+				if err != nil {
+					t.Fatalf("NewHandler() error = %v", err)
+				}
+
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				if test.accept != "" {
+					r.Header.Set(AcceptHeader, test.accept)
+				}
+				w := httptest.NewRecorder()
+				handler.HandleResponse(w, r, test.response)
+
+				if w.Code != test.wantStatus {
+					t.Errorf("status = %d, want %d", w.Code, test.wantStatus)
+				}
+				if got := w.Header().Get("Content-Type"); got != test.wantContentType {
+					t.Errorf("Content-Type = %q, want %q", got, test.wantContentType)
+				}
+				if got := w.Header().Get("Vary"); got != AcceptHeader {
+					t.Errorf("Vary = %q, want %q", got, AcceptHeader)
+				}
+				if recorder.responses != test.wantResponses {
+					t.Errorf("wrapped responses = %d, want %d", recorder.responses, test.wantResponses)
+				}
+				if recorder.rawErrors != test.wantRawErrors {
+					t.Errorf("wrapped raw errors = %d, want %d", recorder.rawErrors, test.wantRawErrors)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,15 @@
+package problem
+
+const (
+	// ContentType is the media type of the problem details, as defined by RFC 7807
+	ContentType = "application/problem+json"
+
+	// JSONContentType is the media type of the JSend bodies, the default format of the responses
+	JSONContentType = "application/json"
+
+	// BlankType is the problem type of the errors without a code, whose title is the HTTP status text
+	BlankType = "about:blank"
+
+	// TypePrefix is the prefix of the problem types of the errors with a code, followed by the code
+	TypePrefix = "urn:uru-mobiles-recipes-api:error:"
+)
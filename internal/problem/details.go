@@ -0,0 +1,91 @@
+package problem
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+)
+
+type (
+	// Details is a problem details body as defined by RFC 7807. Besides the standard members, it carries the stable
+	// code of the error and, for fail bodies, the messages per field
+	Details struct {
+		Type     string `json:"type"`
+		Title    string `json:"title"`
+		Status   int    `json:"status"`
+		Detail   string `json:"detail,omitempty"`
+		Instance string `json:"instance,omitempty"`
+		Code     string `json:"code,omitempty"`
+		Errors   any    `json:"errors,omitempty"`
+	}
+)
+
+// NewDetails creates the problem details of a JSend fail or error body
+//
+// Parameters:
+//
+//   - body: the JSend body
+//   - httpStatus: the HTTP status of the response
+//   - instance: the path of the request the problem occurred on
+//
+// Returns:
+//
+//   - *Details: the problem details, or nil if the body is not a JSend fail or error body
+func NewDetails(body any, httpStatus int, instance string) *Details {
+	details := &Details{
+		Type:     BlankType,
+		Title:    http.StatusText(httpStatus),
+		Status:   httpStatus,
+		Instance: instance,
+	}
+	switch body := body.(type) {
+	case *gonethttpresponsejsend.FailBody:
+		if body == nil {
+			return nil
+		}
+		details.Code = body.Code
+		details.Errors = body.Data
+
+		// A single message describes the whole problem
+		var messages []string
+		collectMessages(body.Data, &messages)
+		if len(messages) == 1 {
+			details.Detail = messages[0]
+		}
+	case *gonethttpresponsejsend.ErrorBody:
+		if body == nil {
+			return nil
+		}
+		details.Code = body.Code
+		details.Detail = body.Message
+	default:
+		return nil
+	}
+	if details.Code != "" {
+		details.Type = TypePrefix + details.Code
+	}
+	return details
+}
+
+// collectMessages collects the messages of the data of a fail body
+//
+// Parameters:
+//
+//   - data: the data
+//   - messages: the messages, appended to
+func collectMessages(data any, messages *[]string) {
+	switch value := data.(type) {
+	case string:
+		*messages = append(*messages, value)
+	case []string:
+		*messages = append(*messages, value...)
+	case map[string][]string:
+		for _, fieldMessages := range value {
+			*messages = append(*messages, fieldMessages...)
+		}
+	case map[string]any:
+		for _, fieldData := range value {
+			collectMessages(fieldData, messages)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package problem
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+)
+
+func TestNewDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   any
+		status int
+		want   *Details
+	}{
+		{
+			name:   "error body",
+			body:   &gonethttpresponsejsend.ErrorBody{Message: "The recipe was not found", Code: "recipe_not_found"},
+			status: http.StatusNotFound,
+			want: &Details{
+				Type:     TypePrefix + "recipe_not_found",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "The recipe was not found",
+				Instance: "/recipes/1",
+				Code:     "recipe_not_found",
+			},
+		},
+		{
+			name:   "error body without a code",
+			body:   &gonethttpresponsejsend.ErrorBody{Message: "Something failed"},
+			status: http.StatusInternalServerError,
+			want: &Details{
+				Type:     BlankType,
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "Something failed",
+				Instance: "/recipes/1",
+			},
+		},
+		{
+			name: "fail body with a single message",
+			body: &gonethttpresponsejsend.FailBody{
+				Data: map[string][]string{"name": {"Name is required"}},
+				Code: "validation_failed",
+			},
+			status: http.StatusBadRequest,
+			want: &Details{
+				Type:     TypePrefix + "validation_failed",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "Name is required",
+				Instance: "/recipes/1",
+				Code:     "validation_failed",
+				Errors:   map[string][]string{"name": {"Name is required"}},
+			},
+		},
+		{
+			name: "fail body with several messages",
+			body: &gonethttpresponsejsend.FailBody{
+				Data: map[string]any{
+					"name":     []string{"Name is required"},
+					"servings": "Servings must be positive",
+				},
+			},
+			status: http.StatusBadRequest,
+			want: &Details{
+				Type:     BlankType,
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Instance: "/recipes/1",
+				Errors: map[string]any{
+					"name":     []string{"Name is required"},
+					"servings": "Servings must be positive",
+				},
+			},
+		},
+		{
+			name:   "fail body with a message",
+			body:   &gonethttpresponsejsend.FailBody{Data: "Too many requests", Code: "too_many_requests"},
+			status: http.StatusTooManyRequests,
+			want: &Details{
+				Type:     TypePrefix + "too_many_requests",
+				Title:    "Too Many Requests",
+				Status:   http.StatusTooManyRequests,
+				Detail:   "Too many requests",
+				Instance: "/recipes/1",
+				Code:     "too_many_requests",
+				Errors:   "Too many requests",
+			},
+		},
+		{name: "nil fail body", body: (*gonethttpresponsejsend.FailBody)(nil), status: http.StatusBadRequest},
+		{name: "nil error body", body: (*gonethttpresponsejsend.ErrorBody)(nil), status: http.StatusBadRequest},
+		{name: "other body", body: map[string]string{"name": "Arepas"}, status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := NewDetails(test.body, test.status, "/recipes/1"); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("NewDetails() = %+v, want %+v", got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestCollectMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want int
+	}{
+		{name: "message", data: "Name is required", want: 1},
+		{name: "messages", data: []string{"Name is required", "Steps are required"}, want: 2},
+		{name: "messages per field", data: map[string][]string{"name": {"a", "b"}, "steps": {"c"}}, want: 3},
+		{name: "nested fields", data: map[string]any{"name": "a", "nested": map[string]any{"steps": []string{"b"}}}, want: 2},
+		{name: "other data", data: 42},
+		{name: "nil"},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				var messages []string
+				collectMessages(test.data, &messages)
+				if len(messages) != test.want {
+					t.Errorf("collectMessages() = %q, want %d messages", messages, test.want)
+				}
+			},
+		)
+	}
+}
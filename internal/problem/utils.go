@@ -0,0 +1,23 @@
+package problem
+
+import (
+	"net/http"
+
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// Accepts checks if the client asks for problem details. The problem+json media type must be listed explicitly in the
+// Accept header, with a quality value not lower than the one of JSON, since JSend is the default format
+//
+// Parameters:
+//
+//   - r: the HTTP request
+//
+// Returns:
+//
+//   - bool: true if the errors must be rendered as problem details, false otherwise
+func Accepts(r *http.Request) bool {
+	mediaTypes := internalrequest.GetAcceptedMediaTypes(r)
+	quality, ok := mediaTypes[ContentType]
+	return ok && quality > 0 && quality >= mediaTypes[JSONContentType]
+}
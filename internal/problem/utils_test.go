@@ -0,0 +1,42 @@
+package problem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept []string
+		want   bool
+	}{
+		{name: "no header"},
+		{name: "problem details", accept: []string{ContentType}, want: true},
+		{name: "JSON", accept: []string{JSONContentType}},
+		{name: "wildcard", accept: []string{"*/*"}},
+		{name: "problem details preferred", accept: []string{JSONContentType + ";q=0.5, " + ContentType}, want: true},
+		{name: "JSON preferred", accept: []string{ContentType + ";q=0.5, " + JSONContentType}},
+		{name: "same quality", accept: []string{JSONContentType + ";q=0.8, " + ContentType + ";q=0.8"}, want: true},
+		{name: "rejected", accept: []string{ContentType + ";q=0"}},
+		{name: "invalid quality", accept: []string{ContentType + ";q=high"}},
+		{name: "uppercase", accept: []string{"Application/Problem+JSON"}, want: true},
+		{name: "several headers", accept: []string{JSONContentType + ";q=0.2", ContentType}, want: true},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				for _, value := range test.accept {
+					r.Header.Add(internalrequest.AcceptHeader, value)
+				}
+				if got := Accepts(r); got != test.want {
+					t.Errorf("Accepts() with %q = %t, want %t", test.accept, got, test.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,44 @@
+package profiles
+
+import (
+	"log/slog"
+
+	pbauth "github.com/ralvarezdev/grpc-auth-proto-go/compiled/ralvarezdev/auth"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+)
+
+const (
+	// UsernameField is the auth service profile field that holds the username
+	UsernameField = "username"
+
+	// RecentLimit is the maximum number of recipes and groups listed on a public profile
+	RecentLimit = 10
+)
+
+var (
+	// PublicFields are the auth service profile fields shown on the public profiles, the rest are never stored
+	PublicFields = []string{UsernameField, "first_name", "last_name"}
+
+	// Profiles is the cache of the public profiles of the users
+	Profiles *Cache
+)
+
+// Load initializes the profiles constants
+//
+// Parameters:
+//
+//   - service: The recipes SQLite service
+//   - client: The gRPC auth client
+//   - logger: The logger (optional, can be nil)
+func Load(
+	service *internalsqliterecipes.Service,
+	client pbauth.AuthClient,
+	logger *slog.Logger,
+) {
+	cache, err := NewCache(service, client, PublicFields, RecentLimit, logger)
+	if err != nil {
+		panic(err)
+	}
+	Profiles = cache
+}
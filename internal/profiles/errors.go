@@ -0,0 +1,12 @@
+package profiles
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilCache          = errors.New("profiles cache cannot be nil")
+	ErrNilRecipesService = errors.New("recipes service cannot be nil")
+	ErrNilAuthClient     = errors.New("auth client cannot be nil")
+	ErrMissingUsername   = errors.New("profile has no username")
+)
@@ -0,0 +1,222 @@
+package profiles
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	pbauth "github.com/ralvarezdev/grpc-auth-proto-go/compiled/ralvarezdev/auth"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	pbempty "google.golang.org/protobuf/types/known/emptypb"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Cache keeps a snapshot of the public fields of the auth service profile of each user, taken whenever the user
+	// reads or changes their own profile, so the public profiles are served without calling the auth service
+	Cache struct {
+		service *internalsqliterecipes.Service
+		client  pbauth.AuthClient
+		fields  []string
+		limit   int
+		logger  *slog.Logger
+	}
+)
+
+// NewCache creates a new Cache
+//
+// Parameters:
+//
+//   - service: the recipes SQLite service
+//   - client: the gRPC auth client
+//   - fields: the auth service profile fields that are stored, the rest are dropped
+//   - limit: the maximum number of recipes and groups listed on a public profile
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Cache: the Cache instance
+//   - error: an error if the service or the client is nil
+func NewCache(
+	service *internalsqliterecipes.Service,
+	client pbauth.AuthClient,
+	fields []string,
+	limit int,
+	logger *slog.Logger,
+) (*Cache, error) {
+	// Check if the service or the client is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+	if client == nil {
+		return nil, ErrNilAuthClient
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "profiles"),
+		)
+	}
+
+	return &Cache{
+		service: service,
+		client:  client,
+		fields:  fields,
+		limit:   limit,
+		logger:  logger,
+	}, nil
+}
+
+// logError logs an error if the logger is set
+//
+// Parameters:
+//
+//   - msg: the log message
+//   - err: the error to log
+//   - userID: the ID of the user
+func (c *Cache) logError(msg string, err error, userID string) {
+	if c.logger != nil {
+		c.logger.Error(
+			msg,
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// snapshot keeps the public fields of an auth service profile
+//
+// Parameters:
+//
+//   - profile: the auth service profile
+//
+// Returns:
+//
+//   - string: the username
+//   - []byte: the public fields, encoded as a JSON object
+//   - error: an error if the profile could not be encoded or has no username
+func (c *Cache) snapshot(profile proto.Message) (string, []byte, error) {
+	// Encode the profile with the field names of the proto definition, which match the ones of this API
+	encodedProfile, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(profile)
+	if err != nil {
+		return "", nil, err
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(encodedProfile, &fields); err != nil {
+		return "", nil, err
+	}
+
+	// Keep the public fields
+	publicFields := make(map[string]any, len(c.fields))
+	for _, field := range c.fields {
+		if value, ok := fields[field]; ok {
+			publicFields[field] = value
+		}
+	}
+
+	// Get the username
+	username, _ := publicFields[UsernameField].(string)
+	if username == "" {
+		return "", nil, ErrMissingUsername
+	}
+
+	encodedPublicFields, err := json.Marshal(publicFields)
+	if err != nil {
+		return "", nil, err
+	}
+	return username, encodedPublicFields, nil
+}
+
+// Store stores the snapshot of the auth service profile of a user. Errors are logged, the request that read the
+// profile must not fail because of them
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+//   - profile: the auth service profile of the user
+func (c *Cache) Store(
+	ctx context.Context,
+	userID string,
+	profile proto.Message,
+) {
+	if c == nil {
+		return
+	}
+
+	username, encodedProfile, err := c.snapshot(profile)
+	if err != nil {
+		c.logError("Failed to read user profile", err, userID)
+		return
+	}
+	if err = c.service.UpsertUserProfile(
+		ctx,
+		userID,
+		username,
+		encodedProfile,
+	); err != nil {
+		c.logError("Failed to store user profile", err, userID)
+	}
+}
+
+// Refresh reads the auth service profile of a user again and stores its snapshot, after the user changed it.
+// Errors are logged, the change itself already succeeded
+//
+// Parameters:
+//
+//   - grpcCtx: the context for the gRPC call, carrying the authorization token of the user
+//   - userID: the ID of the user
+func (c *Cache) Refresh(grpcCtx context.Context, userID string) {
+	if c == nil {
+		return
+	}
+
+	profile, err := c.client.GetMyProfile(grpcCtx, &pbempty.Empty{})
+	if err != nil {
+		c.logError("Failed to refresh user profile", err, userID)
+		return
+	}
+	c.Store(grpcCtx, userID, profile)
+}
+
+// Forget deletes the snapshot of the profile of a user, after the user was deleted. Errors are logged, the user
+// itself was already deleted
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - userID: the ID of the user
+func (c *Cache) Forget(ctx context.Context, userID string) {
+	if c == nil {
+		return
+	}
+
+	if err := c.service.DeleteUserProfile(ctx, userID); err != nil {
+		c.logError("Failed to delete user profile", err, userID)
+	}
+}
+
+// Get gets the public profile of a user by username
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - username: the username of the user, case-insensitive
+//   - language: the language used to localize the tags
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.UserProfile: the profile
+//   - error: an error if the user has no snapshot or the profile could not be read
+func (c *Cache) Get(
+	ctx context.Context,
+	username, language string,
+) (*internalrouterapiv1recipe.UserProfile, error) {
+	if c == nil {
+		return nil, ErrNilCache
+	}
+	return c.service.GetUserProfile(ctx, username, language, c.limit)
+}
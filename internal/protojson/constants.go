@@ -5,7 +5,11 @@ import (
 
 	goflagsmode "github.com/ralvarezdev/go-flags/mode"
 	gonethttphandler "github.com/ralvarezdev/go-net/http/handler"
-	gonethttphandlerprotojsonjsend "github.com/ralvarezdev/go-net/http/handler/protojson/jsend"
+	gonethttphandlerprotojson "github.com/ralvarezdev/go-net/http/handler/protojson"
+	gonethttpresponsehandlerjsend "github.com/ralvarezdev/go-net/http/response/handler/jsend"
+
+	internalmessages "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/messages"
+	internalnegotiation "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/negotiation"
 )
 
 var (
@@ -18,15 +22,38 @@ var (
 // Parameters:
 //
 //   - mode: the go-flags mode flag to determine if the environment is in debug mode
+//   - messages: the message catalog used to localize the fail and error bodies, also rendered as problem details if
+//     the client asks for them
 //   - logger: the logger instance
-func Load(mode *goflagsmode.Flag, logger *slog.Logger) {
+func Load(mode *goflagsmode.Flag, messages *internalmessages.Catalog, logger *slog.Logger) {
 	// Initialize the handler
-	handler, err := gonethttphandlerprotojsonjsend.NewHandler(
+	rawErrorHandler, err := internalmessages.NewRawErrorHandler(
+		gonethttpresponsehandlerjsend.NewRawErrorHandler(logger),
+		messages,
+	)
+	if err != nil {
+		panic(err)
+	}
+	handler, err := gonethttphandlerprotojson.NewHandler(
+		mode,
+		rawErrorHandler,
+	)
+	if err != nil {
+		panic(err)
+	}
+	localizedHandler, err := internalmessages.NewHandler(handler, messages)
+	if err != nil {
+		panic(err)
+	}
+
+	// Encode the success responses in binary protobuf when the client asks for it
+	negotiatedHandler, err := internalnegotiation.NewHandler(
 		mode,
-		logger,
+		localizedHandler,
+		internalnegotiation.NewProtobufEncoder(),
 	)
 	if err != nil {
 		panic(err)
 	}
-	Handler = handler
+	Handler = negotiatedHandler
 }
@@ -0,0 +1,54 @@
+package recommender
+
+import (
+	"log/slog"
+	"time"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+)
+
+const (
+	// RefreshPeriod is the period the similarities and recommendations are computed again
+	RefreshPeriod = time.Hour
+
+	// MaxSimilar is the number of similar recipes kept for each recipe
+	MaxSimilar = 20
+
+	// MaxRecommendations is the number of recipes kept as recommendations for each user
+	MaxRecommendations = 50
+
+	// MinSimilarity is the similarity below which two recipes are not considered similar
+	MinSimilarity = 0.05
+)
+
+var (
+	// SignalWeights are the weights of each way a user showed interest in a recipe. Forking a recipe or saving it
+	// to a group says more about the taste of the user than owning it, which includes imports and forks, and cooking
+	// it says the most
+	SignalWeights = map[internalsqliterecipes.RecipeSignalKind]float64{
+		internalsqliterecipes.RecipeSignalKindOwn:   1,
+		internalsqliterecipes.RecipeSignalKindFork:  2,
+		internalsqliterecipes.RecipeSignalKindGroup: 2,
+		internalsqliterecipes.RecipeSignalKindCook:  3,
+	}
+
+	// Recommendations is the recommender that computes the similar and recommended recipes
+	Recommendations *Recommender
+)
+
+// Load initializes the recommender constants
+//
+// Parameters:
+//
+//   - service: The recipes SQLite service
+//   - logger: The logger (optional, can be nil)
+func Load(
+	service *internalsqliterecipes.Service,
+	logger *slog.Logger,
+) {
+	recommender, err := NewRecommender(service, logger)
+	if err != nil {
+		panic(err)
+	}
+	Recommendations = recommender
+}
@@ -0,0 +1,10 @@
+package recommender
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilRecommender    = errors.New("recommender cannot be nil")
+	ErrNilRecipesService = errors.New("recipes service cannot be nil")
+)
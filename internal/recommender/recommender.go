@@ -0,0 +1,311 @@
+package recommender
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Recommender periodically computes the public recipes most similar to each recipe, comparing the TF-IDF
+	// vectors of their ingredients and canonical tags, and the public recipes recommended to each user, adding up
+	// the similarities to the recipes the user owns, forked, saved to a group or cooked. The results are stored, so
+	// the reads do not compute anything
+	Recommender struct {
+		service *internalsqliterecipes.Service
+		logger  *slog.Logger
+	}
+
+	// posting is the weight of a term in the vector of a public recipe, by the index of the recipe
+	posting struct {
+		index  int
+		weight float64
+	}
+)
+
+// NewRecommender creates a new Recommender
+//
+// Parameters:
+//
+//   - service: the recipes SQLite service
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Recommender: the Recommender instance
+//   - error: an error if the service is nil
+func NewRecommender(
+	service *internalsqliterecipes.Service,
+	logger *slog.Logger,
+) (*Recommender, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "recommender"),
+		)
+	}
+
+	return &Recommender{
+		service: service,
+		logger:  logger,
+	}, nil
+}
+
+// Start computes the recommendations and keeps computing them periodically, until the context is done
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the recommender is nil
+func (r *Recommender) Start(ctx context.Context) error {
+	// Check if the recommender is nil
+	if r == nil {
+		return ErrNilRecommender
+	}
+
+	go func() {
+		ticker := time.NewTicker(RefreshPeriod)
+		defer ticker.Stop()
+
+		for {
+			if err := r.Refresh(ctx); err != nil && ctx.Err() == nil && r.logger != nil {
+				r.logger.Error(
+					"Failed to compute recommendations",
+					slog.String("error", err.Error()),
+				)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Refresh computes the similarities and recommendations and replaces the stored ones
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if they could not be computed or stored
+func (r *Recommender) Refresh(ctx context.Context) error {
+	// Check if the recommender is nil
+	if r == nil {
+		return ErrNilRecommender
+	}
+
+	features, err := r.service.ListRecipeFeatures(ctx)
+	if err != nil {
+		return err
+	}
+	signals, err := r.service.ListRecipeSignals(ctx)
+	if err != nil {
+		return err
+	}
+
+	similarities := Similarities(features)
+	recommendations := Recommend(features, similarities, signals)
+	return r.service.ReplaceRecommendations(ctx, similarities, recommendations)
+}
+
+// terms returns the distinct terms of a recipe: its normalized ingredient names and its tag IDs
+//
+// Parameters:
+//
+//   - recipe: the features of the recipe
+//
+// Returns:
+//
+//   - []string: the terms
+func terms(recipe *internalsqliterecipes.RecipeFeatures) []string {
+	recipeTerms := make([]string, 0, len(recipe.Ingredients)+len(recipe.TagIDs))
+	for _, ingredient := range recipe.Ingredients {
+		if name := strings.Join(strings.Fields(strings.ToLower(ingredient)), " "); name != "" {
+			recipeTerms = append(recipeTerms, "ingredient:"+name)
+		}
+	}
+	for _, tagID := range recipe.TagIDs {
+		recipeTerms = append(recipeTerms, "tag:"+strconv.Itoa(tagID))
+	}
+	slices.Sort(recipeTerms)
+	return slices.Compact(recipeTerms)
+}
+
+// top sorts scored recipe IDs from the highest score and keeps the first ones
+//
+// Parameters:
+//
+//   - scores: the scores by recipe ID
+//   - limit: the maximum number of recipe IDs to keep
+//
+// Returns:
+//
+//   - []*internalsqliterecipes.ScoredRecipeID: the recipe IDs with their scores
+func top(scores map[int]float64, limit int) []*internalsqliterecipes.ScoredRecipeID {
+	scored := make([]*internalsqliterecipes.ScoredRecipeID, 0, len(scores))
+	for recipeID, score := range scores {
+		scored = append(scored, &internalsqliterecipes.ScoredRecipeID{ID: recipeID, Score: score})
+	}
+	slices.SortFunc(
+		scored, func(a, b *internalsqliterecipes.ScoredRecipeID) int {
+			if c := cmp.Compare(b.Score, a.Score); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.ID, a.ID)
+		},
+	)
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+// Similarities computes the public recipes most similar to each recipe, as the cosine similarity of their TF-IDF
+// vectors. Only the recipes sharing a term are compared
+//
+// Parameters:
+//
+//   - features: the features of every recipe
+//
+// Returns:
+//
+//   - map[int][]*internalsqliterecipes.ScoredRecipeID: the most similar public recipes, by recipe ID
+func Similarities(
+	features []*internalsqliterecipes.RecipeFeatures,
+) map[int][]*internalsqliterecipes.ScoredRecipeID {
+	// Count the recipes of each term
+	recipeTerms := make([][]string, len(features))
+	frequencies := make(map[string]int)
+	for i, recipe := range features {
+		recipeTerms[i] = terms(recipe)
+		for _, term := range recipeTerms[i] {
+			frequencies[term]++
+		}
+	}
+
+	// Weight the terms by their rarity and index the public recipes by term
+	vectors := make([][]float64, len(features))
+	postings := make(map[string][]posting)
+	total := float64(len(features))
+	for i, recipe := range features {
+		var norm float64
+		vectors[i] = make([]float64, len(recipeTerms[i]))
+		for j, term := range recipeTerms[i] {
+			vectors[i][j] = math.Log(1 + total/float64(frequencies[term]))
+			norm += vectors[i][j] * vectors[i][j]
+		}
+		norm = math.Sqrt(norm)
+		for j := range vectors[i] {
+			vectors[i][j] /= norm
+		}
+
+		if recipe.Visibility != internalrouterapiv1recipe.VisibilityPublic {
+			continue
+		}
+		for j, term := range recipeTerms[i] {
+			postings[term] = append(postings[term], posting{index: i, weight: vectors[i][j]})
+		}
+	}
+
+	// Add up the products of the shared terms
+	similarities := make(map[int][]*internalsqliterecipes.ScoredRecipeID, len(features))
+	for i, recipe := range features {
+		scores := make(map[int]float64)
+		for j, term := range recipeTerms[i] {
+			for _, other := range postings[term] {
+				if other.index != i {
+					scores[features[other.index].ID] += vectors[i][j] * other.weight
+				}
+			}
+		}
+		for recipeID, score := range scores {
+			if score < MinSimilarity {
+				delete(scores, recipeID)
+			}
+		}
+		if len(scores) > 0 {
+			similarities[recipe.ID] = top(scores, MaxSimilar)
+		}
+	}
+	return similarities
+}
+
+// Recommend computes the public recipes recommended to each user, as the average similarity to the recipes the user
+// showed interest in, weighted by the kind of interest. The recipes of the user and the ones they already showed
+// interest in are left out
+//
+// Parameters:
+//
+//   - features: the features of every recipe
+//   - similarities: the most similar public recipes, by recipe ID
+//   - signals: the recipes every user showed interest in
+//
+// Returns:
+//
+//   - map[string][]*internalsqliterecipes.ScoredRecipeID: the recommended recipes, by user ID
+func Recommend(
+	features []*internalsqliterecipes.RecipeFeatures,
+	similarities map[int][]*internalsqliterecipes.ScoredRecipeID,
+	signals []*internalsqliterecipes.RecipeSignal,
+) map[string][]*internalsqliterecipes.ScoredRecipeID {
+	ownersByID := make(map[int]string, len(features))
+	for _, recipe := range features {
+		ownersByID[recipe.ID] = recipe.OwnerID
+	}
+
+	// Keep the strongest interest of each user in each recipe
+	interests := make(map[string]map[int]float64)
+	for _, signal := range signals {
+		if interests[signal.UserID] == nil {
+			interests[signal.UserID] = make(map[int]float64)
+		}
+		interests[signal.UserID][signal.RecipeID] = max(
+			interests[signal.UserID][signal.RecipeID],
+			SignalWeights[signal.Kind],
+		)
+	}
+
+	recommendations := make(map[string][]*internalsqliterecipes.ScoredRecipeID, len(interests))
+	for userID, recipes := range interests {
+		var totalWeight float64
+		scores := make(map[int]float64)
+		for recipeID, weight := range recipes {
+			totalWeight += weight
+			for _, similar := range similarities[recipeID] {
+				if _, ok := recipes[similar.ID]; ok || ownersByID[similar.ID] == userID {
+					continue
+				}
+				scores[similar.ID] += weight * similar.Score
+			}
+		}
+		if len(scores) == 0 {
+			continue
+		}
+		for recipeID := range scores {
+			scores[recipeID] /= totalWeight
+		}
+		recommendations[userID] = top(scores, MaxRecommendations)
+	}
+	return recommendations
+}
@@ -0,0 +1,306 @@
+package request
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+const (
+	// DefaultLimit is the default number of items returned by paginated endpoints
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of items returned by paginated endpoints
+	MaxLimit = 100
+
+	// DefaultLanguage is the default language used to localize the responses
+	DefaultLanguage = "en"
+
+	// LimitQueryParameter is the query parameter for the pagination limit
+	LimitQueryParameter = "limit"
+
+	// OffsetQueryParameter is the query parameter for the pagination offset
+	OffsetQueryParameter = "offset"
+
+	// SinceQueryParameter is the query parameter for the token of the previous sync
+	SinceQueryParameter = "since"
+
+	// LanguageQueryParameter is the query parameter for the response language
+	LanguageQueryParameter = "lang"
+
+	// AcceptLanguageHeader is the header with the languages accepted by the client
+	AcceptLanguageHeader = "Accept-Language"
+
+	// AcceptHeader is the header with the media types accepted by the client
+	AcceptHeader = "Accept"
+)
+
+var (
+	ErrInvalidPathParameter  = errors.New("invalid path parameter")
+	ErrInvalidQueryParameter = errors.New("invalid query parameter")
+	ErrMissingQueryParameter = errors.New("missing query parameter")
+)
+
+// GetPathID gets a positive integer ID from a path wildcard
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//   - key: The wildcard key
+//
+// Returns:
+//
+//   - int: The ID
+//   - error: A fail field error if the wildcard is not a positive integer
+func GetPathID(r *http.Request, key string) (int, error) {
+	id, err := strconv.Atoi(r.PathValue(key))
+	if err != nil || id <= 0 {
+		return 0, gonethttpresponse.NewFailFieldErrorWithCode(
+			key,
+			ErrInvalidPathParameter,
+			internalerrorcodes.InvalidPathParameter.String(),
+			http.StatusBadRequest,
+		)
+	}
+	return id, nil
+}
+
+// GetQueryInt gets an integer from a query parameter within the given bounds
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//   - key: The query parameter key
+//   - defaultValue: The value returned if the query parameter is not set
+//   - minValue: The minimum accepted value
+//   - maxValue: The maximum accepted value
+//
+// Returns:
+//
+//   - int: The query parameter value
+//   - error: A fail field error if the query parameter is not an integer within the bounds
+func GetQueryInt(
+	r *http.Request,
+	key string,
+	defaultValue, minValue, maxValue int,
+) (int, error) {
+	rawValue := r.URL.Query().Get(key)
+	if rawValue == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(rawValue)
+	if err != nil || value < minValue || value > maxValue {
+		return 0, gonethttpresponse.NewFailFieldErrorWithCode(
+			key,
+			ErrInvalidQueryParameter,
+			internalerrorcodes.InvalidQueryParameter.String(),
+			http.StatusBadRequest,
+		)
+	}
+	return value, nil
+}
+
+// GetRequiredQueryInt gets an integer from a query parameter that must be set, within the given bounds
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//   - key: The query parameter key
+//   - minValue: The minimum accepted value
+//   - maxValue: The maximum accepted value
+//
+// Returns:
+//
+//   - int: The query parameter value
+//   - error: A fail field error if the query parameter is not set or is not an integer within the bounds
+func GetRequiredQueryInt(
+	r *http.Request,
+	key string,
+	minValue, maxValue int,
+) (int, error) {
+	if r.URL.Query().Get(key) == "" {
+		return 0, gonethttpresponse.NewFailFieldErrorWithCode(
+			key,
+			ErrMissingQueryParameter,
+			internalerrorcodes.MissingQueryParameter.String(),
+			http.StatusBadRequest,
+		)
+	}
+	return GetQueryInt(r, key, 0, minValue, maxValue)
+}
+
+// GetQueryBool gets a boolean from a query parameter
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//   - key: The query parameter key
+//
+// Returns:
+//
+//   - bool: The query parameter value, false if it's not set
+//   - error: A fail field error if the query parameter is not a boolean
+func GetQueryBool(r *http.Request, key string) (bool, error) {
+	rawValue := r.URL.Query().Get(key)
+	if rawValue == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		return false, gonethttpresponse.NewFailFieldErrorWithCode(
+			key,
+			ErrInvalidQueryParameter,
+			internalerrorcodes.InvalidQueryParameter.String(),
+			http.StatusBadRequest,
+		)
+	}
+	return value, nil
+}
+
+// GetPagination gets the limit and offset query parameters
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//
+// Returns:
+//
+//   - int: The limit
+//   - int: The offset
+//   - error: A fail field error if any of the query parameters is invalid
+func GetPagination(r *http.Request) (int, int, error) {
+	limit, err := GetQueryInt(r, LimitQueryParameter, DefaultLimit, 1, MaxLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := GetQueryInt(r, OffsetQueryParameter, 0, 0, math.MaxInt32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return limit, offset, nil
+}
+
+// GetAcceptedMediaTypes gets the media types the client lists in the Accept header with their quality value. Wildcard
+// ranges such as */* are kept as they are written
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//
+// Returns:
+//
+//   - map[string]float64: The quality value by lowercase media type without parameters, empty if the client did not
+//     send the header. A media type listed more than once keeps its highest quality value
+func GetAcceptedMediaTypes(r *http.Request) map[string]float64 {
+	mediaTypes := make(map[string]float64)
+	for _, header := range r.Header.Values(AcceptHeader) {
+		for _, entry := range strings.Split(header, ",") {
+			mediaType, params, _ := strings.Cut(entry, ";")
+			mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+			if mediaType == "" {
+				continue
+			}
+			quality := 1.0
+			for _, param := range strings.Split(params, ";") {
+				if rawQuality, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+					parsedQuality, err := strconv.ParseFloat(strings.TrimSpace(rawQuality), 64)
+					if err != nil {
+						quality = 0
+					} else {
+						quality = parsedQuality
+					}
+				}
+			}
+			if current, ok := mediaTypes[mediaType]; !ok || quality > current {
+				mediaTypes[mediaType] = quality
+			}
+		}
+	}
+	return mediaTypes
+}
+
+// GetLanguages gets the languages the client accepts, in order of preference. The lang query parameter goes first,
+// followed by the languages of the Accept-Language header sorted by their quality value
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//
+// Returns:
+//
+//   - []string: The lowercase language codes without duplicates, empty if the client did not ask for any
+func GetLanguages(r *http.Request) []string {
+	type acceptedLanguage struct {
+		language string
+		quality  float64
+	}
+
+	// Parse the Accept-Language header, ignoring the wildcard and the rejected languages
+	var accepted []acceptedLanguage
+	for _, header := range r.Header.Values(AcceptLanguageHeader) {
+		for _, entry := range strings.Split(header, ",") {
+			tag, params, _ := strings.Cut(entry, ";")
+			quality := 1.0
+			if rawQuality, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				parsedQuality, err := strconv.ParseFloat(strings.TrimSpace(rawQuality), 64)
+				if err != nil {
+					continue
+				}
+				quality = parsedQuality
+			}
+			if quality <= 0 || strings.TrimSpace(tag) == "*" {
+				continue
+			}
+			accepted = append(accepted, acceptedLanguage{language: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(
+		accepted, func(i, j int) bool {
+			return accepted[i].quality > accepted[j].quality
+		},
+	)
+
+	// Collect the languages
+	candidates := []string{r.URL.Query().Get(LanguageQueryParameter)}
+	for _, entry := range accepted {
+		candidates = append(candidates, entry.language)
+	}
+	var languages []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		language := internalsqliterecipes.NormalizeLanguage(candidate)
+		if language == "" || seen[language] {
+			continue
+		}
+		seen[language] = true
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// GetLanguage gets the language used to localize the response from the lang query parameter or the Accept-Language
+// header
+//
+// Parameters:
+//
+//   - r: The HTTP request
+//
+// Returns:
+//
+//   - string: The lowercase language code, or the default language if it's not set
+func GetLanguage(r *http.Request) string {
+	if languages := GetLanguages(r); len(languages) > 0 {
+		return languages[0]
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,98 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetLanguages(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage []string
+		want           []string
+	}{
+		{name: "none"},
+		{name: "query parameter", query: "?lang=es", want: []string{"es"}},
+		{name: "region subtag", acceptLanguage: []string{"es-VE"}, want: []string{"es"}},
+		{name: "uppercase", acceptLanguage: []string{"ES_ve"}, want: []string{"es"}},
+		{
+			name:           "sorted by quality",
+			acceptLanguage: []string{"fr;q=0.5, en;q=0.8, es"},
+			want:           []string{"es", "en", "fr"},
+		},
+		{
+			name:           "same quality keeps the order",
+			acceptLanguage: []string{"pt, en;q=0.7, fr;q=0.7"},
+			want:           []string{"pt", "en", "fr"},
+		},
+		{
+			name:           "query parameter goes first",
+			query:          "?lang=fr",
+			acceptLanguage: []string{"es, en;q=0.5"},
+			want:           []string{"fr", "es", "en"},
+		},
+		{
+			name:           "several headers",
+			acceptLanguage: []string{"es;q=0.4", "en"},
+			want:           []string{"en", "es"},
+		},
+		{
+			name:           "duplicates",
+			query:          "?lang=es",
+			acceptLanguage: []string{"es-VE, es-ES;q=0.9, en;q=0.8"},
+			want:           []string{"es", "en"},
+		},
+		{
+			name:           "wildcard and rejected languages",
+			acceptLanguage: []string{"*, fr;q=0, es;q=0.3"},
+			want:           []string{"es"},
+		},
+		{
+			name:           "invalid entries",
+			query:          "?lang=e1",
+			acceptLanguage: []string{"x, english, es;q=abc, en;q=0.2"},
+			want:           []string{"en"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/recipes"+test.query, nil)
+				for _, value := range test.acceptLanguage {
+					r.Header.Add(AcceptLanguageHeader, value)
+				}
+				if got := GetLanguages(r); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("GetLanguages() = %q, want %q", got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestGetLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "default", want: DefaultLanguage},
+		{name: "only rejected languages", acceptLanguage: "es;q=0", want: DefaultLanguage},
+		{name: "preferred", acceptLanguage: "en;q=0.1, es-VE", want: "es"},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/recipes", nil)
+				if test.acceptLanguage != "" {
+					r.Header.Set(AcceptLanguageHeader, test.acceptLanguage)
+				}
+				if got := GetLanguage(r); got != test.want {
+					t.Errorf("GetLanguage() = %q, want %q", got, test.want)
+				}
+			},
+		)
+	}
+}
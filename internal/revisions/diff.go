@@ -0,0 +1,132 @@
+package revisions
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// Diff compares two revisions of a recipe. The scalar fields are reported only when they changed, while the
+// ingredients and the steps are reported in full, each item marked as unchanged, added or removed
+//
+// Parameters:
+//
+//   - from: the older revision
+//   - to: the newer revision
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.RevisionDiff: the diff
+//   - error: an error if any of the revisions is nil
+func Diff(from, to *internalrouterapiv1recipe.Revision) (*internalrouterapiv1recipe.RevisionDiff, error) {
+	// Check if the revisions are nil
+	if from == nil || to == nil {
+		return nil, ErrNilRevision
+	}
+
+	diff := &internalrouterapiv1recipe.RevisionDiff{
+		RecipeID:    to.RecipeID,
+		From:        from.Number,
+		To:          to.Number,
+		Fields:      make([]internalrouterapiv1recipe.FieldChange, 0),
+		Ingredients: make([]internalrouterapiv1recipe.IngredientChange, 0),
+		Steps:       make([]internalrouterapiv1recipe.StepChange, 0),
+	}
+
+	// Compare the scalar fields
+	addField := func(field string, fromValue, toValue any) {
+		if fromValue != toValue {
+			diff.Fields = append(
+				diff.Fields, internalrouterapiv1recipe.FieldChange{
+					Field: field,
+					From:  fromValue,
+					To:    toValue,
+				},
+			)
+		}
+	}
+	addField("name", from.Name, to.Name)
+	addField("description", from.Description, to.Description)
+	addField("preparation_time", from.PreparationTime, to.PreparationTime)
+	addField("cooking_time", from.CookingTime, to.CookingTime)
+	addField("servings", from.Servings, to.Servings)
+	addField("difficulty", from.Difficulty, to.Difficulty)
+	addField("image_url", from.ImageURL, to.ImageURL)
+
+	// Compare the lists
+	for _, change := range diffLists(from.Ingredients, to.Ingredients) {
+		diff.Ingredients = append(
+			diff.Ingredients, internalrouterapiv1recipe.IngredientChange{
+				Operation:  change.operation,
+				Ingredient: change.item,
+			},
+		)
+	}
+	for _, change := range diffLists(from.Steps, to.Steps) {
+		diff.Steps = append(
+			diff.Steps, internalrouterapiv1recipe.StepChange{
+				Operation: change.operation,
+				Step:      change.item,
+			},
+		)
+	}
+	return diff, nil
+}
+
+type (
+	// listChange is an item of a list marked with the operation that turns the older list into the newer one
+	listChange[T comparable] struct {
+		operation internalrouterapiv1recipe.DiffOperation
+		item      T
+	}
+)
+
+// diffLists compares two lists through their longest common subsequence, so an item inserted in the middle of a
+// list does not mark the items after it as changed
+//
+// Parameters:
+//
+//   - from: the older list
+//   - to: the newer list
+//
+// Returns:
+//
+//   - []listChange[T]: the items of both lists in order, the removed ones before the added ones that replace them
+func diffLists[T comparable](from, to []T) []listChange[T] {
+	// lengths[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lengths := make([][]int, len(from)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	changes := make([]listChange[T], 0, max(len(from), len(to)))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			changes = append(changes, listChange[T]{internalrouterapiv1recipe.DiffOperationUnchanged, from[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			changes = append(changes, listChange[T]{internalrouterapiv1recipe.DiffOperationRemoved, from[i]})
+			i++
+		default:
+			changes = append(changes, listChange[T]{internalrouterapiv1recipe.DiffOperationAdded, to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		changes = append(changes, listChange[T]{internalrouterapiv1recipe.DiffOperationRemoved, from[i]})
+	}
+	for ; j < len(to); j++ {
+		changes = append(changes, listChange[T]{internalrouterapiv1recipe.DiffOperationAdded, to[j]})
+	}
+	return changes
+}
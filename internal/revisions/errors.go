@@ -0,0 +1,9 @@
+package revisions
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilRevision = errors.New("revision cannot be nil")
+)
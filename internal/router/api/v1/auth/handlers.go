@@ -6,12 +6,12 @@ import (
 	gogrpcnethttp "github.com/ralvarezdev/go-grpc/client/net/http"
 	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
 	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
-	gonethttpresponsejsendgrpc "github.com/ralvarezdev/go-net/http/response/jsend/grpc"
 	pbauth "github.com/ralvarezdev/grpc-auth-proto-go/compiled/ralvarezdev/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	pbempty "google.golang.org/protobuf/types/known/emptypb"
 
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
 	internalgrpcauth "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/grpc/auth"
 	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
 	internalprotojson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/protojson"
@@ -25,8 +25,8 @@ import (
 // @Produce json
 // @Param request body pbauth.SignUpRequest true "Sign Up Request"
 // @Success 201 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/signup [post]
 func SignUp(w http.ResponseWriter, r *http.Request) error {
 	// Get the body from the context
@@ -40,7 +40,7 @@ func SignUp(w http.ResponseWriter, r *http.Request) error {
 		r.Context(),
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -60,9 +60,9 @@ func SignUp(w http.ResponseWriter, r *http.Request) error {
 // @Produce json
 // @Param request body pbauth.LogInRequest true "Log In Request"
 // @Success 201 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/login [post]
 func LogIn(w http.ResponseWriter, r *http.Request) error {
 	// Get the body from the context
@@ -81,14 +81,15 @@ func LogIn(w http.ResponseWriter, r *http.Request) error {
 		grpc.Header(&header),
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response (if the response contains 2FA methods, return a fail response)
 	if responseBody != nil && responseBody.GetTwoFactorMethods() != nil {
 		internalprotojson.Handler.HandleResponse(
-			w, r, gonethttpresponsejsend.NewFailResponse(
+			w, r, gonethttpresponsejsend.NewFailResponseWithCode(
 				responseBody,
+				internalerrorcodes.TwoFactorRequired.String(),
 				http.StatusBadRequest,
 			),
 		)
@@ -121,8 +122,8 @@ func LogIn(w http.ResponseWriter, r *http.Request) error {
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[pbauth.ListRefreshTokensResponse]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/refresh-tokens [get]
 func ListRefreshTokens(
 	w http.ResponseWriter,
@@ -142,7 +143,7 @@ func ListRefreshTokens(
 		&pbempty.Empty{},
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -164,8 +165,8 @@ func ListRefreshTokens(
 // @Security CookieAuth
 // @Param request body pbauth.GetRefreshTokenRequest true "Get Refresh Token Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[pbauth.GetRefreshTokenResponse]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/refresh-token [get]
 func GetRefreshToken(
 	w http.ResponseWriter,
@@ -191,7 +192,7 @@ func GetRefreshToken(
 		requestBody,
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -213,9 +214,9 @@ func GetRefreshToken(
 // @Security CookieAuth
 // @Param request body pbauth.RevokeRefreshTokenRequest true "Revoke Refresh Token Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 404 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/refresh-token [delete]
 func RevokeRefreshToken(
 	w http.ResponseWriter,
@@ -244,7 +245,7 @@ func RevokeRefreshToken(
 		requestBody,
 		grpc.Header(&header),
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Parse the metadata to clear cookies
@@ -273,8 +274,8 @@ func RevokeRefreshToken(
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/logout [post]
 func LogOut(w http.ResponseWriter, r *http.Request) error {
 	// Create the context for the gRPC call
@@ -294,7 +295,7 @@ func LogOut(w http.ResponseWriter, r *http.Request) error {
 		&pbempty.Empty{},
 		grpc.Header(&header),
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Parse the metadata to clear cookies
@@ -323,8 +324,8 @@ func LogOut(w http.ResponseWriter, r *http.Request) error {
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/refresh-tokens [delete]
 func RevokeRefreshTokens(
 	w http.ResponseWriter,
@@ -347,7 +348,7 @@ func RevokeRefreshTokens(
 		&pbempty.Empty{},
 		grpc.Header(&header),
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Parse the metadata to clear cookies
@@ -376,8 +377,8 @@ func RevokeRefreshTokens(
 // @Produce json
 // @Security CookieAuth
 // @Success 201 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/refresh-token [post]
 func RefreshToken(w http.ResponseWriter, r *http.Request) error {
 	// Create the context for the gRPC call
@@ -397,7 +398,7 @@ func RefreshToken(w http.ResponseWriter, r *http.Request) error {
 		&pbempty.Empty{},
 		grpc.Header(&header),
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Parse the metadata to clear cookies
@@ -426,8 +427,8 @@ func RefreshToken(w http.ResponseWriter, r *http.Request) error {
 // @Produce json
 // @Security CookieAuth
 // @Success 201 {object} gonethttpresponsejsend.SuccessBody[pbauth.Generate2FATOTPUrlResponse]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/totp/generate [post]
 func Generate2FATOTPUrl(
 	w http.ResponseWriter,
@@ -447,7 +448,7 @@ func Generate2FATOTPUrl(
 		&pbempty.Empty{},
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -469,9 +470,9 @@ func Generate2FATOTPUrl(
 // @Security CookieAuth
 // @Param request body pbauth.Verify2FATOTPRequest true "Verify 2FA TOTP Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/totp/verify [post]
 func Verify2FATOTP(
 	w http.ResponseWriter,
@@ -496,7 +497,7 @@ func Verify2FATOTP(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -517,8 +518,8 @@ func Verify2FATOTP(
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/totp [delete]
 func Revoke2FATOTP(
 	w http.ResponseWriter,
@@ -537,7 +538,7 @@ func Revoke2FATOTP(
 		ctx,
 		&pbempty.Empty{},
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -559,9 +560,9 @@ func Revoke2FATOTP(
 // @Security CookieAuth
 // @Param request body pbauth.ChangeEmailRequest true "Change Email Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/email [put]
 func ChangeEmail(
 	w http.ResponseWriter,
@@ -586,7 +587,7 @@ func ChangeEmail(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -607,9 +608,9 @@ func ChangeEmail(
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/email/send-verification [post]
 func SendEmailVerificationToken(
 	w http.ResponseWriter,
@@ -628,7 +629,7 @@ func SendEmailVerificationToken(
 		ctx,
 		&pbempty.Empty{},
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -650,8 +651,8 @@ func SendEmailVerificationToken(
 // @Security CookieAuth
 // @Param request body pbauth.VerifyEmailRequest true "Verify Email Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/email/verify [post]
 func VerifyEmail(
 	w http.ResponseWriter,
@@ -676,7 +677,7 @@ func VerifyEmail(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -698,9 +699,9 @@ func VerifyEmail(
 // @Security CookieAuth
 // @Param request body pbauth.ChangePasswordRequest true "Change Password Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/password [put]
 func ChangePassword(
 	w http.ResponseWriter,
@@ -725,7 +726,7 @@ func ChangePassword(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -747,9 +748,9 @@ func ChangePassword(
 // @Security CookieAuth
 // @Param request body pbauth.ForgotPasswordRequest true "Forgot Password Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/password/forgot [post]
 func ForgotPassword(
 	w http.ResponseWriter,
@@ -774,7 +775,7 @@ func ForgotPassword(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -796,8 +797,8 @@ func ForgotPassword(
 // @Security CookieAuth
 // @Param request body pbauth.ResetPasswordRequest true "Reset Password Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/password/reset [post]
 func ResetPassword(
 	w http.ResponseWriter,
@@ -822,7 +823,7 @@ func ResetPassword(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -844,9 +845,9 @@ func ResetPassword(
 // @Security CookieAuth
 // @Param request body pbauth.ChangePhoneNumberRequest true "Change Phone Number Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/phone-number [put]
 func ChangePhoneNumber(
 	w http.ResponseWriter,
@@ -871,7 +872,7 @@ func ChangePhoneNumber(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -892,9 +893,9 @@ func ChangePhoneNumber(
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/phone-number/send-verification [post]
 func SendPhoneNumberVerificationCode(
 	w http.ResponseWriter,
@@ -913,7 +914,7 @@ func SendPhoneNumberVerificationCode(
 		ctx,
 		&pbempty.Empty{},
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -935,9 +936,9 @@ func SendPhoneNumberVerificationCode(
 // @Security CookieAuth
 // @Param request body pbauth.VerifyPhoneNumberRequest true "Verify Phone Number Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/phone-number/verify [post]
 func VerifyPhoneNumber(
 	w http.ResponseWriter,
@@ -963,7 +964,7 @@ func VerifyPhoneNumber(
 		requestBody,
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -985,9 +986,9 @@ func VerifyPhoneNumber(
 // @Security CookieAuth
 // @Param request body pbauth.EnableUser2FARequest true "Enable User 2FA Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[pbauth.EnableUser2FAResponse]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/enable [post]
 func EnableUser2FA(
 	w http.ResponseWriter,
@@ -1013,7 +1014,7 @@ func EnableUser2FA(
 		requestBody,
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -1035,9 +1036,9 @@ func EnableUser2FA(
 // @Security CookieAuth
 // @Param request body pbauth.DisableUser2FARequest true "Disable User 2FA Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/disable [post]
 func DisableUser2FA(
 	w http.ResponseWriter,
@@ -1062,7 +1063,7 @@ func DisableUser2FA(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -1084,9 +1085,9 @@ func DisableUser2FA(
 // @Security CookieAuth
 // @Param request body pbauth.RegenerateUser2FARecoveryCodesRequest true "Regenerate User 2FA Recovery Codes Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[pbauth.RegenerateUser2FARecoveryCodesResponse]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/recovery-codes/regenerate [post]
 func RegenerateUser2FARecoveryCodes(
 	w http.ResponseWriter,
@@ -1112,7 +1113,7 @@ func RegenerateUser2FARecoveryCodes(
 		requestBody,
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -1134,9 +1135,9 @@ func RegenerateUser2FARecoveryCodes(
 // @Security CookieAuth
 // @Param request body pbauth.SendUser2FAEmailCodeRequest true "Send User 2FA Email Code Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/auth/2fa/email/send-code [post]
 func SendUser2FAEmailCode(
 	w http.ResponseWriter,
@@ -1161,7 +1162,7 @@ func SendUser2FAEmailCode(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
 	}
 
 	// Handle the response
@@ -0,0 +1,120 @@
+package cookbooks
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// GetCookbook gets the status of a cookbook generation job
+// @Summary Get a cookbook
+// @Description Gets the status of a PDF cookbook generation job of a group owned by the authenticated user, with the download URL once it is done
+// @Tags api v1 cookbooks
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Cookbook ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetCookbookResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/cookbooks/{id} [get]
+func GetCookbook(w http.ResponseWriter, r *http.Request) error {
+	// Get the cookbook ID
+	cookbookID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the job
+	job, err := internalsqlite.RecipesService.GetOwnedCookbookJob(
+		r.Context(),
+		userID,
+		cookbookID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Add the download URL once the PDF is ready
+	responseBody := &GetCookbookResponse{Cookbook: job}
+	if job.Status == internalrouterapiv1recipe.JobStatusDone {
+		responseBody.DownloadURL = fmt.Sprintf("/api/v1/cookbooks/%d/pdf", job.ID)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			responseBody,
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// DownloadCookbook downloads a generated cookbook
+// @Summary Download a cookbook
+// @Description Downloads the PDF of a cookbook generation job that is done
+// @Tags api v1 cookbooks
+// @Produce application/pdf
+// @Security CookieAuth
+// @Param id path int true "Cookbook ID"
+// @Success 200 {file} file
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 409 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/cookbooks/{id}/pdf [get]
+func DownloadCookbook(w http.ResponseWriter, r *http.Request) error {
+	// Get the cookbook ID
+	cookbookID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the PDF
+	job, pdf, err := internalsqlite.RecipesService.GetCookbookPDF(
+		r.Context(),
+		userID,
+		cookbookID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Write the PDF as is, it is not wrapped in a JSend body
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf)))
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="cookbook-%d-%d.pdf"`, job.GroupID, job.ID),
+	)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdf)
+	return nil
+}
@@ -0,0 +1,13 @@
+package cookbooks
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// GetCookbookResponse is the response body of a cookbook generation job
+	GetCookbookResponse struct {
+		Cookbook    *internalrouterapiv1recipe.CookbookJob `json:"cookbook"`
+		DownloadURL string                                 `json:"download_url,omitempty"` // set once the PDF is ready
+	}
+)
@@ -0,0 +1,28 @@
+package cookbooks
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/cookbooks",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddEndpointHandler(
+				"GET /{id}",
+				GetCookbook,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/pdf",
+				DownloadCookbook,
+			)
+		},
+	}
+)
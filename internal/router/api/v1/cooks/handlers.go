@@ -0,0 +1,157 @@
+package cooks
+
+import (
+	"net/http"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// ListMyCooks lists the cooking journal of the authenticated user
+// @Summary List my cooking journal
+// @Description Lists the times the authenticated user cooked a recipe, most recently cooked first. The cooks of deleted recipes are kept with the name the recipe had
+// @Tags api v1 cooks
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of cooks"
+// @Param offset query int false "Number of cooks to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListCooksResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/cooks [get]
+func ListMyCooks(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the cooks
+	cooks, err := internalsqlite.RecipesService.ListCookLogs(
+		r.Context(),
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListCooksResponse{Cooks: cooks},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// UpdateCook updates a cook of the authenticated user
+// @Summary Update a cook
+// @Description Replaces the date, notes, substitutions, rating and photo URLs of a cook logged by the authenticated user
+// @Tags api v1 cooks
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Cook ID"
+// @Param request body UpdateCookRequest true "Update Cook Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/cooks/{id} [put]
+func UpdateCook(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*UpdateCookRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the cook ID
+	cookID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Update the cook
+	if err = internalsqlite.RecipesService.UpdateCookLog(
+		r.Context(),
+		userID,
+		requestBody.CookLog(cookID),
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteCook deletes a cook of the authenticated user
+// @Summary Delete a cook
+// @Description Deletes a cook logged by the authenticated user
+// @Tags api v1 cooks
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Cook ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/cooks/{id} [delete]
+func DeleteCook(w http.ResponseWriter, r *http.Request) error {
+	// Get the cook ID
+	cookID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the cook
+	if err = internalsqlite.RecipesService.DeleteCookLog(
+		r.Context(),
+		userID,
+		cookID,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
@@ -0,0 +1,41 @@
+package cooks
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// UpdateCookRequest is the request body to update a cook log
+	UpdateCookRequest struct {
+		CookedOn      string                                       `json:"cooked_on"` // as YYYY-MM-DD
+		Notes         string                                       `json:"notes,omitempty"`
+		Substitutions []internalrouterapiv1recipe.CookSubstitution `json:"substitutions,omitempty"`
+		Rating        int                                          `json:"rating,omitempty"` // from 1 to 5, 0 to remove the rating
+		Photos        []string                                     `json:"photos,omitempty"` // photo URLs
+	}
+
+	// ListCooksResponse is the response body of the cooking journal
+	ListCooksResponse struct {
+		Cooks []*internalrouterapiv1recipe.CookLog `json:"cooks"`
+	}
+)
+
+// CookLog maps the request body to a cook log
+//
+// Parameters:
+//
+//   - id: the ID of the cook log
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookLog: the cook log
+func (u *UpdateCookRequest) CookLog(id int) *internalrouterapiv1recipe.CookLog {
+	return &internalrouterapiv1recipe.CookLog{
+		ID:            id,
+		CookedOn:      u.CookedOn,
+		Notes:         u.Notes,
+		Substitutions: u.Substitutions,
+		Rating:        u.Rating,
+		Photos:        u.Photos,
+	}
+}
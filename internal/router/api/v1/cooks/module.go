@@ -0,0 +1,33 @@
+package cooks
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/cooks",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListMyCooks,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}",
+				UpdateCook,
+				internalmiddleware.ValidateJSON(UpdateCookRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}",
+				DeleteCook,
+			)
+		},
+	}
+)
@@ -0,0 +1,93 @@
+package diets
+
+import (
+	"net/http"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+)
+
+// GetMyDiets gets the dietary preferences of the authenticated user
+// @Summary Get my diets
+// @Description Gets the dietary preferences of the authenticated user, used to flag the ingredients of a recipe that do not suit them
+// @Tags api v1 diets
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[DietsResponse]
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/diets [get]
+func GetMyDiets(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the diets
+	diets, err := internalsqlite.RecipesService.GetUserDiets(r.Context(), userID)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&DietsResponse{Diets: diets},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// SetMyDiets replaces the dietary preferences of the authenticated user
+// @Summary Set my diets
+// @Description Replaces the dietary preferences of the authenticated user. The known diets are vegetarian, vegan, gluten_free, dairy_free, egg_free and nut_free
+// @Tags api v1 diets
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body SetDietsRequest true "Set Diets Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[DietsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/diets [put]
+func SetMyDiets(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*SetDietsRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Set the diets
+	diets, err := internalsqlite.RecipesService.SetUserDiets(
+		r.Context(),
+		userID,
+		requestBody.Diets,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&DietsResponse{Diets: diets},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,17 @@
+package diets
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// SetDietsRequest is the request body to replace the dietary preferences of the user
+	SetDietsRequest struct {
+		Diets []internalrouterapiv1recipe.Diet `json:"diets"` // empty to remove every preference
+	}
+
+	// DietsResponse is the response body of the dietary preferences of the user
+	DietsResponse struct {
+		Diets []internalrouterapiv1recipe.Diet `json:"diets"`
+	}
+)
@@ -0,0 +1,29 @@
+package diets
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/diets",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				GetMyDiets,
+			)
+			m.AddExactEndpointHandler(
+				"PUT /",
+				SetMyDiets,
+				internalmiddleware.ValidateJSON(SetDietsRequest{}),
+			)
+		},
+	}
+)
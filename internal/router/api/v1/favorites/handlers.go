@@ -0,0 +1,187 @@
+package favorites
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internaltrending "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/trending"
+)
+
+// ListFavoriteRecipes lists the favorite recipes of the authenticated user
+// @Summary List the favorite recipes
+// @Description Lists the recipes the authenticated user marked as a favorite, most recently marked first. Recipes made private by their owners are left out
+// @Tags api v1 favorites
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListFavoriteRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/favorites [get]
+func ListFavoriteRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the favorite recipes
+	recipes, err := internalsqlite.RecipesService.ListFavoriteRecipes(
+		r.Context(),
+		userID,
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListFavoriteRecipesResponse{Recipes: recipes},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// AddFavorite marks a recipe as a favorite of the authenticated user
+// @Summary Add a favorite
+// @Description Marks a recipe the authenticated user can read as a favorite. Marking a recipe twice has no effect and responds with the favorite already stored. Favorites of public recipes of other users count for the trending recipes
+// @Tags api v1 favorites
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[AddFavoriteResponse]
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[AddFavoriteResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/favorites/{id} [put]
+func AddFavorite(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Mark the recipe
+	favorite, created, err := internalsqlite.RecipesService.AddFavorite(
+		r.Context(),
+		userID,
+		recipeID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Count the favorite for the trending recipes, only the first time the recipe is marked
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		recipe, getErr := internalsqlite.RecipesService.GetRecipe(
+			r.Context(),
+			userID,
+			recipeID,
+			internalrequest.GetLanguage(r),
+		)
+		if getErr != nil {
+			return internalsqliterecipes.ParseError(getErr)
+		}
+		internaltrending.Recipes.Track(
+			r.Context(),
+			recipe,
+			internalrouterapiv1recipe.ActivityKindFavorite,
+			internaltrending.Actor(r),
+		)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&AddFavoriteResponse{Favorite: favorite},
+			status,
+		),
+	)
+	return nil
+}
+
+// RemoveFavorite unmarks a recipe as a favorite of the authenticated user
+// @Summary Remove a favorite
+// @Description Unmarks a recipe as a favorite of the authenticated user
+// @Tags api v1 favorites
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/favorites/{id} [delete]
+func RemoveFavorite(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Unmark the recipe
+	if err = internalsqlite.RecipesService.RemoveFavorite(
+		r.Context(),
+		userID,
+		recipeID,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
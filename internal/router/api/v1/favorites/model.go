@@ -0,0 +1,17 @@
+package favorites
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// ListFavoriteRecipesResponse is the response body of the favorite recipes of a user
+	ListFavoriteRecipesResponse struct {
+		Recipes []*internalrouterapiv1recipe.Recipe `json:"recipes"`
+	}
+
+	// AddFavoriteResponse is the response body of a recipe marked as a favorite
+	AddFavoriteResponse struct {
+		Favorite *internalrouterapiv1recipe.Favorite `json:"favorite"`
+	}
+)
@@ -0,0 +1,32 @@
+package favorites
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/favorites",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListFavoriteRecipes,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}",
+				AddFavorite,
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}",
+				RemoveFavorite,
+			)
+		},
+	}
+)
@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"math"
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalfeed "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/feed"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// GetFeed gets the activity feed of the authenticated user
+// @Summary Get the feed
+// @Description Lists the recipes and forks recently published by the users the authenticated user follows, newest first. Pass the next cursor of a page as before to get the following page
+// @Tags api v1 feed
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param before query int false "Cursor of the page, the ID of the oldest event already read"
+// @Param limit query int false "Maximum number of events"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetFeedResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/feed [get]
+func GetFeed(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the cursor and the limit
+	before, err := internalrequest.GetQueryInt(
+		r,
+		internalfeed.BeforeQueryParameter,
+		0,
+		1,
+		math.MaxInt32,
+	)
+	if err != nil {
+		return err
+	}
+	limit, err := internalrequest.GetQueryInt(
+		r,
+		internalrequest.LimitQueryParameter,
+		internalrequest.DefaultLimit,
+		1,
+		internalrequest.MaxLimit,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Read the feed
+	events, next, err := internalfeed.Feeds.Read(
+		r.Context(),
+		userID,
+		before,
+		limit,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	recipes := make([]*internalrouterapiv1recipe.Recipe, 0, len(events))
+	for _, event := range events {
+		recipes = append(recipes, event.Recipe)
+	}
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetFeedResponse{
+				Events: events,
+				Next:   next,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
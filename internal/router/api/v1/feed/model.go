@@ -0,0 +1,13 @@
+package feed
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// GetFeedResponse is the response body of a page of the feed
+	GetFeedResponse struct {
+		Events []*internalrouterapiv1recipe.FeedEvent `json:"events"`
+		Next   int                                    `json:"next,omitempty"` // cursor of the next page, omitted on the last page
+	}
+)
@@ -0,0 +1,24 @@
+package feed
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/feed",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				GetFeed,
+			)
+		},
+	}
+)
@@ -0,0 +1,182 @@
+package follows
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalfeed "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/feed"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// ListFollowees lists the users the authenticated user follows
+// @Summary List the followed users
+// @Description Lists the users the authenticated user follows, most recently followed first
+// @Tags api v1 follows
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of users"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListFollowsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/follows [get]
+func ListFollowees(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the followed users
+	follows, err := internalsqlite.RecipesService.ListFollowees(
+		r.Context(),
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListFollowsResponse{Follows: follows},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ListFollowers lists the followers of the authenticated user
+// @Summary List the followers
+// @Description Lists the users that follow the authenticated user, most recent first
+// @Tags api v1 follows
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of users"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListFollowsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/follows/followers [get]
+func ListFollowers(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the followers
+	follows, err := internalsqlite.RecipesService.ListFollowers(
+		r.Context(),
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListFollowsResponse{Follows: follows},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// FollowUser makes the authenticated user follow a user
+// @Summary Follow a user
+// @Description Makes the authenticated user follow a user, whose public recipes and forks then show up in the feed. Following a user twice has no effect
+// @Tags api v1 follows
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/follows/{user_id} [put]
+func FollowUser(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Follow the user
+	if err = internalfeed.Feeds.Follow(
+		r.Context(),
+		userID,
+		r.PathValue("user_id"),
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// UnfollowUser makes the authenticated user stop following a user
+// @Summary Unfollow a user
+// @Description Makes the authenticated user stop following a user, whose activity is removed from the feed
+// @Tags api v1 follows
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/follows/{user_id} [delete]
+func UnfollowUser(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Unfollow the user
+	if err = internalfeed.Feeds.Unfollow(
+		r.Context(),
+		userID,
+		r.PathValue("user_id"),
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
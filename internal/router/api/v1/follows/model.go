@@ -0,0 +1,12 @@
+package follows
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// ListFollowsResponse is the response body of a list of followed or following users
+	ListFollowsResponse struct {
+		Follows []*internalrouterapiv1recipe.Follow `json:"follows"`
+	}
+)
@@ -0,0 +1,36 @@
+package follows
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/follows",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListFollowees,
+			)
+			m.AddEndpointHandler(
+				"GET /followers",
+				ListFollowers,
+			)
+			m.AddEndpointHandler(
+				"PUT /{user_id}",
+				FollowUser,
+			)
+			m.AddEndpointHandler(
+				"DELETE /{user_id}",
+				UnfollowUser,
+			)
+		},
+	}
+)
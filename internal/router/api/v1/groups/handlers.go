@@ -0,0 +1,499 @@
+package groups
+
+import (
+	"net/http"
+	"time"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalcookbook "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/cookbook"
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaletag "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/etag"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internalshare "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/share"
+)
+
+// CreateGroup creates a recipe group owned by the authenticated user
+// @Summary Create a recipe group
+// @Description Creates a recipe group owned by the authenticated user, keeping the order of its recipes
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body CreateGroupRequest true "Create Group Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateGroupResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups [post]
+func CreateGroup(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*CreateGroupRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Create the group
+	groupID, err := internalsqlite.RecipesService.CreateGroup(
+		r.Context(),
+		userID,
+		requestBody.Group(),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateGroupResponse{ID: groupID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// ListMyGroups lists the recipe groups of the authenticated user
+// @Summary List my recipe groups
+// @Description Lists the recipe groups owned by the authenticated user, newest first
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of groups"
+// @Param offset query int false "Number of groups to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListGroupsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups [get]
+func ListMyGroups(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the groups
+	groups, err := internalsqlite.RecipesService.ListGroups(
+		r.Context(),
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListGroupsResponse{Groups: groups},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetGroup gets a recipe group of the authenticated user
+// @Summary Get a recipe group
+// @Description Gets a recipe group owned by the authenticated user with the IDs of its recipes, in order
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Group ID"
+// @Param If-None-Match header string false "Entity tags of the representations the client already has"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetGroupResponse]
+// @Header 200 {string} ETag "Version of the group and digest of the representation"
+// @Success 304 "Not modified"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups/{id} [get]
+func GetGroup(w http.ResponseWriter, r *http.Request) error {
+	// Get the group ID
+	groupID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the version before the group, so it is never newer than the content sent
+	version, err := internalsqlite.RecipesService.GetVersion(
+		r.Context(),
+		internalrouterapiv1recipe.SyncEntityGroup,
+		groupID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Get the group
+	group, err := internalsqlite.RecipesService.GetGroup(
+		r.Context(),
+		userID,
+		groupID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send no content if the client already has this representation
+	responseBody := &GetGroupResponse{Group: group}
+	tag, err := internaletag.FormatRepresentation(r, version, responseBody)
+	if err != nil {
+		return err
+	}
+	if internaletag.CheckNotModified(w, r, tag) {
+		return nil
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			responseBody,
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// UpdateGroup updates a recipe group of the authenticated user
+// @Summary Update a recipe group
+// @Description Replaces the title, description and recipes of a recipe group owned by the authenticated user
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Group ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body UpdateGroupRequest true "Update Group Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the group"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups/{id} [put]
+func UpdateGroup(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*UpdateGroupRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the group ID
+	groupID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Update the group
+	newVersion, err := internalsqlite.RecipesService.UpdateGroup(
+		r.Context(),
+		userID,
+		requestBody.Group(groupID),
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// SetGroupVisibility sets the visibility of a recipe group of the authenticated user
+// @Summary Set the visibility of a recipe group
+// @Description Sets who can see a recipe group owned by the authenticated user: only its owner (private), anyone with its ID (unlisted) or anyone, listing it on the owner's profile (public)
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Group ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body SetGroupVisibilityRequest true "Set Group Visibility Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the group"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups/{id}/visibility [put]
+func SetGroupVisibility(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*SetGroupVisibilityRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the group ID
+	groupID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Set the visibility
+	newVersion, err := internalsqlite.RecipesService.SetGroupVisibility(
+		r.Context(),
+		userID,
+		groupID,
+		requestBody.Visibility,
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteGroup deletes a recipe group of the authenticated user
+// @Summary Delete a recipe group
+// @Description Deletes a recipe group owned by the authenticated user along with its cookbooks, the recipes are kept
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Group ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups/{id} [delete]
+func DeleteGroup(w http.ResponseWriter, r *http.Request) error {
+	// Get the group ID
+	groupID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the group
+	if err = internalsqlite.RecipesService.DeleteGroup(
+		r.Context(),
+		userID,
+		groupID,
+		versions,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// GenerateCookbook requests the PDF cookbook of a recipe group
+// @Summary Generate a PDF cookbook
+// @Description Queues the generation of a PDF cookbook with a cover page, a table of contents and one recipe per page. The cookbook generated from the current content of the group is returned instead when there is one, so a new job is only queued after the group or its recipes change. Poll the cookbooks endpoint until the job is done
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Group ID"
+// @Param lang query string false "Language of the recipes and tag names, the recipes without a translation to it are printed in their own language"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GenerateCookbookResponse]
+// @Success 202 {object} gonethttpresponsejsend.SuccessBody[GenerateCookbookResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups/{id}/cookbook [post]
+func GenerateCookbook(w http.ResponseWriter, r *http.Request) error {
+	// Get the group ID
+	groupID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Request the cookbook
+	job, queued, err := internalcookbook.Jobs.Request(
+		r.Context(),
+		userID,
+		groupID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Respond with 202 when a new job was queued
+	status := http.StatusOK
+	if queued {
+		status = http.StatusAccepted
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GenerateCookbookResponse{Cookbook: job},
+			status,
+		),
+	)
+	return nil
+}
+
+// CreateGroupShareLink creates a share link to a recipe group of the authenticated user
+// @Summary Share a recipe group
+// @Description Creates an expiring signed link that lets anyone, without an account, read a recipe group owned by the authenticated user along with its recipes
+// @Tags api v1 groups
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Group ID"
+// @Param expires_in query int false "Hours the link is valid for" default(168) maximum(720)
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[ShareLinkResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/groups/{id}/share [post]
+func CreateGroupShareLink(w http.ResponseWriter, r *http.Request) error {
+	// Get the group ID
+	groupID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the expiration
+	expiresIn, err := internalrequest.GetQueryInt(
+		r,
+		internalshare.ExpirationQueryParameter,
+		internalshare.DefaultExpirationHours,
+		1,
+		internalshare.MaxExpirationHours,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Check if the user owns the group
+	if _, err = internalsqlite.RecipesService.GetGroup(
+		r.Context(),
+		userID,
+		groupID,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Create the link
+	link, err := internalshare.Signer.NewLink(
+		internalshare.KindGroup,
+		groupID,
+		userID,
+		time.Duration(expiresIn)*time.Hour,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ShareLinkResponse{Link: link},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
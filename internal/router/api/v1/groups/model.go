@@ -0,0 +1,84 @@
+package groups
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// CreateGroupRequest is the request body to create a recipe group
+	CreateGroupRequest struct {
+		Title       string                               `json:"title"`
+		Description string                               `json:"description,omitempty"`
+		Visibility  internalrouterapiv1recipe.Visibility `json:"visibility,omitempty"` // private (default), unlisted or public
+		RecipeIDs   []int                                `json:"recipe_ids,omitempty"` // in the order they appear in the cookbook
+	}
+
+	// CreateGroupResponse is the response body of a created recipe group
+	CreateGroupResponse struct {
+		ID int `json:"id"`
+	}
+
+	// UpdateGroupRequest is the request body to update a recipe group
+	UpdateGroupRequest struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		RecipeIDs   []int  `json:"recipe_ids"` // in the order they appear in the cookbook
+	}
+
+	// SetGroupVisibilityRequest is the request body to set the visibility of a recipe group
+	SetGroupVisibilityRequest struct {
+		Visibility internalrouterapiv1recipe.Visibility `json:"visibility"` // private, unlisted or public
+	}
+
+	// GetGroupResponse is the response body of a recipe group
+	GetGroupResponse struct {
+		Group *internalrouterapiv1recipe.Group `json:"group"`
+	}
+
+	// ListGroupsResponse is the response body of a list of recipe groups
+	ListGroupsResponse struct {
+		Groups []*internalrouterapiv1recipe.Group `json:"groups"`
+	}
+
+	// ShareLinkResponse is the response body of a created share link
+	ShareLinkResponse struct {
+		Link *internalrouterapiv1recipe.ShareLink `json:"link"`
+	}
+
+	// GenerateCookbookResponse is the response body of a requested cookbook, whose status is polled on the cookbooks endpoint
+	GenerateCookbookResponse struct {
+		Cookbook *internalrouterapiv1recipe.CookbookJob `json:"cookbook"`
+	}
+)
+
+// Group maps the request body to a recipe group
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the group
+func (c *CreateGroupRequest) Group() *internalrouterapiv1recipe.Group {
+	return &internalrouterapiv1recipe.Group{
+		Title:       c.Title,
+		Description: c.Description,
+		Visibility:  c.Visibility,
+		RecipeIDs:   c.RecipeIDs,
+	}
+}
+
+// Group maps the request body to a recipe group
+//
+// Parameters:
+//
+//   - id: the ID of the group
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the group
+func (u *UpdateGroupRequest) Group(id int) *internalrouterapiv1recipe.Group {
+	return &internalrouterapiv1recipe.Group{
+		ID:          id,
+		Title:       u.Title,
+		Description: u.Description,
+		RecipeIDs:   u.RecipeIDs,
+	}
+}
@@ -0,0 +1,55 @@
+package groups
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/groups",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"POST /",
+				CreateGroup,
+				internalmiddleware.ValidateJSON(CreateGroupRequest{}),
+			)
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListMyGroups,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}",
+				GetGroup,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}",
+				UpdateGroup,
+				internalmiddleware.ValidateJSON(UpdateGroupRequest{}),
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}/visibility",
+				SetGroupVisibility,
+				internalmiddleware.ValidateJSON(SetGroupVisibilityRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}",
+				DeleteGroup,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/cookbook",
+				GenerateCookbook,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/share",
+				CreateGroupShareLink,
+			)
+		},
+	}
+)
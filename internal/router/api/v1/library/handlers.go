@@ -0,0 +1,167 @@
+package library
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalbulk "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/bulk"
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// ImportLibrary queues the import of an archive exported by another recipe manager
+// @Summary Import a recipe library
+// @Description Queues the import of a Paprika archive (.paprikarecipes), a MealMaster file or a CSV file sent as the raw request body. Each entry becomes a recipe of the authenticated user, poll the import to follow its per-entry report
+// @Tags api v1 library
+// @Accept application/octet-stream
+// @Produce json
+// @Security CookieAuth
+// @Param format query string true "Archive format" Enums(paprika, mealmaster, csv)
+// @Param archive body string true "Archive"
+// @Success 202 {object} gonethttpresponsejsend.SuccessBody[ImportResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 413 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/library/imports [post]
+func ImportLibrary(w http.ResponseWriter, r *http.Request) error {
+	// Get the format
+	format, err := internalbulk.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		return internalbulk.ParseError(err)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Read the archive, one byte over the limit is enough to reject it
+	archive, err := io.ReadAll(io.LimitReader(r.Body, internalbulk.MaxArchiveSize+1))
+	if err != nil {
+		return err
+	}
+
+	// Queue the import
+	job, err := internalbulk.Jobs.Queue(r.Context(), userID, format, archive)
+	if err != nil {
+		return internalbulk.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ImportResponse{Import: job},
+			http.StatusAccepted,
+		),
+	)
+	return nil
+}
+
+// GetImport gets a bulk import job with its report
+// @Summary Get a library import
+// @Description Gets the status of a bulk import job of the authenticated user with the result of each entry imported so far
+// @Tags api v1 library
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Import ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ImportResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/library/imports/{id} [get]
+func GetImport(w http.ResponseWriter, r *http.Request) error {
+	// Get the import ID
+	importID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the job
+	job, err := internalsqlite.RecipesService.GetOwnedImportJob(
+		r.Context(),
+		userID,
+		importID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ImportResponse{Import: job},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ExportLibrary exports every recipe of the authenticated user
+// @Summary Export the recipe library
+// @Description Exports every recipe of the authenticated user as a Paprika archive, a MealMaster file or a CSV file that other recipe managers, and the import endpoint, can read
+// @Tags api v1 library
+// @Produce application/zip
+// @Produce text/plain
+// @Produce text/csv
+// @Security CookieAuth
+// @Param format query string true "Archive format" Enums(paprika, mealmaster, csv)
+// @Param lang query string false "Language of the tag names"
+// @Success 200 {file} file
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/library/export [get]
+func ExportLibrary(w http.ResponseWriter, r *http.Request) error {
+	// Get the format
+	format, err := internalbulk.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		return internalbulk.ParseError(err)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Export the library
+	archive, err := internalbulk.Export(
+		r.Context(),
+		internalsqlite.RecipesService,
+		userID,
+		format,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalbulk.ParseError(err)
+	}
+
+	// Write the archive as is, it is not wrapped in a JSend body
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="recipes%s"`, format.Extension()),
+	)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(archive)
+	return nil
+}
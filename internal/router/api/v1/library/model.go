@@ -0,0 +1,12 @@
+package library
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// ImportResponse is the response body of a bulk import job, whose report is filled in as the entries are imported
+	ImportResponse struct {
+		Import *internalrouterapiv1recipe.ImportJob `json:"import"`
+	}
+)
@@ -0,0 +1,32 @@
+package library
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/library",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddEndpointHandler(
+				"POST /imports",
+				ImportLibrary,
+			)
+			m.AddEndpointHandler(
+				"GET /imports/{id}",
+				GetImport,
+			)
+			m.AddEndpointHandler(
+				"GET /export",
+				ExportLibrary,
+			)
+		},
+	}
+)
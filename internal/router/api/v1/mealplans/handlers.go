@@ -0,0 +1,405 @@
+package mealplans
+
+import (
+	"net/http"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaletag "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/etag"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// CreateMealPlan creates a meal plan owned by the authenticated user
+// @Summary Create a meal plan
+// @Description Creates a meal plan owned by the authenticated user, with the recipes planned for each date. The entries are sorted by date, keeping the order of the entries of the same date
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body CreateMealPlanRequest true "Create Meal Plan Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateMealPlanResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans [post]
+func CreateMealPlan(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*CreateMealPlanRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Create the meal plan
+	planID, err := internalsqlite.RecipesService.CreateMealPlan(
+		r.Context(),
+		userID,
+		requestBody.MealPlan(),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateMealPlanResponse{ID: planID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// ListMyMealPlans lists the meal plans of the authenticated user
+// @Summary List my meal plans
+// @Description Lists the meal plans owned by the authenticated user, newest first
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of meal plans"
+// @Param offset query int false "Number of meal plans to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListMealPlansResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans [get]
+func ListMyMealPlans(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the meal plans
+	plans, err := internalsqlite.RecipesService.ListMealPlans(
+		r.Context(),
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListMealPlansResponse{MealPlans: plans},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetMealPlan gets a meal plan of the authenticated user
+// @Summary Get a meal plan
+// @Description Gets a meal plan owned by the authenticated user with its entries, sorted by date
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Meal plan ID"
+// @Param If-None-Match header string false "Entity tags of the representations the client already has"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetMealPlanResponse]
+// @Header 200 {string} ETag "Version of the meal plan and digest of the representation"
+// @Success 304 "Not modified"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans/{id} [get]
+func GetMealPlan(w http.ResponseWriter, r *http.Request) error {
+	// Get the meal plan ID
+	planID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the version before the meal plan, so it is never newer than the content sent
+	version, err := internalsqlite.RecipesService.GetVersion(
+		r.Context(),
+		internalrouterapiv1recipe.SyncEntityMealPlan,
+		planID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Get the meal plan
+	plan, err := internalsqlite.RecipesService.GetMealPlan(
+		r.Context(),
+		userID,
+		planID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send no content if the client already has this representation
+	responseBody := &GetMealPlanResponse{MealPlan: plan}
+	tag, err := internaletag.FormatRepresentation(r, version, responseBody)
+	if err != nil {
+		return err
+	}
+	if internaletag.CheckNotModified(w, r, tag) {
+		return nil
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			responseBody,
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// UpdateMealPlan updates a meal plan of the authenticated user
+// @Summary Update a meal plan
+// @Description Replaces the title and the entries of a meal plan owned by the authenticated user
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Meal plan ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body UpdateMealPlanRequest true "Update Meal Plan Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the meal plan"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans/{id} [put]
+func UpdateMealPlan(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*UpdateMealPlanRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the meal plan ID
+	planID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Update the meal plan
+	newVersion, err := internalsqlite.RecipesService.UpdateMealPlan(
+		r.Context(),
+		userID,
+		requestBody.MealPlan(planID),
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteMealPlan deletes a meal plan of the authenticated user
+// @Summary Delete a meal plan
+// @Description Deletes a meal plan owned by the authenticated user, the recipes and the shopping lists created from it are kept
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Meal plan ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans/{id} [delete]
+func DeleteMealPlan(w http.ResponseWriter, r *http.Request) error {
+	// Get the meal plan ID
+	planID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the meal plan
+	if err = internalsqlite.RecipesService.DeleteMealPlan(
+		r.Context(),
+		userID,
+		planID,
+		versions,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// GetMealPlanCost estimates the cost of a meal plan
+// @Summary Estimate the cost of a meal plan
+// @Description Estimates the cost of a meal plan owned by the authenticated user, and of each of its entries, with the price list of the user. The ingredients of each entry are scaled to its servings and priced like the ones of a recipe. Ingredients that cannot be priced are listed as missing on their entry, and the entries whose recipe was made private by its owner are marked as unavailable, in both cases the total is then incomplete
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Meal plan ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetMealPlanCostResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans/{id}/cost [get]
+func GetMealPlanCost(w http.ResponseWriter, r *http.Request) error {
+	// Get the meal plan ID
+	planID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Estimate the cost
+	cost, err := internalsqlite.RecipesService.EstimateMealPlanCost(
+		r.Context(),
+		userID,
+		planID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetMealPlanCostResponse{Cost: cost},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// CreateShoppingList creates a shopping list from a meal plan of the authenticated user
+// @Summary Create a shopping list from a meal plan
+// @Description Creates a shopping list owned by the authenticated user with the ingredients of one of their meal plans, scaled to the servings of each entry. The amounts of the same ingredient are added up when their units convert to each other, and the recipes made private by their owner are left out
+// @Tags api v1 meal plans
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Meal plan ID"
+// @Param request body CreateShoppingListRequest true "Create Shopping List Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateShoppingListResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/meal-plans/{id}/shopping-list [post]
+func CreateShoppingList(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*CreateShoppingListRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the meal plan ID
+	planID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Create the shopping list
+	listID, err := internalsqlite.RecipesService.CreateMealPlanShoppingList(
+		r.Context(),
+		userID,
+		planID,
+		requestBody.Title,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateShoppingListResponse{ID: listID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,78 @@
+package mealplans
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// CreateMealPlanRequest is the request body to create a meal plan
+	CreateMealPlanRequest struct {
+		Title   string                                    `json:"title"`
+		Entries []internalrouterapiv1recipe.MealPlanEntry `json:"entries,omitempty"` // sorted by date, keeping the order of the entries of the same date
+	}
+
+	// CreateMealPlanResponse is the response body of a created meal plan
+	CreateMealPlanResponse struct {
+		ID int `json:"id"`
+	}
+
+	// UpdateMealPlanRequest is the request body to update a meal plan
+	UpdateMealPlanRequest struct {
+		Title   string                                    `json:"title"`
+		Entries []internalrouterapiv1recipe.MealPlanEntry `json:"entries"` // sorted by date, keeping the order of the entries of the same date
+	}
+
+	// GetMealPlanResponse is the response body of a meal plan
+	GetMealPlanResponse struct {
+		MealPlan *internalrouterapiv1recipe.MealPlan `json:"meal_plan"`
+	}
+
+	// ListMealPlansResponse is the response body of a list of meal plans
+	ListMealPlansResponse struct {
+		MealPlans []*internalrouterapiv1recipe.MealPlan `json:"meal_plans"`
+	}
+
+	// GetMealPlanCostResponse is the response body of the estimated cost of a meal plan
+	GetMealPlanCostResponse struct {
+		Cost *internalrouterapiv1recipe.MealPlanCost `json:"cost"`
+	}
+
+	// CreateShoppingListRequest is the request body to create a shopping list from a meal plan
+	CreateShoppingListRequest struct {
+		Title string `json:"title,omitempty"` // the title of the meal plan by default
+	}
+
+	// CreateShoppingListResponse is the response body of a shopping list created from a meal plan
+	CreateShoppingListResponse struct {
+		ID int `json:"id"`
+	}
+)
+
+// MealPlan maps the request body to a meal plan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.MealPlan: the meal plan
+func (c *CreateMealPlanRequest) MealPlan() *internalrouterapiv1recipe.MealPlan {
+	return &internalrouterapiv1recipe.MealPlan{
+		Title:   c.Title,
+		Entries: c.Entries,
+	}
+}
+
+// MealPlan maps the request body to a meal plan
+//
+// Parameters:
+//
+//   - id: the ID of the meal plan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.MealPlan: the meal plan
+func (u *UpdateMealPlanRequest) MealPlan(id int) *internalrouterapiv1recipe.MealPlan {
+	return &internalrouterapiv1recipe.MealPlan{
+		ID:      id,
+		Title:   u.Title,
+		Entries: u.Entries,
+	}
+}
@@ -0,0 +1,51 @@
+package mealplans
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/meal-plans",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"POST /",
+				CreateMealPlan,
+				internalmiddleware.ValidateJSON(CreateMealPlanRequest{}),
+			)
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListMyMealPlans,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}",
+				GetMealPlan,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}",
+				UpdateMealPlan,
+				internalmiddleware.ValidateJSON(UpdateMealPlanRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}",
+				DeleteMealPlan,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/cost",
+				GetMealPlanCost,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/shopping-list",
+				CreateShoppingList,
+				internalmiddleware.ValidateJSON(CreateShoppingListRequest{}),
+			)
+		},
+	}
+)
@@ -4,7 +4,26 @@ import (
 	gonethttp "github.com/ralvarezdev/go-net/http"
 
 	internalrouterapiv1auth "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/auth"
+	internalrouterapiv1cookbooks "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/cookbooks"
+	internalrouterapiv1cooks "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/cooks"
+	internalrouterapiv1diets "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/diets"
+	internalrouterapiv1favorites "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/favorites"
+	internalrouterapiv1feed "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/feed"
+	internalrouterapiv1follows "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/follows"
+	internalrouterapiv1groups "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/groups"
+	internalrouterapiv1library "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/library"
+	internalrouterapiv1mealplans "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/mealplans"
+	internalrouterapiv1prices "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/prices"
+	internalrouterapiv1public "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/public"
+	internalrouterapiv1recipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipes"
+	internalrouterapiv1recommendations "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recommendations"
+	internalrouterapiv1shared "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/shared"
+	internalrouterapiv1shoppinglists "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/shoppinglists"
+	internalrouterapiv1substitutions "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/substitutions"
+	internalrouterapiv1sync "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/sync"
+	internalrouterapiv1tags "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/tags"
 	internalrouterapiv1user "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/user"
+	internalrouterapiv1users "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/users"
 )
 
 var (
@@ -13,6 +32,25 @@ var (
 		Submodules: gonethttp.NewSubmodules(
 			internalrouterapiv1auth.Module,
 			internalrouterapiv1user.Module,
+			internalrouterapiv1recipes.Module,
+			internalrouterapiv1tags.Module,
+			internalrouterapiv1groups.Module,
+			internalrouterapiv1cookbooks.Module,
+			internalrouterapiv1library.Module,
+			internalrouterapiv1shared.Module,
+			internalrouterapiv1public.Module,
+			internalrouterapiv1follows.Module,
+			internalrouterapiv1feed.Module,
+			internalrouterapiv1users.Module,
+			internalrouterapiv1recommendations.Module,
+			internalrouterapiv1cooks.Module,
+			internalrouterapiv1diets.Module,
+			internalrouterapiv1substitutions.Module,
+			internalrouterapiv1prices.Module,
+			internalrouterapiv1mealplans.Module,
+			internalrouterapiv1shoppinglists.Module,
+			internalrouterapiv1favorites.Module,
+			internalrouterapiv1sync.Module,
 		),
 		AddHandlersFn: func(m *gonethttp.Module) {
 			m.AddEndpointHandler(
@@ -0,0 +1,125 @@
+package prices
+
+import (
+	"net/http"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+)
+
+// ListMyPrices lists the price list of the authenticated user
+// @Summary List my ingredient prices
+// @Description Lists the prices the authenticated user pays for an amount of each ingredient, used to estimate the cost of the recipes
+// @Tags api v1 prices
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListPricesResponse]
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/prices [get]
+func ListMyPrices(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// List the prices
+	prices, err := internalsqlite.RecipesService.ListIngredientPrices(r.Context(), userID)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListPricesResponse{Prices: prices},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// SetPrice sets the price of an ingredient for the authenticated user
+// @Summary Set an ingredient price
+// @Description Sets the price the authenticated user pays for an amount of an ingredient, such as 2.50 for 1 kg of flour, replacing the previous price of the ingredient. Ingredients are identified by the slug of their name, and a recipe ingredient takes the price of the longest run of words of its name that has one
+// @Tags api v1 prices
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body SetPriceRequest true "Set Price Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/prices [put]
+func SetPrice(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*SetPriceRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Set the price
+	if err = internalsqlite.RecipesService.SetIngredientPrice(
+		r.Context(),
+		userID,
+		requestBody.IngredientPrice(),
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeletePrice deletes the price of an ingredient of the authenticated user
+// @Summary Delete an ingredient price
+// @Description Deletes the price of an ingredient from the price list of the authenticated user
+// @Tags api v1 prices
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param slug path string true "Ingredient slug"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/prices/{slug} [delete]
+func DeletePrice(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the price
+	if err = internalsqlite.RecipesService.DeleteIngredientPrice(
+		r.Context(),
+		userID,
+		r.PathValue("slug"),
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
@@ -0,0 +1,34 @@
+package prices
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// SetPriceRequest is the request body to set the price of an ingredient
+	SetPriceRequest struct {
+		Ingredient string  `json:"ingredient"`
+		Quantity   float64 `json:"quantity"` // amount the price is paid for
+		Unit       string  `json:"unit,omitempty"`
+		Price      float64 `json:"price,omitempty"` // 0 for free ingredients
+	}
+
+	// ListPricesResponse is the response body of the price list of the user
+	ListPricesResponse struct {
+		Prices []*internalrouterapiv1recipe.IngredientPrice `json:"prices"`
+	}
+)
+
+// IngredientPrice maps the request body to an ingredient price
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.IngredientPrice: the ingredient price
+func (s *SetPriceRequest) IngredientPrice() *internalrouterapiv1recipe.IngredientPrice {
+	return &internalrouterapiv1recipe.IngredientPrice{
+		Ingredient: s.Ingredient,
+		Quantity:   s.Quantity,
+		Unit:       s.Unit,
+		Price:      s.Price,
+	}
+}
@@ -0,0 +1,33 @@
+package prices
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/prices",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListMyPrices,
+			)
+			m.AddExactEndpointHandler(
+				"PUT /",
+				SetPrice,
+				internalmiddleware.ValidateJSON(SetPriceRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{slug}",
+				DeletePrice,
+			)
+		},
+	}
+)
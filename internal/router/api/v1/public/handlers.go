@@ -0,0 +1,179 @@
+package public
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internaltrending "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/trending"
+)
+
+// ListPublicRecipes lists the public recipes
+// @Summary Browse the public recipes
+// @Description Lists the public recipes of every user, newest first. No session is needed
+// @Tags api v1 public
+// @Accept json
+// @Produce json
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListPublicRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 429 {object} errorcodes.ErrorBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/public/recipes [get]
+func ListPublicRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the recipes
+	recipes, err := internalsqlite.RecipesService.ListPublicRecipes(
+		r.Context(),
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListPublicRecipesResponse{Recipes: recipes},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// SearchPublicRecipes searches the public recipes
+// @Summary Search the public recipes
+// @Description Lists the public recipes whose name, description or ingredient names contain the given text. The recipes whose name matches come first. No session is needed
+// @Tags api v1 public
+// @Accept json
+// @Produce json
+// @Param q query string true "Text to search for"
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListPublicRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 429 {object} errorcodes.ErrorBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/public/recipes/search [get]
+func SearchPublicRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// Search the recipes
+	recipes, err := internalsqlite.RecipesService.SearchPublicRecipes(
+		r.Context(),
+		r.URL.Query().Get("q"),
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListPublicRecipesResponse{Recipes: recipes},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetPublicRecipe gets a public recipe
+// @Summary Get a public recipe
+// @Description Gets a public recipe with its tags. Private and unlisted recipes are not found. No session is needed
+// @Tags api v1 public
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetPublicRecipeResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 429 {object} errorcodes.ErrorBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/public/recipes/{id} [get]
+func GetPublicRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the recipe
+	recipe, err := internalsqlite.RecipesService.GetPublicRecipe(
+		r.Context(),
+		recipeID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Count the view for the trending recipes
+	internaltrending.Recipes.Track(
+		r.Context(),
+		recipe,
+		internalrouterapiv1recipe.ActivityKindView,
+		internaltrending.Actor(r),
+	)
+
+	// Serve the recipe in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipe,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetPublicRecipeResponse{Recipe: recipe},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,17 @@
+package public
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// GetPublicRecipeResponse is the response body of a public recipe
+	GetPublicRecipeResponse struct {
+		Recipe *internalrouterapiv1recipe.Recipe `json:"recipe"`
+	}
+
+	// ListPublicRecipesResponse is the response body of a list of public recipes
+	ListPublicRecipesResponse struct {
+		Recipes []*internalrouterapiv1recipe.Recipe `json:"recipes"`
+	}
+)
@@ -0,0 +1,33 @@
+package public
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	// Module serves the read-only browse endpoints that need no session, behind their own stricter rate limiter
+	Module = &gonethttp.Module{
+		Pattern: "/public",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.LimitPublicRequests,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddEndpointHandler(
+				"GET /recipes",
+				ListPublicRecipes,
+			)
+			m.AddEndpointHandler(
+				"GET /recipes/search",
+				SearchPublicRecipes,
+			)
+			m.AddEndpointHandler(
+				"GET /recipes/{id}",
+				GetPublicRecipe,
+			)
+		},
+	}
+)
@@ -1,19 +1,687 @@
 package recipe
 
+import (
+	"time"
+)
+
+type (
+	// TagKind is the kind of tag attached to a recipe
+	TagKind string
+
+	// JobStatus is the status of a background job
+	JobStatus string
+
+	// Visibility is who, besides its owner, can read a recipe
+	Visibility string
+
+	// DiffOperation is the operation that turns an item of a list of a revision into the next one
+	DiffOperation string
+
+	// FeedEventKind is the kind of activity shown in the feed of the followers of a user
+	FeedEventKind string
+
+	// ActivityKind is the kind of activity on a recipe counted to rank the trending recipes
+	ActivityKind string
+
+	// AnnotationTarget is the part of a recipe a private annotation is attached to
+	AnnotationTarget string
+
+	// Diet is a dietary preference of a user
+	Diet string
+
+	// MissingPriceReason is why the cost of an ingredient of a recipe could not be estimated
+	MissingPriceReason string
+
+	// SyncEntity is the kind of entity kept in sync with the offline clients
+	SyncEntity string
+
+	// SyncOperation is the operation of a change pushed by an offline client
+	SyncOperation string
+)
+
+const (
+	// TagKindCuisine is the kind for cuisine tags (Venezuelan, Italian, ...)
+	TagKindCuisine TagKind = "cuisine"
+
+	// TagKindCourse is the kind for course tags (starter, main, dessert, ...)
+	TagKindCourse TagKind = "course"
+
+	// TagKindUser is the kind for free-form tags created by users
+	TagKindUser TagKind = "user"
+)
+
+const (
+	// VisibilityPrivate is the visibility of a recipe only its owner can read
+	VisibilityPrivate Visibility = "private"
+
+	// VisibilityUnlisted is the visibility of a recipe anyone can read by its ID but is not listed
+	VisibilityUnlisted Visibility = "unlisted"
+
+	// VisibilityPublic is the visibility of a recipe anyone can read and find in the listings
+	VisibilityPublic Visibility = "public"
+)
+
+const (
+	// JobStatusPending is the status of a job waiting for a worker
+	JobStatusPending JobStatus = "pending"
+
+	// JobStatusRunning is the status of a job being processed
+	JobStatusRunning JobStatus = "running"
+
+	// JobStatusDone is the status of a job that finished
+	JobStatusDone JobStatus = "done"
+
+	// JobStatusFailed is the status of a job that could not be processed
+	JobStatusFailed JobStatus = "failed"
+)
+
+const (
+	// DiffOperationUnchanged is the operation of an item kept between both revisions
+	DiffOperationUnchanged DiffOperation = "unchanged"
+
+	// DiffOperationAdded is the operation of an item only present in the newer revision
+	DiffOperationAdded DiffOperation = "added"
+
+	// DiffOperationRemoved is the operation of an item only present in the older revision
+	DiffOperationRemoved DiffOperation = "removed"
+)
+
+const (
+	// FeedEventKindRecipe is the kind of event of a recipe published by a user
+	FeedEventKindRecipe FeedEventKind = "recipe"
+
+	// FeedEventKindFork is the kind of event of a fork published by a user
+	FeedEventKindFork FeedEventKind = "fork"
+
+	// ActivityKindView is the kind of activity of a user reading a recipe, counted once a day per viewer
+	ActivityKindView ActivityKind = "view"
+
+	// ActivityKindFavorite is the kind of activity of a user marking a recipe as a favorite
+	ActivityKindFavorite ActivityKind = "favorite"
+
+	// ActivityKindCook is the kind of activity of a user cooking a recipe
+	ActivityKindCook ActivityKind = "cook"
+)
+
+const (
+	// AnnotationTargetRecipe is the target of an annotation on the whole recipe
+	AnnotationTargetRecipe AnnotationTarget = "recipe"
+
+	// AnnotationTargetStep is the target of an annotation on a step of the recipe
+	AnnotationTargetStep AnnotationTarget = "step"
+
+	// AnnotationTargetIngredient is the target of an annotation on an ingredient of the recipe
+	AnnotationTargetIngredient AnnotationTarget = "ingredient"
+)
+
+const (
+	// DietVegetarian is the diet without meat or fish
+	DietVegetarian Diet = "vegetarian"
+
+	// DietVegan is the diet without any animal product
+	DietVegan Diet = "vegan"
+
+	// DietGlutenFree is the diet without gluten
+	DietGlutenFree Diet = "gluten_free"
+
+	// DietDairyFree is the diet without milk or dairy products
+	DietDairyFree Diet = "dairy_free"
+
+	// DietEggFree is the diet without eggs
+	DietEggFree Diet = "egg_free"
+
+	// DietNutFree is the diet without peanuts or tree nuts
+	DietNutFree Diet = "nut_free"
+)
+
+const (
+	// MissingPriceReasonNoPrice is the reason of an ingredient without a price on the price list
+	MissingPriceReasonNoPrice MissingPriceReason = "no_price"
+
+	// MissingPriceReasonNoQuantity is the reason of an ingredient without an amount ("salt to taste"), or with one too
+	// large to convert
+	MissingPriceReasonNoQuantity MissingPriceReason = "no_quantity"
+
+	// MissingPriceReasonIncompatibleUnit is the reason of an ingredient whose unit does not convert to the unit of its
+	// price, like a weight priced by volume
+	MissingPriceReasonIncompatibleUnit MissingPriceReason = "incompatible_unit"
+)
+
+const (
+	// SyncEntityRecipe is the entity of the changes to a recipe, its tags included
+	SyncEntityRecipe SyncEntity = "recipe"
+
+	// SyncEntityGroup is the entity of the changes to a recipe group, its recipes included
+	SyncEntityGroup SyncEntity = "group"
+
+	// SyncEntityMealPlan is the entity of the changes to a meal plan, its entries included
+	SyncEntityMealPlan SyncEntity = "meal_plan"
+
+	// SyncEntityShoppingList is the entity of the changes to a shopping list, its items included
+	SyncEntityShoppingList SyncEntity = "shopping_list"
+
+	// SyncEntityFavorite is the entity of the recipes marked or unmarked as a favorite
+	SyncEntityFavorite SyncEntity = "favorite"
+)
+
+const (
+	// SyncOperationCreate is the operation of an entity created offline
+	SyncOperationCreate SyncOperation = "create"
+
+	// SyncOperationUpdate is the operation of an entity updated offline
+	SyncOperationUpdate SyncOperation = "update"
+
+	// SyncOperationDelete is the operation of an entity deleted offline
+	SyncOperationDelete SyncOperation = "delete"
+)
+
+// IsValid checks if the tag kind is one of the known kinds
+//
+// Returns:
+//
+//   - bool: true if the tag kind is valid
+func (t TagKind) IsValid() bool {
+	switch t {
+	case TagKindCuisine, TagKindCourse, TagKindUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid checks if the visibility is one of the known levels
+//
+// Returns:
+//
+//   - bool: true if the visibility is valid
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPrivate, VisibilityUnlisted, VisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid checks if the annotation target is one of the known targets
+//
+// Returns:
+//
+//   - bool: true if the annotation target is valid
+func (a AnnotationTarget) IsValid() bool {
+	switch a {
+	case AnnotationTargetRecipe, AnnotationTargetStep, AnnotationTargetIngredient:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid checks if the diet is one of the known diets
+//
+// Returns:
+//
+//   - bool: true if the diet is valid
+func (d Diet) IsValid() bool {
+	switch d {
+	case DietVegetarian, DietVegan, DietGlutenFree, DietDairyFree, DietEggFree, DietNutFree:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid checks if the sync entity is recipe, group, meal_plan, shopping_list or favorite
+//
+// Returns:
+//
+//   - bool: true if the sync entity is valid
+func (s SyncEntity) IsValid() bool {
+	switch s {
+	case SyncEntityRecipe, SyncEntityGroup, SyncEntityMealPlan, SyncEntityShoppingList, SyncEntityFavorite:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid checks if the sync operation is create, update or delete
+//
+// Returns:
+//
+//   - bool: true if the sync operation is valid
+func (s SyncOperation) IsValid() bool {
+	switch s {
+	case SyncOperationCreate, SyncOperationUpdate, SyncOperationDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 type Group struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	RecipeIDs   []int  `json:"recipe_ids"` // IDs of recipes in the group
+	ID          int        `json:"id"`
+	OwnerID     string     `json:"owner_id"` // JWT subject of the user that created the group
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Visibility  Visibility `json:"visibility"` // only public groups are listed on the profile of their owner
+	RecipeIDs   []int      `json:"recipe_ids"` // IDs of recipes in the group, in order
+}
+
+type MealPlan struct {
+	ID      int             `json:"id"`
+	OwnerID string          `json:"owner_id"` // JWT subject of the user that created the meal plan
+	Title   string          `json:"title"`
+	Entries []MealPlanEntry `json:"entries"` // sorted by date, then in the order they were sent
+}
+
+type MealPlanEntry struct {
+	Date     string `json:"date"`           // as YYYY-MM-DD
+	Meal     string `json:"meal,omitempty"` // such as breakfast, lunch or dinner
+	RecipeID int    `json:"recipe_id"`
+	Servings int    `json:"servings,omitempty"` // servings to cook, 0 for the servings of the recipe
+}
+
+type ShoppingList struct {
+	ID      int                `json:"id"`
+	OwnerID string             `json:"owner_id"` // JWT subject of the user that created the shopping list
+	Title   string             `json:"title"`
+	Items   []ShoppingListItem `json:"items"`
+}
+
+type ShoppingListItem struct {
+	Quantity float64 `json:"quantity,omitempty"` // 0 when the amount is unspecified
+	Unit     string  `json:"unit,omitempty"`
+	Name     string  `json:"name"`
+	Checked  bool    `json:"checked,omitempty"` // already bought
+}
+
+type Favorite struct {
+	ID        int       `json:"id"`
+	RecipeID  int       `json:"recipe_id"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Recipe struct {
-	ID              int      `json:"id"`
-	Name            string   `json:"name"`
-	Description     string   `json:"description"`
-	PreparationTime int      `json:"preparation_time"` // in minutes
-	CookingTime     int      `json:"cooking_time"`     // in minutes
-	Steps           []string `json:"steps"`
-	Servings        int      `json:"servings"`
-	Difficulty      string   `json:"difficulty"`
+	ID               int            `json:"id"`
+	OwnerID          string         `json:"owner_id"` // JWT subject of the author
+	Name             string         `json:"name"`
+	Description      string         `json:"description"`
+	PreparationTime  int            `json:"preparation_time"` // in minutes
+	CookingTime      int            `json:"cooking_time"`     // in minutes
+	Ingredients      []Ingredient   `json:"ingredients"`
+	Steps            []string       `json:"steps"`
+	Servings         int            `json:"servings"`
+	Difficulty       string         `json:"difficulty"`
+	SourceURL        string         `json:"source_url,omitempty"` // page the recipe was imported from
+	ImageURL         string         `json:"image_url,omitempty"`
+	Visibility       Visibility     `json:"visibility"`
+	ForkedFrom       *int           `json:"forked_from,omitempty"` // recipe this one was forked from, unset once it is deleted
+	Attribution      []Attribution  `json:"attribution,omitempty"` // recipes this one descends from, the closest first
+	ForkCount        int            `json:"fork_count"`            // forks the reader can see, the public ones and their own
+	Tags             []Tag          `json:"tags"`
+	Annotations      []Annotation   `json:"annotations,omitempty"`       // private annotations of the reader, only set when reading the recipe by its ID
+	DietConflicts    []DietConflict `json:"diet_conflicts,omitempty"`    // ingredients that do not suit the diets of the reader, only set when asked for
+	Language         string         `json:"language"`                    // language of the name, description, ingredients and steps
+	TranslatedFrom   string         `json:"translated_from,omitempty"`   // language the recipe was written in, set when a translation is served
+	LanguageFallback bool           `json:"language_fallback,omitempty"` // none of the requested languages is available, the original is served
+	Translations     []string       `json:"translations,omitempty"`      // languages the recipe is translated to
+}
+
+type RecipeTranslation struct {
+	Language    string    `json:"language"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Ingredients []string  `json:"ingredients"` // names of the ingredients, in recipe order
+	Steps       []string  `json:"steps"`
+	Outdated    bool      `json:"outdated,omitempty"` // the recipe gained or lost ingredients or steps since, so the translation is not served
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type DietConflict struct {
+	Position      int            `json:"position"` // position of the ingredient, starting at 1
+	Ingredient    string         `json:"ingredient"`
+	Diets         []Diet         `json:"diets"`         // diets of the reader the ingredient does not suit
+	Substitutions []Substitution `json:"substitutions"` // substitutions that suit every diet of the reader
+}
+
+type SubstitutableIngredient struct {
+	Name          string         `json:"name"`
+	Aliases       []string       `json:"aliases,omitempty"`
+	Conflicts     []Diet         `json:"conflicts,omitempty"` // diets the ingredient does not suit
+	Substitutions []Substitution `json:"substitutions"`
+}
+
+type Substitution struct {
+	Quantity    float64      `json:"quantity"` // amount of the replaced ingredient the replacement stands for
+	Unit        string       `json:"unit,omitempty"`
+	Replacement []Ingredient `json:"replacement"`
+	Notes       string       `json:"notes,omitempty"`
+	Conflicts   []Diet       `json:"conflicts,omitempty"` // diets the replacement does not suit
+}
+
+type Annotation struct {
+	ID         int              `json:"id"`
+	Target     AnnotationTarget `json:"target"`
+	Position   int              `json:"position,omitempty"` // position of the annotated step or ingredient, starting at 1
+	Note       string           `json:"note,omitempty"`
+	Substitute string           `json:"substitute,omitempty"` // only set on ingredient annotations
+	Outdated   bool             `json:"outdated,omitempty"`   // the annotated step or ingredient is no longer in the recipe
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+type Attribution struct {
+	RecipeID int    `json:"recipe_id"`
+	OwnerID  string `json:"owner_id"` // JWT subject of the author of the recipe when it was forked
+	Name     string `json:"name"`
+}
+
+type Ingredient struct {
+	Quantity float64 `json:"quantity,omitempty"` // 0 when the amount is unspecified ("salt to taste")
+	Unit     string  `json:"unit,omitempty"`
+	Name     string  `json:"name"`
+}
+
+type Tag struct {
+	ID          int     `json:"id"`
+	Slug        string  `json:"slug"` // canonical slug, unique per kind (and per owner for user tags)
+	Kind        TagKind `json:"kind"`
+	Name        string  `json:"name"`               // localized name, falls back to the slug
+	OwnerID     *string `json:"owner_id,omitempty"` // only set for user tags
+	RecipeCount *int    `json:"recipe_count,omitempty"`
+}
+
+type CookbookJob struct {
+	ID         int        `json:"id"`
+	GroupID    int        `json:"group_id"`
+	OwnerID    string     `json:"owner_id"`
+	Language   string     `json:"language"` // language of the tag names in the PDF
+	Status     JobStatus  `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type ImportJob struct {
+	ID         int          `json:"id"`
+	OwnerID    string       `json:"owner_id"`
+	Format     string       `json:"format"` // archive format, such as paprika, mealmaster or csv
+	Status     JobStatus    `json:"status"`
+	Error      string       `json:"error,omitempty"` // set when the archive could not be read
+	Total      int          `json:"total"`
+	Imported   int          `json:"imported"`
+	Failed     int          `json:"failed"`
+	Items      []ImportItem `json:"items,omitempty"` // per-entry report, set once the job is done
+	CreatedAt  time.Time    `json:"created_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+}
+
+type ImportItem struct {
+	Index       int    `json:"index"` // position of the entry in the archive, starting at 1
+	Name        string `json:"name"`
+	RecipeID    int    `json:"recipe_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+	DuplicateOf int    `json:"duplicate_of,omitempty"` // most similar recipe already in the library, if the entry is a likely duplicate
+}
+
+type CookLog struct {
+	ID            int                `json:"id"`
+	UserID        string             `json:"user_id"`             // JWT subject of the user that cooked the recipe
+	RecipeID      *int               `json:"recipe_id,omitempty"` // unset once the recipe is deleted
+	RecipeName    string             `json:"recipe_name"`         // name of the recipe when it was cooked
+	CookedOn      string             `json:"cooked_on"`           // as YYYY-MM-DD
+	Notes         string             `json:"notes,omitempty"`
+	Substitutions []CookSubstitution `json:"substitutions"`
+	Rating        int                `json:"rating,omitempty"` // from 1 to 5, 0 when unrated
+	Photos        []string           `json:"photos"`           // photo URLs
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type CookSubstitution struct {
+	Ingredient string `json:"ingredient"` // ingredient of the recipe that was replaced
+	Substitute string `json:"substitute"`
+}
+
+type CookStats struct {
+	RecipeID       int      `json:"recipe_id"`
+	CookCount      int      `json:"cook_count"` // times anyone cooked the recipe
+	CookUserCount  int      `json:"cook_user_count"`
+	RatingCount    int      `json:"rating_count"`
+	AverageRating  *float64 `json:"average_rating,omitempty"` // unset when no cook was rated
+	MyCookCount    int      `json:"my_cook_count"`
+	MyLastCookedOn *string  `json:"my_last_cooked_on,omitempty"`
+}
+
+type IngredientPrice struct {
+	Ingredient string    `json:"ingredient"`
+	Slug       string    `json:"slug"`     // prices are matched to the ingredients of a recipe by this slug
+	Quantity   float64   `json:"quantity"` // amount the price is paid for
+	Unit       string    `json:"unit,omitempty"`
+	Price      float64   `json:"price"` // in the currency of the user
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type RecipeCost struct {
+	RecipeID   int              `json:"recipe_id"`
+	Servings   int              `json:"servings"`
+	Total      float64          `json:"total"`       // cost of the priced ingredients
+	PerServing float64          `json:"per_serving"` // total divided by the servings
+	Complete   bool             `json:"complete"`    // every ingredient is priced, otherwise the total is a lower bound
+	Items      []IngredientCost `json:"items"`
+	Missing    []MissingPrice   `json:"missing"`
+}
+
+type IngredientCost struct {
+	Position   int     `json:"position"` // position of the ingredient, starting at 1
+	Ingredient string  `json:"ingredient"`
+	PriceSlug  string  `json:"price_slug"` // slug of the price used
+	Cost       float64 `json:"cost"`
+}
+
+type MissingPrice struct {
+	Position   int                `json:"position"` // position of the ingredient, starting at 1
+	Ingredient string             `json:"ingredient"`
+	Reason     MissingPriceReason `json:"reason"`
+}
+
+type MealPlanCost struct {
+	MealPlanID int                 `json:"meal_plan_id"`
+	Total      float64             `json:"total"`    // cost of the priced ingredients of every entry
+	Complete   bool                `json:"complete"` // every entry is complete, otherwise the total is a lower bound
+	Entries    []MealPlanEntryCost `json:"entries"`
+}
+
+type MealPlanEntryCost struct {
+	Position    int            `json:"position"` // position of the entry, starting at 1
+	RecipeID    int            `json:"recipe_id"`
+	Servings    int            `json:"servings"` // servings the ingredients were scaled to
+	Total       float64        `json:"total"`
+	Complete    bool           `json:"complete"`
+	Unavailable bool           `json:"unavailable,omitempty"` // the recipe was made private by its owner, so it is not priced
+	Missing     []MissingPrice `json:"missing"`
+}
+
+type ShoppingListCost struct {
+	ShoppingListID int              `json:"shopping_list_id"`
+	Total          float64          `json:"total"`     // cost of the priced items
+	Remaining      float64          `json:"remaining"` // cost of the priced items not checked yet
+	Complete       bool             `json:"complete"`  // every item is priced, otherwise the totals are lower bounds
+	Items          []IngredientCost `json:"items"`
+	Missing        []MissingPrice   `json:"missing"`
+}
+
+type Duplicate struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Similarity float64 `json:"similarity"` // from 0 to 1, likely duplicates start at 0.55
+}
+
+type Revision struct {
+	RecipeID        int          `json:"recipe_id"`
+	Number          int          `json:"number"`    // starts at 1 and increases with each edit of the recipe
+	AuthorID        string       `json:"author_id"` // JWT subject of the user that made the edit
+	Name            string       `json:"name"`
+	Description     string       `json:"description"`
+	PreparationTime int          `json:"preparation_time"` // in minutes
+	CookingTime     int          `json:"cooking_time"`     // in minutes
+	Ingredients     []Ingredient `json:"ingredients"`
+	Steps           []string     `json:"steps"`
+	Servings        int          `json:"servings"`
+	Difficulty      string       `json:"difficulty"`
+	ImageURL        string       `json:"image_url,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+type RevisionDiff struct {
+	RecipeID    int                `json:"recipe_id"`
+	From        int                `json:"from"`   // number of the older revision
+	To          int                `json:"to"`     // number of the newer revision
+	Fields      []FieldChange      `json:"fields"` // only the fields that changed
+	Ingredients []IngredientChange `json:"ingredients"`
+	Steps       []StepChange       `json:"steps"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"` // JSON name of the field
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type IngredientChange struct {
+	Operation  DiffOperation `json:"operation"`
+	Ingredient Ingredient    `json:"ingredient"`
+}
+
+type StepChange struct {
+	Operation DiffOperation `json:"operation"`
+	Step      string        `json:"step"`
+}
+
+type ShareLink struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"` // path of the read-only view, which needs no session
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type Follow struct {
+	UserID    string    `json:"user_id"` // JWT subject of the followed or following user
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FeedEvent struct {
+	ID        int           `json:"id"` // increasing, used as the feed cursor
+	Kind      FeedEventKind `json:"kind"`
+	ActorID   string        `json:"actor_id"` // JWT subject of the user that published the recipe
+	Recipe    *Recipe       `json:"recipe"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type ScoredRecipe struct {
+	Recipe *Recipe `json:"recipe"`
+	Score  float64 `json:"score"` // meaning depends on the ranking: trending activity, similarity or recommendation strength
+}
+
+type UserProfile struct {
+	UserID         string         `json:"user_id"` // JWT subject of the user
+	Username       string         `json:"username"`
+	Profile        map[string]any `json:"profile"` // public fields of the auth service profile
+	RecipeCount    int            `json:"recipe_count"`
+	GroupCount     int            `json:"group_count"`
+	FollowerCount  int            `json:"follower_count"`
+	FollowingCount int            `json:"following_count"`
+	Recipes        []*Recipe      `json:"recipes"`    // latest public recipes
+	Groups         []*Group       `json:"groups"`     // public groups, listing only their public recipes
+	UpdatedAt      time.Time      `json:"updated_at"` // when the auth service profile was last read
+}
+
+type TagTranslation struct {
+	Language string   `json:"language"` // ISO 639-1 code
+	Name     string   `json:"name"`
+	Synonyms []string `json:"synonyms"`
+}
+
+type SyncRecipe struct {
+	ID      int     `json:"id"`
+	Version int     `json:"version"` // sent back as the base version of the changes pushed to the recipe
+	Deleted bool    `json:"deleted"`
+	Recipe  *Recipe `json:"recipe,omitempty"` // omitted once deleted
+}
+
+type SyncGroup struct {
+	ID      int    `json:"id"`
+	Version int    `json:"version"` // sent back as the base version of the changes pushed to the group
+	Deleted bool   `json:"deleted"`
+	Group   *Group `json:"group,omitempty"` // omitted once deleted
+}
+
+type SyncMealPlan struct {
+	ID       int       `json:"id"`
+	Version  int       `json:"version"` // sent back as the base version of the changes pushed to the meal plan
+	Deleted  bool      `json:"deleted"`
+	MealPlan *MealPlan `json:"meal_plan,omitempty"` // omitted once deleted
+}
+
+type SyncShoppingList struct {
+	ID           int           `json:"id"`
+	Version      int           `json:"version"` // sent back as the base version of the changes pushed to the list
+	Deleted      bool          `json:"deleted"`
+	ShoppingList *ShoppingList `json:"shopping_list,omitempty"` // omitted once deleted
+}
+
+type SyncFavorite struct {
+	ID       int       `json:"id"`
+	Version  int       `json:"version"` // sent back as the base version of the deletion of the favorite
+	Deleted  bool      `json:"deleted"`
+	Favorite *Favorite `json:"favorite,omitempty"` // omitted once deleted
+}
+
+type SyncDelta struct {
+	Token         string              `json:"token"`          // sent back as since to get the changes made after this delta
+	HasMore       bool                `json:"has_more"`       // more changes are waiting, ask again with the token right away
+	Reset         bool                `json:"reset"`          // the token was not known, so the whole library is sent again
+	Recipes       []*SyncRecipe       `json:"recipes"`        // oldest change first
+	Groups        []*SyncGroup        `json:"groups"`         // oldest change first
+	MealPlans     []*SyncMealPlan     `json:"meal_plans"`     // oldest change first
+	ShoppingLists []*SyncShoppingList `json:"shopping_lists"` // oldest change first
+	Favorites     []*SyncFavorite     `json:"favorites"`      // oldest change first
+}
+
+type SyncChange struct {
+	Entity       SyncEntity
+	Operation    SyncOperation
+	ClientID     string
+	ID           int
+	BaseVersion  int
+	Recipe       *Recipe
+	Tags         []string // replaces the tags of the recipe if not nil
+	Group        *Group
+	MealPlan     *MealPlan
+	ShoppingList *ShoppingList
+	RecipeID     int // recipe marked as a favorite on create
+}
+
+type SyncResult struct {
+	ClientID  string        `json:"client_id,omitempty"`
+	Entity    SyncEntity    `json:"entity"`
+	Operation SyncOperation `json:"operation"`
+	ID        int           `json:"id"`      // assigned by the server to the entities created offline
+	Version   int           `json:"version"` // version of the entity after the change
+	Deleted   bool          `json:"deleted"`
+	Code      string        `json:"code,omitempty"`  // set when the change could not be applied
+	Error     string        `json:"error,omitempty"` // set when the change could not be applied
+}
+
+type SyncConflict struct {
+	ClientID     string        `json:"client_id,omitempty"`
+	Entity       SyncEntity    `json:"entity"`
+	Operation    SyncOperation `json:"operation"`
+	ID           int           `json:"id"`
+	BaseVersion  int           `json:"base_version"`            // version the change was made over
+	Version      int           `json:"version"`                 // current version on the server
+	Deleted      bool          `json:"deleted"`                 // the entity was deleted on the server
+	Recipe       *Recipe       `json:"recipe,omitempty"`        // current content on the server, omitted once deleted
+	Group        *Group        `json:"group,omitempty"`         // current content on the server, omitted once deleted
+	MealPlan     *MealPlan     `json:"meal_plan,omitempty"`     // current content on the server, omitted once deleted
+	ShoppingList *ShoppingList `json:"shopping_list,omitempty"` // current content on the server, omitted once deleted
+	Favorite     *Favorite     `json:"favorite,omitempty"`      // current content on the server, omitted once deleted
 }
@@ -0,0 +1,1812 @@
+package recipes
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+	internaletag "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/etag"
+	internalexporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/exporter"
+	internalfeed "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/feed"
+	internalimporter "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/importer"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrevisions "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/revisions"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internalshare "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/share"
+	internalsubstitutions "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/substitutions"
+	internaltrending "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/trending"
+)
+
+// CreateRecipe creates a recipe owned by the authenticated user
+// @Summary Create a recipe
+// @Description Creates a recipe owned by the authenticated user, private unless another visibility is set. Tags are resolved to canonical cuisine and course tags by slug or synonym, any other label becomes a user tag. If the library of the user already holds a recipe with a similar name and ingredients, the recipe is not saved and the likely duplicates are returned, unless allow_duplicate is set; a duplicate can instead be merged into the existing recipe
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body CreateRecipeRequest true "Create Recipe Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateRecipeResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 409 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes [post]
+func CreateRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*CreateRecipeRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Write the recipe in the request language unless told otherwise
+	recipe := requestBody.Recipe()
+	if recipe.Language == "" {
+		recipe.Language = internalrequest.GetLanguage(r)
+	}
+
+	// Check if the library already holds a likely duplicate
+	if !requestBody.AllowDuplicate {
+		duplicates, err := internalsqlite.RecipesService.FindDuplicateRecipes(
+			r.Context(),
+			userID,
+			recipe,
+		)
+		if err != nil {
+			return internalsqliterecipes.ParseError(err)
+		}
+		if len(duplicates) > 0 {
+			internaljson.Handler.HandleResponse(
+				w, r, gonethttpresponsejsend.NewFailResponseWithCode(
+					&DuplicateRecipeResponse{Duplicates: duplicates},
+					internalerrorcodes.DuplicateRecipe.String(),
+					http.StatusConflict,
+				),
+			)
+			return nil
+		}
+	}
+
+	// Create the recipe
+	recipeID, err := internalsqlite.RecipesService.CreateRecipe(
+		r.Context(),
+		userID,
+		recipe,
+		requestBody.Tags,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Announce it to the followers of the user if it is public
+	internalfeed.Feeds.Publish(r.Context(), recipeID)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateRecipeResponse{ID: recipeID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// ListMyRecipes lists the recipes of the authenticated user
+// @Summary List my recipes
+// @Description Lists the recipes owned by the authenticated user, newest first
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes [get]
+func ListMyRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the recipes
+	recipes, err := internalsqlite.RecipesService.ListRecipesByOwnerID(
+		r.Context(),
+		userID,
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListRecipesResponse{Recipes: recipes},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ListTrendingRecipes lists the trending public recipes
+// @Summary List the trending recipes
+// @Description Lists the public recipes with the most activity in the window, weighting views (counted once a day per viewer), favorites and cooks, with the older activity counting less. The ranking is refreshed every minute
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param window query string false "Ranked period: day (default) or week"
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListTrendingRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/trending [get]
+func ListTrendingRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the window
+	window, err := internaltrending.ParseWindow(
+		r.URL.Query().Get(internaltrending.WindowQueryParameter),
+	)
+	if err != nil {
+		return internaltrending.ParseError(err)
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// Rank the recipes
+	recipes, err := internaltrending.Recipes.Trending(
+		r.Context(),
+		window,
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internaltrending.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateScoredRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListTrendingRecipesResponse{
+				Window:  window,
+				Recipes: recipes,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetRecipe gets a recipe
+// @Summary Get a recipe
+// @Description Gets a recipe with its tags and the private annotations of the authenticated user. Private recipes can only be read by their owner. The recipe is served in the first language asked for it has a translation to, or flagged as a fallback in its own language if it has none. With diet_conflicts set, the ingredients that do not suit the diets of the authenticated user are flagged along with the substitutions that suit them
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param If-None-Match header string false "Entity tags of the representations the client already has"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Param diet_conflicts query bool false "Flag the ingredients that do not suit the diets of the user"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetRecipeResponse]
+// @Header 200 {string} ETag "Version of the recipe and digest of the representation"
+// @Success 304 "Not modified"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id} [get]
+func GetRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Check if the diet conflicts are asked for
+	withConflicts, err := internalrequest.GetQueryBool(r, internalsubstitutions.ConflictsQueryParameter)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the version before the recipe, so it is never newer than the content sent
+	version, err := internalsqlite.RecipesService.GetVersion(
+		r.Context(),
+		internalrouterapiv1recipe.SyncEntityRecipe,
+		recipeID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Get the recipe
+	recipe, err := internalsqlite.RecipesService.GetRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Merge the private annotations of the user
+	if err = internalsqlite.RecipesService.AnnotateRecipe(
+		r.Context(),
+		userID,
+		recipe,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Flag the ingredients that do not suit the diets of the user, if asked for
+	if withConflicts {
+		diets, dietsErr := internalsqlite.RecipesService.GetUserDiets(r.Context(), userID)
+		if dietsErr != nil {
+			return internalsqliterecipes.ParseError(dietsErr)
+		}
+		recipe.DietConflicts = internalsubstitutions.Substitutions.Conflicts(recipe, diets)
+	}
+
+	// Count the view for the trending recipes
+	internaltrending.Recipes.Track(
+		r.Context(),
+		recipe,
+		internalrouterapiv1recipe.ActivityKindView,
+		internaltrending.Actor(r),
+	)
+
+	// Serve the recipe in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipe,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send no content if the client already has this representation
+	responseBody := &GetRecipeResponse{Recipe: recipe}
+	tag, err := internaletag.FormatRepresentation(r, version, responseBody)
+	if err != nil {
+		return err
+	}
+	if internaletag.CheckNotModified(w, r, tag) {
+		return nil
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			responseBody,
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// UpdateRecipe updates a recipe of the authenticated user
+// @Summary Update a recipe
+// @Description Replaces the content of a recipe owned by the authenticated user
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body UpdateRecipeRequest true "Update Recipe Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the recipe"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id} [put]
+func UpdateRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*UpdateRecipeRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Update the recipe
+	newVersion, err := internalsqlite.RecipesService.UpdateRecipe(
+		r.Context(),
+		userID,
+		requestBody.Recipe(recipeID),
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteRecipe deletes a recipe of the authenticated user
+// @Summary Delete a recipe
+// @Description Deletes a recipe owned by the authenticated user
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id} [delete]
+func DeleteRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the recipe
+	if err = internalsqlite.RecipesService.DeleteRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		versions,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// SetRecipeTags replaces the tags of a recipe of the authenticated user
+// @Summary Set the tags of a recipe
+// @Description Replaces the tags of a recipe owned by the authenticated user. Each label is resolved to a canonical cuisine or course tag by slug or synonym, any other label becomes a user tag
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body SetRecipeTagsRequest true "Set Recipe Tags Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the recipe"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/tags [put]
+func SetRecipeTags(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*SetRecipeTagsRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Set the recipe tags
+	newVersion, err := internalsqlite.RecipesService.SetRecipeTags(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.Tags,
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// ImportRecipe reads a recipe from a web page
+// @Summary Import a recipe
+// @Description Reads the schema.org recipe, in JSON-LD or microdata, of a web page fetched from its URL or uploaded as HTML. The recipe is returned as a draft with suggested tags and the recipes of the library it likely duplicates, and is not saved until it is sent to the create endpoint
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body ImportRecipeRequest true "Import Recipe Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ImportRecipeResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 413 {object} errorcodes.FailBody
+// @Failure 422 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Failure 502 {object} errorcodes.FailBody
+// @Router /api/v1/recipes/import [post]
+func ImportRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*ImportRecipeRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Import the recipe
+	draft, err := internalimporter.Import(
+		r.Context(),
+		internalimporter.Fetcher,
+		requestBody.URL,
+		requestBody.HTML,
+	)
+	if err != nil {
+		return internalimporter.ParseError(err)
+	}
+
+	// Look for likely duplicates in the library
+	duplicates, err := internalsqlite.RecipesService.FindDuplicateRecipes(
+		r.Context(),
+		userID,
+		draft.Recipe,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ImportRecipeResponse{
+				Recipe:     draft.Recipe,
+				Tags:       draft.Tags,
+				Duplicates: duplicates,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ExportRecipe exports a recipe to share or print it
+// @Summary Export a recipe
+// @Description Exports a recipe as a schema.org Recipe JSON-LD document, a Markdown document or a self-contained printable HTML page
+// @Tags api v1 recipes
+// @Produce application/ld+json
+// @Produce text/markdown
+// @Produce text/html
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param format query string false "Export format" Enums(jsonld, markdown, html) default(jsonld)
+// @Param lang query string false "Language of the tag names"
+// @Success 200 {string} string
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/export [get]
+func ExportRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the format
+	format, err := internalexporter.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		return internalexporter.ParseError(err)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the recipe
+	recipe, err := internalsqlite.RecipesService.GetRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Export the recipe
+	body, err := internalexporter.Export(recipe, format)
+	if err != nil {
+		return internalexporter.ParseError(err)
+	}
+
+	// Write the exported recipe as is, it is not wrapped in a JSend body
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`inline; filename="recipe-%d%s"`, recipeID, format.Extension()),
+	)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+	return nil
+}
+
+// ListRecipeRevisions lists the revisions of a recipe
+// @Summary List the revisions of a recipe
+// @Description Lists the revisions of a recipe, newest first. A revision is recorded each time the content of the recipe changes
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param limit query int false "Maximum number of revisions"
+// @Param offset query int false "Number of revisions to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListRevisionsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/revisions [get]
+func ListRecipeRevisions(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the revisions
+	revisions, err := internalsqlite.RecipesService.ListRecipeRevisions(
+		r.Context(),
+		userID,
+		recipeID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListRevisionsResponse{Revisions: revisions},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetRecipeRevision gets a revision of a recipe
+// @Summary Get a revision of a recipe
+// @Description Gets the content of a recipe as it was at the given revision
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetRevisionResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/revisions/{number} [get]
+func GetRecipeRevision(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID and the revision number
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+	number, err := internalrequest.GetPathID(r, "number")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the revision
+	revision, err := internalsqlite.RecipesService.GetRecipeRevision(
+		r.Context(),
+		userID,
+		recipeID,
+		number,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetRevisionResponse{Revision: revision},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// DiffRecipeRevisions compares two revisions of a recipe
+// @Summary Compare two revisions of a recipe
+// @Description Compares two revisions of a recipe. The fields are reported only when they changed, while the ingredients and the steps are reported in full, each one marked as unchanged, added or removed
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param from query int true "Number of the older revision"
+// @Param to query int true "Number of the newer revision"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[DiffRevisionsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/revisions/diff [get]
+func DiffRecipeRevisions(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID and the revision numbers
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+	fromNumber, err := internalrequest.GetRequiredQueryInt(r, "from", 1, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+	toNumber, err := internalrequest.GetRequiredQueryInt(r, "to", 1, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the revisions
+	from, err := internalsqlite.RecipesService.GetRecipeRevision(r.Context(), userID, recipeID, fromNumber)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+	to, err := internalsqlite.RecipesService.GetRecipeRevision(r.Context(), userID, recipeID, toNumber)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Compare them
+	diff, err := internalrevisions.Diff(from, to)
+	if err != nil {
+		return err
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&DiffRevisionsResponse{Diff: diff},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// RevertRecipe restores a revision of a recipe of the authenticated user
+// @Summary Revert a recipe
+// @Description Restores the content of a revision of a recipe owned by the authenticated user. The restored content is recorded as a new revision, so no revision is lost
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param number path int true "Number of the revision to restore"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[RevertRecipeResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/revisions/{number}/revert [post]
+func RevertRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID and the revision number
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+	number, err := internalrequest.GetPathID(r, "number")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Revert the recipe
+	latest, err := internalsqlite.RecipesService.RevertRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		number,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&RevertRecipeResponse{Revision: latest},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// MergeRecipes merges a duplicate into a recipe of the authenticated user
+// @Summary Merge a duplicate into a recipe
+// @Description Merges a duplicate into a recipe, both owned by the authenticated user, and deletes the duplicate. The recipe keeps its content and visibility, fills its empty fields and adds the missing ingredients from the duplicate as a new revision, and takes the tags, group places, meal plan entries, favorites and forks of the duplicate
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param request body MergeRecipesRequest true "Merge Recipes Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/merge [post]
+func MergeRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*MergeRecipesRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Merge the recipes
+	if err = internalsqlite.RecipesService.MergeRecipes(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.RecipeID,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// ForkRecipe copies a recipe into the library of the authenticated user
+// @Summary Fork a recipe
+// @Description Copies a recipe the authenticated user can read into their library to tweak it. The fork starts private, links to the original recipe and keeps the chain of recipes it descends from as its attribution
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateRecipeResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/fork [post]
+func ForkRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Fork the recipe
+	forkID, err := internalsqlite.RecipesService.ForkRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateRecipeResponse{ID: forkID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// LogCook logs that the authenticated user cooked a recipe
+// @Summary Log a cook
+// @Description Logs that the authenticated user cooked a recipe they can read, on a date (today by default), with personal notes, the substitutions made, a rating and photo URLs. Cooks of public recipes of other users count for the trending recipes, and cooks not rated below 3 feed the recommendations
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param request body LogCookRequest true "Log Cook Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[LogCookResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/cooks [post]
+func LogCook(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*LogCookRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the recipe
+	recipe, err := internalsqlite.RecipesService.GetRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Log the cook
+	cook, err := internalsqlite.RecipesService.LogCook(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.CookLog(),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Count the cook for the trending recipes
+	internaltrending.Recipes.Track(
+		r.Context(),
+		recipe,
+		internalrouterapiv1recipe.ActivityKindCook,
+		internaltrending.Actor(r),
+	)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&LogCookResponse{Cook: cook},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// ListRecipeCooks gets the cook stats of a recipe and lists the times the authenticated user cooked it
+// @Summary List the cooks of a recipe
+// @Description Gets how many times a recipe the authenticated user can read was cooked, by how many users and its average rating, and lists the times the authenticated user cooked it, most recently cooked first
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param limit query int false "Maximum number of cooks"
+// @Param offset query int false "Number of cooks to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListRecipeCooksResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/cooks [get]
+func ListRecipeCooks(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the stats and the cooks
+	stats, cooks, err := internalsqlite.RecipesService.ListRecipeCookLogs(
+		r.Context(),
+		userID,
+		recipeID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListRecipeCooksResponse{
+				Stats: stats,
+				Cooks: cooks,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// CreateAnnotation annotates a recipe for the authenticated user
+// @Summary Annotate a recipe
+// @Description Attaches a private note to a recipe the authenticated user can read, to one of its steps or to one of its ingredients. Ingredient annotations can also hold a substitute. Annotations are only shown to their author when reading the recipe, and follow their step or ingredient when the recipe is edited
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param request body CreateAnnotationRequest true "Create Annotation Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateAnnotationResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/annotations [post]
+func CreateAnnotation(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*CreateAnnotationRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Create the annotation
+	annotationID, err := internalsqlite.RecipesService.CreateRecipeAnnotation(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.Annotation(),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateAnnotationResponse{ID: annotationID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// UpdateAnnotation updates an annotation of the authenticated user on a recipe
+// @Summary Update an annotation
+// @Description Replaces the note and substitute of a private annotation of the authenticated user on a recipe
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param annotation_id path int true "Annotation ID"
+// @Param request body UpdateAnnotationRequest true "Update Annotation Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/annotations/{annotation_id} [put]
+func UpdateAnnotation(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*UpdateAnnotationRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe and annotation IDs
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+	annotationID, err := internalrequest.GetPathID(r, "annotation_id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Update the annotation
+	if err = internalsqlite.RecipesService.UpdateRecipeAnnotation(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.Annotation(annotationID),
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteAnnotation deletes an annotation of the authenticated user on a recipe
+// @Summary Delete an annotation
+// @Description Deletes a private annotation of the authenticated user on a recipe
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param annotation_id path int true "Annotation ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/annotations/{annotation_id} [delete]
+func DeleteAnnotation(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe and annotation IDs
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+	annotationID, err := internalrequest.GetPathID(r, "annotation_id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the annotation
+	if err = internalsqlite.RecipesService.DeleteRecipeAnnotation(
+		r.Context(),
+		userID,
+		recipeID,
+		annotationID,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// ListRecipeTranslations lists the translations of a recipe
+// @Summary List the translations of a recipe
+// @Description Lists the translations of a recipe the authenticated user can read, along with the language it is written in. Translations made before ingredients or steps were added to or removed from the recipe are flagged as outdated and are not served until they are set again
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListTranslationsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/translations [get]
+func ListRecipeTranslations(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the recipe
+	recipe, err := internalsqlite.RecipesService.GetRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// List the translations
+	translations, err := internalsqlite.RecipesService.ListRecipeTranslations(
+		r.Context(),
+		recipe,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListTranslationsResponse{
+				Language:     recipe.Language,
+				Translations: translations,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// SetRecipeTranslation translates a recipe of the authenticated user
+// @Summary Translate a recipe
+// @Description Sets the translation of a recipe owned by the authenticated user to a language, replacing the previous one. Every ingredient and step must be translated, in the same order as in the recipe; the amounts and units of the ingredients are kept. The translation is served on the read endpoints to the clients that ask for its language with the lang query parameter or the Accept-Language header
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param language path string true "ISO 639 code of the language"
+// @Param If-Match header string true "Entity tags of the versions of the recipe the change may be made over, or * to skip the check"
+// @Param request body SetTranslationRequest true "Set Translation Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the recipe"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/translations/{language} [put]
+func SetRecipeTranslation(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*SetTranslationRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Set the translation
+	newVersion, err := internalsqlite.RecipesService.SetRecipeTranslation(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.Translation(r.PathValue("language")),
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteRecipeTranslation deletes a translation of a recipe of the authenticated user
+// @Summary Delete a translation of a recipe
+// @Description Deletes the translation of a recipe owned by the authenticated user to a language
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param language path string true "ISO 639 code of the language"
+// @Param If-Match header string true "Entity tags of the versions of the recipe the change may be made over, or * to skip the check"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the recipe"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/translations/{language} [delete]
+func DeleteRecipeTranslation(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the translation
+	newVersion, err := internalsqlite.RecipesService.DeleteRecipeTranslation(
+		r.Context(),
+		userID,
+		recipeID,
+		r.PathValue("language"),
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// GetRecipeCost estimates the cost of a recipe
+// @Summary Estimate the cost of a recipe
+// @Description Estimates the cost of a recipe the authenticated user can read, and of each serving, with the price list of the user. The amount of each ingredient is converted to the unit of its price. Ingredients without a price, without a usable amount or whose unit does not convert to the unit of their price are listed as missing and left out of the total, which is then incomplete
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetRecipeCostResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/cost [get]
+func GetRecipeCost(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the recipe
+	recipe, err := internalsqlite.RecipesService.GetRecipe(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Estimate the cost
+	cost, err := internalsqlite.RecipesService.EstimateRecipeCost(
+		r.Context(),
+		userID,
+		recipe,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetRecipeCostResponse{Cost: cost},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ListSimilarRecipes lists the recipes similar to a recipe
+// @Summary List the recipes similar to a recipe
+// @Description Lists the public recipes that share the most ingredients and cuisine or course tags with a recipe the authenticated user can read, rarer ingredients counting more, most similar first. The similarities are computed every hour, so a new recipe has none until the next run
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListSimilarRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/similar [get]
+func ListSimilarRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// List the similar recipes
+	recipes, err := internalsqlite.RecipesService.ListSimilarRecipes(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateScoredRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListSimilarRecipesResponse{Recipes: recipes},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ListRecipeForks lists the forks of a recipe of the authenticated user
+// @Summary List the forks of a recipe
+// @Description Lists the public recipes forked from a recipe owned by the authenticated user, newest first, along with the total number of forks, private ones included
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param limit query int false "Maximum number of forks"
+// @Param offset query int false "Number of forks to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListForksResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/forks [get]
+func ListRecipeForks(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the forks
+	forks, count, err := internalsqlite.RecipesService.ListRecipeForks(
+		r.Context(),
+		userID,
+		recipeID,
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the forks in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		forks...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListForksResponse{
+				Count: count,
+				Forks: forks,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// SetRecipeVisibility sets the visibility of a recipe of the authenticated user
+// @Summary Set the visibility of a recipe
+// @Description Sets who can read a recipe owned by the authenticated user: only its owner (private), anyone with its ID (unlisted) or anyone, listing it (public)
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body SetRecipeVisibilityRequest true "Set Recipe Visibility Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the recipe"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/visibility [put]
+func SetRecipeVisibility(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*SetRecipeVisibilityRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Set the visibility
+	newVersion, err := internalsqlite.RecipesService.SetRecipeVisibility(
+		r.Context(),
+		userID,
+		recipeID,
+		requestBody.Visibility,
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Announce it to the followers of the user the first time it is published
+	internalfeed.Feeds.Publish(r.Context(), recipeID)
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// CreateRecipeShareLink creates a share link to a recipe of the authenticated user
+// @Summary Share a recipe
+// @Description Creates an expiring signed link that lets anyone, without an account, read a recipe owned by the authenticated user, whatever its visibility
+// @Tags api v1 recipes
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Recipe ID"
+// @Param expires_in query int false "Hours the link is valid for" default(168) maximum(720)
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[ShareLinkResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recipes/{id}/share [post]
+func CreateRecipeShareLink(w http.ResponseWriter, r *http.Request) error {
+	// Get the recipe ID
+	recipeID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the expiration
+	expiresIn, err := internalrequest.GetQueryInt(
+		r,
+		internalshare.ExpirationQueryParameter,
+		internalshare.DefaultExpirationHours,
+		1,
+		internalshare.MaxExpirationHours,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Check if the user owns the recipe
+	if err = internalsqlite.RecipesService.CheckRecipeOwnership(
+		r.Context(),
+		userID,
+		recipeID,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Create the link
+	link, err := internalshare.Signer.NewLink(
+		internalshare.KindRecipe,
+		recipeID,
+		userID,
+		time.Duration(expiresIn)*time.Hour,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ShareLinkResponse{Link: link},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
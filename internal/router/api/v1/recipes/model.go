@@ -0,0 +1,300 @@
+package recipes
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+	internaltrending "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/trending"
+)
+
+type (
+	// CreateRecipeRequest is the request body to create a recipe
+	CreateRecipeRequest struct {
+		Name            string                                 `json:"name"`
+		Description     string                                 `json:"description"`
+		PreparationTime int                                    `json:"preparation_time"` // in minutes
+		CookingTime     int                                    `json:"cooking_time"`     // in minutes
+		Ingredients     []internalrouterapiv1recipe.Ingredient `json:"ingredients,omitempty"`
+		Steps           []string                               `json:"steps"`
+		Servings        int                                    `json:"servings"`
+		Difficulty      string                                 `json:"difficulty"`
+		SourceURL       string                                 `json:"source_url,omitempty"` // set when confirming an imported draft
+		ImageURL        string                                 `json:"image_url,omitempty"`
+		Visibility      internalrouterapiv1recipe.Visibility   `json:"visibility,omitempty"`      // private, unlisted or public, private by default
+		Language        string                                 `json:"language,omitempty"`        // ISO 639 code of the language the recipe is written in, the request language by default
+		Tags            []string                               `json:"tags,omitempty"`            // tag slugs, synonyms or free-form labels
+		AllowDuplicate  bool                                   `json:"allow_duplicate,omitempty"` // saves the recipe even if a likely duplicate is already in the library
+	}
+
+	// CreateRecipeResponse is the response body of a created recipe
+	CreateRecipeResponse struct {
+		ID int `json:"id"`
+	}
+
+	// DuplicateRecipeResponse is the fail response body of a recipe with likely duplicates in the library of the user
+	DuplicateRecipeResponse struct {
+		Duplicates []*internalrouterapiv1recipe.Duplicate `json:"duplicates"` // most similar first
+	}
+
+	// MergeRecipesRequest is the request body to merge a duplicate into a recipe
+	MergeRecipesRequest struct {
+		RecipeID int `json:"recipe_id"` // ID of the duplicate, which is deleted once merged
+	}
+
+	// UpdateRecipeRequest is the request body to update a recipe
+	UpdateRecipeRequest struct {
+		Name            string                                 `json:"name"`
+		Description     string                                 `json:"description"`
+		PreparationTime int                                    `json:"preparation_time"` // in minutes
+		CookingTime     int                                    `json:"cooking_time"`     // in minutes
+		Ingredients     []internalrouterapiv1recipe.Ingredient `json:"ingredients,omitempty"`
+		Steps           []string                               `json:"steps"`
+		Servings        int                                    `json:"servings"`
+		Difficulty      string                                 `json:"difficulty"`
+		ImageURL        string                                 `json:"image_url,omitempty"`
+		Language        string                                 `json:"language,omitempty"` // ISO 639 code of the language the recipe is written in, kept if not set
+	}
+
+	// ImportRecipeRequest is the request body to import a recipe from a web page, either fetched from its URL or uploaded as HTML
+	ImportRecipeRequest struct {
+		URL  string `json:"url,omitempty"`
+		HTML string `json:"html,omitempty"`
+	}
+
+	// ImportRecipeResponse is the response body of an imported recipe draft, which is not saved until it is sent to the create endpoint
+	ImportRecipeResponse struct {
+		Recipe     *internalrouterapiv1recipe.Recipe      `json:"recipe"`
+		Tags       []string                               `json:"tags"`       // suggested labels taken from the cuisine, category and keywords
+		Duplicates []*internalrouterapiv1recipe.Duplicate `json:"duplicates"` // recipes of the library that are likely duplicates of the draft, most similar first
+	}
+
+	// SetRecipeTagsRequest is the request body to replace the tags of a recipe
+	SetRecipeTagsRequest struct {
+		Tags []string `json:"tags"` // tag slugs, synonyms or free-form labels
+	}
+
+	// SetRecipeVisibilityRequest is the request body to set the visibility of a recipe
+	SetRecipeVisibilityRequest struct {
+		Visibility internalrouterapiv1recipe.Visibility `json:"visibility"` // private, unlisted or public
+	}
+
+	// ShareLinkResponse is the response body of a created share link
+	ShareLinkResponse struct {
+		Link *internalrouterapiv1recipe.ShareLink `json:"link"`
+	}
+
+	// ListTrendingRecipesResponse is the response body of the trending recipes
+	ListTrendingRecipesResponse struct {
+		Window  internaltrending.Window                   `json:"window"`
+		Recipes []*internalrouterapiv1recipe.ScoredRecipe `json:"recipes"`
+	}
+
+	// LogCookRequest is the request body to log that the user cooked a recipe
+	LogCookRequest struct {
+		CookedOn      string                                       `json:"cooked_on,omitempty"` // as YYYY-MM-DD, today by default
+		Notes         string                                       `json:"notes,omitempty"`
+		Substitutions []internalrouterapiv1recipe.CookSubstitution `json:"substitutions,omitempty"`
+		Rating        int                                          `json:"rating,omitempty"` // from 1 to 5, unrated by default
+		Photos        []string                                     `json:"photos,omitempty"` // photo URLs
+	}
+
+	// LogCookResponse is the response body of a logged cook
+	LogCookResponse struct {
+		Cook *internalrouterapiv1recipe.CookLog `json:"cook"`
+	}
+
+	// ListRecipeCooksResponse is the response body of the cook stats of a recipe and the times the user cooked it
+	ListRecipeCooksResponse struct {
+		Stats *internalrouterapiv1recipe.CookStats `json:"stats"`
+		Cooks []*internalrouterapiv1recipe.CookLog `json:"cooks"`
+	}
+
+	// CreateAnnotationRequest is the request body to annotate a recipe, one of its steps or one of its ingredients
+	CreateAnnotationRequest struct {
+		Target     internalrouterapiv1recipe.AnnotationTarget `json:"target"`             // recipe, step or ingredient
+		Position   int                                        `json:"position,omitempty"` // position of the step or ingredient, starting at 1
+		Note       string                                     `json:"note,omitempty"`
+		Substitute string                                     `json:"substitute,omitempty"` // only for ingredients
+	}
+
+	// CreateAnnotationResponse is the response body of a created annotation
+	CreateAnnotationResponse struct {
+		ID int `json:"id"`
+	}
+
+	// UpdateAnnotationRequest is the request body to update an annotation
+	UpdateAnnotationRequest struct {
+		Note       string `json:"note,omitempty"`
+		Substitute string `json:"substitute,omitempty"` // only for ingredients
+	}
+
+	// GetRecipeCostResponse is the response body of the estimated cost of a recipe
+	GetRecipeCostResponse struct {
+		Cost *internalrouterapiv1recipe.RecipeCost `json:"cost"`
+	}
+
+	// ListSimilarRecipesResponse is the response body of the recipes similar to a recipe
+	ListSimilarRecipesResponse struct {
+		Recipes []*internalrouterapiv1recipe.ScoredRecipe `json:"recipes"`
+	}
+
+	// GetRecipeResponse is the response body of a recipe
+	GetRecipeResponse struct {
+		Recipe *internalrouterapiv1recipe.Recipe `json:"recipe"`
+	}
+
+	// ListRecipesResponse is the response body of a list of recipes
+	ListRecipesResponse struct {
+		Recipes []*internalrouterapiv1recipe.Recipe `json:"recipes"`
+	}
+
+	// ListForksResponse is the response body of a list of recipe forks
+	ListForksResponse struct {
+		Count int                                 `json:"count"` // total number of forks
+		Forks []*internalrouterapiv1recipe.Recipe `json:"forks"`
+	}
+
+	// ListRevisionsResponse is the response body of a list of recipe revisions
+	ListRevisionsResponse struct {
+		Revisions []*internalrouterapiv1recipe.Revision `json:"revisions"`
+	}
+
+	// GetRevisionResponse is the response body of a recipe revision
+	GetRevisionResponse struct {
+		Revision *internalrouterapiv1recipe.Revision `json:"revision"`
+	}
+
+	// DiffRevisionsResponse is the response body of the comparison of two recipe revisions
+	DiffRevisionsResponse struct {
+		Diff *internalrouterapiv1recipe.RevisionDiff `json:"diff"`
+	}
+
+	// RevertRecipeResponse is the response body of a reverted recipe
+	RevertRecipeResponse struct {
+		Revision int `json:"revision"` // number of the latest revision, which holds the restored content
+	}
+
+	// SetTranslationRequest is the request body to translate a recipe to a language
+	SetTranslationRequest struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		Ingredients []string `json:"ingredients,omitempty"` // translated ingredient names, in the same order as the recipe ingredients
+		Steps       []string `json:"steps"`                 // translated steps, in the same order as the recipe steps
+	}
+
+	// ListTranslationsResponse is the response body of a list of recipe translations
+	ListTranslationsResponse struct {
+		Language     string                                         `json:"language"` // language the recipe is written in
+		Translations []*internalrouterapiv1recipe.RecipeTranslation `json:"translations"`
+	}
+)
+
+// Recipe maps the request body to a recipe
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the recipe
+func (c *CreateRecipeRequest) Recipe() *internalrouterapiv1recipe.Recipe {
+	return &internalrouterapiv1recipe.Recipe{
+		Name:            c.Name,
+		Description:     c.Description,
+		PreparationTime: c.PreparationTime,
+		CookingTime:     c.CookingTime,
+		Ingredients:     c.Ingredients,
+		Steps:           c.Steps,
+		Servings:        c.Servings,
+		Difficulty:      c.Difficulty,
+		SourceURL:       c.SourceURL,
+		ImageURL:        c.ImageURL,
+		Visibility:      c.Visibility,
+		Language:        c.Language,
+	}
+}
+
+// Recipe maps the request body to a recipe
+//
+// Parameters:
+//
+//   - id: the ID of the recipe
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the recipe
+func (u *UpdateRecipeRequest) Recipe(id int) *internalrouterapiv1recipe.Recipe {
+	return &internalrouterapiv1recipe.Recipe{
+		ID:              id,
+		Name:            u.Name,
+		Description:     u.Description,
+		PreparationTime: u.PreparationTime,
+		CookingTime:     u.CookingTime,
+		Ingredients:     u.Ingredients,
+		Steps:           u.Steps,
+		Servings:        u.Servings,
+		Difficulty:      u.Difficulty,
+		ImageURL:        u.ImageURL,
+		Language:        u.Language,
+	}
+}
+
+// Translation maps the request body to a recipe translation
+//
+// Parameters:
+//
+//   - language: the language of the translation
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.RecipeTranslation: the translation
+func (s *SetTranslationRequest) Translation(language string) *internalrouterapiv1recipe.RecipeTranslation {
+	return &internalrouterapiv1recipe.RecipeTranslation{
+		Language:    language,
+		Name:        s.Name,
+		Description: s.Description,
+		Ingredients: s.Ingredients,
+		Steps:       s.Steps,
+	}
+}
+
+// CookLog maps the request body to a cook log
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.CookLog: the cook log
+func (l *LogCookRequest) CookLog() *internalrouterapiv1recipe.CookLog {
+	return &internalrouterapiv1recipe.CookLog{
+		CookedOn:      l.CookedOn,
+		Notes:         l.Notes,
+		Substitutions: l.Substitutions,
+		Rating:        l.Rating,
+		Photos:        l.Photos,
+	}
+}
+
+// Annotation maps the request body to an annotation
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Annotation: the annotation
+func (c *CreateAnnotationRequest) Annotation() *internalrouterapiv1recipe.Annotation {
+	return &internalrouterapiv1recipe.Annotation{
+		Target:     c.Target,
+		Position:   c.Position,
+		Note:       c.Note,
+		Substitute: c.Substitute,
+	}
+}
+
+// Annotation maps the request body to an annotation
+//
+// Parameters:
+//
+//   - annotationID: the ID of the annotation
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Annotation: the annotation
+func (u *UpdateAnnotationRequest) Annotation(annotationID int) *internalrouterapiv1recipe.Annotation {
+	return &internalrouterapiv1recipe.Annotation{
+		ID:         annotationID,
+		Note:       u.Note,
+		Substitute: u.Substitute,
+	}
+}
@@ -0,0 +1,142 @@
+package recipes
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/recipes",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"POST /",
+				CreateRecipe,
+				internalmiddleware.ValidateJSON(CreateRecipeRequest{}),
+			)
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListMyRecipes,
+			)
+			m.AddEndpointHandler(
+				"POST /import",
+				ImportRecipe,
+				internalmiddleware.ValidateJSON(ImportRecipeRequest{}),
+			)
+			m.AddEndpointHandler(
+				"GET /trending",
+				ListTrendingRecipes,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}",
+				GetRecipe,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}",
+				UpdateRecipe,
+				internalmiddleware.ValidateJSON(UpdateRecipeRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}",
+				DeleteRecipe,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/export",
+				ExportRecipe,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/merge",
+				MergeRecipes,
+				internalmiddleware.ValidateJSON(MergeRecipesRequest{}),
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/fork",
+				ForkRecipe,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/forks",
+				ListRecipeForks,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/cooks",
+				LogCook,
+				internalmiddleware.ValidateJSON(LogCookRequest{}),
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/cooks",
+				ListRecipeCooks,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/annotations",
+				CreateAnnotation,
+				internalmiddleware.ValidateJSON(CreateAnnotationRequest{}),
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}/annotations/{annotation_id}",
+				UpdateAnnotation,
+				internalmiddleware.ValidateJSON(UpdateAnnotationRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}/annotations/{annotation_id}",
+				DeleteAnnotation,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/translations",
+				ListRecipeTranslations,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}/translations/{language}",
+				SetRecipeTranslation,
+				internalmiddleware.ValidateJSON(SetTranslationRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}/translations/{language}",
+				DeleteRecipeTranslation,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/cost",
+				GetRecipeCost,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/similar",
+				ListSimilarRecipes,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/revisions",
+				ListRecipeRevisions,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/revisions/diff",
+				DiffRecipeRevisions,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/revisions/{number}",
+				GetRecipeRevision,
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/revisions/{number}/revert",
+				RevertRecipe,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}/visibility",
+				SetRecipeVisibility,
+				internalmiddleware.ValidateJSON(SetRecipeVisibilityRequest{}),
+			)
+			m.AddEndpointHandler(
+				"POST /{id}/share",
+				CreateRecipeShareLink,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}/tags",
+				SetRecipeTags,
+				internalmiddleware.ValidateJSON(SetRecipeTagsRequest{}),
+			)
+		},
+	}
+)
@@ -0,0 +1,92 @@
+package recommendations
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internaltrending "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/trending"
+)
+
+// ListRecommendations lists the recipes recommended to the authenticated user
+// @Summary List my recommendations
+// @Description Lists the public recipes of other users most similar to the ones the authenticated user owns, forked, saved to a group or cooked, best first. The recommendations are computed every hour; until the user has any, the trending recipes of the week are listed instead
+// @Tags api v1 recommendations
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListRecommendationsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/recommendations [get]
+func ListRecommendations(w http.ResponseWriter, r *http.Request) error {
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// List the recommendations
+	language := internalrequest.GetLanguage(r)
+	recipes, err := internalsqlite.RecipesService.ListRecommendedRecipes(
+		r.Context(),
+		userID,
+		language,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Fall back to the trending recipes for the users without recommendations
+	trending := len(recipes) == 0 && offset == 0
+	if trending {
+		if recipes, err = internaltrending.Recipes.Trending(
+			r.Context(),
+			internaltrending.WindowWeek,
+			language,
+			limit,
+			offset,
+		); err != nil {
+			return internaltrending.ParseError(err)
+		}
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateScoredRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListRecommendationsResponse{
+				Recipes:  recipes,
+				Trending: trending,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
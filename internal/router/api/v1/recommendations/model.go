@@ -0,0 +1,13 @@
+package recommendations
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// ListRecommendationsResponse is the response body of the recipes recommended to a user
+	ListRecommendationsResponse struct {
+		Recipes  []*internalrouterapiv1recipe.ScoredRecipe `json:"recipes"`
+		Trending bool                                      `json:"trending"` // true if the user has no recommendations yet and the trending recipes of the week are listed instead
+	}
+)
@@ -0,0 +1,125 @@
+package shared
+
+import (
+	"net/http"
+	"time"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalshare "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/share"
+)
+
+// GetSharedRecipe gets a recipe through a share link
+// @Summary Get a shared recipe
+// @Description Gets a recipe through a share link created by its owner. No session is needed
+// @Tags api v1 shared
+// @Accept json
+// @Produce json
+// @Param token path string true "Share link token"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetSharedRecipeResponse]
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 410 {object} errorcodes.FailBody
+// @Failure 429 {object} errorcodes.ErrorBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shared/recipes/{token} [get]
+func GetSharedRecipe(w http.ResponseWriter, r *http.Request) error {
+	// Verify the token
+	claims, err := internalshare.Signer.Verify(r.PathValue("token"), internalshare.KindRecipe)
+	if err != nil {
+		return internalshare.ParseError(err)
+	}
+
+	// Get the recipe as the user that shared it
+	recipe, err := internalsqlite.RecipesService.GetRecipe(
+		r.Context(),
+		claims.OwnerID,
+		claims.ID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipe in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipe,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetSharedRecipeResponse{
+				Recipe:    recipe,
+				ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetSharedGroup gets a recipe group through a share link
+// @Summary Get a shared recipe group
+// @Description Gets a recipe group and its recipes through a share link created by its owner. No session is needed
+// @Tags api v1 shared
+// @Accept json
+// @Produce json
+// @Param token path string true "Share link token"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetSharedGroupResponse]
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 410 {object} errorcodes.FailBody
+// @Failure 429 {object} errorcodes.ErrorBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shared/groups/{token} [get]
+func GetSharedGroup(w http.ResponseWriter, r *http.Request) error {
+	// Verify the token
+	claims, err := internalshare.Signer.Verify(r.PathValue("token"), internalshare.KindGroup)
+	if err != nil {
+		return internalshare.ParseError(err)
+	}
+
+	// Get the group as the user that shared it
+	group, recipes, err := internalsqlite.RecipesService.ListGroupRecipes(
+		r.Context(),
+		claims.OwnerID,
+		claims.ID,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetSharedGroupResponse{
+				Group:     group,
+				Recipes:   recipes,
+				ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
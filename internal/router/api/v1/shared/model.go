@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"time"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// GetSharedRecipeResponse is the response body of a recipe read through a share link
+	GetSharedRecipeResponse struct {
+		Recipe    *internalrouterapiv1recipe.Recipe `json:"recipe"`
+		ExpiresAt time.Time                         `json:"expires_at"` // when the share link expires
+	}
+
+	// GetSharedGroupResponse is the response body of a recipe group read through a share link
+	GetSharedGroupResponse struct {
+		Group     *internalrouterapiv1recipe.Group    `json:"group"`
+		Recipes   []*internalrouterapiv1recipe.Recipe `json:"recipes"`    // in the order of the group
+		ExpiresAt time.Time                           `json:"expires_at"` // when the share link expires
+	}
+)
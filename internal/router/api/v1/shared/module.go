@@ -0,0 +1,29 @@
+package shared
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	// Module serves the read-only views opened through share links, which need no session
+	Module = &gonethttp.Module{
+		Pattern: "/shared",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.LimitPublicRequests,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddEndpointHandler(
+				"GET /recipes/{token}",
+				GetSharedRecipe,
+			)
+			m.AddEndpointHandler(
+				"GET /groups/{token}",
+				GetSharedGroup,
+			)
+		},
+	}
+)
@@ -0,0 +1,349 @@
+package shoppinglists
+
+import (
+	"net/http"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaletag "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/etag"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// CreateShoppingList creates a shopping list owned by the authenticated user
+// @Summary Create a shopping list
+// @Description Creates a shopping list owned by the authenticated user, with the amount of each item to buy. Shopping lists can also be created from a meal plan
+// @Tags api v1 shopping lists
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body CreateShoppingListRequest true "Create Shopping List Request"
+// @Success 201 {object} gonethttpresponsejsend.SuccessBody[CreateShoppingListResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shopping-lists [post]
+func CreateShoppingList(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*CreateShoppingListRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Create the shopping list
+	listID, err := internalsqlite.RecipesService.CreateShoppingList(
+		r.Context(),
+		userID,
+		requestBody.ShoppingList(),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&CreateShoppingListResponse{ID: listID},
+			http.StatusCreated,
+		),
+	)
+	return nil
+}
+
+// ListMyShoppingLists lists the shopping lists of the authenticated user
+// @Summary List my shopping lists
+// @Description Lists the shopping lists owned by the authenticated user, newest first
+// @Tags api v1 shopping lists
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param limit query int false "Maximum number of shopping lists"
+// @Param offset query int false "Number of shopping lists to skip"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListShoppingListsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shopping-lists [get]
+func ListMyShoppingLists(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the shopping lists
+	lists, err := internalsqlite.RecipesService.ListShoppingLists(
+		r.Context(),
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListShoppingListsResponse{ShoppingLists: lists},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// GetShoppingList gets a shopping list of the authenticated user
+// @Summary Get a shopping list
+// @Description Gets a shopping list owned by the authenticated user with its items
+// @Tags api v1 shopping lists
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Shopping list ID"
+// @Param If-None-Match header string false "Entity tags of the representations the client already has"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetShoppingListResponse]
+// @Header 200 {string} ETag "Version of the shopping list and digest of the representation"
+// @Success 304 "Not modified"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shopping-lists/{id} [get]
+func GetShoppingList(w http.ResponseWriter, r *http.Request) error {
+	// Get the shopping list ID
+	listID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the version before the shopping list, so it is never newer than the content sent
+	version, err := internalsqlite.RecipesService.GetVersion(
+		r.Context(),
+		internalrouterapiv1recipe.SyncEntityShoppingList,
+		listID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Get the shopping list
+	list, err := internalsqlite.RecipesService.GetShoppingList(
+		r.Context(),
+		userID,
+		listID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send no content if the client already has this representation
+	responseBody := &GetShoppingListResponse{ShoppingList: list}
+	tag, err := internaletag.FormatRepresentation(r, version, responseBody)
+	if err != nil {
+		return err
+	}
+	if internaletag.CheckNotModified(w, r, tag) {
+		return nil
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			responseBody,
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// UpdateShoppingList updates a shopping list of the authenticated user
+// @Summary Update a shopping list
+// @Description Replaces the title and the items of a shopping list, checking items off is an update owned by the authenticated user
+// @Tags api v1 shopping lists
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Shopping list ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Param request body UpdateShoppingListRequest true "Update Shopping List Request"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Header 200 {string} ETag "New version of the shopping list"
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shopping-lists/{id} [put]
+func UpdateShoppingList(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*UpdateShoppingListRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the shopping list ID
+	listID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Update the shopping list
+	newVersion, err := internalsqlite.RecipesService.UpdateShoppingList(
+		r.Context(),
+		userID,
+		requestBody.ShoppingList(listID),
+		versions,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Send the new version
+	internaletag.SetVersion(w, newVersion)
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// DeleteShoppingList deletes a shopping list of the authenticated user
+// @Summary Delete a shopping list
+// @Description Deletes a shopping list owned by the authenticated user
+// @Tags api v1 shopping lists
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Shopping list ID"
+// @Param If-Match header string true "Entity tags of the versions the change may be made over, or * to skip the check"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 412 {object} errorcodes.FailBody
+// @Failure 428 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shopping-lists/{id} [delete]
+func DeleteShoppingList(w http.ResponseWriter, r *http.Request) error {
+	// Get the shopping list ID
+	listID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the versions the change may be made over
+	versions, err := internaletag.GetIfMatchVersions(r)
+	if err != nil {
+		return err
+	}
+
+	// Delete the shopping list
+	if err = internalsqlite.RecipesService.DeleteShoppingList(
+		r.Context(),
+		userID,
+		listID,
+		versions,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(nil, http.StatusOK),
+	)
+	return nil
+}
+
+// GetShoppingListCost estimates the cost of a shopping list
+// @Summary Estimate the cost of a shopping list
+// @Description Estimates the cost of a shopping list owned by the authenticated user, and of the items not checked yet, with the price list of the user. Each item is priced like an ingredient of a recipe. Items without a price, without a usable amount or whose unit does not convert to the unit of their price are listed as missing and left out of the totals, which are then incomplete
+// @Tags api v1 shopping lists
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param id path int true "Shopping list ID"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetShoppingListCostResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/shopping-lists/{id}/cost [get]
+func GetShoppingListCost(w http.ResponseWriter, r *http.Request) error {
+	// Get the shopping list ID
+	listID, err := internalrequest.GetPathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Estimate the cost
+	cost, err := internalsqlite.RecipesService.EstimateShoppingListCost(
+		r.Context(),
+		userID,
+		listID,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetShoppingListCostResponse{Cost: cost},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,68 @@
+package shoppinglists
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// CreateShoppingListRequest is the request body to create a shopping list
+	CreateShoppingListRequest struct {
+		Title string                                       `json:"title"`
+		Items []internalrouterapiv1recipe.ShoppingListItem `json:"items,omitempty"`
+	}
+
+	// CreateShoppingListResponse is the response body of a created shopping list
+	CreateShoppingListResponse struct {
+		ID int `json:"id"`
+	}
+
+	// UpdateShoppingListRequest is the request body to update a shopping list
+	UpdateShoppingListRequest struct {
+		Title string                                       `json:"title"`
+		Items []internalrouterapiv1recipe.ShoppingListItem `json:"items"`
+	}
+
+	// GetShoppingListResponse is the response body of a shopping list
+	GetShoppingListResponse struct {
+		ShoppingList *internalrouterapiv1recipe.ShoppingList `json:"shopping_list"`
+	}
+
+	// ListShoppingListsResponse is the response body of a list of shopping lists
+	ListShoppingListsResponse struct {
+		ShoppingLists []*internalrouterapiv1recipe.ShoppingList `json:"shopping_lists"`
+	}
+
+	// GetShoppingListCostResponse is the response body of the estimated cost of a shopping list
+	GetShoppingListCostResponse struct {
+		Cost *internalrouterapiv1recipe.ShoppingListCost `json:"cost"`
+	}
+)
+
+// ShoppingList maps the request body to a shopping list
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShoppingList: the shopping list
+func (c *CreateShoppingListRequest) ShoppingList() *internalrouterapiv1recipe.ShoppingList {
+	return &internalrouterapiv1recipe.ShoppingList{
+		Title: c.Title,
+		Items: c.Items,
+	}
+}
+
+// ShoppingList maps the request body to a shopping list
+//
+// Parameters:
+//
+//   - id: the ID of the shopping list
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShoppingList: the shopping list
+func (u *UpdateShoppingListRequest) ShoppingList(id int) *internalrouterapiv1recipe.ShoppingList {
+	return &internalrouterapiv1recipe.ShoppingList{
+		ID:    id,
+		Title: u.Title,
+		Items: u.Items,
+	}
+}
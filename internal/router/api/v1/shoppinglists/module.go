@@ -0,0 +1,46 @@
+package shoppinglists
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/shopping-lists",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"POST /",
+				CreateShoppingList,
+				internalmiddleware.ValidateJSON(CreateShoppingListRequest{}),
+			)
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListMyShoppingLists,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}",
+				GetShoppingList,
+			)
+			m.AddEndpointHandler(
+				"PUT /{id}",
+				UpdateShoppingList,
+				internalmiddleware.ValidateJSON(UpdateShoppingListRequest{}),
+			)
+			m.AddEndpointHandler(
+				"DELETE /{id}",
+				DeleteShoppingList,
+			)
+			m.AddEndpointHandler(
+				"GET /{id}/cost",
+				GetShoppingListCost,
+			)
+		},
+	}
+)
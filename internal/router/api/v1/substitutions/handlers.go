@@ -0,0 +1,53 @@
+package substitutions
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internalsubstitutions "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/substitutions"
+)
+
+// GetSubstitutions gets the substitutions of an ingredient
+// @Summary Get the substitutions of an ingredient
+// @Description Gets the catalog entry of an ingredient with its substitutions, each one giving the amount of the ingredient it replaces and the ingredients that replace it. The ingredient is matched by name or alias, or by the longest run of words of the name that is a catalog ingredient. When diets are given, only the substitutions that suit them all are listed
+// @Tags api v1 substitutions
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param ingredient query string true "Ingredient name"
+// @Param diet query string false "Comma-separated diets the substitutions must suit"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetSubstitutionsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/substitutions [get]
+func GetSubstitutions(w http.ResponseWriter, r *http.Request) error {
+	// Get the diets
+	diets, err := internalsubstitutions.ParseDiets(
+		r.URL.Query().Get(internalsubstitutions.DietQueryParameter),
+	)
+	if err != nil {
+		return internalsubstitutions.ParseError(err)
+	}
+
+	// Look up the ingredient
+	ingredient, err := internalsubstitutions.Substitutions.Lookup(
+		r.URL.Query().Get(internalsubstitutions.IngredientQueryParameter),
+		diets,
+	)
+	if err != nil {
+		return internalsubstitutions.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetSubstitutionsResponse{Ingredient: ingredient},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,12 @@
+package substitutions
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// GetSubstitutionsResponse is the response body of the substitutions of an ingredient
+	GetSubstitutionsResponse struct {
+		Ingredient *internalrouterapiv1recipe.SubstitutableIngredient `json:"ingredient"`
+	}
+)
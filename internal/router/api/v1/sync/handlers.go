@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"net/http"
+
+	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalfeed "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/feed"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalmessages "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/messages"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+// GetSyncChanges lists the synced entities of the authenticated user changed since the previous sync
+// @Summary Get the changes since the previous sync
+// @Description Lists the recipes, groups, meal plans, shopping lists and favorites of the authenticated user created, updated or deleted since the sync the token was issued by, oldest change first. Each entity is listed once with its current content and version, or as deleted. A recipe changes along with its tags and translations, a group with its recipes and a meal plan with its entries, and a recipe is served in the first language asked for it has a translation to. Send the returned token as since on the next sync, right away while has_more is set; without a token the whole library is sent. If the token is ahead of the server, reset is set and the whole library is sent again
+// @Tags api v1 sync
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param since query string false "Token of the previous sync"
+// @Param limit query int false "Maximum number of changed entities"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetSyncChangesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/sync [get]
+func GetSyncChanges(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the limit
+	limit, err := internalrequest.GetQueryInt(
+		r,
+		internalrequest.LimitQueryParameter,
+		internalrequest.DefaultLimit,
+		1,
+		internalrequest.MaxLimit,
+	)
+	if err != nil {
+		return err
+	}
+
+	// List the changes
+	delta, err := internalsqlite.RecipesService.ListSyncChanges(
+		r.Context(),
+		userID,
+		r.URL.Query().Get(internalrequest.SinceQueryParameter),
+		limit,
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for, as their translations are synced along with them
+	recipes := make([]*internalrouterapiv1recipe.Recipe, 0, len(delta.Recipes))
+	for _, syncRecipe := range delta.Recipes {
+		if syncRecipe.Recipe != nil {
+			recipes = append(recipes, syncRecipe.Recipe)
+		}
+	}
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetSyncChangesResponse{Delta: delta},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// PushSyncChanges applies the changes the authenticated user made offline
+// @Summary Push the changes made offline
+// @Description Applies the changes the authenticated user made offline to recipes, groups, meal plans, shopping lists and favorites, in order: if a change is not valid, none is applied. Favorites are only created, with the recipe to mark, or deleted; marking a recipe that is already a favorite results in the favorite already stored. A change that could not be applied, such as one to an entity the user does not own, is reported in its result with the code and localized message of the error, while the rest are still applied. Updates and deletes carry the version of the entity they were made over; if the entity changed on the server since, the change is not applied but reported as a conflict with the current content and version, to be resolved and pushed again. Deleting an entity already deleted is not a conflict. The results carry the IDs assigned to the entities created offline, matched by client_id, and the new versions
+// @Tags api v1 sync
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param request body PushSyncChangesRequest true "Push Sync Changes Request"
+// @Param lang query string false "Language of the created recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the created recipes and the tag names, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[PushSyncChangesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 403 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/sync [post]
+func PushSyncChanges(w http.ResponseWriter, r *http.Request) error {
+	// Get the body from the context
+	requestBody, ok := gonethttpctx.GetBody(r).(*PushSyncChangesRequest)
+	if !ok {
+		panic(gonethttpctx.ErrInvalidBodyType)
+	}
+
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Write the created recipes in the request language unless told otherwise
+	language := internalrequest.GetLanguage(r)
+	changes := requestBody.SyncChanges()
+	for _, change := range changes {
+		if change.Operation != internalrouterapiv1recipe.SyncOperationCreate || change.Recipe == nil {
+			continue
+		}
+		if change.Recipe.Language == "" {
+			change.Recipe.Language = language
+		}
+	}
+
+	// Apply the changes
+	results, conflicts, err := internalsqlite.RecipesService.PushSyncChanges(
+		r.Context(),
+		userID,
+		changes,
+		language,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Localize the errors of the changes that failed and announce the recipes that are now public to the followers of
+	// the user
+	languages := internalrequest.GetLanguages(r)
+	for _, result := range results {
+		if result.Error != "" {
+			_, result.Error = internalmessages.Messages.Localize(result.Error, languages)
+			continue
+		}
+		if result.Entity == internalrouterapiv1recipe.SyncEntityRecipe && !result.Deleted {
+			internalfeed.Feeds.Publish(r.Context(), result.ID)
+		}
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&PushSyncChangesResponse{
+				Results:   results,
+				Conflicts: conflicts,
+			},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,166 @@
+package sync
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// SyncRecipeRequest is the content of a recipe created or updated offline
+	SyncRecipeRequest struct {
+		Name            string                                 `json:"name"`
+		Description     string                                 `json:"description"`
+		PreparationTime int                                    `json:"preparation_time"` // in minutes
+		CookingTime     int                                    `json:"cooking_time"`     // in minutes
+		Ingredients     []internalrouterapiv1recipe.Ingredient `json:"ingredients,omitempty"`
+		Steps           []string                               `json:"steps"`
+		Servings        int                                    `json:"servings"`
+		Difficulty      string                                 `json:"difficulty"`
+		ImageURL        string                                 `json:"image_url,omitempty"`
+		Visibility      internalrouterapiv1recipe.Visibility   `json:"visibility,omitempty"` // private by default on create, kept if not set on update
+		Language        string                                 `json:"language,omitempty"`   // ISO 639 code, the request language by default on create, kept if not set on update
+		Tags            []string                               `json:"tags,omitempty"`       // tag slugs, synonyms or free-form labels, kept if not set on update
+	}
+
+	// SyncGroupRequest is the content of a recipe group created or updated offline
+	SyncGroupRequest struct {
+		Title       string                               `json:"title"`
+		Description string                               `json:"description,omitempty"`
+		Visibility  internalrouterapiv1recipe.Visibility `json:"visibility,omitempty"` // private by default on create, kept if not set on update
+		RecipeIDs   []int                                `json:"recipe_ids,omitempty"` // in the order they appear in the cookbook
+	}
+
+	// SyncMealPlanRequest is the content of a meal plan created or updated offline
+	SyncMealPlanRequest struct {
+		Title   string                                    `json:"title"`
+		Entries []internalrouterapiv1recipe.MealPlanEntry `json:"entries,omitempty"` // sorted by date, keeping the order of the entries of the same date
+	}
+
+	// SyncShoppingListRequest is the content of a shopping list created or updated offline
+	SyncShoppingListRequest struct {
+		Title string                                       `json:"title"`
+		Items []internalrouterapiv1recipe.ShoppingListItem `json:"items,omitempty"`
+	}
+
+	// SyncChangeRequest is a change made offline to a recipe, a group, a meal plan, a shopping list or a favorite
+	SyncChangeRequest struct {
+		Entity       internalrouterapiv1recipe.SyncEntity    `json:"entity"`                  // recipe, group, meal_plan, shopping_list or favorite
+		Operation    internalrouterapiv1recipe.SyncOperation `json:"operation"`               // create, update or delete, favorites are only created or deleted
+		ClientID     string                                  `json:"client_id,omitempty"`     // echoed back to match the results to the local entities
+		ID           int                                     `json:"id,omitempty"`            // required to update or delete
+		BaseVersion  int                                     `json:"base_version,omitempty"`  // version the change was made over, required to update or delete
+		Recipe       *SyncRecipeRequest                      `json:"recipe,omitempty"`        // required to create or update a recipe
+		Group        *SyncGroupRequest                       `json:"group,omitempty"`         // required to create or update a group
+		MealPlan     *SyncMealPlanRequest                    `json:"meal_plan,omitempty"`     // required to create or update a meal plan
+		ShoppingList *SyncShoppingListRequest                `json:"shopping_list,omitempty"` // required to create or update a shopping list
+		RecipeID     int                                     `json:"recipe_id,omitempty"`     // recipe to mark as a favorite, required to create a favorite
+	}
+
+	// PushSyncChangesRequest is the request body to push the changes made offline
+	PushSyncChangesRequest struct {
+		Changes []SyncChangeRequest `json:"changes"` // applied in order
+	}
+
+	// PushSyncChangesResponse is the response body of the pushed changes
+	PushSyncChangesResponse struct {
+		Results   []*internalrouterapiv1recipe.SyncResult   `json:"results"`   // applied changes, in order
+		Conflicts []*internalrouterapiv1recipe.SyncConflict `json:"conflicts"` // changes not applied because the entity changed on the server, in order
+	}
+
+	// GetSyncChangesResponse is the response body of the changes since the previous sync
+	GetSyncChangesResponse struct {
+		Delta *internalrouterapiv1recipe.SyncDelta `json:"delta"`
+	}
+)
+
+// Recipe maps the request body to a recipe
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Recipe: the recipe
+func (s *SyncRecipeRequest) Recipe() *internalrouterapiv1recipe.Recipe {
+	return &internalrouterapiv1recipe.Recipe{
+		Name:            s.Name,
+		Description:     s.Description,
+		PreparationTime: s.PreparationTime,
+		CookingTime:     s.CookingTime,
+		Ingredients:     s.Ingredients,
+		Steps:           s.Steps,
+		Servings:        s.Servings,
+		Difficulty:      s.Difficulty,
+		ImageURL:        s.ImageURL,
+		Visibility:      s.Visibility,
+		Language:        s.Language,
+	}
+}
+
+// Group maps the request body to a recipe group
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.Group: the group
+func (s *SyncGroupRequest) Group() *internalrouterapiv1recipe.Group {
+	return &internalrouterapiv1recipe.Group{
+		Title:       s.Title,
+		Description: s.Description,
+		Visibility:  s.Visibility,
+		RecipeIDs:   s.RecipeIDs,
+	}
+}
+
+// MealPlan maps the request body to a meal plan
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.MealPlan: the meal plan
+func (s *SyncMealPlanRequest) MealPlan() *internalrouterapiv1recipe.MealPlan {
+	return &internalrouterapiv1recipe.MealPlan{
+		Title:   s.Title,
+		Entries: s.Entries,
+	}
+}
+
+// ShoppingList maps the request body to a shopping list
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShoppingList: the shopping list
+func (s *SyncShoppingListRequest) ShoppingList() *internalrouterapiv1recipe.ShoppingList {
+	return &internalrouterapiv1recipe.ShoppingList{
+		Title: s.Title,
+		Items: s.Items,
+	}
+}
+
+// SyncChanges maps the request body to the sync changes
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.SyncChange: the changes, in order
+func (p *PushSyncChangesRequest) SyncChanges() []*internalrouterapiv1recipe.SyncChange {
+	changes := make([]*internalrouterapiv1recipe.SyncChange, 0, len(p.Changes))
+	for _, c := range p.Changes {
+		change := &internalrouterapiv1recipe.SyncChange{
+			Entity:      c.Entity,
+			Operation:   c.Operation,
+			ClientID:    c.ClientID,
+			ID:          c.ID,
+			BaseVersion: c.BaseVersion,
+			RecipeID:    c.RecipeID,
+		}
+		if c.Recipe != nil {
+			change.Recipe = c.Recipe.Recipe()
+			change.Tags = c.Recipe.Tags
+		}
+		if c.Group != nil {
+			change.Group = c.Group.Group()
+		}
+		if c.MealPlan != nil {
+			change.MealPlan = c.MealPlan.MealPlan()
+		}
+		if c.ShoppingList != nil {
+			change.ShoppingList = c.ShoppingList.ShoppingList()
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,29 @@
+package sync
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/sync",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				GetSyncChanges,
+			)
+			m.AddExactEndpointHandler(
+				"POST /",
+				PushSyncChanges,
+				internalmiddleware.ValidateJSON(PushSyncChangesRequest{}),
+			)
+		},
+	}
+)
@@ -0,0 +1,187 @@
+package tags
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqlite "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite"
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// DefaultAutocompleteLimit is the default number of tags returned by the autocomplete endpoint
+	DefaultAutocompleteLimit = 10
+
+	// MaxAutocompleteLimit is the maximum number of tags returned by the autocomplete endpoint
+	MaxAutocompleteLimit = 50
+)
+
+// ListTags lists the tags
+// @Summary List the tags
+// @Description Lists the canonical cuisine and course tags and the authenticated user's own tags, with the number of recipes tagged with each one that the user can read: their own and the public ones
+// @Tags api v1 tags
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param kind query string false "Tag kind" Enums(cuisine, course, user)
+// @Param lang query string false "Language of the tag names"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListTagsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/tags [get]
+func ListTags(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// List the tags
+	tags, err := internalsqlite.RecipesService.ListTags(
+		r.Context(),
+		userID,
+		internalrouterapiv1recipe.TagKind(r.URL.Query().Get("kind")),
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListTagsResponse{Tags: tags},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// AutocompleteTags suggests tags for a partial input
+// @Summary Autocomplete tags
+// @Description Lists the tags whose slug, synonyms or localized name start with the given text
+// @Tags api v1 tags
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param q query string true "Text typed by the user"
+// @Param kind query string false "Tag kind" Enums(cuisine, course, user)
+// @Param limit query int false "Maximum number of tags"
+// @Param lang query string false "Language of the tag names"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListTagsResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/tags/autocomplete [get]
+func AutocompleteTags(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the limit
+	limit, err := internalrequest.GetQueryInt(
+		r,
+		internalrequest.LimitQueryParameter,
+		DefaultAutocompleteLimit,
+		1,
+		MaxAutocompleteLimit,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Autocomplete the tags
+	tags, err := internalsqlite.RecipesService.AutocompleteTags(
+		r.Context(),
+		userID,
+		internalrouterapiv1recipe.TagKind(r.URL.Query().Get("kind")),
+		r.URL.Query().Get("q"),
+		internalrequest.GetLanguage(r),
+		limit,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListTagsResponse{Tags: tags},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
+
+// ListTagRecipes lists the recipes tagged with a tag
+// @Summary Browse the recipes of a tag
+// @Description Lists the recipes tagged with a canonical tag or one of the authenticated user's own tags, newest first
+// @Tags api v1 tags
+// @Accept json
+// @Produce json
+// @Security CookieAuth
+// @Param kind path string true "Tag kind" Enums(cuisine, course, user)
+// @Param slug path string true "Tag slug"
+// @Param limit query int false "Maximum number of recipes"
+// @Param offset query int false "Number of recipes to skip"
+// @Param lang query string false "Language of the recipes and the tag names, preferred over Accept-Language"
+// @Param Accept-Language header string false "Languages of the recipes, in order of preference"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[ListTagRecipesResponse]
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/tags/{kind}/{slug}/recipes [get]
+func ListTagRecipes(w http.ResponseWriter, r *http.Request) error {
+	// Get the authenticated user ID
+	userID, err := internaljwt.GetCtxUserID(r)
+	if err != nil {
+		return err
+	}
+
+	// Get the pagination
+	limit, offset, err := internalrequest.GetPagination(r)
+	if err != nil {
+		return err
+	}
+
+	// List the recipes of the tag
+	tag, recipes, err := internalsqlite.RecipesService.ListRecipesByTag(
+		r.Context(),
+		userID,
+		internalrouterapiv1recipe.TagKind(r.PathValue("kind")),
+		r.PathValue("slug"),
+		internalrequest.GetLanguage(r),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Serve the recipes in the languages asked for
+	if err = internalsqlite.RecipesService.TranslateRecipes(
+		r.Context(),
+		internalrequest.GetLanguages(r),
+		recipes...,
+	); err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&ListTagRecipesResponse{Tag: tag, Recipes: recipes},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,18 @@
+package tags
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// ListTagsResponse is the response body of a list of tags
+	ListTagsResponse struct {
+		Tags []internalrouterapiv1recipe.Tag `json:"tags"`
+	}
+
+	// ListTagRecipesResponse is the response body of the recipes tagged with a tag
+	ListTagRecipesResponse struct {
+		Tag     *internalrouterapiv1recipe.Tag      `json:"tag"`
+		Recipes []*internalrouterapiv1recipe.Recipe `json:"recipes"`
+	}
+)
@@ -0,0 +1,32 @@
+package tags
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	Module = &gonethttp.Module{
+		Pattern: "/tags",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.AuthenticateAccessToken,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddExactEndpointHandler(
+				"GET /",
+				ListTags,
+			)
+			m.AddEndpointHandler(
+				"GET /autocomplete",
+				AutocompleteTags,
+			)
+			m.AddEndpointHandler(
+				"GET /{kind}/{slug}/recipes",
+				ListTagRecipes,
+			)
+		},
+	}
+)
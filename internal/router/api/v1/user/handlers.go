@@ -6,12 +6,13 @@ import (
 	gogrpcnethttp "github.com/ralvarezdev/go-grpc/client/net/http"
 	gonethttpctx "github.com/ralvarezdev/go-net/http/context"
 	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
-	gonethttpresponsejsendgrpc "github.com/ralvarezdev/go-net/http/response/jsend/grpc"
 	pbauth "github.com/ralvarezdev/grpc-auth-proto-go/compiled/ralvarezdev/auth"
 	pbempty "google.golang.org/protobuf/types/known/emptypb"
 
 	internalgrpcauth "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/grpc/auth"
 	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalprofiles "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/profiles"
 	internalprotojson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/protojson"
 )
 
@@ -24,9 +25,9 @@ import (
 // @Security CookieAuth
 // @Param request body pbauth.UpdateProfileRequest true "Update Profile Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/user/profile [put]
 func UpdateProfile(
 	w http.ResponseWriter,
@@ -51,7 +52,12 @@ func UpdateProfile(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
+	}
+
+	// Refresh the snapshot served on the public profile
+	if userID, userErr := internaljwt.GetCtxUserID(r); userErr == nil {
+		internalprofiles.Profiles.Refresh(ctx, userID)
 	}
 
 	// Handle the response
@@ -69,8 +75,8 @@ func UpdateProfile(
 // @Produce json
 // @Security CookieAuth
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[pbauth.GetMyProfileResponse]
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/user/profile [get]
 func GetMyProfile(
 	w http.ResponseWriter,
@@ -90,7 +96,12 @@ func GetMyProfile(
 		&pbempty.Empty{},
 	)
 	if err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
+	}
+
+	// Store the snapshot served on the public profile
+	if userID, userErr := internaljwt.GetCtxUserID(r); userErr == nil {
+		internalprofiles.Profiles.Store(r.Context(), userID, responseBody)
 	}
 
 	// Handle the response
@@ -110,9 +121,9 @@ func GetMyProfile(
 // @Security CookieAuth
 // @Param request body pbauth.ChangeUsernameRequest true "Change Username Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/user/username [put]
 func ChangeUsername(
 	w http.ResponseWriter,
@@ -137,7 +148,12 @@ func ChangeUsername(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
+	}
+
+	// Refresh the snapshot served on the public profile, so the old username stops resolving
+	if userID, userErr := internaljwt.GetCtxUserID(r); userErr == nil {
+		internalprofiles.Profiles.Refresh(ctx, userID)
 	}
 
 	// Handle the response
@@ -157,9 +173,9 @@ func ChangeUsername(
 // @Security CookieAuth
 // @Param request body pbauth.DeleteUserRequest true "Delete User Request"
 // @Success 200 {object} gonethttpresponsejsend.SuccessBody[any]
-// @Failure 400 {object} gonethttpresponsejsend.FailBody
-// @Failure 401 {object} gonethttpresponsejsend.FailBody
-// @Failure 500 {object} gonethttpresponsejsend.ErrorBody
+// @Failure 400 {object} errorcodes.FailBody
+// @Failure 401 {object} errorcodes.FailBody
+// @Failure 500 {object} errorcodes.ErrorBody
 // @Router /api/v1/user [delete]
 func DeleteUser(
 	w http.ResponseWriter,
@@ -184,7 +200,12 @@ func DeleteUser(
 		ctx,
 		requestBody,
 	); err != nil {
-		return gonethttpresponsejsendgrpc.ParseError(err, true)
+		return internalgrpcauth.ParseError(err, true)
+	}
+
+	// Remove the public profile
+	if userID, userErr := internaljwt.GetCtxUserID(r); userErr == nil {
+		internalprofiles.Profiles.Forget(r.Context(), userID)
 	}
 
 	// Handle the response
@@ -0,0 +1,46 @@
+package users
+
+import (
+	"net/http"
+
+	gonethttpresponsejsend "github.com/ralvarezdev/go-net/http/response/jsend"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljson "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/json"
+	internalprofiles "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/profiles"
+	internalrequest "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/request"
+)
+
+// GetUserProfile gets the public profile of a user
+// @Summary Get the public profile of a user
+// @Description Gets the public profile of a user by username: the public fields of their account, their latest public recipes, their public groups and their follower counts. The account fields are the ones the user last read or changed through this API, the auth service is not called. No session is needed
+// @Tags api v1 users
+// @Accept json
+// @Produce json
+// @Param username path string true "Username, case-insensitive"
+// @Param lang query string false "Language of the tag names"
+// @Success 200 {object} gonethttpresponsejsend.SuccessBody[GetUserProfileResponse]
+// @Failure 404 {object} errorcodes.FailBody
+// @Failure 429 {object} errorcodes.ErrorBody
+// @Failure 500 {object} errorcodes.ErrorBody
+// @Router /api/v1/users/{username} [get]
+func GetUserProfile(w http.ResponseWriter, r *http.Request) error {
+	// Get the profile
+	profile, err := internalprofiles.Profiles.Get(
+		r.Context(),
+		r.PathValue("username"),
+		internalrequest.GetLanguage(r),
+	)
+	if err != nil {
+		return internalsqliterecipes.ParseError(err)
+	}
+
+	// Handle the response
+	internaljson.Handler.HandleResponse(
+		w, r, gonethttpresponsejsend.NewSuccessResponse(
+			&GetUserProfileResponse{Profile: profile},
+			http.StatusOK,
+		),
+	)
+	return nil
+}
@@ -0,0 +1,12 @@
+package users
+
+import (
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// GetUserProfileResponse is the response body of a public user profile
+	GetUserProfileResponse struct {
+		Profile *internalrouterapiv1recipe.UserProfile `json:"profile"`
+	}
+)
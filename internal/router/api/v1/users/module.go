@@ -0,0 +1,25 @@
+package users
+
+import (
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalmiddleware "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/middleware"
+)
+
+var (
+	// Module serves the public profiles of the users, which need no session, behind the public endpoints rate limiter
+	Module = &gonethttp.Module{
+		Pattern: "/users",
+		BeforeLoadFn: func(m *gonethttp.Module) {
+			m.Middlewares = gonethttp.NewMiddlewares(
+				internalmiddleware.LimitPublicRequests,
+			)
+		},
+		AddHandlersFn: func(m *gonethttp.Module) {
+			m.AddEndpointHandler(
+				"GET /{username}",
+				GetUserProfile,
+			)
+		},
+	}
+)
@@ -0,0 +1,47 @@
+package share
+
+import (
+	internalloader "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/loader"
+)
+
+const (
+	// EnvShareLinkSecret is the environment variable for the secret that signs the share links. It is independent of
+	// the JWT keys, so rotating it only revokes the share links
+	EnvShareLinkSecret = "SHARE_LINK_SECRET"
+
+	// MinSecretLength is the minimum length of the share link secret, in bytes
+	MinSecretLength = 32
+
+	// DefaultExpirationHours is the number of hours a share link is valid for when it is not set
+	DefaultExpirationHours = 7 * 24
+
+	// MaxExpirationHours is the maximum number of hours a share link can be valid for
+	MaxExpirationHours = 30 * 24
+
+	// ExpirationQueryParameter is the query parameter for the number of hours a share link is valid for
+	ExpirationQueryParameter = "expires_in"
+)
+
+var (
+	// Signer is the signer of the share links
+	Signer *TokenSigner
+)
+
+// Load loads the share link secret and creates the signer
+func Load() {
+	// Load the secret
+	var secret string
+	if err := internalloader.Loader.LoadVariable(
+		EnvShareLinkSecret,
+		&secret,
+	); err != nil {
+		panic(err)
+	}
+
+	// Create the signer
+	signer, err := NewTokenSigner([]byte(secret))
+	if err != nil {
+		panic(err)
+	}
+	Signer = signer
+}
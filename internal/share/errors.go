@@ -0,0 +1,48 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrNilSigner      = errors.New("share link signer cannot be nil")
+	ErrSecretTooShort = errors.New("share link secret is too short")
+	ErrInvalidKind    = errors.New("invalid share link kind")
+	ErrInvalidToken   = errors.New("invalid share link")
+	ErrExpiredToken   = errors.New("share link has expired")
+)
+
+// ParseError maps a share link error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the share link error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidToken):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"token",
+			err,
+			internalerrorcodes.InvalidShareLink.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrExpiredToken):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			"token",
+			err,
+			internalerrorcodes.ExpiredShareLink.String(),
+			http.StatusGone,
+		)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,187 @@
+package share
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Kind is the kind of resource a share link gives access to
+	Kind string
+
+	// Claims are the claims carried by a share link token
+	Claims struct {
+		Kind      Kind   `json:"k"`
+		ID        int    `json:"id"`
+		OwnerID   string `json:"sub"` // the resource is read as this user, who created the link
+		ExpiresAt int64  `json:"exp"` // Unix time
+	}
+
+	// TokenSigner signs and verifies the share link tokens. A token is the base64url encoded JSON claims followed by
+	// a dot and their base64url encoded HMAC-SHA256
+	TokenSigner struct {
+		secret []byte
+	}
+)
+
+const (
+	// KindRecipe is the kind of the share links of a recipe
+	KindRecipe Kind = "recipe"
+
+	// KindGroup is the kind of the share links of a recipe group
+	KindGroup Kind = "group"
+)
+
+// Path returns the path of the API endpoint that reads the resources of a kind through a share link
+//
+// Returns:
+//
+//   - string: the path, without the token
+func (k Kind) Path() string {
+	switch k {
+	case KindRecipe:
+		return "/api/v1/shared/recipes/"
+	case KindGroup:
+		return "/api/v1/shared/groups/"
+	default:
+		return ""
+	}
+}
+
+// NewTokenSigner creates a new TokenSigner
+//
+// Parameters:
+//
+//   - secret: the HMAC secret
+//
+// Returns:
+//
+//   - *TokenSigner: the TokenSigner instance
+//   - error: an error if the secret is too short
+func NewTokenSigner(secret []byte) (*TokenSigner, error) {
+	if len(secret) < MinSecretLength {
+		return nil, ErrSecretTooShort
+	}
+	return &TokenSigner{secret: secret}, nil
+}
+
+// sign computes the signature of the encoded claims
+//
+// Parameters:
+//
+//   - payload: the base64url encoded claims
+//
+// Returns:
+//
+//   - []byte: the signature
+func (t *TokenSigner) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// NewLink creates a share link to a resource, read as the user that creates it
+//
+// Parameters:
+//
+//   - kind: the kind of the resource
+//   - id: the ID of the resource
+//   - ownerID: the ID of the user that owns the resource
+//   - expiresIn: how long the link is valid for
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.ShareLink: the share link
+//   - error: an error if the kind is unknown or the token could not be signed
+func (t *TokenSigner) NewLink(
+	kind Kind,
+	id int,
+	ownerID string,
+	expiresIn time.Duration,
+) (*internalrouterapiv1recipe.ShareLink, error) {
+	// Check if the signer is nil
+	if t == nil {
+		return nil, ErrNilSigner
+	}
+
+	// Check the kind
+	path := kind.Path()
+	if path == "" {
+		return nil, ErrInvalidKind
+	}
+
+	// Encode and sign the claims
+	expiresAt := time.Now().Add(expiresIn).UTC().Truncate(time.Second)
+	claims, err := json.Marshal(
+		Claims{
+			Kind:      kind,
+			ID:        id,
+			OwnerID:   ownerID,
+			ExpiresAt: expiresAt.Unix(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	token := payload + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload))
+
+	return &internalrouterapiv1recipe.ShareLink{
+		Token:     token,
+		URL:       path + token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Verify checks the signature and the expiration of a share link token
+//
+// Parameters:
+//
+//   - token: the token
+//   - kind: the kind of resource the token must give access to
+//
+// Returns:
+//
+//   - *Claims: the claims of the token
+//   - error: an error if the token is malformed, was not signed with the secret, is for another kind of resource or
+//     has expired
+func (t *TokenSigner) Verify(token string, kind Kind) (*Claims, error) {
+	// Check if the signer is nil
+	if t == nil {
+		return nil, ErrNilSigner
+	}
+
+	// Check the signature before decoding anything else
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	decodedSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decodedSignature, t.sign(payload)) {
+		return nil, ErrInvalidToken
+	}
+
+	// Decode the claims
+	decodedPayload, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err = json.Unmarshal(decodedPayload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if claims.Kind != kind {
+		return nil, ErrInvalidToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
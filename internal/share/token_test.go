@@ -0,0 +1,200 @@
+package share
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestSigner creates the signer used by the tests
+//
+// Parameters:
+//
+//   - t: the test
+//   - secret: the secret, repeated up to the minimum length
+//
+// Returns:
+//
+//   - *TokenSigner: the signer
+func newTestSigner(t *testing.T, secret string) *TokenSigner {
+	t.Helper()
+	signer, err := NewTokenSigner([]byte(strings.Repeat(secret, MinSecretLength)))
+	if err != nil {
+		t.Fatalf("NewTokenSigner() error = %v", err)
+	}
+	return signer
+}
+
+// signPayload signs a raw payload, as a token forged with the secret would be
+//
+// Parameters:
+//
+//   - signer: the signer
+//   - claims: the JSON claims
+//
+// Returns:
+//
+//   - string: the token
+func signPayload(signer *TokenSigner, claims string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signer.sign(payload))
+}
+
+func TestNewTokenSigner(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret []byte
+		err    error
+	}{
+		{name: "minimum length", secret: make([]byte, MinSecretLength)},
+		{name: "too short", secret: make([]byte, MinSecretLength-1), err: ErrSecretTooShort},
+		{name: "missing", err: ErrSecretTooShort},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if _, err := NewTokenSigner(test.secret); !errors.Is(err, test.err) {
+					t.Errorf("NewTokenSigner() error = %v, want %v", err, test.err)
+				}
+			},
+		)
+	}
+}
+
+func TestNewLink(t *testing.T) {
+	signer := newTestSigner(t, "s")
+
+	link, err := signer.NewLink(KindGroup, 7, "u1", time.Hour)
+	if err != nil {
+		t.Fatalf("NewLink() error = %v", err)
+	}
+	if link.URL != KindGroup.Path()+link.Token {
+		t.Errorf("NewLink() URL = %s, want the path of the kind followed by the token", link.URL)
+	}
+	if expiresIn := time.Until(link.ExpiresAt); expiresIn <= 59*time.Minute || expiresIn > time.Hour {
+		t.Errorf("NewLink() expires in %s, want an hour", expiresIn)
+	}
+
+	if _, err = signer.NewLink("profile", 7, "u1", time.Hour); !errors.Is(err, ErrInvalidKind) {
+		t.Errorf("NewLink() of an unknown kind error = %v, want %v", err, ErrInvalidKind)
+	}
+	var nilSigner *TokenSigner
+	if _, err = nilSigner.NewLink(KindRecipe, 7, "u1", time.Hour); !errors.Is(err, ErrNilSigner) {
+		t.Errorf("NewLink() of a nil signer error = %v, want %v", err, ErrNilSigner)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signer := newTestSigner(t, "s")
+	link := func(kind Kind, expiresIn time.Duration) string {
+		t.Helper()
+		created, err := signer.NewLink(kind, 42, "u1", expiresIn)
+		if err != nil {
+			t.Fatalf("NewLink() error = %v", err)
+		}
+		return created.Token
+	}
+	valid := link(KindRecipe, time.Hour)
+	payload, signature, _ := strings.Cut(valid, ".")
+
+	// Tamper with the claims, keeping the signature of the original ones
+	tampered := base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"k":"recipe","id":43,"sub":"u1","exp":9999999999}`),
+	) + "." + signature
+
+	// Flip the last character of the signature
+	flipped := []byte(signature)
+	if flipped[len(flipped)-1] == 'A' {
+		flipped[len(flipped)-1] = 'B'
+	} else {
+		flipped[len(flipped)-1] = 'A'
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		kind  Kind
+		err   error
+	}{
+		{name: "valid", token: valid, kind: KindRecipe},
+		{name: "tampered claims", token: tampered, kind: KindRecipe, err: ErrInvalidToken},
+		{name: "tampered signature", token: payload + "." + string(flipped), kind: KindRecipe, err: ErrInvalidToken},
+		{
+			name:  "signed with another secret",
+			token: signPayload(newTestSigner(t, "o"), `{"k":"recipe","id":42,"sub":"u1","exp":9999999999}`),
+			kind:  KindRecipe,
+			err:   ErrInvalidToken,
+		},
+		{name: "other kind", token: valid, kind: KindGroup, err: ErrInvalidToken},
+		{name: "expired", token: link(KindRecipe, -time.Second), kind: KindRecipe, err: ErrExpiredToken},
+		{name: "expiring now", token: link(KindRecipe, 0), kind: KindRecipe, err: ErrExpiredToken},
+		{name: "empty", kind: KindRecipe, err: ErrInvalidToken},
+		{name: "no signature", token: payload, kind: KindRecipe, err: ErrInvalidToken},
+		{name: "empty signature", token: payload + ".", kind: KindRecipe, err: ErrInvalidToken},
+		{name: "signature not base64", token: payload + ".!!", kind: KindRecipe, err: ErrInvalidToken},
+		{name: "extra part", token: valid + ".x", kind: KindRecipe, err: ErrInvalidToken},
+		{
+			name:  "signed payload not base64",
+			token: "!!." + base64.RawURLEncoding.EncodeToString(signer.sign("!!")),
+			kind:  KindRecipe,
+			err:   ErrInvalidToken,
+		},
+		{name: "signed claims not JSON", token: signPayload(signer, "recipe"), kind: KindRecipe, err: ErrInvalidToken},
+		{
+			name:  "signed claims of the wrong type",
+			token: signPayload(signer, `{"k":"recipe","id":"42","sub":"u1","exp":9999999999}`),
+			kind:  KindRecipe,
+			err:   ErrInvalidToken,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				claims, err := signer.Verify(test.token, test.kind)
+				if !errors.Is(err, test.err) {
+					t.Fatalf("Verify() error = %v, want %v", err, test.err)
+				}
+				if test.err != nil {
+					if claims != nil {
+						t.Errorf("Verify() = %+v, want nil", claims)
+					}
+					return
+				}
+				if claims.Kind != test.kind || claims.ID != 42 || claims.OwnerID != "u1" {
+					t.Errorf("Verify() = %+v, want the claims of the link", claims)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"nil signer", func(t *testing.T) {
+			var nilSigner *TokenSigner
+			if _, err := nilSigner.Verify(valid, KindRecipe); !errors.Is(err, ErrNilSigner) {
+				t.Errorf("Verify() error = %v, want %v", err, ErrNilSigner)
+			}
+		},
+	)
+}
+
+func TestKindPath(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{kind: KindRecipe, want: "/api/v1/shared/recipes/"},
+		{kind: KindGroup, want: "/api/v1/shared/groups/"},
+		{kind: "profile"},
+	}
+	for _, test := range tests {
+		t.Run(
+			string(test.kind), func(t *testing.T) {
+				if got := test.kind.Path(); got != test.want {
+					t.Errorf("Path() = %q, want %q", got, test.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,297 @@
+package substitutions
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Catalog is the knowledge base of ingredient substitutions. Ingredients are matched by the slug of their name or
+	// aliases, or by the longest run of words of a recipe ingredient name that is one of them, so "unsalted butter"
+	// matches butter while "peanut butter" matches its own entry. Entries without substitutions keep such names, like
+	// "almond milk", from matching a shorter entry
+	Catalog struct {
+		ingredients map[string]*internalrouterapiv1recipe.SubstitutableIngredient
+	}
+
+	// catalogFile is the layout of a substitution catalog data file
+	catalogFile struct {
+		Ingredients []internalrouterapiv1recipe.SubstitutableIngredient `json:"ingredients"`
+	}
+)
+
+// NewCatalog creates a new Catalog
+//
+// Parameters:
+//
+//   - ingredients: the substitutable ingredients
+//
+// Returns:
+//
+//   - *Catalog: the Catalog instance
+//   - error: an error if an ingredient or substitution is not valid or a name is repeated
+func NewCatalog(ingredients []internalrouterapiv1recipe.SubstitutableIngredient) (*Catalog, error) {
+	catalog := &Catalog{
+		ingredients: make(map[string]*internalrouterapiv1recipe.SubstitutableIngredient),
+	}
+	for i := range ingredients {
+		ingredient := &ingredients[i]
+		if err := checkIngredient(ingredient); err != nil {
+			return nil, err
+		}
+		if ingredient.Substitutions == nil {
+			ingredient.Substitutions = []internalrouterapiv1recipe.Substitution{}
+		}
+
+		// Index the ingredient by its name and aliases
+		for _, name := range append([]string{ingredient.Name}, ingredient.Aliases...) {
+			slug := internalsqliterecipes.Slugify(name)
+			if slug == "" {
+				return nil, fmt.Errorf("%w: %q", ErrEmptyCatalogIngredient, ingredient.Name)
+			}
+			if _, ok := catalog.ingredients[slug]; ok {
+				return nil, fmt.Errorf("%w: %q", ErrDuplicateCatalogName, name)
+			}
+			catalog.ingredients[slug] = ingredient
+		}
+	}
+	return catalog, nil
+}
+
+// ParseCatalog parses a substitution catalog data file
+//
+// Parameters:
+//
+//   - data: the JSON data file, an object with the list of substitutable ingredients
+//
+// Returns:
+//
+//   - *Catalog: the Catalog instance
+//   - error: an error if the data file is not valid
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var file catalogFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCatalog, err)
+	}
+	return NewCatalog(file.Ingredients)
+}
+
+// checkIngredient checks a substitutable ingredient of the catalog
+//
+// Parameters:
+//
+//   - ingredient: the substitutable ingredient
+//
+// Returns:
+//
+//   - error: an error if the ingredient or any of its substitutions is not valid
+func checkIngredient(ingredient *internalrouterapiv1recipe.SubstitutableIngredient) error {
+	if strings.TrimSpace(ingredient.Name) == "" {
+		return ErrEmptyCatalogIngredient
+	}
+	if !validDiets(ingredient.Conflicts) {
+		return fmt.Errorf("%w: %q", ErrInvalidCatalogDiet, ingredient.Name)
+	}
+	for _, substitution := range ingredient.Substitutions {
+		if substitution.Quantity <= 0 || len(substitution.Replacement) == 0 {
+			return fmt.Errorf("%w: %q", ErrInvalidCatalogReplacement, ingredient.Name)
+		}
+		for _, replacement := range substitution.Replacement {
+			if strings.TrimSpace(replacement.Name) == "" {
+				return fmt.Errorf("%w: %q", ErrInvalidCatalogReplacement, ingredient.Name)
+			}
+		}
+		if !validDiets(substitution.Conflicts) {
+			return fmt.Errorf("%w: %q", ErrInvalidCatalogDiet, ingredient.Name)
+		}
+	}
+	return nil
+}
+
+// validDiets checks if every diet is one of the known diets
+//
+// Parameters:
+//
+//   - diets: the diets
+//
+// Returns:
+//
+//   - bool: true if every diet is valid
+func validDiets(diets []internalrouterapiv1recipe.Diet) bool {
+	for _, diet := range diets {
+		if !diet.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseDiets parses a comma-separated list of diets
+//
+// Parameters:
+//
+//   - value: the comma-separated diets, can be empty
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Diet: the diets
+//   - error: an error if a diet is not valid
+func ParseDiets(value string) ([]internalrouterapiv1recipe.Diet, error) {
+	diets := make([]internalrouterapiv1recipe.Diet, 0)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		diet := internalrouterapiv1recipe.Diet(strings.ToLower(field))
+		if !diet.IsValid() {
+			return nil, ErrInvalidDiet
+		}
+		diets = append(diets, diet)
+	}
+	return diets, nil
+}
+
+// conflictingDiets gets the diets of a list that are also in another one
+//
+// Parameters:
+//
+//   - conflicts: the diets an ingredient does not suit
+//   - diets: the diets of the reader
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Diet: the diets of the reader the ingredient does not suit
+func conflictingDiets(conflicts, diets []internalrouterapiv1recipe.Diet) []internalrouterapiv1recipe.Diet {
+	var matched []internalrouterapiv1recipe.Diet
+	for _, diet := range diets {
+		if slices.Contains(conflicts, diet) && !slices.Contains(matched, diet) {
+			matched = append(matched, diet)
+		}
+	}
+	return matched
+}
+
+// suitableSubstitutions gets the substitutions of an ingredient that suit every diet
+//
+// Parameters:
+//
+//   - ingredient: the substitutable ingredient
+//   - diets: the diets
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.Substitution: the substitutions that suit the diets
+func suitableSubstitutions(
+	ingredient *internalrouterapiv1recipe.SubstitutableIngredient,
+	diets []internalrouterapiv1recipe.Diet,
+) []internalrouterapiv1recipe.Substitution {
+	substitutions := make([]internalrouterapiv1recipe.Substitution, 0, len(ingredient.Substitutions))
+	for _, substitution := range ingredient.Substitutions {
+		if len(conflictingDiets(substitution.Conflicts, diets)) == 0 {
+			substitutions = append(substitutions, substitution)
+		}
+	}
+	return substitutions
+}
+
+// Match finds the catalog ingredient of an ingredient name
+//
+// Parameters:
+//
+//   - name: the ingredient name
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.SubstitutableIngredient: the catalog ingredient, nil if none matches
+func (c *Catalog) Match(name string) *internalrouterapiv1recipe.SubstitutableIngredient {
+	if c == nil {
+		return nil
+	}
+
+	// Look for the longest run of words that is a catalog ingredient
+	for _, run := range internalsqliterecipes.SlugRuns(internalsqliterecipes.Slugify(name)) {
+		if ingredient, ok := c.ingredients[run]; ok {
+			return ingredient
+		}
+	}
+	return nil
+}
+
+// Lookup gets the substitutions of an ingredient
+//
+// Parameters:
+//
+//   - name: the ingredient name
+//   - diets: the diets the substitutions must suit, all substitutions are returned if empty
+//
+// Returns:
+//
+//   - *internalrouterapiv1recipe.SubstitutableIngredient: the catalog ingredient with the suitable substitutions
+//   - error: an error if the name is empty or the ingredient has no substitutions
+func (c *Catalog) Lookup(
+	name string,
+	diets []internalrouterapiv1recipe.Diet,
+) (*internalrouterapiv1recipe.SubstitutableIngredient, error) {
+	if c == nil {
+		return nil, ErrNilCatalog
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrEmptyIngredientQuery
+	}
+
+	ingredient := c.Match(name)
+	if ingredient == nil || len(ingredient.Substitutions) == 0 {
+		return nil, ErrIngredientNotFound
+	}
+
+	result := *ingredient
+	result.Substitutions = suitableSubstitutions(ingredient, diets)
+	return &result, nil
+}
+
+// Conflicts finds the ingredients of a recipe that do not suit some diets, along with the substitutions that suit
+// them all
+//
+// Parameters:
+//
+//   - recipe: the recipe
+//   - diets: the diets of the reader
+//
+// Returns:
+//
+//   - []internalrouterapiv1recipe.DietConflict: the conflicting ingredients, in recipe order
+func (c *Catalog) Conflicts(
+	recipe *internalrouterapiv1recipe.Recipe,
+	diets []internalrouterapiv1recipe.Diet,
+) []internalrouterapiv1recipe.DietConflict {
+	conflicts := make([]internalrouterapiv1recipe.DietConflict, 0)
+	if c == nil || recipe == nil || len(diets) == 0 {
+		return conflicts
+	}
+
+	for i, recipeIngredient := range recipe.Ingredients {
+		ingredient := c.Match(recipeIngredient.Name)
+		if ingredient == nil {
+			continue
+		}
+		matched := conflictingDiets(ingredient.Conflicts, diets)
+		if len(matched) == 0 {
+			continue
+		}
+		conflicts = append(
+			conflicts, internalrouterapiv1recipe.DietConflict{
+				Position:      i + 1,
+				Ingredient:    recipeIngredient.Name,
+				Diets:         matched,
+				Substitutions: suitableSubstitutions(ingredient, diets),
+			},
+		)
+	}
+	return conflicts
+}
@@ -0,0 +1,61 @@
+package substitutions
+
+import (
+	_ "embed"
+	"log/slog"
+	"os"
+)
+
+const (
+	// EnvCatalogFile is the environment variable for the path of a JSON file that replaces the built-in substitution
+	// catalog. When it is not set, the built-in catalog is used
+	EnvCatalogFile = "SUBSTITUTIONS_CATALOG_FILE"
+
+	// IngredientQueryParameter is the query parameter of the ingredient to find substitutions for
+	IngredientQueryParameter = "ingredient"
+
+	// DietQueryParameter is the query parameter of the comma-separated diets the substitutions must suit
+	DietQueryParameter = "diet"
+
+	// ConflictsQueryParameter is the query parameter that asks a recipe read to flag the ingredients that do not suit
+	// the diets of the reader
+	ConflictsQueryParameter = "diet_conflicts"
+)
+
+var (
+	//go:embed data/substitutions.json
+	defaultCatalog []byte
+
+	// Substitutions is the substitution catalog
+	Substitutions *Catalog
+)
+
+// Load loads the substitution catalog
+//
+// Parameters:
+//
+//   - logger: The logger (optional, can be nil)
+func Load(logger *slog.Logger) {
+	data := defaultCatalog
+
+	// Read the catalog file if it is set
+	if path, ok := os.LookupEnv(EnvCatalogFile); ok && path != "" {
+		fileData, err := os.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		if logger != nil {
+			logger.Info(
+				"Substitution catalog loaded from a file",
+				slog.String("path", path),
+			)
+		}
+		data = fileData
+	}
+
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		panic(err)
+	}
+	Substitutions = catalog
+}
@@ -0,0 +1,59 @@
+package substitutions
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrNilCatalog                = errors.New("substitution catalog cannot be nil")
+	ErrInvalidCatalog            = errors.New("invalid substitution catalog")
+	ErrEmptyCatalogIngredient    = errors.New("catalog ingredient name cannot be empty")
+	ErrDuplicateCatalogName      = errors.New("catalog ingredient name or alias is repeated")
+	ErrInvalidCatalogDiet        = errors.New("catalog ingredient or substitution has an unknown diet")
+	ErrInvalidCatalogReplacement = errors.New("catalog substitution must have a positive quantity and a replacement")
+	ErrEmptyIngredientQuery      = errors.New("ingredient cannot be empty")
+	ErrIngredientNotFound        = errors.New("no substitutions known for the ingredient")
+	ErrInvalidDiet               = errors.New("invalid diet, must be vegetarian, vegan, gluten_free, dairy_free, egg_free or nut_free")
+)
+
+// ParseError maps a substitutions error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the substitutions error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	switch {
+	case errors.Is(err, ErrEmptyIngredientQuery):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			IngredientQueryParameter,
+			err,
+			internalerrorcodes.EmptyIngredient.String(),
+			http.StatusBadRequest,
+		)
+	case errors.Is(err, ErrIngredientNotFound):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			IngredientQueryParameter,
+			err,
+			internalerrorcodes.SubstitutionsNotFound.String(),
+			http.StatusNotFound,
+		)
+	case errors.Is(err, ErrInvalidDiet):
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			DietQueryParameter,
+			err,
+			internalerrorcodes.InvalidDiet.String(),
+			http.StatusBadRequest,
+		)
+	default:
+		return err
+	}
+}
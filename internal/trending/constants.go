@@ -0,0 +1,69 @@
+package trending
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+const (
+	// KeyPrefix is the prefix of the Redis keys that hold the activity counters
+	KeyPrefix = "trending:"
+
+	// RestoredKey is the Redis key set once the counters kept on the database are copied back to Redis. It disappears
+	// along with the counters if Redis is flushed
+	RestoredKey = KeyPrefix + "restored"
+
+	// BucketPeriod is the period counted by each activity counter
+	BucketPeriod = time.Hour
+
+	// Retention is how long the activity counters are kept, a bit longer than the widest window
+	Retention = 8 * 24 * time.Hour
+
+	// ViewerPeriod is the period during which the views of the same viewer on a recipe are counted once
+	ViewerPeriod = 24 * time.Hour
+
+	// ScoresTTL is how long the trending scores of a window are cached before they are computed again
+	ScoresTTL = time.Minute
+
+	// PersistPeriod is the period the activity counters are copied to the database
+	PersistPeriod = 5 * time.Minute
+
+	// WindowQueryParameter is the query parameter of the trending window
+	WindowQueryParameter = "window"
+)
+
+var (
+	// Weights are the weights of each kind of activity in the trending scores
+	Weights = map[internalrouterapiv1recipe.ActivityKind]float64{
+		internalrouterapiv1recipe.ActivityKindView:     1,
+		internalrouterapiv1recipe.ActivityKindFavorite: 3,
+		internalrouterapiv1recipe.ActivityKindCook:     5,
+	}
+
+	// Recipes is the activity tracker of the recipes
+	Recipes *Tracker
+)
+
+// Load initializes the trending constants
+//
+// Parameters:
+//
+//   - service: The recipes SQLite service
+//   - client: The Redis client
+//   - logger: The logger (optional, can be nil)
+func Load(
+	service *internalsqliterecipes.Service,
+	client *redis.Client,
+	logger *slog.Logger,
+) {
+	tracker, err := NewTracker(service, client, logger)
+	if err != nil {
+		panic(err)
+	}
+	Recipes = tracker
+}
@@ -0,0 +1,38 @@
+package trending
+
+import (
+	"errors"
+	"net/http"
+
+	gonethttpresponse "github.com/ralvarezdev/go-net/http/response"
+
+	internalerrorcodes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/errorcodes"
+)
+
+var (
+	ErrNilTracker        = errors.New("trending tracker cannot be nil")
+	ErrNilRecipesService = errors.New("recipes service cannot be nil")
+	ErrNilRedisClient    = errors.New("redis client cannot be nil")
+	ErrInvalidWindow     = errors.New("invalid window, must be day or week")
+)
+
+// ParseError maps a trending error to a JSend fail error, returning any other error unchanged
+//
+// Parameters:
+//
+//   - err: the trending error
+//
+// Returns:
+//
+//   - error: the JSend fail error
+func ParseError(err error) error {
+	if errors.Is(err, ErrInvalidWindow) {
+		return gonethttpresponse.NewFailFieldErrorWithCode(
+			WindowQueryParameter,
+			err,
+			internalerrorcodes.InvalidTrendingWindow.String(),
+			http.StatusBadRequest,
+		)
+	}
+	return err
+}
@@ -0,0 +1,484 @@
+package trending
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	gonethttp "github.com/ralvarezdev/go-net/http"
+
+	internalsqliterecipes "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/databases/sqlite/recipes"
+	internaljwt "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/jwt"
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+type (
+	// Tracker counts the activity on the public recipes in hourly Redis sorted sets, one per kind of activity, and
+	// ranks them by the sum of their counters weighted by kind and decayed by age. The views are counted once a day
+	// per viewer, using a HyperLogLog per recipe. The counters are copied to the database periodically and copied
+	// back to Redis if it loses them
+	Tracker struct {
+		service *internalsqliterecipes.Service
+		client  *redis.Client
+		logger  *slog.Logger
+	}
+)
+
+// NewTracker creates a new Tracker
+//
+// Parameters:
+//
+//   - service: the recipes SQLite service
+//   - client: the Redis client
+//   - logger: the logger (optional, can be nil)
+//
+// Returns:
+//
+//   - *Tracker: the Tracker instance
+//   - error: an error if the service or the client is nil
+func NewTracker(
+	service *internalsqliterecipes.Service,
+	client *redis.Client,
+	logger *slog.Logger,
+) (*Tracker, error) {
+	// Check if the service or the client is nil
+	if service == nil {
+		return nil, ErrNilRecipesService
+	}
+	if client == nil {
+		return nil, ErrNilRedisClient
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "trending"),
+		)
+	}
+
+	return &Tracker{
+		service: service,
+		client:  client,
+		logger:  logger,
+	}, nil
+}
+
+// logError logs an error if the logger is set
+//
+// Parameters:
+//
+//   - msg: the log message
+//   - err: the error to log
+func (t *Tracker) logError(msg string, err error) {
+	if t.logger != nil {
+		t.logger.Error(msg, slog.String("error", err.Error()))
+	}
+}
+
+// Actor identifies who performs an activity: the authenticated user, or the client IP for the requests without a
+// session
+//
+// Parameters:
+//
+//   - r: the HTTP request
+//
+// Returns:
+//
+//   - string: the actor
+func Actor(r *http.Request) string {
+	if userID, err := internaljwt.GetCtxUserID(r); err == nil {
+		return userActor(userID)
+	}
+	return "ip:" + gonethttp.GetClientIP(r)
+}
+
+// userActor returns the actor of an authenticated user
+//
+// Parameters:
+//
+//   - userID: the ID of the user
+//
+// Returns:
+//
+//   - string: the actor
+func userActor(userID string) string {
+	return "user:" + userID
+}
+
+// bucket returns the start of the bucket that counts the activity at the given time
+//
+// Parameters:
+//
+//   - t: the time
+//
+// Returns:
+//
+//   - time.Time: the start of the bucket
+func bucket(t time.Time) time.Time {
+	return t.Truncate(BucketPeriod)
+}
+
+// counterKey returns the key of the sorted set that counts a kind of activity during a bucket
+//
+// Parameters:
+//
+//   - kind: the kind of activity
+//   - bucketStart: the start of the bucket
+//
+// Returns:
+//
+//   - string: the key
+func counterKey(kind internalrouterapiv1recipe.ActivityKind, bucketStart time.Time) string {
+	return KeyPrefix + string(kind) + ":" + strconv.FormatInt(bucketStart.Unix(), 10)
+}
+
+// viewersKey returns the key of the HyperLogLog of the viewers of a recipe during the current viewer period
+//
+// Parameters:
+//
+//   - recipeID: the ID of the recipe
+//   - now: the current time
+//
+// Returns:
+//
+//   - string: the key
+func viewersKey(recipeID int, now time.Time) string {
+	return KeyPrefix + "viewers:" + strconv.Itoa(recipeID) + ":" +
+		strconv.FormatInt(now.Truncate(ViewerPeriod).Unix(), 10)
+}
+
+// scoresKey returns the key of the sorted set that caches the trending scores of a window
+//
+// Parameters:
+//
+//   - window: the window
+//
+// Returns:
+//
+//   - string: the key
+func scoresKey(window Window) string {
+	return KeyPrefix + "scores:" + string(window)
+}
+
+// Track counts an activity on a recipe. Only the activity of other users on public recipes is counted, and views are
+// counted once per viewer period. Failures are logged, the request that performed the activity must not fail because
+// of them
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - recipe: the recipe
+//   - kind: the kind of activity
+//   - actor: who performed the activity, see Actor
+func (t *Tracker) Track(
+	ctx context.Context,
+	recipe *internalrouterapiv1recipe.Recipe,
+	kind internalrouterapiv1recipe.ActivityKind,
+	actor string,
+) {
+	if t == nil || recipe == nil {
+		return
+	}
+	if recipe.Visibility != internalrouterapiv1recipe.VisibilityPublic || actor == userActor(recipe.OwnerID) {
+		return
+	}
+	now := time.Now()
+
+	// Count each viewer once
+	if kind == internalrouterapiv1recipe.ActivityKindView {
+		key := viewersKey(recipe.ID, now)
+		added, err := t.client.PFAdd(ctx, key, actor).Result()
+		if err != nil {
+			t.logError("Failed to count recipe viewer", err)
+			return
+		}
+		if added == 0 {
+			return
+		}
+		t.client.Expire(ctx, key, ViewerPeriod)
+	}
+
+	// Increment the counter of the current bucket
+	key := counterKey(kind, bucket(now))
+	if _, err := t.client.Pipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZIncrBy(ctx, key, 1, strconv.Itoa(recipe.ID))
+			pipe.Expire(ctx, key, Retention)
+			return nil
+		},
+	); err != nil {
+		t.logError("Failed to count recipe activity", err)
+	}
+}
+
+// scores computes the trending scores of a window unless they are cached
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - window: the window
+//
+// Returns:
+//
+//   - error: an error if the scores could not be computed
+func (t *Tracker) scores(ctx context.Context, window Window) error {
+	key := scoresKey(window)
+	exists, err := t.client.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if exists > 0 {
+		return nil
+	}
+
+	_, err = t.client.Pipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZUnionStore(ctx, key, windowStore(window, time.Now()))
+			pipe.Expire(ctx, key, ScoresTTL)
+			return nil
+		},
+	)
+	return err
+}
+
+// decay returns the factor the activity of a bucket is weighted by for its age, halved every half-life of the window
+//
+// Parameters:
+//
+//   - window: the window
+//   - now: the current time
+//   - bucketStart: the start of the bucket
+//
+// Returns:
+//
+//   - float64: the factor, 1 for the activity counted now
+func decay(window Window, now, bucketStart time.Time) float64 {
+	return math.Pow(0.5, float64(now.Sub(bucketStart))/float64(window.HalfLife()))
+}
+
+// windowStore returns the union of the counters of a window, each one weighted by its kind and its age
+//
+// Parameters:
+//
+//   - window: the window
+//   - now: the current time
+//
+// Returns:
+//
+//   - *redis.ZStore: the weighted counters, summed
+func windowStore(window Window, now time.Time) *redis.ZStore {
+	last := bucket(now)
+	buckets := int(window.Duration() / BucketPeriod)
+	store := &redis.ZStore{
+		Keys:      make([]string, 0, buckets*len(Weights)),
+		Weights:   make([]float64, 0, buckets*len(Weights)),
+		Aggregate: "SUM",
+	}
+	for i := range buckets {
+		bucketStart := last.Add(-time.Duration(i) * BucketPeriod)
+		factor := decay(window, now, bucketStart)
+		for kind, weight := range Weights {
+			store.Keys = append(store.Keys, counterKey(kind, bucketStart))
+			store.Weights = append(store.Weights, weight*factor)
+		}
+	}
+	return store
+}
+
+// Trending lists the public recipes with the highest trending scores in a window
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - window: the window
+//   - language: the language used to localize the tags
+//   - limit: the maximum number of recipes
+//   - offset: the number of recipes to skip
+//
+// Returns:
+//
+//   - []*internalrouterapiv1recipe.ScoredRecipe: the recipes with their scores, highest first
+//   - error: an error if the window is invalid or the recipes could not be ranked
+func (t *Tracker) Trending(
+	ctx context.Context,
+	window Window,
+	language string,
+	limit, offset int,
+) ([]*internalrouterapiv1recipe.ScoredRecipe, error) {
+	if t == nil {
+		return nil, ErrNilTracker
+	}
+	if !window.IsValid() {
+		return nil, ErrInvalidWindow
+	}
+
+	// Rank the recipes
+	if err := t.scores(ctx, window); err != nil {
+		return nil, err
+	}
+	ranked, err := t.client.ZRevRangeWithScores(
+		ctx,
+		scoresKey(window),
+		int64(offset),
+		int64(offset+limit-1),
+	).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the recipes, the ones that are no longer public are left out
+	recipeIDs := make([]int, 0, len(ranked))
+	scoresByID := make(map[int]float64, len(ranked))
+	for _, member := range ranked {
+		recipeID, convErr := strconv.Atoi(member.Member.(string))
+		if convErr != nil {
+			continue
+		}
+		recipeIDs = append(recipeIDs, recipeID)
+		scoresByID[recipeID] = member.Score
+	}
+	recipes, err := t.service.ListPublicRecipesByIDs(ctx, language, recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	trending := make([]*internalrouterapiv1recipe.ScoredRecipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		trending = append(
+			trending, &internalrouterapiv1recipe.ScoredRecipe{
+				Recipe: recipe,
+				Score:  scoresByID[recipe.ID],
+			},
+		)
+	}
+	return trending, nil
+}
+
+// Start copies the counters back to Redis if needed and starts copying them to the database periodically, until
+// the context is done
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the tracker is nil
+func (t *Tracker) Start(ctx context.Context) error {
+	// Check if the tracker is nil
+	if t == nil {
+		return ErrNilTracker
+	}
+
+	go func() {
+		ticker := time.NewTicker(PersistPeriod)
+		defer ticker.Stop()
+
+		for {
+			t.Persist(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Persist copies the counters of the current and the previous buckets to the database and deletes the expired ones.
+// If Redis lost its counters, the ones kept on the database are copied back first. Failures are logged
+//
+// Parameters:
+//
+//   - ctx: the context
+func (t *Tracker) Persist(ctx context.Context) {
+	if t == nil {
+		return
+	}
+
+	if err := t.restore(ctx); err != nil {
+		t.logError("Failed to restore recipe activity", err)
+	}
+
+	// Read the counters. The previous bucket gets a last copy once it is over, as the period between copies is
+	// shorter than the buckets
+	now := time.Now()
+	activity := make([]*internalsqliterecipes.RecipeActivity, 0)
+	for _, bucketStart := range []time.Time{bucket(now).Add(-BucketPeriod), bucket(now)} {
+		for kind := range Weights {
+			counters, err := t.client.ZRangeWithScores(ctx, counterKey(kind, bucketStart), 0, -1).Result()
+			if err != nil {
+				t.logError("Failed to read recipe activity", err)
+				return
+			}
+			for _, counter := range counters {
+				recipeID, convErr := strconv.Atoi(counter.Member.(string))
+				if convErr != nil {
+					continue
+				}
+				activity = append(
+					activity, &internalsqliterecipes.RecipeActivity{
+						RecipeID: recipeID,
+						Kind:     kind,
+						Bucket:   bucketStart.Unix(),
+						Count:    int64(counter.Score),
+					},
+				)
+			}
+		}
+	}
+
+	// Store them and drop the expired ones
+	if err := t.service.UpsertRecipeActivity(ctx, activity); err != nil {
+		return
+	}
+	_ = t.service.DeleteRecipeActivity(ctx, bucket(now.Add(-Retention)).Unix())
+}
+
+// restore copies the counters kept on the database to Redis, unless they were already copied since Redis was last
+// flushed
+//
+// Parameters:
+//
+//   - ctx: the context
+//
+// Returns:
+//
+//   - error: an error if the counters could not be copied
+func (t *Tracker) restore(ctx context.Context) error {
+	// Only one instance copies the counters
+	first, err := t.client.SetNX(ctx, RestoredKey, 1, 0).Result()
+	if err != nil || !first {
+		return err
+	}
+
+	now := time.Now()
+	activity, err := t.service.ListRecipeActivity(ctx, bucket(now.Add(-Retention)).Unix())
+	if err != nil || len(activity) == 0 {
+		return err
+	}
+
+	// Add the stored counts to the ones counted since the flush, all of them or none, so copying them again after a
+	// failure does not count them twice
+	_, err = t.client.TxPipelined(
+		ctx, func(pipe redis.Pipeliner) error {
+			for _, counter := range activity {
+				bucketStart := time.Unix(counter.Bucket, 0)
+				key := counterKey(counter.Kind, bucketStart)
+				pipe.ZIncrBy(ctx, key, float64(counter.Count), strconv.Itoa(counter.RecipeID))
+				pipe.ExpireAt(ctx, key, bucketStart.Add(Retention))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		// Copy them again on the next run
+		t.client.Del(ctx, RestoredKey)
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package trending
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	internalrouterapiv1recipe "github.com/ralvarezdev/uru-mobiles-recipes-api/internal/router/api/v1/recipe"
+)
+
+func TestBucket(t *testing.T) {
+	start := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "start of the bucket", t: start, want: start},
+		{name: "within the bucket", t: start.Add(59*time.Minute + 59*time.Second), want: start},
+		{name: "next bucket", t: start.Add(BucketPeriod), want: start.Add(BucketPeriod)},
+		{name: "previous bucket", t: start.Add(-time.Nanosecond), want: start.Add(-BucketPeriod)},
+		{
+			name: "other time zone",
+			t:    start.Add(30 * time.Minute).In(time.FixedZone("VET", -4*60*60)),
+			want: start,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := bucket(test.t); !got.Equal(test.want) {
+					t.Errorf("bucket(%v) = %v, want %v", test.t, got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestCounterKey(t *testing.T) {
+	bucketStart := time.Unix(1773500400, 0)
+	want := KeyPrefix + "cook:1773500400"
+	if got := counterKey(internalrouterapiv1recipe.ActivityKindCook, bucketStart); got != want {
+		t.Errorf("counterKey() = %s, want %s", got, want)
+	}
+	if counterKey(internalrouterapiv1recipe.ActivityKindView, bucketStart) ==
+		counterKey(internalrouterapiv1recipe.ActivityKindView, bucketStart.Add(BucketPeriod)) {
+		t.Error("counterKey() is the same for two buckets")
+	}
+}
+
+func TestDecay(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		window Window
+		age    time.Duration
+		want   float64
+	}{
+		{name: "now", window: WindowDay, want: 1},
+		{name: "one half-life", window: WindowDay, age: 6 * time.Hour, want: 0.5},
+		{name: "two half-lives", window: WindowDay, age: 12 * time.Hour, want: 0.25},
+		{name: "end of the window", window: WindowDay, age: 24 * time.Hour, want: 0.0625},
+		{name: "week half-life", window: WindowWeek, age: 48 * time.Hour, want: 0.5},
+		{name: "half a half-life", window: WindowWeek, age: 24 * time.Hour, want: math.Sqrt(0.5)},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := decay(test.window, now, now.Add(-test.age)); math.Abs(got-test.want) > 1e-9 {
+					t.Errorf("decay(%s, %s) = %f, want %f", test.window, test.age, got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestWindowStore(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 30, 0, 0, time.UTC)
+	for _, window := range []Window{WindowDay, WindowWeek} {
+		t.Run(
+			string(window), func(t *testing.T) {
+				store := windowStore(window, now)
+				buckets := int(window.Duration() / BucketPeriod)
+				if len(store.Keys) != buckets*len(Weights) || len(store.Weights) != len(store.Keys) {
+					t.Fatalf(
+						"windowStore() has %d keys and %d weights, want %d",
+						len(store.Keys),
+						len(store.Weights),
+						buckets*len(Weights),
+					)
+				}
+				if store.Aggregate != "SUM" {
+					t.Errorf("windowStore() aggregate = %s, want SUM", store.Aggregate)
+				}
+
+				// Every counter of the window is weighted by its kind and decayed by its age
+				weights := make(map[string]float64, len(store.Keys))
+				for i, key := range store.Keys {
+					if _, ok := weights[key]; ok {
+						t.Fatalf("windowStore() repeats the key %s", key)
+					}
+					weights[key] = store.Weights[i]
+				}
+				for i := range buckets {
+					bucketStart := bucket(now).Add(-time.Duration(i) * BucketPeriod)
+					for kind, weight := range Weights {
+						key := counterKey(kind, bucketStart)
+						got, ok := weights[key]
+						if !ok {
+							t.Fatalf("windowStore() is missing the key %s", key)
+						}
+						if want := weight * decay(window, now, bucketStart); math.Abs(got-want) > 1e-9 {
+							t.Errorf("weight of %s = %f, want %f", key, got, want)
+						}
+					}
+				}
+
+				// The current activity counts the most, and the buckets outside the window are left out
+				viewWeight := Weights[internalrouterapiv1recipe.ActivityKindView]
+				current := weights[counterKey(internalrouterapiv1recipe.ActivityKindView, bucket(now))]
+				if current <= 0 || current > viewWeight {
+					t.Errorf("weight of the current views = %f, want it in (0, %f]", current, viewWeight)
+				}
+				outside := counterKey(internalrouterapiv1recipe.ActivityKindView, bucket(now).Add(-window.Duration()))
+				if _, ok := weights[outside]; ok {
+					t.Errorf("windowStore() has the key %s, which is outside the window", outside)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,80 @@
+package trending
+
+import (
+	"time"
+)
+
+type (
+	// Window is the period of activity ranked by the trending scores
+	Window string
+)
+
+const (
+	// WindowDay ranks the activity of the last day
+	WindowDay Window = "day"
+
+	// WindowWeek ranks the activity of the last week
+	WindowWeek Window = "week"
+
+	// DefaultWindow is the window used when none is requested
+	DefaultWindow = WindowDay
+)
+
+// ParseWindow parses a trending window, falling back to the default window when it is empty
+//
+// Parameters:
+//
+//   - value: the requested window
+//
+// Returns:
+//
+//   - Window: the window
+//   - error: an error if the window is unknown
+func ParseWindow(value string) (Window, error) {
+	if value == "" {
+		return DefaultWindow, nil
+	}
+	window := Window(value)
+	if !window.IsValid() {
+		return "", ErrInvalidWindow
+	}
+	return window, nil
+}
+
+// IsValid checks if the window is one of the known windows
+//
+// Returns:
+//
+//   - bool: true if the window is valid
+func (w Window) IsValid() bool {
+	switch w {
+	case WindowDay, WindowWeek:
+		return true
+	default:
+		return false
+	}
+}
+
+// Duration returns the period of activity ranked by the window
+//
+// Returns:
+//
+//   - time.Duration: the period
+func (w Window) Duration() time.Duration {
+	if w == WindowWeek {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// HalfLife returns the age at which the activity counts half as much as the current one in the window
+//
+// Returns:
+//
+//   - time.Duration: the half-life
+func (w Window) HalfLife() time.Duration {
+	if w == WindowWeek {
+		return 48 * time.Hour
+	}
+	return 6 * time.Hour
+}
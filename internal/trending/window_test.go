@@ -0,0 +1,65 @@
+package trending
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  Window
+		err   error
+	}{
+		{name: "default", want: DefaultWindow},
+		{name: "day", value: "day", want: WindowDay},
+		{name: "week", value: "week", want: WindowWeek},
+		{name: "unknown", value: "month", err: ErrInvalidWindow},
+		{name: "case sensitive", value: "Day", err: ErrInvalidWindow},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				window, err := ParseWindow(test.value)
+				if window != test.want || !errors.Is(err, test.err) {
+					t.Errorf("ParseWindow(%q) = %q, %v, want %q, %v", test.value, window, err, test.want, test.err)
+				}
+			},
+		)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		window   Window
+		valid    bool
+		duration time.Duration
+		halfLife time.Duration
+	}{
+		{window: WindowDay, valid: true, duration: 24 * time.Hour, halfLife: 6 * time.Hour},
+		{window: WindowWeek, valid: true, duration: 7 * 24 * time.Hour, halfLife: 48 * time.Hour},
+		{window: "month", duration: 24 * time.Hour, halfLife: 6 * time.Hour},
+	}
+	for _, test := range tests {
+		t.Run(
+			string(test.window), func(t *testing.T) {
+				if valid := test.window.IsValid(); valid != test.valid {
+					t.Errorf("IsValid() = %t, want %t", valid, test.valid)
+				}
+				if duration := test.window.Duration(); duration != test.duration {
+					t.Errorf("Duration() = %s, want %s", duration, test.duration)
+				}
+				if halfLife := test.window.HalfLife(); halfLife != test.halfLife {
+					t.Errorf("HalfLife() = %s, want %s", halfLife, test.halfLife)
+				}
+
+				// The widest window must fit in the counters kept
+				if test.valid && test.window.Duration() >= Retention {
+					t.Errorf("Duration() = %s, want it shorter than the retention of %s", test.window.Duration(), Retention)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,10 @@
+package units
+
+import (
+	"errors"
+)
+
+var (
+	ErrIncompatibleUnits  = errors.New("units measure different things and cannot be converted")
+	ErrQuantityOutOfRange = errors.New("quantity is out of range")
+)
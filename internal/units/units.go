@@ -0,0 +1,180 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type (
+	// Dimension is what a unit measures
+	Dimension string
+
+	// Unit is a unit of measure. Mass and volume units are converted through their factor to the base unit of their
+	// dimension, grams and milliliters. Any other unit, like clove or can, counts pieces and only converts to itself
+	Unit struct {
+		Symbol    string
+		Dimension Dimension
+		Factor    float64
+	}
+)
+
+const (
+	// DimensionMass is the dimension of the units that weigh
+	DimensionMass Dimension = "mass"
+
+	// DimensionVolume is the dimension of the units that measure capacity
+	DimensionVolume Dimension = "volume"
+
+	// DimensionCount is the dimension of the units that count pieces
+	DimensionCount Dimension = "count"
+)
+
+var (
+	// known are the mass and volume units by symbol, with US customary volumes
+	known = map[string]Unit{
+		"mg":    {Symbol: "mg", Dimension: DimensionMass, Factor: 0.001},
+		"g":     {Symbol: "g", Dimension: DimensionMass, Factor: 1},
+		"kg":    {Symbol: "kg", Dimension: DimensionMass, Factor: 1000},
+		"oz":    {Symbol: "oz", Dimension: DimensionMass, Factor: 28.349523125},
+		"lb":    {Symbol: "lb", Dimension: DimensionMass, Factor: 453.59237},
+		"ml":    {Symbol: "ml", Dimension: DimensionVolume, Factor: 1},
+		"l":     {Symbol: "l", Dimension: DimensionVolume, Factor: 1000},
+		"pinch": {Symbol: "pinch", Dimension: DimensionVolume, Factor: 0.308057599609375},
+		"tsp":   {Symbol: "tsp", Dimension: DimensionVolume, Factor: 4.92892159375},
+		"tbsp":  {Symbol: "tbsp", Dimension: DimensionVolume, Factor: 14.78676478125},
+		"fl oz": {Symbol: "fl oz", Dimension: DimensionVolume, Factor: 29.5735295625},
+		"cup":   {Symbol: "cup", Dimension: DimensionVolume, Factor: 236.5882365},
+	}
+
+	// aliases maps the spellings of the units, in English and Spanish, to their symbol
+	aliases = map[string]string{
+		"milligram":    "mg",
+		"milligrams":   "mg",
+		"gr":           "g",
+		"gram":         "g",
+		"grams":        "g",
+		"gramo":        "g",
+		"gramos":       "g",
+		"kilo":         "kg",
+		"kilos":        "kg",
+		"kilogram":     "kg",
+		"kilograms":    "kg",
+		"kilogramo":    "kg",
+		"kilogramos":   "kg",
+		"ounce":        "oz",
+		"ounces":       "oz",
+		"onza":         "oz",
+		"onzas":        "oz",
+		"lbs":          "lb",
+		"pound":        "lb",
+		"pounds":       "lb",
+		"libra":        "lb",
+		"libras":       "lb",
+		"milliliter":   "ml",
+		"milliliters":  "ml",
+		"millilitre":   "ml",
+		"millilitres":  "ml",
+		"mililitro":    "ml",
+		"mililitros":   "ml",
+		"liter":        "l",
+		"liters":       "l",
+		"litre":        "l",
+		"litres":       "l",
+		"litro":        "l",
+		"litros":       "l",
+		"pinches":      "pinch",
+		"pizca":        "pinch",
+		"pizcas":       "pinch",
+		"teaspoon":     "tsp",
+		"teaspoons":    "tsp",
+		"cucharadita":  "tsp",
+		"cucharaditas": "tsp",
+		"tablespoon":   "tbsp",
+		"tablespoons":  "tbsp",
+		"tbs":          "tbsp",
+		"cucharada":    "tbsp",
+		"cucharadas":   "tbsp",
+		"fluid ounce":  "fl oz",
+		"fluid ounces": "fl oz",
+		"c":            "cup",
+		"cups":         "cup",
+		"taza":         "cup",
+		"tazas":        "cup",
+		"piece":        "",
+		"pieces":       "",
+		"unit":         "",
+		"units":        "",
+		"unidad":       "",
+		"unidades":     "",
+		"cloves":       "clove",
+		"diente":       "clove",
+		"dientes":      "clove",
+		"cans":         "can",
+		"lata":         "can",
+		"latas":        "can",
+		"slices":       "slice",
+		"rebanada":     "slice",
+		"rebanadas":    "slice",
+	}
+)
+
+// Parse parses a unit. Unknown units, and no unit at all, count pieces
+//
+// Parameters:
+//
+//   - unit: the unit, as written on an ingredient
+//
+// Returns:
+//
+//   - Unit: the unit
+func Parse(unit string) Unit {
+	symbol := strings.Join(strings.Fields(strings.ToLower(strings.TrimSuffix(strings.TrimSpace(unit), "."))), " ")
+	if alias, ok := aliases[symbol]; ok {
+		symbol = alias
+	}
+	if u, ok := known[symbol]; ok {
+		return u
+	}
+	return Unit{Symbol: symbol, Dimension: DimensionCount, Factor: 1}
+}
+
+// Compatible checks if a unit converts to another one
+//
+// Parameters:
+//
+//   - other: the other unit
+//
+// Returns:
+//
+//   - bool: true if both units measure the same thing
+func (u Unit) Compatible(other Unit) bool {
+	if u.Dimension != other.Dimension {
+		return false
+	}
+	return u.Dimension != DimensionCount || u.Symbol == other.Symbol
+}
+
+// Convert converts a quantity between two units
+//
+// Parameters:
+//
+//   - quantity: the quantity
+//   - from: the unit of the quantity
+//   - to: the unit to convert the quantity to
+//
+// Returns:
+//
+//   - float64: the quantity in the target unit
+//   - error: an error if the units measure different things or the quantity is not a finite number in both units
+func Convert(quantity float64, from, to string) (float64, error) {
+	fromUnit, toUnit := Parse(from), Parse(to)
+	if !fromUnit.Compatible(toUnit) {
+		return 0, fmt.Errorf("%w: %q to %q", ErrIncompatibleUnits, from, to)
+	}
+	converted := quantity * fromUnit.Factor / toUnit.Factor
+	if math.IsInf(converted, 0) || math.IsNaN(converted) {
+		return 0, fmt.Errorf("%w: %g %s in %s", ErrQuantityOutOfRange, quantity, from, to)
+	}
+	return converted, nil
+}
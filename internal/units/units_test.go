@@ -0,0 +1,109 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		unit string
+		want Unit
+	}{
+		// Known units
+		{name: "symbol", unit: "kg", want: Unit{Symbol: "kg", Dimension: DimensionMass, Factor: 1000}},
+		{name: "english alias", unit: "Tablespoons", want: Unit{Symbol: "tbsp", Dimension: DimensionVolume, Factor: 14.78676478125}},
+		{name: "spanish alias", unit: "tazas", want: Unit{Symbol: "cup", Dimension: DimensionVolume, Factor: 236.5882365}},
+		{name: "abbreviation with a period", unit: " Oz. ", want: Unit{Symbol: "oz", Dimension: DimensionMass, Factor: 28.349523125}},
+		{name: "two words", unit: "Fluid   Ounces", want: Unit{Symbol: "fl oz", Dimension: DimensionVolume, Factor: 29.5735295625}},
+
+		// Counted units
+		{name: "counted alias", unit: "dientes", want: Unit{Symbol: "clove", Dimension: DimensionCount, Factor: 1}},
+		{name: "pieces", unit: "unidades", want: Unit{Dimension: DimensionCount, Factor: 1}},
+		{name: "no unit", unit: "", want: Unit{Dimension: DimensionCount, Factor: 1}},
+		{name: "unknown unit", unit: "Bunch", want: Unit{Symbol: "bunch", Dimension: DimensionCount, Factor: 1}},
+		{name: "only a period", unit: ".", want: Unit{Dimension: DimensionCount, Factor: 1}},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if unit := Parse(test.unit); unit != test.want {
+					t.Errorf("Parse(%q) = %+v, want %+v", test.unit, unit, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want bool
+	}{
+		{name: "same dimension", from: "lb", to: "g", want: true},
+		{name: "different dimensions", from: "g", to: "ml"},
+		{name: "same counted unit", from: "clove", to: "dientes", want: true},
+		{name: "different counted units", from: "clove", to: "can"},
+		{name: "pieces", from: "", to: "piece", want: true},
+		{name: "counted and measured", from: "can", to: "g"},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if compatible := Parse(test.from).Compatible(Parse(test.to)); compatible != test.want {
+					t.Errorf("Compatible(%q, %q) = %t, want %t", test.from, test.to, compatible, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity float64
+		from     string
+		to       string
+		want     float64
+		err      error
+	}{
+		// Valid conversions
+		{name: "mass", quantity: 1.5, from: "kg", to: "g", want: 1500},
+		{name: "imperial mass", quantity: 16, from: "oz", to: "lb", want: 1},
+		{name: "volume", quantity: 1, from: "cup", to: "ml", want: 236.5882365},
+		{name: "spoons", quantity: 3, from: "tsp", to: "tbsp", want: 1},
+		{name: "aliases", quantity: 2, from: "cucharadas", to: "teaspoons", want: 6},
+		{name: "counted unit", quantity: 3, from: "cloves", to: "clove", want: 3},
+		{name: "pieces", quantity: 4, from: "", to: "units", want: 4},
+
+		// Incompatible units
+		{name: "mass to volume", quantity: 1, from: "g", to: "ml", err: ErrIncompatibleUnits},
+		{name: "counted units", quantity: 1, from: "clove", to: "can", err: ErrIncompatibleUnits},
+		{name: "counted to mass", quantity: 1, from: "can", to: "g", err: ErrIncompatibleUnits},
+
+		// Limits
+		{name: "zero", quantity: 0, from: "kg", to: "g", want: 0},
+		{name: "smallest quantity", quantity: math.SmallestNonzeroFloat64, from: "g", to: "g", want: math.SmallestNonzeroFloat64},
+		{name: "largest quantity", quantity: math.MaxFloat64, from: "g", to: "g", want: math.MaxFloat64},
+		{name: "overflowing quantity", quantity: math.MaxFloat64, from: "kg", to: "mg", err: ErrQuantityOutOfRange},
+		{name: "infinite quantity", quantity: math.Inf(1), from: "g", to: "g", err: ErrQuantityOutOfRange},
+		{name: "not a number", quantity: math.NaN(), from: "cup", to: "ml", err: ErrQuantityOutOfRange},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				quantity, err := Convert(test.quantity, test.from, test.to)
+				if !errors.Is(err, test.err) {
+					t.Fatalf("Convert(%g, %q, %q) error = %v, want %v", test.quantity, test.from, test.to, err, test.err)
+				}
+				if math.Abs(quantity-test.want) > 1e-9*math.Max(1, math.Abs(test.want)) {
+					t.Errorf("Convert(%g, %q, %q) = %g, want %g", test.quantity, test.from, test.to, quantity, test.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,402 @@
+-- Recipes database schema (recipes.db). The service creates these tables on connection, then adds the columns
+-- introduced after a table was created to the databases that lack them, counting the migrations in PRAGMA user_version
+
+CREATE TABLE IF NOT EXISTS recipes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	preparation_time INTEGER NOT NULL,
+	cooking_time INTEGER NOT NULL,
+	ingredients TEXT NOT NULL DEFAULT '[]',
+	steps TEXT NOT NULL,
+	servings INTEGER NOT NULL,
+	difficulty TEXT NOT NULL,
+	source_url TEXT NOT NULL DEFAULT '',
+	image_url TEXT NOT NULL DEFAULT '',
+	visibility TEXT NOT NULL DEFAULT 'private',
+	forked_from INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+	attribution TEXT NOT NULL DEFAULT '[]',
+	fingerprint TEXT NOT NULL DEFAULT '{}',
+	language TEXT NOT NULL DEFAULT 'en',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS recipes_owner_id_idx ON recipes (owner_id);
+CREATE INDEX IF NOT EXISTS recipes_forked_from_idx ON recipes (forked_from);
+CREATE INDEX IF NOT EXISTS recipes_visibility_idx ON recipes (visibility);
+
+-- Recipe revisions: a snapshot of the content of a recipe after each edit
+CREATE TABLE IF NOT EXISTS recipe_revisions (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	number INTEGER NOT NULL,
+	author_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	preparation_time INTEGER NOT NULL,
+	cooking_time INTEGER NOT NULL,
+	ingredients TEXT NOT NULL,
+	steps TEXT NOT NULL,
+	servings INTEGER NOT NULL,
+	difficulty TEXT NOT NULL,
+	image_url TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (recipe_id, number)
+);
+
+-- Tags: canonical cuisine and course tags have an empty owner ID, user tags belong to their owner
+CREATE TABLE IF NOT EXISTS tags (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	slug TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	owner_id TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL,
+	UNIQUE (kind, slug, owner_id)
+);
+
+CREATE TABLE IF NOT EXISTS tag_translations (
+	tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	language TEXT NOT NULL,
+	name TEXT NOT NULL,
+	PRIMARY KEY (tag_id, language)
+);
+
+CREATE TABLE IF NOT EXISTS tag_synonyms (
+	tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	language TEXT NOT NULL,
+	synonym TEXT NOT NULL,
+	PRIMARY KEY (tag_id, language, synonym)
+);
+CREATE INDEX IF NOT EXISTS tag_synonyms_synonym_idx ON tag_synonyms (synonym);
+
+CREATE TABLE IF NOT EXISTS recipe_tags (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+	PRIMARY KEY (recipe_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_tags_tag_id_idx ON recipe_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS recipe_groups (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	visibility TEXT NOT NULL DEFAULT 'private',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS recipe_groups_owner_id_idx ON recipe_groups (owner_id);
+
+CREATE TABLE IF NOT EXISTS recipe_group_items (
+	group_id INTEGER NOT NULL REFERENCES recipe_groups (id) ON DELETE CASCADE,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	PRIMARY KEY (group_id, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_group_items_recipe_id_idx ON recipe_group_items (recipe_id);
+
+CREATE TABLE IF NOT EXISTS cookbook_jobs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	group_id INTEGER NOT NULL REFERENCES recipe_groups (id) ON DELETE CASCADE,
+	owner_id TEXT NOT NULL,
+	language TEXT NOT NULL,
+	fingerprint TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	pdf BLOB,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	finished_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS cookbook_jobs_group_id_idx ON cookbook_jobs (group_id, language, fingerprint);
+CREATE INDEX IF NOT EXISTS cookbook_jobs_status_idx ON cookbook_jobs (status);
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	format TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	archive BLOB,
+	total INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	finished_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS import_jobs_status_idx ON import_jobs (status);
+
+CREATE TABLE IF NOT EXISTS import_job_items (
+	job_id INTEGER NOT NULL REFERENCES import_jobs (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	recipe_id INTEGER,
+	error TEXT NOT NULL DEFAULT '',
+	duplicate_of INTEGER,
+	PRIMARY KEY (job_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS follows (
+	follower_id TEXT NOT NULL,
+	followee_id TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (follower_id, followee_id),
+	CHECK (follower_id != followee_id)
+);
+CREATE INDEX IF NOT EXISTS follows_followee_id_idx ON follows (followee_id);
+
+CREATE TABLE IF NOT EXISTS feed_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	actor_id TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS feed_events_recipe_id_idx ON feed_events (recipe_id);
+CREATE INDEX IF NOT EXISTS feed_events_actor_id_idx ON feed_events (actor_id, id);
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+	user_id TEXT PRIMARY KEY,
+	username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+	profile TEXT NOT NULL DEFAULT '{}',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS recipe_activity (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	kind TEXT NOT NULL,
+	bucket INTEGER NOT NULL,
+	count INTEGER NOT NULL,
+	PRIMARY KEY (bucket, kind, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_activity_recipe_id_idx ON recipe_activity (recipe_id);
+
+CREATE TABLE IF NOT EXISTS recipe_similarities (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	similar_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	score REAL NOT NULL,
+	PRIMARY KEY (recipe_id, similar_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_similarities_similar_id_idx ON recipe_similarities (similar_id);
+
+CREATE TABLE IF NOT EXISTS recipe_recommendations (
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	score REAL NOT NULL,
+	PRIMARY KEY (user_id, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS recipe_recommendations_recipe_id_idx ON recipe_recommendations (recipe_id);
+
+CREATE TABLE IF NOT EXISTS cook_logs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+	recipe_name TEXT NOT NULL,
+	cooked_on TEXT NOT NULL,
+	notes TEXT NOT NULL DEFAULT '',
+	substitutions TEXT NOT NULL DEFAULT '[]',
+	rating INTEGER NOT NULL DEFAULT 0,
+	photos TEXT NOT NULL DEFAULT '[]',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS cook_logs_user_id_idx ON cook_logs (user_id, cooked_on);
+CREATE INDEX IF NOT EXISTS cook_logs_recipe_id_idx ON cook_logs (recipe_id);
+
+CREATE TABLE IF NOT EXISTS recipe_annotations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	target TEXT NOT NULL,
+	position INTEGER NOT NULL DEFAULT 0,
+	anchor TEXT NOT NULL DEFAULT '',
+	note TEXT NOT NULL DEFAULT '',
+	substitute TEXT NOT NULL DEFAULT '',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS recipe_annotations_user_id_idx ON recipe_annotations (user_id, recipe_id);
+CREATE INDEX IF NOT EXISTS recipe_annotations_recipe_id_idx ON recipe_annotations (recipe_id);
+
+CREATE TABLE IF NOT EXISTS user_diets (
+	user_id TEXT PRIMARY KEY,
+	diets TEXT NOT NULL DEFAULT '[]',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS ingredient_prices (
+	user_id TEXT NOT NULL,
+	slug TEXT NOT NULL,
+	ingredient TEXT NOT NULL,
+	quantity REAL NOT NULL,
+	unit TEXT NOT NULL DEFAULT '',
+	price REAL NOT NULL,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (user_id, slug)
+);
+
+CREATE TABLE IF NOT EXISTS meal_plans (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS meal_plans_owner_id_idx ON meal_plans (owner_id);
+
+CREATE TABLE IF NOT EXISTS meal_plan_entries (
+	plan_id INTEGER NOT NULL REFERENCES meal_plans (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	planned_on TEXT NOT NULL,
+	meal TEXT NOT NULL DEFAULT '',
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	servings INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (plan_id, position)
+);
+CREATE INDEX IF NOT EXISTS meal_plan_entries_recipe_id_idx ON meal_plan_entries (recipe_id);
+
+CREATE TABLE IF NOT EXISTS shopping_lists (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	items TEXT NOT NULL DEFAULT '[]',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS shopping_lists_owner_id_idx ON shopping_lists (owner_id);
+
+CREATE TABLE IF NOT EXISTS favorites (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (user_id, recipe_id)
+);
+CREATE INDEX IF NOT EXISTS favorites_recipe_id_idx ON favorites (recipe_id);
+
+CREATE TABLE IF NOT EXISTS recipe_translations (
+	recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+	language TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	ingredients TEXT NOT NULL DEFAULT '[]',
+	steps TEXT NOT NULL DEFAULT '[]',
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (recipe_id, language)
+);
+
+CREATE TABLE IF NOT EXISTS sync_changes (
+	sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id TEXT NOT NULL,
+	entity TEXT NOT NULL,
+	entity_id INTEGER NOT NULL,
+	deleted INTEGER NOT NULL DEFAULT 0,
+	UNIQUE (entity, entity_id)
+);
+CREATE INDEX IF NOT EXISTS sync_changes_owner_id_idx ON sync_changes (owner_id, sequence);
+CREATE TRIGGER IF NOT EXISTS recipes_sync_insert_trg AFTER INSERT ON recipes
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'recipe', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipes_sync_update_trg AFTER UPDATE ON recipes
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'recipe', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipes_sync_delete_trg AFTER DELETE ON recipes
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted) VALUES (OLD.owner_id, 'recipe', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_tags_sync_insert_trg AFTER INSERT ON recipe_tags
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = NEW.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_tags_sync_delete_trg AFTER DELETE ON recipe_tags
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = OLD.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_translations_sync_insert_trg AFTER INSERT ON recipe_translations
+BEGIN
+	DELETE FROM sync_changes WHERE entity = 'recipe' AND entity_id IN (SELECT id FROM recipes WHERE id = NEW.recipe_id);
+	INSERT INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = NEW.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_translations_sync_update_trg AFTER UPDATE ON recipe_translations
+BEGIN
+	DELETE FROM sync_changes WHERE entity = 'recipe' AND entity_id IN (SELECT id FROM recipes WHERE id = NEW.recipe_id);
+	INSERT INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = NEW.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_translations_sync_delete_trg AFTER DELETE ON recipe_translations
+BEGIN
+	DELETE FROM sync_changes WHERE entity = 'recipe' AND entity_id IN (SELECT id FROM recipes WHERE id = OLD.recipe_id);
+	INSERT INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'recipe', id FROM recipes WHERE id = OLD.recipe_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_groups_sync_insert_trg AFTER INSERT ON recipe_groups
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'group', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_groups_sync_update_trg AFTER UPDATE ON recipe_groups
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'group', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_groups_sync_delete_trg AFTER DELETE ON recipe_groups
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted) VALUES (OLD.owner_id, 'group', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_group_items_sync_insert_trg AFTER INSERT ON recipe_group_items
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'group', id FROM recipe_groups WHERE id = NEW.group_id;
+END;
+CREATE TRIGGER IF NOT EXISTS recipe_group_items_sync_delete_trg AFTER DELETE ON recipe_group_items
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'group', id FROM recipe_groups WHERE id = OLD.group_id;
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plans_sync_insert_trg AFTER INSERT ON meal_plans
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'meal_plan', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plans_sync_update_trg AFTER UPDATE ON meal_plans
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'meal_plan', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plans_sync_delete_trg AFTER DELETE ON meal_plans
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted)
+	VALUES (OLD.owner_id, 'meal_plan', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plan_entries_sync_insert_trg AFTER INSERT ON meal_plan_entries
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'meal_plan', id FROM meal_plans WHERE id = NEW.plan_id;
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plan_entries_sync_update_trg AFTER UPDATE ON meal_plan_entries
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'meal_plan', id FROM meal_plans WHERE id = NEW.plan_id;
+END;
+CREATE TRIGGER IF NOT EXISTS meal_plan_entries_sync_delete_trg AFTER DELETE ON meal_plan_entries
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id)
+	SELECT owner_id, 'meal_plan', id FROM meal_plans WHERE id = OLD.plan_id;
+END;
+CREATE TRIGGER IF NOT EXISTS shopping_lists_sync_insert_trg AFTER INSERT ON shopping_lists
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'shopping_list', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS shopping_lists_sync_update_trg AFTER UPDATE ON shopping_lists
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.owner_id, 'shopping_list', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS shopping_lists_sync_delete_trg AFTER DELETE ON shopping_lists
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted)
+	VALUES (OLD.owner_id, 'shopping_list', OLD.id, 1);
+END;
+CREATE TRIGGER IF NOT EXISTS favorites_sync_insert_trg AFTER INSERT ON favorites
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id) VALUES (NEW.user_id, 'favorite', NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS favorites_sync_delete_trg AFTER DELETE ON favorites
+BEGIN
+	INSERT OR REPLACE INTO sync_changes (owner_id, entity, entity_id, deleted) VALUES (OLD.user_id, 'favorite', OLD.id, 1);
+END;